@@ -0,0 +1,291 @@
+// Command adclgen generates the content types of ADC messages from YAML
+// specification files, see package spec for the format of the files.
+//
+// It is meant to be invoked by go generate, e.g.
+//
+//	//go:generate adclgen -spec specs/ -out protocol/message
+//
+// The files are written as by generator.FileGenerator.RenderFiles, i.e. one
+// file per message and the shared support file. With -tests, the test file of
+// each message is written as well, see generator.StructGenerator.RenderTest.
+// Existing files are overwritten, other files in the output directory are
+// left untouched.
+//
+// With -helpers, the support declarations used by the content types are
+// written to the file generator.HelpersFileName, so that the output directory
+// forms a self-contained package. Otherwise, the package has to provide them.
+//
+// With -ext, the specification files of protocol extensions (e.g. SUDP, NAT0,
+// BLOM or CCPM) in the directory are loaded as well. The messages of each
+// extension are generated into a sub-package of the output directory named
+// after the extension in lower case, e.g. nat0, and the names of their flag
+// types and constants are prefixed with the extension name, e.g. NAT0RCMFlag.
+// This keeps extension messages apart from the messages of the base protocol,
+// even if both specify the same command.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+	"github.com/seoester/adcl/protocol/generator/spec"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "specification `path`, a YAML file or a directory of YAML files")
+	outDir := flag.String("out", ".", "output `directory`, created if it does not exist")
+	packageName := flag.String("package", "", "`name` of the generated package (default \"message\")")
+	messages := flag.String("messages", "", "comma-separated `commands` or names of the messages to generate (default all)")
+	tags := flag.String("tags", "", "build constraint `expression` of the generated files")
+	tests := flag.Bool("tests", false, "also generate a test file per message, including round-trip tests of the examples")
+	helpers := flag.Bool("helpers", false, "also generate the support declarations of the package, making it self-contained")
+	extDir := flag.String("ext", "", "`directory` of YAML specification files of protocol extensions")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: adclgen -spec path [flags]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if len(*specPath) == 0 || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var opts []generator.Option
+	if len(*packageName) > 0 {
+		opts = append(opts, generator.WithPackage(*packageName))
+	}
+	if len(*tags) > 0 {
+		opts = append(opts, generator.WithBuildTags(*tags))
+	}
+	if *helpers {
+		opts = append(opts, generator.WithHelpers())
+	}
+
+	err := run(*specPath, *outDir, splitList(*messages), *tests, opts)
+	if err == nil && len(*extDir) > 0 {
+		err = runExtensions(*extDir, *outDir, *tests, *tags, *helpers)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "adclgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the messages of the specification at specPath into outDir.
+// All messages are generated if selected is empty.
+func run(specPath, outDir string, selected []string, tests bool, opts []generator.Option) error {
+	definition, err := loadDefinition(specPath)
+	if err != nil {
+		return err
+	}
+
+	return generate(definition, outDir, selected, tests, opts)
+}
+
+// runExtensions generates the messages of the extension specification files
+// in extDir. The messages of each extension are generated into a package of
+// their own below outDir.
+func runExtensions(extDir, outDir string, tests bool, tags string, helpers bool) error {
+	definitions, err := loadExtensions(extDir)
+	if err != nil {
+		return err
+	}
+
+	for _, definition := range definitions {
+		pkg := strings.ToLower(definition.Extension)
+		opts := []generator.Option{
+			generator.WithPackage(pkg),
+			generator.WithFlagPrefix(definition.Extension),
+		}
+		if len(tags) > 0 {
+			opts = append(opts, generator.WithBuildTags(tags))
+		}
+		if helpers {
+			opts = append(opts, generator.WithHelpers())
+		}
+
+		err := generate(definition, filepath.Join(outDir, pkg), nil, tests, opts)
+		if err != nil {
+			return errors.Wrapf(err, "extension %s", definition.Extension)
+		}
+	}
+
+	return nil
+}
+
+// generate generates the messages of the definition into outDir. All
+// messages are generated if selected is empty.
+func generate(definition *generator.Definition, outDir string, selected []string, tests bool, opts []generator.Option) error {
+	var err error
+	if len(selected) > 0 {
+		definition.Messages, err = selectMessages(definition.Messages, selected)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := generator.NewFileGenerator(definition, opts...).RenderFiles()
+	if err != nil {
+		return errors.Wrap(err, "generating files")
+	}
+
+	err = os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return errors.Wrap(err, "creating output directory")
+	}
+
+	for name, src := range files {
+		err := ioutil.WriteFile(filepath.Join(outDir, name), src, 0644)
+		if err != nil {
+			return errors.Wrapf(err, "writing file %s", name)
+		}
+	}
+
+	if tests {
+		opts = append(opts, generator.WithEnums(definition.Enums...))
+		for _, message := range definition.Messages {
+			err := writeTestFile(outDir, message, opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTestFile generates the test file of the message into outDir.
+func writeTestFile(outDir string, message *generator.Message, opts []generator.Option) error {
+	name := message.Name
+	if len(name) == 0 {
+		name = message.Command
+	}
+	name = strings.TrimSuffix(generator.ContentFileName(name), ".go") + "_test.go"
+
+	buf := bytes.NewBuffer(nil)
+	err := generator.NewStructGenerator(message, opts...).RenderTest(buf)
+	if err != nil {
+		return errors.Wrapf(err, "generating test file %s", name)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(outDir, name), buf.Bytes(), 0644)
+	if err != nil {
+		return errors.Wrapf(err, "writing file %s", name)
+	}
+
+	return nil
+}
+
+// loadDefinition loads the specification file or directory at path.
+func loadDefinition(path string) (*generator.Definition, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading specification")
+	}
+
+	if info.IsDir() {
+		return spec.LoadDir(path)
+	}
+
+	return spec.LoadFiles(path)
+}
+
+// loadExtensions loads the specification files in dir and merges the files
+// of each extension, returning one definition per extension ordered by name.
+// Files not declaring an extension are rejected.
+func loadExtensions(dir string) ([]*generator.Definition, error) {
+	paths, err := spec.Files(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byExtension := make(map[string][]string)
+	for _, path := range paths {
+		definition, err := spec.LoadFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(definition.Extension) == 0 {
+			return nil, errors.Errorf("specification file %s of extension directory declares no extension", path)
+		}
+
+		byExtension[definition.Extension] = append(byExtension[definition.Extension], path)
+	}
+
+	extensions := make([]string, 0, len(byExtension))
+	for extension := range byExtension {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+
+	definitions := make([]*generator.Definition, 0, len(extensions))
+	for _, extension := range extensions {
+		definition, err := spec.LoadFiles(byExtension[extension]...)
+		if err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+// selectMessages returns the messages whose command or name is in selected,
+// keeping the order of messages. An error is returned if any entry of
+// selected does not match a message.
+func selectMessages(messages []*generator.Message, selected []string) ([]*generator.Message, error) {
+	matched := make(map[string]bool, len(selected))
+	for _, sel := range selected {
+		matched[sel] = false
+	}
+
+	var result []*generator.Message
+	for _, message := range messages {
+		_, byCommand := matched[message.Command]
+		_, byName := matched[message.Name]
+		if !byCommand && !(byName && len(message.Name) > 0) {
+			continue
+		}
+
+		result = append(result, message)
+		if byCommand {
+			matched[message.Command] = true
+		}
+		if byName {
+			matched[message.Name] = true
+		}
+	}
+
+	for _, sel := range selected {
+		if !matched[sel] {
+			return nil, errors.Errorf("no message with command or name %s in specification", sel)
+		}
+	}
+
+	return result, nil
+}
+
+// splitList splits the comma-separated list s, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) > 0 {
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
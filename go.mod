@@ -1,9 +1,21 @@
 module github.com/seoester/adcl
 
+go 1.21
+
 require (
 	github.com/cheekybits/genny v1.0.0
 	github.com/dave/jennifer v1.2.0
 	github.com/onsi/ginkgo v1.6.0
 	github.com/onsi/gomega v1.4.2
 	github.com/pkg/errors v0.8.0
+	gopkg.in/yaml.v2 v2.2.1
+)
+
+require (
+	github.com/hpcloud/tail v1.0.0 // indirect
+	golang.org/x/net v0.0.0-20180906233101-161cd47e91fd // indirect
+	golang.org/x/sys v0.0.0-20180909124046-d0be0721c37e // indirect
+	golang.org/x/text v0.3.0 // indirect
+	gopkg.in/fsnotify.v1 v1.4.7 // indirect
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
 )
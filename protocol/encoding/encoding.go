@@ -4,6 +4,8 @@ package encoding
 
 import (
 	"errors"
+	"net"
+	"net/netip"
 	"strings"
 	"unicode/utf8"
 )
@@ -11,6 +13,12 @@ import (
 // Error variables related to the encoding package.
 var (
 	ErrInvalidString = errors.New("invalid string, is not utf-8 encoded")
+	ErrInvalidIP     = errors.New("invalid ip address, cannot be parsed")
+	ErrInvalidTTH    = errors.New("invalid tth root, is not 39 characters of canonical base32")
+
+	ErrInvalidTimestamp = errors.New("invalid timestamp, is not an integral number of seconds")
+	ErrInvalidDuration  = errors.New("invalid duration, is not an integral number of seconds")
+	ErrInvalidFeatures  = errors.New("invalid feature list, is not a comma-separated list of four character names")
 )
 
 var encoder = strings.NewReplacer(
@@ -46,6 +54,36 @@ func DecodeADCString(s string) (string, error) {
 	return decoder.Replace(s), nil
 }
 
+// ParseIP parses an IPv4 or IPv6 address parameter. An error is returned if the
+// passed in string is not a textual representation of an IP address.
+func ParseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, ErrInvalidIP
+	}
+
+	return ip, nil
+}
+
+// ParseAddr parses an IPv4 or IPv6 address parameter. An error is returned if
+// the passed in string is not a textual representation of an IP address or
+// carries a zone, which ADC does not support.
+func ParseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, ErrInvalidIP
+	}
+
+	return addr, nil
+}
+
+// FormatAddr returns the textual representation of the IP address addr as
+// used by address parameters. IPv4-mapped IPv6 addresses are formatted as
+// IPv4 addresses.
+func FormatAddr(addr netip.Addr) string {
+	return addr.Unmap().String()
+}
+
 // Constants which are used in encodings and checks.
 const (
 	byteA byte = 'A'
@@ -0,0 +1,103 @@
+package encoding
+
+import (
+	"strings"
+)
+
+// Feature is the four character name of a protocol feature, as listed by
+// feature list parameters such as SU of INF.
+type Feature string
+
+// Known features, advertised as supported by clients.
+const (
+	FeatureTCP4 Feature = "TCP4"
+	FeatureTCP6 Feature = "TCP6"
+	FeatureUDP4 Feature = "UDP4"
+	FeatureUDP6 Feature = "UDP6"
+	FeatureADC0 Feature = "ADC0"
+	FeatureADCS Feature = "ADCS"
+	FeatureNAT0 Feature = "NAT0"
+	FeatureSEGA Feature = "SEGA"
+	FeatureASCH Feature = "ASCH"
+	FeatureKEYP Feature = "KEYP"
+)
+
+// Features is a set of features. The features are kept in the order in which
+// they were added, so that formatting a parsed set yields the parsed value.
+type Features []Feature
+
+// ParseFeatures parses a comma-separated feature list parameter. The empty
+// string is parsed as the empty set. An error is returned if any feature is
+// not four upper case letters or digits.
+func ParseFeatures(s string) (Features, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	names := strings.Split(s, ",")
+	features := make(Features, 0, len(names))
+	for _, name := range names {
+		if !isFeatureName(name) {
+			return nil, ErrInvalidFeatures
+		}
+		features.Add(Feature(name))
+	}
+
+	return features, nil
+}
+
+// isFeatureName returns true if name consists of four upper case letters or
+// digits.
+func isFeatureName(name string) bool {
+	if len(name) != 4 {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Has returns true if feature is in the set.
+func (f Features) Has(feature Feature) bool {
+	for _, ft := range f {
+		if ft == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add adds feature to the end of the set, if it is not in the set already.
+func (f *Features) Add(feature Feature) {
+	if !f.Has(feature) {
+		*f = append(*f, feature)
+	}
+}
+
+// Remove removes feature from the set, retaining the order of the other
+// features.
+func (f *Features) Remove(feature Feature) {
+	for i, ft := range *f {
+		if ft == feature {
+			*f = append((*f)[:i:i], (*f)[i+1:]...)
+			return
+		}
+	}
+}
+
+// String returns the comma-separated feature list parameter of the set.
+func (f Features) String() string {
+	names := make([]string, len(f))
+	for i, ft := range f {
+		names[i] = string(ft)
+	}
+
+	return strings.Join(names, ",")
+}
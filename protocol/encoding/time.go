@@ -0,0 +1,46 @@
+package encoding
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// ParseTimestamp parses a timestamp parameter, i.e. the decimal number of
+// seconds since the Unix epoch. The returned time is in UTC. An error is
+// returned if s is not a decimal integer.
+func ParseTimestamp(s string) (time.Time, error) {
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimestamp
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// FormatTimestamp returns the timestamp parameter of t. Fractions of seconds
+// are truncated.
+func FormatTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// ParseDuration parses a duration parameter, i.e. the decimal number of
+// seconds. Negative durations are kept, as some params assign them a meaning,
+// e.g. -1 for forever. An error is returned if s is not a decimal integer or
+// exceeds the range of time.Duration.
+func ParseDuration(s string) (time.Duration, error) {
+	const maxSec = math.MaxInt64 / int64(time.Second)
+
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || sec > maxSec || sec < -maxSec {
+		return 0, ErrInvalidDuration
+	}
+
+	return time.Duration(sec) * time.Second, nil
+}
+
+// FormatDuration returns the duration parameter of d. Fractions of seconds
+// are truncated.
+func FormatDuration(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
@@ -0,0 +1,36 @@
+package encoding
+
+// TTHLength is the length of base32 encoded TTH roots.
+const TTHLength = 39
+
+// TTH is the root of a Tiger tree hash, as used to identify files and
+// clients.
+type TTH [24]byte
+
+// ParseTTH parses the base32 encoded TTH root s. An error is returned if s is
+// not the canonical, TTHLength characters long base32 encoding of a root.
+func ParseTTH(s string) (TTH, error) {
+	var tth TTH
+
+	if len(s) != TTHLength {
+		return tth, ErrInvalidTTH
+	}
+
+	raw, err := DecodeBase32String(s)
+	if err != nil || len(raw) != len(tth) {
+		return tth, ErrInvalidTTH
+	}
+
+	copy(tth[:], raw)
+	// Trailing bits of the last character must be zero.
+	if tth.String() != s {
+		return TTH{}, ErrInvalidTTH
+	}
+
+	return tth, nil
+}
+
+// String returns the canonical base32 encoding of the root.
+func (t TTH) String() string {
+	return EncodeToBase32String(t[:])
+}
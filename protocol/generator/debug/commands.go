@@ -4,61 +4,607 @@ import (
 	"github.com/seoester/adcl/protocol/generator"
 )
 
+var messages = []*generator.Message{
+	&sidCommand,
+	&resCommand,
+	&lstCommand,
+	&mixCommand,
+	&bitCommand,
+	&gtdCommand,
+	&mrkCommand,
+	&infCommand,
+	&exfCommand,
+	&staCommand,
+	&quiCommand,
+	&msgCommand,
+	&pasCommand,
+	&dldCommand,
+	&schCommand,
+}
+
+var enums = []*generator.Enum{
+	&severityEnum,
+}
+
+var severityEnum = generator.Enum{
+	Name:    "Severity",
+	Type:    "int",
+	Comment: "Severity is the severity of a status.",
+	Values: []*generator.EnumValue{
+		&generator.EnumValue{Name: "Success", Value: "0"},
+		&generator.EnumValue{Name: "Recoverable", Value: "1"},
+		&generator.EnumValue{Name: "Fatal", Value: "2"},
+	},
+}
+
 var sidCommand = generator.Message{
 	Command: "SID",
+	Types:   "I",
+	Phases:  []string{"PROTOCOL"},
 	PositionalParams: []*generator.Param{
 		&generator.Param{
 			Mode:     generator.ParamModePositional,
 			Name:     "SID",
 			Type:     "base32",
 			Required: true,
+			Length:   4,
 		},
 	},
 }
 
 var resCommand = generator.Message{
 	Command: "RES",
+	Comment: "Search result, sent in response to a search request matching files of the client.",
+	Section: "ADC § 5.3.8",
 	NamedParams: []*generator.Param{
 		&generator.Param{
-			Mode:     generator.ParamModeNamed,
-			Name:     "FN",
-			Type:     "string",
-			Required: true,
+			Mode:        generator.ParamModeNamed,
+			Name:        "FN",
+			DisplayName: "File name",
+			Type:        "string",
+			Required:    true,
+			Comment:     "Full filename including path in share, using / as the path separator.",
 		},
 		&generator.Param{
 			Mode:     generator.ParamModeNamed,
 			Name:     "SI",
 			Type:     "int",
 			Required: true,
+			Min:      "0",
 		},
 		&generator.Param{
 			Mode:     generator.ParamModeNamed,
 			Name:     "SL",
 			Type:     "int",
 			Required: false,
+			Min:      "0",
+			Max:      "1024",
+		},
+		&generator.Param{
+			Mode:         generator.ParamModeNamed,
+			Name:         "TO",
+			Type:         "string",
+			Required:     true,
+			Sensitive:    true,
+			CacheKeyPart: true,
+		},
+		&generator.Param{
+			Mode:         generator.ParamModeNamed,
+			Name:         "TR",
+			Type:         "tth",
+			Required:     false,
+			Sensitive:    true,
+			CacheKeyPart: true,
 		},
 		&generator.Param{
 			Mode:     generator.ParamModeNamed,
-			Name:     "TO",
+			Name:     "TD",
+			Type:     "int",
+			Required: false,
+		},
+	},
+	Flags: []*generator.Flag{
+		&generator.Flag{
+			Comment: "FI, FO, DA; EXT § 3.27 ASCH - Extended searching capability (EXT v1.0.8)",
+		},
+	},
+	Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "RES FNdir/some\\sfile SI1024 SL3 TOtoken",
+			Values: map[string]string{"FN": "dir/some file", "SI": "1024", "SL": "3", "TO": "token"},
+		},
+		&generator.Example{
+			Line: "RES FNfile SI0 TOtoken TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ TD2",
+			Values: map[string]string{"FN": "file", "SI": "0", "TO": "token",
+				"TR": "LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ", "TD": "2"},
+		},
+	},
+}
+
+// lstCommand is a synthetic message with a single positional param of dynamic
+// multiplicity.
+var lstCommand = generator.Message{
+	Command: "LST",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Items",
+			Type:     "string",
+			Mapper:   "list",
+			Required: true,
+		},
+	},
+}
+
+// mixCommand is a synthetic message with positional params of mixed
+// multiplicity.
+var mixCommand = generator.Message{
+	Command: "MIX",
+	Types:   "BDH",
+	Phases:  []string{"NORMAL"},
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Code",
+			Type:     "int",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Items",
+			Type:     "string",
+			Mapper:   "list",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
 			Type:     "string",
 			Required: true,
 		},
+	},
+	NamedParams: []*generator.Param{
 		&generator.Param{
 			Mode:     generator.ParamModeNamed,
-			Name:     "TR",
-			Type:     "base32",
+			Name:     "NI",
+			Type:     "string",
 			Required: false,
 		},
+		&generator.Param{
+			Mode:          generator.ParamModeNamed,
+			Name:          "SV",
+			Type:          "int",
+			Required:      false,
+			AllowedValues: []string{"0", "1", "2"},
+		},
+		&generator.Param{
+			Mode:              generator.ParamModeNamed,
+			Name:              "PR",
+			Type:              "string",
+			Required:          false,
+			AllowedValues:     []string{"ADC/1.0"},
+			ValidationMessage: "PR must name a supported protocol revision",
+		},
+	},
+	Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "MIX 7 a b\\sc desc NInick",
+			Values: map[string]string{"Code": "7", "Items": "[a b c]", "Description": "desc", "NI": "nick"},
+		},
+		&generator.Example{
+			Line:   "MIX 7 desc",
+			Values: map[string]string{"Code": "7", "Description": "desc"},
+		},
+	},
+}
+
+// bitCommand is a synthetic message with a positional bitmask param.
+var bitCommand = generator.Message{
+	Command: "BIT",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Status",
+			Type:     "int",
+			Mapper:   "bitmask",
+			Required: true,
+			Bits:     []string{"Fatal", "Recoverable", "Permanent"},
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
+			Type:     "string",
+			Required: true,
+		},
+	},
+	Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "BIT 5 desc",
+			Values: map[string]string{"Status": "5", "Description": "desc"},
+		},
+	},
+}
+
+// gtdCommand is a synthetic message with an optional positional param in the
+// middle of the positional params, gated by a named param.
+var gtdCommand = generator.Message{
+	Command: "GTD",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Code",
+			Type:     "int",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Target",
+			Type:     "string",
+			Required: false,
+			GatedBy:  "TR",
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
+			Type:     "string",
+			Required: true,
+		},
+	},
+	NamedParams: []*generator.Param{
 		&generator.Param{
 			Mode:     generator.ParamModeNamed,
-			Name:     "TD",
+			Name:     "TR",
 			Type:     "int",
 			Required: false,
 		},
 	},
-	Flags: []*generator.Flag{
-		&generator.Flag{
-			Comment: "FI, FO, DA; EXT § 3.27 ASCH - Extended searching capability (EXT v1.0.8)",
+}
+
+// mrkCommand is a synthetic message with a fixed marker token between its
+// positional params.
+var mrkCommand = generator.Message{
+	Command: "MRK",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Code",
+			Type:     "int",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Marker",
+			Type:     "string",
+			Required: true,
+			Const:    "V2",
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
+			Type:     "string",
+			Required: true,
+		},
+	},
+}
+
+// infCommand mirrors the named params of the INF message of the ADC protocol.
+// It is used to benchmark the named accessors of large messages.
+var infCommand = generator.Message{
+	Command: "INF",
+	Types:   "BCI",
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:   generator.ParamModeNamed,
+			Name:   "ID",
+			Type:   "base32",
+			Length: 39,
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "PD",
+			Type: "bytes",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "I4",
+			Type: "addr",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "I6",
+			Type: "addr",
+		},
+		&generator.Param{
+			Mode:       generator.ParamModeNamed,
+			Name:       "U4",
+			Type:       "int",
+			RequiredIf: &generator.Condition{Param: "SU", Value: "UDP4"},
+		},
+		&generator.Param{
+			Mode:       generator.ParamModeNamed,
+			Name:       "U6",
+			Type:       "int",
+			RequiredIf: &generator.Condition{Param: "SU", Value: "UDP6"},
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "SS",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "SF",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "VE",
+			Type: "string",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "US",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "DS",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "SL",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "AS",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "AM",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "EM",
+			Type: "string",
+		},
+		&generator.Param{
+			Mode:     generator.ParamModeNamed,
+			Name:     "NI",
+			JSONName: "nick",
+			Type:     "string",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "DE",
+			Type: "string",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "HN",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "HR",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "HO",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "TO",
+			Type: "string",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "CT",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "AW",
+			Type: "int",
+		},
+		&generator.Param{
+			Mode:       generator.ParamModeNamed,
+			Name:       "OP",
+			Type:       "int",
+			Deprecated: "superseded by CT",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "SU",
+			Type: "features",
+		},
+	},
+}
+
+// exfCommand is a synthetic family of messages, whose commands start with EX.
+var exfCommand = generator.Message{
+	Command: "EX?",
+	Name:    "EXF",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
+			Type:     "string",
+			Required: true,
+		},
+	},
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModeNamed,
+			Name:     "NI",
+			Type:     "string",
+			Required: false,
+		},
+	},
+}
+
+// staCommand is a synthetic status message with a param of an enum type.
+var staCommand = generator.Message{
+	Command: "STA",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Severity",
+			Type:     "Severity",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Description",
+			Type:     "string",
+			Required: true,
+		},
+	},
+}
+
+var quiCommand = generator.Message{
+	Command: "QUI",
+	Comment: "Notification that a client has disconnected.",
+	Section: "ADC § 5.3.17",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "SID",
+			Type:     "base32",
+			Required: true,
+			Length:   4,
+		},
+	},
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:        generator.ParamModeNamed,
+			Name:        "TL",
+			DisplayName: "Time left",
+			Type:        "duration",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "MS",
+			Type: "string",
+		},
+	}, Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "QUI AAAB TL-1 MSbanned",
+			Values: map[string]string{"SID": "AAAB", "TL": "-1s", "MS": "banned"},
+		},
+	},
+}
+
+// msgCommand is a chat message carrying the timestamp of the TS extension.
+var msgCommand = generator.Message{
+	Command: "MSG",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Text",
+			Type:     "string",
+			Required: true,
+		},
+	},
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:        generator.ParamModeNamed,
+			Name:        "TS",
+			DisplayName: "Timestamp",
+			Type:        "timestamp",
+		},
+	},
+}
+
+var pasCommand = generator.Message{
+	Command: "PAS",
+	Comment: "Password hash, sent in response to a password request of the hub.",
+	Section: "ADC § 5.3.4",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:      generator.ParamModePositional,
+			Name:      "Password",
+			Type:      "base32",
+			Required:  true,
+			Sensitive: true,
+		},
+	},
+}
+
+// dldCommand is a synthetic download request, naming the file either by its
+// TTH or by its name.
+var dldCommand = generator.Message{
+	Command: "DLD",
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "TR",
+			Type: "tth",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "FN",
+			Type: "string",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "SI",
+			Type: "int",
+		},
+	},
+	OneOf: [][]string{{"TR", "FN"}},
+	Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "DLD FNfile SI42",
+			Values: map[string]string{"FN": "file", "SI": "42"},
+		},
+	},
+}
+
+// schCommand is a search request with repeated named params, each term of
+// AN, NO and EX being a param of its own.
+var schCommand = generator.Message{
+	Command: "SCH",
+	Comment: "Search request, matching files by the search terms.",
+	Section: "ADC § 5.3.7",
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:    generator.ParamModeNamed,
+			Name:    "AN",
+			Type:    "string",
+			Mapper:  "list",
+			Comment: "Search terms all of which must be contained in the full path of a file.",
+		},
+		&generator.Param{
+			Mode:    generator.ParamModeNamed,
+			Name:    "NO",
+			Type:    "string",
+			Mapper:  "list",
+			Comment: "Search terms none of which may be contained in the full path of a file.",
+		},
+		&generator.Param{
+			Mode:    generator.ParamModeNamed,
+			Name:    "EX",
+			Type:    "string",
+			Mapper:  "list",
+			Comment: "File extensions, one of which a file must have.",
+		},
+		&generator.Param{
+			Mode:    generator.ParamModeNamed,
+			Name:    "TO",
+			Type:    "string",
+			Comment: "Token identifying the search, to be included in the results.",
+		},
+	},
+	Examples: []*generator.Example{
+		&generator.Example{
+			Line:   "SCH ANsome ANfile NOold EXmkv EXmp4 TOtoken",
+			Values: map[string]string{"AN": "[some file]", "NO": "[old]", "EX": "[mkv mp4]", "TO": "token"},
 		},
 	},
 }
@@ -1,13 +1,92 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/seoester/adcl/protocol/generator"
 )
 
+// main generates the content types of all messages into the current working
+// directory, one content_???.go file and one content_???_test.go file per
+// message as well as the shared adc_support.go file. The content types are
+// generated with NoReflect into the noreflect directory next to it as well.
 func main() {
-	g := generator.NewStructGenerator(&resCommand)
-	err := g.Generate()
+	definition := &generator.Definition{
+		Enums:    enums,
+		Messages: messages,
+	}
+
+	err := generateFiles(definition)
+	if err != nil {
+		panic(err)
+	}
+
+	err = generateNoReflectFiles(definition)
 	if err != nil {
 		panic(err)
 	}
+
+	for _, message := range messages {
+		err := generateTestFile(message)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func generateFiles(definition *generator.Definition) error {
+	g := generator.NewFileGenerator(definition, generator.WithRawPassthrough(), generator.WithClone(), generator.WithEncodeTo(),
+		generator.WithStructTags())
+	files, err := g.RenderFiles()
+	if err != nil {
+		return err
+	}
+
+	for name, src := range files {
+		err := ioutil.WriteFile(name, src, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateNoReflectFiles generates the content types with NoReflect into the
+// noreflect package, which is only built under the noreflect build tag.
+func generateNoReflectFiles(definition *generator.Definition) error {
+	g := generator.NewFileGenerator(definition, generator.WithNoReflect(), generator.WithPackage("noreflect"),
+		generator.WithBuildTags("noreflect"), generator.WithHelpers())
+	files, err := g.RenderFiles()
+	if err != nil {
+		return err
+	}
+
+	for name, src := range files {
+		err := ioutil.WriteFile(filepath.Join("..", "noreflect", name), src, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func generateTestFile(message *generator.Message) error {
+	name := message.Name
+	if len(name) == 0 {
+		name = message.Command
+	}
+
+	f, err := os.Create("content_" + strings.ToLower(name) + "_test.go")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	g := generator.NewStructGenerator(message, generator.WithEnums(enums...))
+	return g.RenderTest(f)
 }
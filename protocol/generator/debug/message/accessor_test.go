@@ -0,0 +1,375 @@
+package message_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+// recoverPanic calls f and returns the value passed to panic.
+func recoverPanic(f func()) (val interface{}) {
+	defer func() {
+		val = recover()
+	}()
+
+	f()
+	return
+}
+
+var _ = Describe("PosAt()", func() {
+	var sid SIDContent
+	var res RESContent
+	var lst LSTContent
+	var mix MIXContent
+
+	BeforeEach(func() {
+		Ω(sid.ParseInto([]string{"AAAB"}, nil)).Should(Succeed())
+		Ω(res.ParseInto([]string{"FNfile", "SI42", "TOtoken"}, nil)).Should(Succeed())
+		Ω(lst.ParseInto([]string{"a", "b"}, nil)).Should(Succeed())
+		Ω(mix.ParseInto([]string{"1", "a", "b", "description", "NInick"}, nil)).Should(Succeed())
+	})
+
+	It("should return positionals of all layouts", func() {
+		Ω(sid.PosAt(0)).Should(Equal("AAAB"))
+		Ω(lst.PosAt(1)).Should(Equal("b"))
+		Ω(mix.PosAt(0)).Should(Equal("1"))
+		Ω(mix.PosAt(2)).Should(Equal("b"))
+		Ω(mix.PosAt(3)).Should(Equal("description"))
+	})
+
+	It("should panic with the same message for all layouts", func() {
+		accessors := map[string]ParamAccessor{"SID": &sid, "RES": &res, "LST": &lst, "MIX": &mix}
+
+		for command, accessor := range accessors {
+			n := accessor.PosLen()
+			Ω(recoverPanic(func() {
+				accessor.PosAt(n)
+			})).Should(Equal(fmt.Sprintf("%s.PosAt: index %d out of range [0,%d)", command, n, n)))
+
+			Ω(recoverPanic(func() {
+				accessor.PosAt(-1)
+			})).Should(Equal(fmt.Sprintf("%s.PosAt: index -1 out of range [0,%d)", command, n)))
+		}
+	})
+
+	It("should name the index in the panic of gated layouts", func() {
+		var gtd GTDContent
+		Ω(gtd.ParseInto([]string{"1", "desc"}, nil)).Should(Succeed())
+
+		Ω(recoverPanic(func() {
+			gtd.PosAt(5)
+		})).Should(Equal("GTD.PosAt: index 5 out of range [0,2)"))
+	})
+})
+
+var _ = Describe("PosByName()", func() {
+	var mix MIXContent
+
+	BeforeEach(func() {
+		Ω(mix.ParseInto([]string{"1", "a", "b", "description"}, nil)).Should(Succeed())
+	})
+
+	It("should return the positional of a known name", func() {
+		val, ok := mix.PosByName("Code")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("1"))
+
+		val, ok = mix.PosByName("Description")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("description"))
+	})
+
+	It("should return the first value of a multi-valued param", func() {
+		val, ok := mix.PosByName("Items")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("a"))
+
+		Ω(mix.ParseInto([]string{"1", "description"}, nil)).Should(Succeed())
+		_, ok = mix.PosByName("Items")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should not return anything for unknown names", func() {
+		_, ok := mix.PosByName("Unknown")
+		Ω(ok).Should(BeFalse())
+
+		var res RESContent
+		_, ok = res.PosByName("FN")
+		Ω(ok).Should(BeFalse())
+	})
+})
+
+var _ = Describe("Typed getters", func() {
+	var res RESContent
+
+	BeforeEach(func() {
+		Ω(res.ParseInto([]string{"FNfile\\sname", "SI42", "TOtoken", "TD3"}, nil)).Should(Succeed())
+	})
+
+	It("should return the decoded value of present named params", func() {
+		fn, ok := res.GetFN()
+		Ω(ok).Should(BeTrue())
+		Ω(fn).Should(Equal("file name"))
+
+		td, ok := res.GetTD()
+		Ω(ok).Should(BeTrue())
+		Ω(td).Should(Equal(3))
+	})
+
+	It("should report absent named params", func() {
+		_, ok := res.GetSL()
+		Ω(ok).Should(BeFalse())
+
+		var empty RESContent
+		_, ok = empty.GetTO()
+		Ω(ok).Should(BeFalse())
+	})
+})
+
+var _ = Describe("UnknownFlags()", func() {
+	var cnt MIXContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"1", "a", "desc", "NInick", "SV1", "XXext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should exclude known flags", func() {
+		Ω(cnt.UnknownFlags()).Should(Equal(map[string]string{"XX": "ext"}))
+		Ω(cnt.Named()).Should(HaveKey("NI"))
+	})
+
+	It("should return a copy", func() {
+		flags := cnt.UnknownFlags()
+		flags["YY"] = "other"
+		Ω(cnt.Flags).ShouldNot(HaveKey("YY"))
+	})
+
+	It("should return an empty map if there are no unknown flags", func() {
+		var other MIXContent
+		Ω(other.ParseInto([]string{"1", "desc", "NInick"}, nil)).Should(Succeed())
+		Ω(other.UnknownFlags()).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("ContentBase", func() {
+	It("should provide the named accessors of contents without named params", func() {
+		var lst LSTContent
+		Ω(lst.ParseInto([]string{"a", "b", "XXext"}, nil)).Should(Succeed())
+
+		var accessor ParamAccessor = &lst
+		Ω(accessor.Positional()).Should(Equal([]string{"a", "b"}))
+		Ω(accessor.PosAt(1)).Should(Equal("b"))
+		Ω(accessor.Named()).Should(Equal(map[string]string{"XX": "ext"}))
+
+		val, ok := accessor.NamedGet("XX")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("ext"))
+	})
+
+	It("should be overridden by the accessors of contents with named params", func() {
+		var mix MIXContent
+		Ω(mix.ParseInto([]string{"1", "desc", "NInick", "XXext"}, nil)).Should(Succeed())
+
+		var accessor ParamAccessor = &mix
+		Ω(accessor.PosAt(1)).Should(Equal("desc"))
+		Ω(accessor.Named()).Should(Equal(map[string]string{"NI": "nick", "XX": "ext"}))
+
+		val, ok := accessor.NamedGet("NI")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("nick"))
+
+		Ω(mix.ContentBase.Named()).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+
+	It("should return snapshots from Named() with and without named params", func() {
+		var lst LSTContent
+		Ω(lst.ParseInto([]string{"a", "XXext"}, nil)).Should(Succeed())
+		var mix MIXContent
+		Ω(mix.ParseInto([]string{"1", "desc", "NInick", "XXext"}, nil)).Should(Succeed())
+
+		for _, accessor := range []ParamAccessor{&lst, &mix} {
+			named := accessor.Named()
+			named["XX"] = "changed"
+			named["YY"] = "added"
+			delete(named, "NI")
+
+			val, ok := accessor.NamedGet("XX")
+			Ω(ok).Should(BeTrue())
+			Ω(val).Should(Equal("ext"))
+			_, ok = accessor.NamedGet("YY")
+			Ω(ok).Should(BeFalse())
+		}
+		Ω(mix.NI.Value).Should(Equal("nick"))
+		Ω(lst.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+})
+
+var _ = Describe("SetOptionalCount()", func() {
+	It("should return 0 if no optional params are set", func() {
+		var res RESContent
+		Ω(res.ParseInto([]string{"FNfile", "SI42", "TOtoken"}, nil)).Should(Succeed())
+		Ω(res.SetOptionalCount()).Should(Equal(0))
+	})
+
+	It("should count the set optional named params", func() {
+		var res RESContent
+		Ω(res.ParseInto([]string{"FNfile", "SI42", "TOtoken", "SL3"}, nil)).Should(Succeed())
+		Ω(res.SetOptionalCount()).Should(Equal(1))
+
+		Ω(res.ParseInto([]string{"FNfile", "SI42", "TOtoken", "SL3", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ", "TD1"}, nil)).Should(Succeed())
+		Ω(res.SetOptionalCount()).Should(Equal(3))
+
+		res.SL.Unset()
+		Ω(res.SetOptionalCount()).Should(Equal(2))
+	})
+
+	It("should count set optional positional params", func() {
+		var gtd GTDContent
+		Ω(gtd.ParseInto([]string{"1", "description"}, nil)).Should(Succeed())
+		Ω(gtd.SetOptionalCount()).Should(Equal(0))
+
+		Ω(gtd.ParseInto([]string{"1", "target", "description", "TR1"}, nil)).Should(Succeed())
+		Ω(gtd.SetOptionalCount()).Should(Equal(2))
+	})
+
+	It("should return 0 for messages without optional params", func() {
+		var sid SIDContent
+		Ω(sid.ParseInto([]string{"AAAB"}, nil)).Should(Succeed())
+		Ω(sid.SetOptionalCount()).Should(Equal(0))
+	})
+})
+
+var _ = Describe("SetNamedAll()", func() {
+	var mix MIXContent
+
+	BeforeEach(func() {
+		Ω(mix.ParseInto([]string{"1", "a", "description", "NInick", "SV1", "XXext"}, nil)).Should(Succeed())
+	})
+
+	It("should replace all named params and flags", func() {
+		named := map[string]string{"PR": "ADC/1.0", "SV": "2", "YY": "other"}
+		Ω(mix.SetNamedAll(named)).Should(Succeed())
+
+		Ω(mix.Named()).Should(Equal(named))
+		Ω(mix.NI.IsSet).Should(BeFalse())
+		Ω(mix.SV.Value).Should(Equal(2))
+		Ω(mix.PR.Value).Should(Equal("ADC/1.0"))
+		Ω(mix.Flags).Should(Equal(map[string]string{"YY": "other"}))
+		Ω(mix.Positional()).Should(Equal([]string{"1", "a", "description"}))
+	})
+
+	It("should clear all named params if named is empty", func() {
+		Ω(mix.SetNamedAll(nil)).Should(Succeed())
+		Ω(mix.Named()).Should(BeEmpty())
+		Ω(mix.SetOptionalCount()).Should(Equal(0))
+	})
+
+	It("should store flags of messages without named params", func() {
+		var lst LSTContent
+		Ω(lst.SetNamedAll(map[string]string{"XX": "ext"})).Should(Succeed())
+		Ω(lst.Named()).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+
+	It("should return ErrMalformedFlag for keys not two characters long", func() {
+		err := mix.SetNamedAll(map[string]string{"NIX": "nick"})
+		Ω(errors.Is(err, ErrMalformedFlag)).Should(BeTrue())
+	})
+
+	It("should return an error for values which cannot be decoded", func() {
+		Ω(mix.SetNamedAll(map[string]string{"SV": "x"})).ShouldNot(Succeed())
+	})
+
+	It("should not modify the content if an error is returned", func() {
+		Ω(mix.SetNamedAll(map[string]string{"NI": "other", "PR": "ADC/1.0", "SV": "x"})).ShouldNot(Succeed())
+		Ω(mix.Named()).Should(Equal(map[string]string{"NI": "nick", "SV": "1", "XX": "ext"}))
+		Ω(mix.NI.Value).Should(Equal("nick"))
+		Ω(mix.PR.IsSet).Should(BeFalse())
+		Ω(mix.SV.Value).Should(Equal(1))
+	})
+})
+
+var _ = Describe("Flag types", func() {
+	It("should report generated flag constants as known", func() {
+		Ω(RESFlagFN.IsKnown()).Should(BeTrue())
+		Ω(MIXFlagSV.IsKnown()).Should(BeTrue())
+	})
+
+	It("should report arbitrary flags as unknown", func() {
+		Ω(RESFlag("ZZ").IsKnown()).Should(BeFalse())
+		Ω(MRKFlag("ZZ").IsKnown()).Should(BeFalse())
+	})
+
+	It("should name the flag constant in String()", func() {
+		Ω(RESFlagFN.String()).Should(Equal("RESFlagFN"))
+		Ω(RESFlag("ZZ").String()).Should(Equal("RESFlag(ZZ)"))
+	})
+})
+
+var _ = Describe("Accessors of modified fields", func() {
+	It("should encode the values of modified fields", func() {
+		var res, orig RESContent
+		Ω(res.ParseInto([]string{"FNname", "SI5", "TOtoken"}, nil)).Should(Succeed())
+		Ω(orig.ParseInto([]string{"FNname", "SI5", "TOtoken"}, nil)).Should(Succeed())
+
+		res.SI = 7
+		res.FN = "other name"
+		res.MarkDirty()
+
+		Ω(res.MarshalADC()).Should(Equal([]byte("RES FNother\\sname SI7 TOtoken\n")))
+		Ω(res.Named()).Should(Equal(map[string]string{"FN": "other\\sname", "SI": "7", "TO": "token"}))
+		si, ok := res.NamedGet("SI")
+		Ω(ok).Should(BeTrue())
+		Ω(si).Should(Equal("7"))
+		Ω(res.NamedAll("FN")).Should(Equal([]string{"other\\sname"}))
+		Ω(res.ToMap()).Should(HaveKeyWithValue("SI", "7"))
+		Ω(res.Equal(&orig)).Should(BeFalse())
+		Ω(res.HashKey()).ShouldNot(Equal(orig.HashKey()))
+	})
+
+	It("should encode the values of contents built from the fields", func() {
+		res := &RESContent{FN: "f", TO: "t", SI: 3}
+		var parsed RESContent
+		Ω(parsed.ParseInto([]string{"FNf", "SI3", "TOt"}, nil)).Should(Succeed())
+
+		Ω(res.Named()).Should(Equal(map[string]string{"FN": "f", "SI": "3", "TO": "t"}))
+		Ω(res.ToMap()).Should(Equal(map[string]string{"FN": "f", "SI": "3", "TO": "t"}))
+		Ω(res.Equal(&parsed)).Should(BeTrue())
+		Ω(res.HashKey()).Should(Equal(parsed.HashKey()))
+		Ω(res.MarshalADC()).Should(Equal([]byte("RES FNf SI3 TOt\n")))
+
+		si, ok := res.GetSI()
+		Ω(ok).Should(BeTrue())
+		Ω(si).Should(Equal(3))
+
+		sta := &STAContent{Severity: SeverityFatal, Description: "failed"}
+		Ω(sta.Positional()).Should(Equal([]string{"2", "failed"}))
+		Ω(sta.PosAt(0)).Should(Equal("2"))
+		Ω(sta.MarshalADC()).Should(Equal([]byte("STA 2 failed\n")))
+	})
+})
+
+func BenchmarkNamedGet(b *testing.B) {
+	var inf INFContent
+	err := inf.ParseInto([]string{"IDAAAB", "I4127.0.0.1", "SS1024", "VEadcl", "NInick",
+		"DEdescription", "HN1", "SUADC0,TCP4", "XXext"}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	keys := []string{"ID", "I4", "SS", "VE", "NI", "DE", "HN", "SU", "PD", "HO", "XX", "YY"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			_, _ = inf.NamedGet(key)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Bitmask params", func() {
+	var cnt BITContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"5", "desc"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should decode the bitmask into its bits", func() {
+		Ω(cnt.Status.Fatal).Should(BeTrue())
+		Ω(cnt.Status.Recoverable).Should(BeFalse())
+		Ω(cnt.Status.Permanent).Should(BeTrue())
+	})
+
+	It("should encode the bits into the combined value", func() {
+		Ω(cnt.Positional()).Should(Equal([]string{"5", "desc"}))
+		Ω(cnt.PosAt(0)).Should(Equal("5"))
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("BIT 5 desc\n")))
+	})
+
+	It("should reflect modified bits when marshalling", func() {
+		cnt.Status.Recoverable = true
+		cnt.Status.Permanent = false
+		Ω(cnt.PosAt(0)).Should(Equal("3"))
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("BIT 3 desc\n")))
+	})
+
+	It("should round-trip every valid bitmask", func() {
+		for _, status := range []string{"0", "1", "2", "3", "4", "5", "6", "7"} {
+			var c BITContent
+			Ω(c.ParseInto([]string{status, "desc"}, nil)).Should(Succeed())
+			Ω(c.PosAt(0)).Should(Equal(status))
+		}
+	})
+
+	It("should return ErrUnknownBits for bitmasks with unknown bits set", func() {
+		err := cnt.ParseInto([]string{"8", "desc"}, nil)
+		Ω(err).Should(MatchError(ContainSubstring(ErrUnknownBits.Error())))
+
+		err = cnt.ParseInto([]string{"-1", "desc"}, nil)
+		Ω(err).Should(MatchError(ContainSubstring(ErrUnknownBits.Error())))
+	})
+})
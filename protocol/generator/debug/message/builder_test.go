@@ -0,0 +1,70 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/seoester/adcl/protocol/encoding"
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Builder", func() {
+	It("should set the fields and the escaped values", func() {
+		cnt, err := NewRESBuilder().
+			FN("some file").
+			SI(42).
+			SL(3).
+			TO("token").
+			Build()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.FN).Should(Equal("some file"))
+		Ω(cnt.SI).Should(Equal(42))
+		Ω(cnt.SL.Value).Should(Equal(3))
+		Ω(cnt.ADCString()).Should(Equal("RES FNsome\\sfile SI42 SL3 TOtoken\n"))
+	})
+
+	It("should replace all values of multi-valued params", func() {
+		cnt, err := NewMIXBuilder().
+			Code(7).
+			Items("x").
+			Items("a", "b c").
+			Description("desc").
+			Build()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.Items).Should(Equal([]string{"a", "b c"}))
+		Ω(cnt.ADCString()).Should(Equal("MIX 7 a b\\sc desc\n"))
+	})
+
+	It("should set the command of families", func() {
+		cnt, err := NewEXFBuilder("EXA").
+			Description("desc").
+			Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Command()).Should(Equal("EXA"))
+
+		_, err = NewEXFBuilder("SID").Description("desc").Build()
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("should return the first setter error", func() {
+		_, err := NewRESBuilder().
+			FN("\xff").
+			SI(-1).
+			TO("token").
+			Build()
+		Ω(errors.Is(err, encoding.ErrInvalidString)).Should(BeTrue())
+		Ω(errors.Is(err, ErrOutOfRange)).Should(BeFalse())
+	})
+
+	It("should validate the content", func() {
+		_, err := NewRESBuilder().FN("file").SI(1).Build()
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+
+		_, err = NewRESBuilder().FN("file").SI(1).SL(2048).TO("token").Build()
+		Ω(errors.Is(err, ErrOutOfRange)).Should(BeTrue())
+	})
+})
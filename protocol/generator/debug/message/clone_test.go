@@ -0,0 +1,42 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/seoester/adcl/protocol/encoding"
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Clone()", func() {
+	It("should return an equal content", func() {
+		var cnt MIXContent
+		Ω(cnt.UnmarshalADC([]byte("MIX 7 a b desc NInick XXext\n"))).Should(Succeed())
+
+		clone := cnt.Clone()
+		Ω(clone.Equal(&cnt)).Should(BeTrue())
+		Ω(clone.Items).Should(Equal(cnt.Items))
+		Ω(clone.ADCString()).Should(Equal(cnt.ADCString()))
+	})
+
+	It("should not share memory with the content", func() {
+		var cnt INFContent
+		Ω(cnt.UnmarshalADC([]byte("INF PDAEBAG SUTCP4 XXext\n"))).Should(Succeed())
+
+		clone := cnt.Clone()
+		cnt.PD.Value[0] = 0xff
+		cnt.SU.Value[0] = encoding.FeatureUDP4
+		cnt.Flags["XX"] = "changed"
+
+		Ω(clone.PD.Value).Should(Equal([]byte{1, 2, 3}))
+		Ω(clone.SU.Value).Should(Equal(encoding.Features{encoding.FeatureTCP4}))
+		Ω(clone.Flags["XX"]).Should(Equal("ext"))
+
+		var mix MIXContent
+		Ω(mix.ParseInto([]string{"7", "a", "b", "desc"}, nil)).Should(Succeed())
+
+		mixClone := mix.Clone()
+		mix.Items[0] = "changed"
+		Ω(mixClone.Items).Should(Equal([]string{"a", "b"}))
+	})
+})
@@ -0,0 +1,162 @@
+package message
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Error variables related to LengthPrefixCodec.
+var (
+	ErrInvalidPrefixSize = errors.New("length prefix size must be 1, 2 or 4 bytes")
+	ErrFrameTooLong      = errors.New("frame too long for length prefix")
+)
+
+// DefaultMaxFrameLength is the maximum length of frames read and written by a
+// LengthPrefixCodec without MaxLength.
+const DefaultMaxFrameLength = 1 << 20
+
+// frameChunkSize is the length up to which frames are read into a buffer
+// allocated up front. Longer frames are read incrementally, so the memory
+// allocated is bounded by the bytes actually received.
+const frameChunkSize = 1 << 16
+
+// LengthPrefixCodec reads and writes message frames prefixed by their
+// length, as used by some ADC-over-TCP deployments. The length prefix
+// replaces the newline terminating each frame, frames are written without the
+// terminator and read frames do not contain it.
+type LengthPrefixCodec struct {
+	// PrefixSize is the size of the length prefix in bytes, one of 1, 2 or
+	// 4. The length is encoded in big-endian byte order.
+	PrefixSize int
+	// MaxLength limits the length of frames read and written. If zero,
+	// DefaultMaxFrameLength is used. The length is always limited by the
+	// maximum value of the prefix.
+	MaxLength int
+}
+
+// WriteFrame writes frame to w, prefixed by its length. A trailing newline
+// of frame, as appended by FrameBuilder, is not written.
+func (c *LengthPrefixCodec) WriteFrame(w io.Writer, frame []byte) error {
+	if n := len(frame); n > 0 && frame[n-1] == '\n' {
+		frame = frame[:n-1]
+	}
+
+	maxLength, err := c.maxLength()
+	if err != nil {
+		return err
+	}
+	if uint64(len(frame)) > maxLength {
+		return ErrFrameTooLong
+	}
+
+	buf := make([]byte, c.PrefixSize, c.PrefixSize+len(frame))
+	c.putLength(buf, len(frame))
+	buf = append(buf, frame...)
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// ReadFrame reads the next frame from r. Partial reads of the prefix and the
+// frame are continued until the frame is complete. io.EOF is returned if r
+// is exhausted before a frame starts, io.ErrUnexpectedEOF if it is exhausted
+// within a frame.
+func (c *LengthPrefixCodec) ReadFrame(r io.Reader) ([]byte, error) {
+	maxLength, err := c.maxLength()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, c.PrefixSize)
+	_, err = io.ReadFull(r, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	length := c.length(prefix)
+	if length > maxLength {
+		return nil, ErrFrameTooLong
+	}
+
+	if length > frameChunkSize {
+		return readFrameIncrementally(r, length)
+	}
+
+	frame := make([]byte, length)
+	_, err = io.ReadFull(r, frame)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// readFrameIncrementally reads the frame of the given length from r, growing
+// the buffer as the bytes of the frame are received.
+func readFrameIncrementally(r io.Reader, length uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, r, int64(length))
+	if uint64(n) < length && (err == nil || err == io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// maxLength returns the maximum length of frames accepted by the codec.
+func (c *LengthPrefixCodec) maxLength() (uint64, error) {
+	var maxLength uint64
+
+	switch c.PrefixSize {
+	case 1:
+		maxLength = 1<<8 - 1
+	case 2:
+		maxLength = 1<<16 - 1
+	case 4:
+		maxLength = 1<<32 - 1
+	default:
+		return 0, ErrInvalidPrefixSize
+	}
+
+	limit := uint64(DefaultMaxFrameLength)
+	if c.MaxLength > 0 {
+		limit = uint64(c.MaxLength)
+	}
+	if limit < maxLength {
+		maxLength = limit
+	}
+
+	return maxLength, nil
+}
+
+// putLength encodes length into the prefix buf.
+func (c *LengthPrefixCodec) putLength(buf []byte, length int) {
+	switch c.PrefixSize {
+	case 1:
+		buf[0] = byte(length)
+	case 2:
+		binary.BigEndian.PutUint16(buf, uint16(length))
+	case 4:
+		binary.BigEndian.PutUint32(buf, uint32(length))
+	}
+}
+
+// length decodes the length from the prefix buf.
+func (c *LengthPrefixCodec) length(buf []byte) uint64 {
+	switch c.PrefixSize {
+	case 1:
+		return uint64(buf[0])
+	case 2:
+		return uint64(binary.BigEndian.Uint16(buf))
+	default:
+		return uint64(binary.BigEndian.Uint32(buf))
+	}
+}
@@ -0,0 +1,123 @@
+package message_test
+
+import (
+	"bytes"
+	"io"
+	"testing/iotest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("LengthPrefixCodec", func() {
+	var (
+		codec LengthPrefixCodec
+		frame []byte
+	)
+
+	BeforeEach(func() {
+		codec = LengthPrefixCodec{PrefixSize: 2}
+
+		var cnt MIXContent
+		Ω(cnt.ParseInto([]string{"1", "a", "b", "desc", "NInick"}, nil)).Should(Succeed())
+
+		var err error
+		b := FrameBuilder{Type: TypeHubmessage}
+		frame, err = b.Build(&cnt)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should write the frame prefixed by its length without the terminator", func() {
+		buf := bytes.NewBuffer(nil)
+		Ω(codec.WriteFrame(buf, frame)).Should(Succeed())
+		Ω(buf.Bytes()).Should(Equal(append([]byte{0, 22}, "HMIX 1 a b desc NInick"...)))
+	})
+
+	It("should round-trip a message", func() {
+		buf := bytes.NewBuffer(nil)
+		Ω(codec.WriteFrame(buf, frame)).Should(Succeed())
+		Ω(codec.WriteFrame(buf, frame)).Should(Succeed())
+
+		for i := 0; i < 2; i++ {
+			read, err := codec.ReadFrame(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			cnt, err := ParseFrame(read)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(cnt.(*MIXContent).Items).Should(Equal([]string{"a", "b"}))
+			Ω(cnt.(*MIXContent).NI.Value).Should(Equal("nick"))
+		}
+
+		_, err := codec.ReadFrame(buf)
+		Ω(err).Should(Equal(io.EOF))
+	})
+
+	It("should continue partial reads of the prefix and the frame", func() {
+		codec.PrefixSize = 4
+
+		buf := bytes.NewBuffer(nil)
+		Ω(codec.WriteFrame(buf, frame)).Should(Succeed())
+
+		read, err := codec.ReadFrame(iotest.OneByteReader(buf))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(read).Should(Equal(frame[:len(frame)-1]))
+	})
+
+	It("should return io.ErrUnexpectedEOF for truncated frames", func() {
+		buf := bytes.NewBuffer(nil)
+		Ω(codec.WriteFrame(buf, frame)).Should(Succeed())
+
+		_, err := codec.ReadFrame(bytes.NewReader(buf.Bytes()[:10]))
+		Ω(err).Should(Equal(io.ErrUnexpectedEOF))
+
+		_, err = codec.ReadFrame(bytes.NewReader(buf.Bytes()[:1]))
+		Ω(err).Should(Equal(io.ErrUnexpectedEOF))
+	})
+
+	It("should return ErrFrameTooLong for frames exceeding the maximum length", func() {
+		codec.MaxLength = 10
+
+		err := codec.WriteFrame(bytes.NewBuffer(nil), frame)
+		Ω(err).Should(Equal(ErrFrameTooLong))
+
+		_, err = codec.ReadFrame(bytes.NewReader(append([]byte{0, 22}, frame...)))
+		Ω(err).Should(Equal(ErrFrameTooLong))
+	})
+
+	It("should limit the length of frames to DefaultMaxFrameLength by default", func() {
+		codec.PrefixSize = 4
+
+		_, err := codec.ReadFrame(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+		Ω(err).Should(Equal(ErrFrameTooLong))
+
+		long := bytes.Repeat([]byte{'a'}, DefaultMaxFrameLength+1)
+		err = codec.WriteFrame(bytes.NewBuffer(nil), long)
+		Ω(err).Should(Equal(ErrFrameTooLong))
+	})
+
+	It("should read long frames incrementally", func() {
+		codec.PrefixSize = 4
+		long := bytes.Repeat([]byte{'a'}, DefaultMaxFrameLength)
+
+		buf := bytes.NewBuffer(nil)
+		Ω(codec.WriteFrame(buf, long)).Should(Succeed())
+		read, err := codec.ReadFrame(iotest.HalfReader(buf))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(read).Should(Equal(long))
+
+		_, err = codec.ReadFrame(bytes.NewReader([]byte{0, 0x0f, 0, 0, 'a'}))
+		Ω(err).Should(Equal(io.ErrUnexpectedEOF))
+	})
+
+	It("should return ErrInvalidPrefixSize for unsupported prefix sizes", func() {
+		codec.PrefixSize = 3
+
+		err := codec.WriteFrame(bytes.NewBuffer(nil), frame)
+		Ω(err).Should(Equal(ErrInvalidPrefixSize))
+
+		_, err = codec.ReadFrame(bytes.NewReader(frame))
+		Ω(err).Should(Equal(ErrInvalidPrefixSize))
+	})
+})
@@ -0,0 +1,66 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("conditional requirements", func() {
+	It("should require a param if the condition holds", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"SUTCP4,UDP4"}, nil)
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("param U4 of message INF"))
+		Ω(err.Error()).Should(ContainSubstring("required if SU contains UDP4"))
+
+		Ω(cnt.ParseInto([]string{"SUTCP4,UDP4", "U4412"}, nil)).Should(Succeed())
+	})
+
+	It("should not require the param otherwise", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"SUTCP4,UDP6", "U6412"}, nil)).Should(Succeed())
+		Ω(cnt.ParseInto([]string{"NIpeer"}, nil)).Should(Succeed())
+	})
+
+	It("should be checked by Validate", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"SUUDP4", "U4412"}, nil)).Should(Succeed())
+		Ω(cnt.Validate()).Should(Succeed())
+
+		cnt.U4.IsSet = false
+		Ω(errors.Is(cnt.Validate(), ErrMissingParam)).Should(BeTrue())
+	})
+})
+
+var _ = Describe("one-of groups", func() {
+	It("should accept exactly one param of the group", func() {
+		var cnt DLDContent
+		Ω(cnt.ParseInto([]string{"FNfile"}, nil)).Should(Succeed())
+		Ω(cnt.ParseInto([]string{"TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ", "SI42"}, nil)).Should(Succeed())
+	})
+
+	It("should reject none or several params of the group", func() {
+		for _, params := range [][]string{
+			{"SI42"},
+			{"FNfile", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"},
+		} {
+			var cnt DLDContent
+			err := cnt.ParseInto(params, nil)
+			Ω(errors.Is(err, ErrOneOf)).Should(BeTrue(), "%v", params)
+			Ω(err.Error()).Should(ContainSubstring("params TR and FN"))
+		}
+	})
+
+	It("should be checked by Validate", func() {
+		_, err := NewDLDBuilder().SI(42).Build()
+		Ω(errors.Is(err, ErrOneOf)).Should(BeTrue())
+
+		cnt, err := NewDLDBuilder().FN("file").Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.ADCString()).Should(Equal("DLD FNfile\n"))
+	})
+})
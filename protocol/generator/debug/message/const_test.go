@@ -0,0 +1,54 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Const positional params", func() {
+	var cnt MRKContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"1", "V2", "desc"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should parse the params surrounding the marker", func() {
+		Ω(cnt.Code).Should(Equal(1))
+		Ω(cnt.Description).Should(Equal("desc"))
+	})
+
+	It("should include the marker in the positional layout", func() {
+		Ω(cnt.PosLen()).Should(Equal(3))
+		Ω(cnt.PosAt(1)).Should(Equal("V2"))
+		Ω(cnt.Positional()).Should(Equal([]string{"1", "V2", "desc"}))
+		val, ok := cnt.PosByName("Marker")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("V2"))
+	})
+
+	It("should marshal the marker", func() {
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MRK 1 V2 desc\n")))
+
+		var built MRKContent
+		_, err := built.MarshalADC()
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+	})
+
+	It("should return ErrConstMismatch if the marker differs", func() {
+		err := cnt.ParseInto([]string{"1", "V3", "desc"}, nil)
+		Ω(errors.Is(err, ErrConstMismatch)).Should(BeTrue())
+
+		err = cnt.ParseInto([]string{"1", "desc", "V2"}, nil)
+		Ω(errors.Is(err, ErrConstMismatch)).Should(BeTrue())
+	})
+
+	It("should return ErrMissingParam if the marker is missing", func() {
+		err := cnt.ParseInto([]string{"1", "V2"}, nil)
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+	})
+})
@@ -0,0 +1,138 @@
+package message
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestBITContentPosLen(t *testing.T) {
+	var b BITContent
+
+	if got, want := b.PosLen(), len(b.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestBITContentPosExcludesCommand(t *testing.T) {
+	var b BITContent
+	b.Status = struct {
+		Fatal       bool
+		Recoverable bool
+		Permanent   bool
+	}{Fatal: true}
+	b.Description = "p1"
+
+	buf, err := b.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != b.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], b.Command())
+	}
+
+	if got, want := b.PosAt(0), strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := b.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := b.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func TestBITContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"BIT 5 desc", map[string]string{
+			"Description": "desc",
+			"Status":      "5",
+		}},
+	}
+
+	values := func(b *BITContent) map[string]string {
+		values := make(map[string]string)
+		values["Status"] = strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent))
+		values["Description"] = fmt.Sprint(b.Description)
+		return values
+	}
+
+	for _, example := range examples {
+		var b BITContent
+		if err := b.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&b)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := b.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzBITContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("5 desc")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var b BITContent
+		if err := b.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := b.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded BITContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&b) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
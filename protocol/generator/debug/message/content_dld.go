@@ -0,0 +1,846 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	maybe "github.com/seoester/adcl/protocol/maybe"
+	"io"
+	slog "log/slog"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type DLDFlag string
+
+const (
+	DLDFlagTR DLDFlag = "TR"
+	DLDFlagFN DLDFlag = "FN"
+	DLDFlagSI DLDFlag = "SI"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f DLDFlag) String() string {
+	switch f {
+	case DLDFlagTR:
+		return "DLDFlagTR"
+	case DLDFlagFN:
+		return "DLDFlagFN"
+	case DLDFlagSI:
+		return "DLDFlagSI"
+	}
+	return "DLDFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f DLDFlag) IsKnown() bool {
+	switch f {
+	case DLDFlagTR, DLDFlagFN, DLDFlagSI:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &DLDContent{}
+var _ ADCMarshaler = &DLDContent{}
+var _ ADCUnmarshaler = &DLDContent{}
+var _ io.WriterTo = &DLDContent{}
+var _ fmt.Formatter = &DLDContent{}
+var _ fmt.Stringer = &DLDContent{}
+var _ slog.LogValuer = &DLDContent{}
+
+// DLDContent is the content of DLD messages.
+type DLDContent struct {
+	// Exactly one of TR and FN is present.
+	TR    maybe.TTH `adc:"TR" json:"tr,omitempty"`
+	trStr string
+
+	// Exactly one of TR and FN is present.
+	FN    maybe.String `adc:"FN" json:"fn,omitempty"`
+	fnStr string
+
+	SI    maybe.Int `adc:"SI" json:"si,omitempty"`
+	siStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (d *DLDContent) Positional() []string {
+	return d.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (d *DLDContent) AppendPositional(dst []string) []string {
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (d *DLDContent) PosLen() int {
+	return 0
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (d *DLDContent) PosAt(i int) string {
+	panic(fmt.Sprintf("DLD.PosAt: index %d out of range [0,%d)", i, d.PosLen()))
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (d *DLDContent) Named() map[string]string {
+	params := d.UnknownFlags()
+
+	if d.TR.IsSet {
+		params["TR"] = d.TR.Value.String()
+	}
+	if d.FN.IsSet {
+		params["FN"] = escapeValue(d.FN.Value)
+	}
+	if d.SI.IsSet {
+		params["SI"] = strconv.Itoa(d.SI.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (d *DLDContent) NamedGet(key string) (string, bool) {
+	switch DLDFlag(key) {
+	case DLDFlagTR:
+		if d.TR.IsSet {
+			return d.TR.Value.String(), true
+		}
+		return "", false
+	case DLDFlagFN:
+		if d.FN.IsSet {
+			return escapeValue(d.FN.Value), true
+		}
+		return "", false
+	case DLDFlagSI:
+		if d.SI.IsSet {
+			return strconv.Itoa(d.SI.Value), true
+		}
+		return "", false
+	}
+
+	return d.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (d *DLDContent) NamedAll(key string) []string {
+	if val, ok := d.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetTR returns the decoded value of the TR param and whether it is set.
+func (d *DLDContent) GetTR() (encoding.TTH, bool) {
+	return d.TR.Value, d.TR.IsSet
+}
+
+// GetFN returns the decoded value of the FN param and whether it is set.
+func (d *DLDContent) GetFN() (string, bool) {
+	return d.FN.Value, d.FN.IsSet
+}
+
+// GetSI returns the decoded value of the SI param and whether it is set.
+func (d *DLDContent) GetSI() (int, bool) {
+	return d.SI.Value, d.SI.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (d *DLDContent) PosByName(name string) (string, bool) {
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (d *DLDContent) ParseInto(params []string, opts *ParseOptions) error {
+	*d = DLDContent{}
+	d.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message DLD: %w", err)
+	}
+
+	for _, param := range params {
+		switch {
+		case isNamedParam(param):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				d.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message DLD: %w", param[:2], err)
+			}
+			switch DLDFlag(param[:2]) {
+			case DLDFlagTR:
+				if err := opts.checkDuplicateFlag(d.trStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message DLD: %w", param[:2], err)
+				}
+				d.trStr = param
+				val, err := encoding.ParseTTH(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TR of message DLD: %w", err)
+				}
+				d.TR.Set(val)
+			case DLDFlagFN:
+				if err := opts.checkDuplicateFlag(d.fnStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message DLD: %w", param[:2], err)
+				}
+				d.fnStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param FN of message DLD: %w", err)
+				}
+				d.FN.Set(val)
+			case DLDFlagSI:
+				if err := opts.checkDuplicateFlag(d.siStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message DLD: %w", param[:2], err)
+				}
+				d.siStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SI of message DLD: %w", err)
+				}
+				d.SI.Set(val)
+			default:
+				if d.Flags == nil {
+					d.Flags = make(map[string]string)
+				}
+				d.Flags[param[:2]] = param[2:]
+			}
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message DLD: %w", err)
+			}
+		}
+	}
+
+	if !exactlyOne(d.TR.IsSet, d.FN.IsSet) {
+		return fmt.Errorf("parsing message DLD: %w, params TR and FN", ErrOneOf)
+	}
+
+	return nil
+}
+
+// DLDContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func DLDContentFromAccessor(pa ParamAccessor) (*DLDContent, error) {
+	var d DLDContent
+	if err := d.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (d *DLDContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message DLD: %w", err)
+	}
+
+	return d.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (d *DLDContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "DLD" {
+		return fmt.Errorf("parsing message DLD: %w", ErrCommandMismatch)
+	}
+
+	return d.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (d *DLDContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message DLD: %w", err)
+	}
+	if err := d.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	d.raw = rawLine(line)
+	d.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (d *DLDContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, d.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (d *DLDContent) SetNamedAll(named map[string]string) error {
+	decoded := *d
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*d = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (d *DLDContent) setNamedAll(named map[string]string) error {
+	d.dirty = true
+	var zero DLDContent
+	d.TR = zero.TR
+	d.trStr = zero.trStr
+	d.FN = zero.FN
+	d.fnStr = zero.fnStr
+	d.SI = zero.SI
+	d.siStr = zero.siStr
+	d.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message DLD: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch DLDFlag(param[:2]) {
+		case DLDFlagTR:
+			d.trStr = param
+			val, err := encoding.ParseTTH(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TR of message DLD: %w", err)
+			}
+			d.TR.Set(val)
+		case DLDFlagFN:
+			d.fnStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param FN of message DLD: %w", err)
+			}
+			d.FN.Set(val)
+		case DLDFlagSI:
+			d.siStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SI of message DLD: %w", err)
+			}
+			d.SI.Set(val)
+		default:
+			if d.Flags == nil {
+				d.Flags = make(map[string]string)
+			}
+			d.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (d *DLDContent) ToMap() map[string]string {
+	values := d.UnknownFlags()
+	if d.TR.IsSet {
+		values["TR"] = d.TR.Value.String()
+	}
+	if d.FN.IsSet {
+		values["FN"] = escapeValue(d.FN.Value)
+	}
+	if d.SI.IsSet {
+		values["SI"] = strconv.Itoa(d.SI.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (d *DLDContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["TR"]; ok {
+		params = append(params, "TR"+val)
+	}
+	if val, ok := values["FN"]; ok {
+		params = append(params, "FN"+val)
+	}
+	if val, ok := values["SI"]; ok {
+		params = append(params, "SI"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "TR", "FN", "SI":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message DLD: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return d.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (d *DLDContent) MarkDirty() {
+	d.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (d *DLDContent) AppendADC(buf []byte) ([]byte, error) {
+	if d.raw != nil && !d.dirty {
+		return append(buf, d.raw...), nil
+	}
+
+	buf = append(buf, "DLD"...)
+
+	if d.TR.IsSet {
+		buf = append(buf, " TR"...)
+		buf = append(buf, d.TR.Value.String()...)
+	}
+	if d.FN.IsSet {
+		buf = append(buf, " FN"...)
+		buf = appendEscaped(buf, d.FN.Value)
+	}
+	if d.SI.IsSet {
+		buf = append(buf, " SI"...)
+		buf = strconv.AppendInt(buf, int64(d.SI.Value), 10)
+	}
+	buf = appendFlags(buf, d.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (d *DLDContent) EncodeTo(buf []byte) []byte {
+	if d.raw != nil && !d.dirty {
+		return append(buf, d.raw...)
+	}
+
+	buf = append(buf, "DLD"...)
+
+	if d.TR.IsSet {
+		buf = append(buf, " TR"...)
+		buf = append(buf, d.TR.Value.String()...)
+	}
+	if d.FN.IsSet {
+		buf = append(buf, " FN"...)
+		buf = appendEscaped(buf, d.FN.Value)
+	}
+	if d.SI.IsSet {
+		buf = append(buf, " SI"...)
+		buf = strconv.AppendInt(buf, int64(d.SI.Value), 10)
+	}
+	buf = encodeFlags(buf, d.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (d *DLDContent) ADCString() string {
+	if d.raw != nil && !d.dirty {
+		return string(d.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(d.WireSize())
+	builder.WriteString("DLD")
+
+	if d.TR.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TR" + d.TR.Value.String())
+	}
+	if d.FN.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("FN" + escapeValue(d.FN.Value))
+	}
+	if d.SI.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SI" + strconv.Itoa(d.SI.Value))
+	}
+	writeFlags(&builder, d.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (d *DLDContent) Validate() error {
+	var errs []error
+	for _, name := range d.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message DLD: %w", name, ErrMissingParam))
+	}
+	if !exactlyOne(d.TR.IsSet, d.FN.IsSet) {
+		errs = append(errs, fmt.Errorf("validating message DLD: %w, params TR and FN", ErrOneOf))
+	}
+	if err := checkFlagsEscaped(d.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message DLD: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (d *DLDContent) MissingRequired() []string {
+	return nil
+}
+
+var dldDescriptor = MessageDescriptor{
+	Command: "DLD",
+	Named: []ParamDescriptor{{
+		DisplayName: "TR",
+		FlagName:    "TR",
+		Name:        "TR",
+		Required:    false,
+		Type:        "tth",
+	}, {
+		DisplayName: "FN",
+		FlagName:    "FN",
+		Name:        "FN",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "SI",
+		FlagName:    "SI",
+		Name:        "SI",
+		Required:    false,
+		Type:        "int",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (d *DLDContent) Descriptor() MessageDescriptor {
+	return dldDescriptor
+}
+
+// Command returns the command of the content, i.e. DLD.
+func (d *DLDContent) Command() string {
+	return "DLD"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (d *DLDContent) MsgType() byte {
+	return d.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (d *DLDContent) MarshalADC() ([]byte, error) {
+	return d.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (d *DLDContent) WireSize() int {
+	if d.raw != nil && !d.dirty {
+		return len(d.raw)
+	}
+
+	n := len("DLD")
+
+	if d.TR.IsSet {
+		n += 1 + len("TR"+d.TR.Value.String())
+	}
+	if d.FN.IsSet {
+		n += 1 + len("FN"+escapeValue(d.FN.Value))
+	}
+	if d.SI.IsSet {
+		n += 1 + len("SI"+strconv.Itoa(d.SI.Value))
+	}
+	n += flagsSize(d.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (d *DLDContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := d.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (d *DLDContent) Equal(other *DLDContent) bool {
+	return equalParams(d, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (d *DLDContent) EqualIgnoring(other *DLDContent, ignore ...string) bool {
+	if !isIgnored(ignore, "TR") && (d.TR.IsSet != other.TR.IsSet || d.TR.IsSet && d.TR.Value.String() != other.TR.Value.String()) {
+		return false
+	}
+	if !isIgnored(ignore, "FN") && (d.FN.IsSet != other.FN.IsSet || d.FN.IsSet && d.FN.Value != other.FN.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SI") && (d.SI.IsSet != other.SI.IsSet || d.SI.IsSet && d.SI.Value != other.SI.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(d.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (d *DLDContent) HashKey() string {
+	return hashKey(d)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (d *DLDContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(d, line, mode, func(params []string) (ParamAccessor, error) {
+		var other DLDContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (d *DLDContent) Redacted() *DLDContent {
+	redacted := *d
+	if d.Flags != nil {
+		redacted.Flags = d.UnknownFlags()
+	}
+	redacted.raw = nil
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (d *DLDContent) Clone() *DLDContent {
+	clone := *d
+	if d.Flags != nil {
+		clone.Flags = d.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), d.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (d *DLDContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	attrs = append(attrs, slog.String("command", "DLD"))
+	if d.TR.IsSet {
+		attrs = append(attrs, slog.Any("TR", d.TR.Value))
+	}
+	if d.FN.IsSet {
+		attrs = append(attrs, slog.Any("FN", d.FN.Value))
+	}
+	if d.SI.IsSet {
+		attrs = append(attrs, slog.Any("SI", d.SI.Value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (d *DLDContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("DLD")
+	if d.TR.IsSet {
+		fmt.Fprintf(&sb, " TR=%v", d.TR.Value)
+	}
+	if d.FN.IsSet {
+		fmt.Fprintf(&sb, " FN=%q", d.FN.Value)
+	}
+	if d.SI.IsSet {
+		fmt.Fprintf(&sb, " SI=%v", d.SI.Value)
+	}
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (d *DLDContent) Labels() map[string]string {
+	labels := make(map[string]string, 3)
+	if d.TR.IsSet {
+		labels["TR"] = fmt.Sprint(d.TR.Value)
+	}
+	if d.FN.IsSet {
+		labels["FN"] = fmt.Sprint(d.FN.Value)
+	}
+	if d.SI.IsSet {
+		labels["SI"] = fmt.Sprint(d.SI.Value)
+	}
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (d *DLDContent) SetOptionalCount() int {
+	var n int
+	if d.TR.IsSet {
+		n++
+	}
+	if d.FN.IsSet {
+		n++
+	}
+	if d.SI.IsSet {
+		n++
+	}
+	return n
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (d *DLDContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "DLDContent{TR:%v FN:%v SI:%v Flags:%v}", d.TR, d.FN, d.SI, d.Flags)
+		return
+	}
+
+	formatContent(f, verb, d.MarshalADC)
+}
+
+// DLDBuilder builds DLDContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type DLDBuilder struct {
+	content DLDContent
+	err     error
+}
+
+// NewDLDBuilder returns a builder of empty contents.
+func NewDLDBuilder() *DLDBuilder {
+	return &DLDBuilder{}
+}
+
+// TR sets the TR param.
+func (b *DLDBuilder) TR(value encoding.TTH) *DLDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.TR.Set(value)
+	b.content.trStr = "TR" + str
+	return b
+}
+
+// FN sets the FN param.
+func (b *DLDBuilder) FN(value string) *DLDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param FN of message DLD: %w", err)
+		return b
+	}
+	b.content.FN.Set(value)
+	b.content.fnStr = "FN" + str
+	return b
+}
+
+// SI sets the SI param.
+func (b *DLDBuilder) SI(value int) *DLDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SI.Set(value)
+	b.content.siStr = "SI" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *DLDBuilder) Build() (*DLDContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
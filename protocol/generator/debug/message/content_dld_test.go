@@ -0,0 +1,121 @@
+package message
+
+import (
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestDLDContentNamedGet(t *testing.T) {
+	var d DLDContent
+	d.TR.Set(encoding.TTH{})
+	d.FN.Set("sentinel")
+	d.SI.Set(7)
+
+	for _, tc := range []struct {
+		flag DLDFlag
+		want string
+	}{{DLDFlagTR, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}, {DLDFlagFN, "sentinel"}, {DLDFlagSI, "7"}} {
+		val, ok := d.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestDLDContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"DLD FNfile SI42", map[string]string{
+			"FN": "file",
+			"SI": "42",
+		}},
+	}
+
+	values := func(d *DLDContent) map[string]string {
+		values := make(map[string]string)
+		if d.TR.IsSet {
+			values["TR"] = fmt.Sprint(d.TR.Value)
+		}
+		if d.FN.IsSet {
+			values["FN"] = fmt.Sprint(d.FN.Value)
+		}
+		if d.SI.IsSet {
+			values["SI"] = fmt.Sprint(d.SI.Value)
+		}
+		return values
+	}
+
+	for _, example := range examples {
+		var d DLDContent
+		if err := d.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&d)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := d.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzDLDContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("FNfile SI42")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var d DLDContent
+		if err := d.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := d.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded DLDContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&d) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
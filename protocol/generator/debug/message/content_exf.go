@@ -0,0 +1,799 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	maybe "github.com/seoester/adcl/protocol/maybe"
+	"io"
+	slog "log/slog"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type EXFFlag string
+
+const (
+	EXFFlagNI EXFFlag = "NI"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f EXFFlag) String() string {
+	switch f {
+	case EXFFlagNI:
+		return "EXFFlagNI"
+	}
+	return "EXFFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f EXFFlag) IsKnown() bool {
+	switch f {
+	case EXFFlagNI:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &EXFContent{}
+var _ ADCMarshaler = &EXFContent{}
+var _ ADCUnmarshaler = &EXFContent{}
+var _ io.WriterTo = &EXFContent{}
+var _ fmt.Formatter = &EXFContent{}
+var _ fmt.Stringer = &EXFContent{}
+var _ slog.LogValuer = &EXFContent{}
+
+// EXFContent is the content of messages with commands matching EX?.
+type EXFContent struct {
+	Description    string `adc:"Description,positional" json:"description"`
+	descriptionStr string
+
+	NI    maybe.String `adc:"NI" json:"ni,omitempty"`
+	niStr string
+
+	ContentBase
+
+	// command is the concrete command of the family.
+	command string
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (e *EXFContent) Positional() []string {
+	return e.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (e *EXFContent) AppendPositional(dst []string) []string {
+	return append(dst, escapeValue(e.Description))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (e *EXFContent) PosLen() int {
+	return 1
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (e *EXFContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return escapeValue(e.Description)
+	default:
+		panic(fmt.Sprintf("EX?.PosAt: index %d out of range [0,%d)", i, e.PosLen()))
+	}
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (e *EXFContent) Named() map[string]string {
+	params := e.UnknownFlags()
+
+	if e.NI.IsSet {
+		params["NI"] = escapeValue(e.NI.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (e *EXFContent) NamedGet(key string) (string, bool) {
+	switch EXFFlag(key) {
+	case EXFFlagNI:
+		if e.NI.IsSet {
+			return escapeValue(e.NI.Value), true
+		}
+		return "", false
+	}
+
+	return e.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (e *EXFContent) NamedAll(key string) []string {
+	if val, ok := e.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetNI returns the decoded value of the NI param and whether it is set.
+func (e *EXFContent) GetNI() (string, bool) {
+	return e.NI.Value, e.NI.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (e *EXFContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Description":
+		return escapeValue(e.Description), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (e *EXFContent) PositionalValues() string {
+	return e.Description
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (e *EXFContent) ParseInto(params []string, opts *ParseOptions) error {
+	*e = EXFContent{}
+	e.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message EX?: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 1 {
+		return fmt.Errorf("parsing message EX?: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 1 && isNamedParam(param) && (pos+plain >= 1):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				e.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message EX?: %w", param[:2], err)
+			}
+			switch EXFFlag(param[:2]) {
+			case EXFFlagNI:
+				if err := opts.checkDuplicateFlag(e.niStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message EX?: %w", param[:2], err)
+				}
+				e.niStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param NI of message EX?: %w", err)
+				}
+				e.NI.Set(val)
+			default:
+				if e.Flags == nil {
+					e.Flags = make(map[string]string)
+				}
+				e.Flags[param[:2]] = param[2:]
+			}
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				e.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message EX?: %w", err)
+			}
+			e.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message EX?: %w", err)
+			}
+			e.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message EX?: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 1 {
+		return fmt.Errorf("parsing message EX?: %w", ErrMissingParam)
+	}
+
+	if e.Description == "" {
+		return fmt.Errorf("parsing param Description of message EX?: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// EXFContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func EXFContentFromAccessor(pa ParamAccessor) (*EXFContent, error) {
+	var e EXFContent
+	if err := e.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (e *EXFContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message EX?: %w", err)
+	}
+
+	return e.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (e *EXFContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || !matchCommand("EX?", tokens[0]) {
+		return fmt.Errorf("parsing message EX?: %w", ErrCommandMismatch)
+	}
+
+	if err := e.ParseInto(tokens[1:], nil); err != nil {
+		return err
+	}
+	e.command = tokens[0]
+
+	return nil
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (e *EXFContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message EX?: %w", err)
+	}
+	if err := e.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	e.raw = rawLine(line)
+	e.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (e *EXFContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, e.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (e *EXFContent) SetNamedAll(named map[string]string) error {
+	decoded := *e
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*e = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (e *EXFContent) setNamedAll(named map[string]string) error {
+	e.dirty = true
+	var zero EXFContent
+	e.NI = zero.NI
+	e.niStr = zero.niStr
+	e.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message EX?: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch EXFFlag(param[:2]) {
+		case EXFFlagNI:
+			e.niStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param NI of message EX?: %w", err)
+			}
+			e.NI.Set(val)
+		default:
+			if e.Flags == nil {
+				e.Flags = make(map[string]string)
+			}
+			e.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (e *EXFContent) ToMap() map[string]string {
+	values := e.UnknownFlags()
+	values["Description"] = escapeValue(e.Description)
+	if e.NI.IsSet {
+		values["NI"] = escapeValue(e.NI.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (e *EXFContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message EX?: %w", ErrMissingParam)
+	}
+	if val, ok := values["NI"]; ok {
+		params = append(params, "NI"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "Description", "NI":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message EX?: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return e.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (e *EXFContent) MarkDirty() {
+	e.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (e *EXFContent) AppendADC(buf []byte) ([]byte, error) {
+	if e.raw != nil && !e.dirty {
+		return append(buf, e.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, e.command...)
+	if e.command == "" {
+		return buf[:n], fmt.Errorf("marshalling message EX?: %w", ErrMissingCommand)
+	}
+
+	if e.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message EX?: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, e.Description)
+	if e.NI.IsSet {
+		buf = append(buf, " NI"...)
+		buf = appendEscaped(buf, e.NI.Value)
+	}
+	buf = appendFlags(buf, e.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (e *EXFContent) EncodeTo(buf []byte) []byte {
+	if e.raw != nil && !e.dirty {
+		return append(buf, e.raw...)
+	}
+
+	buf = append(buf, e.command...)
+
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, e.Description)
+	if e.NI.IsSet {
+		buf = append(buf, " NI"...)
+		buf = appendEscaped(buf, e.NI.Value)
+	}
+	buf = encodeFlags(buf, e.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (e *EXFContent) ADCString() string {
+	if e.raw != nil && !e.dirty {
+		return string(e.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(e.WireSize())
+	builder.WriteString(e.command)
+	if e.command == "" {
+		return ""
+	}
+
+	if e.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(e.Description))
+	if e.NI.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("NI" + escapeValue(e.NI.Value))
+	}
+	writeFlags(&builder, e.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (e *EXFContent) Validate() error {
+	var errs []error
+	for _, name := range e.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message EX?: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(e.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message EX?: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (e *EXFContent) MissingRequired() []string {
+	var missing []string
+	if e.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var exfDescriptor = MessageDescriptor{
+	Command: "EX?",
+	Named: []ParamDescriptor{{
+		DisplayName: "NI",
+		FlagName:    "NI",
+		Name:        "NI",
+		Required:    false,
+		Type:        "string",
+	}},
+	Positional: []ParamDescriptor{{
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (e *EXFContent) Descriptor() MessageDescriptor {
+	return exfDescriptor
+}
+
+// Command returns the command of the content, which matches EX?. It is
+// set by ParseTokens and FrameParser, or by SetCommand.
+func (e *EXFContent) Command() string {
+	return e.command
+}
+
+// SetCommand sets the command of the content, which must match EX?.
+func (e *EXFContent) SetCommand(command string) error {
+	if !matchCommand("EX?", command) {
+		return fmt.Errorf("setting command of message EX?: %w", ErrCommandMismatch)
+	}
+
+	e.command = command
+	e.dirty = true
+	return nil
+}
+
+func (e *EXFContent) setCommand(command string) {
+	e.command = command
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (e *EXFContent) MsgType() byte {
+	return e.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (e *EXFContent) MarshalADC() ([]byte, error) {
+	return e.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (e *EXFContent) WireSize() int {
+	if e.raw != nil && !e.dirty {
+		return len(e.raw)
+	}
+
+	n := len(e.command)
+
+	n += 1 + len(escapeValue(e.Description))
+	if e.NI.IsSet {
+		n += 1 + len("NI"+escapeValue(e.NI.Value))
+	}
+	n += flagsSize(e.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (e *EXFContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := e.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (e *EXFContent) Equal(other *EXFContent) bool {
+	return e.command == other.command && equalParams(e, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (e *EXFContent) EqualIgnoring(other *EXFContent, ignore ...string) bool {
+	if e.command != other.command {
+		return false
+	}
+	if !isIgnored(ignore, "Description") && e.Description != other.Description {
+		return false
+	}
+	if !isIgnored(ignore, "NI") && (e.NI.IsSet != other.NI.IsSet || e.NI.IsSet && e.NI.Value != other.NI.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(e.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (e *EXFContent) HashKey() string {
+	return hashKey(e)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (e *EXFContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(e, line, mode, func(params []string) (ParamAccessor, error) {
+		var other EXFContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (e *EXFContent) Redacted() *EXFContent {
+	redacted := *e
+	if e.Flags != nil {
+		redacted.Flags = e.UnknownFlags()
+	}
+	redacted.raw = nil
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (e *EXFContent) Clone() *EXFContent {
+	clone := *e
+	if e.Flags != nil {
+		clone.Flags = e.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), e.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (e *EXFContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 3)
+	attrs = append(attrs, slog.String("command", e.command))
+	attrs = append(attrs, slog.Any("Description", e.Description))
+	if e.NI.IsSet {
+		attrs = append(attrs, slog.Any("NI", e.NI.Value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (e *EXFContent) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.command)
+	fmt.Fprintf(&sb, " Description=%q", e.Description)
+	if e.NI.IsSet {
+		fmt.Fprintf(&sb, " NI=%q", e.NI.Value)
+	}
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (e *EXFContent) Labels() map[string]string {
+	labels := make(map[string]string, 2)
+	labels["Description"] = fmt.Sprint(e.Description)
+	if e.NI.IsSet {
+		labels["NI"] = fmt.Sprint(e.NI.Value)
+	}
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (e *EXFContent) SetOptionalCount() int {
+	var n int
+	if e.NI.IsSet {
+		n++
+	}
+	return n
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (e *EXFContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "EXFContent{Description:%v NI:%v Flags:%v}", e.Description, e.NI, e.Flags)
+		return
+	}
+
+	formatContent(f, verb, e.MarshalADC)
+}
+
+// EXFBuilder builds EXFContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type EXFBuilder struct {
+	content EXFContent
+	err     error
+}
+
+// NewEXFBuilder returns a builder of contents with the command, which must match
+// EX?.
+func NewEXFBuilder(command string) *EXFBuilder {
+	b := &EXFBuilder{}
+	b.err = b.content.SetCommand(command)
+	return b
+}
+
+// Description sets the Description param.
+func (b *EXFBuilder) Description(value string) *EXFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message EX?: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// NI sets the NI param.
+func (b *EXFBuilder) NI(value string) *EXFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param NI of message EX?: %w", err)
+		return b
+	}
+	b.content.NI.Set(value)
+	b.content.niStr = "NI" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *EXFBuilder) Build() (*EXFContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
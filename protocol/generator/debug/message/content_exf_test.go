@@ -0,0 +1,101 @@
+package message
+
+import (
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestEXFContentNamedGet(t *testing.T) {
+	var e EXFContent
+	e.NI.Set("sentinel")
+
+	for _, tc := range []struct {
+		flag EXFFlag
+		want string
+	}{{EXFFlagNI, "sentinel"}} {
+		val, ok := e.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestEXFContentPosLen(t *testing.T) {
+	var e EXFContent
+
+	if got, want := e.PosLen(), len(e.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestEXFContentPosExcludesCommand(t *testing.T) {
+	var e EXFContent
+	e.command = "EXX"
+	e.Description = "p0"
+
+	buf, err := e.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != e.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], e.Command())
+	}
+
+	if got, want := e.PosAt(0), escapeValue(e.Description); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := e.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := e.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzEXFContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var e EXFContent
+		if err := e.ParseInto(tokens, nil); err != nil {
+			return
+		}
+		e.command = "EXX"
+
+		buf, err := e.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded EXFContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&e) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
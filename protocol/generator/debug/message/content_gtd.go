@@ -0,0 +1,917 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	maybe "github.com/seoester/adcl/protocol/maybe"
+	"io"
+	slog "log/slog"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type GTDFlag string
+
+const (
+	GTDFlagTR GTDFlag = "TR"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f GTDFlag) String() string {
+	switch f {
+	case GTDFlagTR:
+		return "GTDFlagTR"
+	}
+	return "GTDFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f GTDFlag) IsKnown() bool {
+	switch f {
+	case GTDFlagTR:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &GTDContent{}
+var _ ADCMarshaler = &GTDContent{}
+var _ ADCUnmarshaler = &GTDContent{}
+var _ io.WriterTo = &GTDContent{}
+var _ fmt.Formatter = &GTDContent{}
+var _ fmt.Stringer = &GTDContent{}
+var _ slog.LogValuer = &GTDContent{}
+
+// GTDContent is the content of GTD messages.
+type GTDContent struct {
+	Code    int `adc:"Code,positional" json:"code"`
+	codeStr string
+
+	Target    maybe.String `adc:"Target,positional" json:"target,omitempty"`
+	targetStr string
+
+	Description    string `adc:"Description,positional" json:"description"`
+	descriptionStr string
+
+	TR    maybe.Int `adc:"TR" json:"tr,omitempty"`
+	trStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (g *GTDContent) Positional() []string {
+	return g.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (g *GTDContent) AppendPositional(dst []string) []string {
+	dst = append(dst, strconv.Itoa(g.Code))
+	if g.TR.IsSet {
+		dst = append(dst, escapeValue(g.Target.Value))
+	}
+	dst = append(dst, escapeValue(g.Description))
+
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (g *GTDContent) PosLen() int {
+	var targetGate int
+	if g.TR.IsSet {
+		targetGate = 1
+	}
+
+	return 2 + targetGate
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (g *GTDContent) PosAt(i int) string {
+	var targetGate int
+	if g.TR.IsSet {
+		targetGate = 1
+	}
+
+	switch {
+	case i == 0:
+		return strconv.Itoa(g.Code)
+	case targetGate == 1 && i == 1:
+		return escapeValue(g.Target.Value)
+	case i == 1+targetGate:
+		return escapeValue(g.Description)
+	default:
+		panic(fmt.Sprintf("GTD.PosAt: index %d out of range [0,%d)", i, g.PosLen()))
+	}
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (g *GTDContent) Named() map[string]string {
+	params := g.UnknownFlags()
+
+	if g.TR.IsSet {
+		params["TR"] = strconv.Itoa(g.TR.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (g *GTDContent) NamedGet(key string) (string, bool) {
+	switch GTDFlag(key) {
+	case GTDFlagTR:
+		if g.TR.IsSet {
+			return strconv.Itoa(g.TR.Value), true
+		}
+		return "", false
+	}
+
+	return g.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (g *GTDContent) NamedAll(key string) []string {
+	if val, ok := g.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetTR returns the decoded value of the TR param and whether it is set.
+func (g *GTDContent) GetTR() (int, bool) {
+	return g.TR.Value, g.TR.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (g *GTDContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Code":
+		return strconv.Itoa(g.Code), true
+	case "Target":
+		return escapeValue(g.Target.Value), g.TR.IsSet
+	case "Description":
+		return escapeValue(g.Description), true
+	}
+
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (g *GTDContent) ParseInto(params []string, opts *ParseOptions) error {
+	*g = GTDContent{}
+	g.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message GTD: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	var targetGate int
+	if plain >= 2 && hasNamedParam(params, "TR") {
+		targetGate = 1
+	}
+
+	if len(params) < 2+targetGate+targetGate {
+		return fmt.Errorf("parsing message GTD: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case isNamedParam(param) && (pos+plain >= 2+targetGate || targetGate == 1 && param[:2] == "TR"):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				g.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message GTD: %w", param[:2], err)
+			}
+			switch GTDFlag(param[:2]) {
+			case GTDFlagTR:
+				if err := opts.checkDuplicateFlag(g.trStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message GTD: %w", param[:2], err)
+				}
+				g.trStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TR of message GTD: %w", err)
+				}
+				g.TR.Set(val)
+			default:
+				if g.Flags == nil {
+					g.Flags = make(map[string]string)
+				}
+				g.Flags[param[:2]] = param[2:]
+			}
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				g.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Code of message GTD: %w", err)
+			}
+			g.codeStr = param
+			val, err := strconv.Atoi(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Code of message GTD: %w", err)
+			}
+			g.Code = val
+		case targetGate == 1 && pos == 1:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				g.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Target of message GTD: %w", err)
+			}
+			g.targetStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Target of message GTD: %w", err)
+			}
+			g.Target.Set(val)
+		case pos == 1+targetGate:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				g.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message GTD: %w", err)
+			}
+			g.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message GTD: %w", err)
+			}
+			g.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message GTD: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 2+targetGate {
+		return fmt.Errorf("parsing message GTD: %w", ErrMissingParam)
+	}
+
+	if g.Description == "" {
+		return fmt.Errorf("parsing param Description of message GTD: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// GTDContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func GTDContentFromAccessor(pa ParamAccessor) (*GTDContent, error) {
+	var g GTDContent
+	if err := g.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (g *GTDContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message GTD: %w", err)
+	}
+
+	return g.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (g *GTDContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "GTD" {
+		return fmt.Errorf("parsing message GTD: %w", ErrCommandMismatch)
+	}
+
+	return g.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (g *GTDContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message GTD: %w", err)
+	}
+	if err := g.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	g.raw = rawLine(line)
+	g.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (g *GTDContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, g.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (g *GTDContent) SetNamedAll(named map[string]string) error {
+	decoded := *g
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*g = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (g *GTDContent) setNamedAll(named map[string]string) error {
+	g.dirty = true
+	var zero GTDContent
+	g.TR = zero.TR
+	g.trStr = zero.trStr
+	g.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message GTD: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch GTDFlag(param[:2]) {
+		case GTDFlagTR:
+			g.trStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TR of message GTD: %w", err)
+			}
+			g.TR.Set(val)
+		default:
+			if g.Flags == nil {
+				g.Flags = make(map[string]string)
+			}
+			g.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (g *GTDContent) ToMap() map[string]string {
+	values := g.UnknownFlags()
+	values["Code"] = strconv.Itoa(g.Code)
+	if g.Target.IsSet {
+		values["Target"] = escapeValue(g.Target.Value)
+	}
+	values["Description"] = escapeValue(g.Description)
+	if g.TR.IsSet {
+		values["TR"] = strconv.Itoa(g.TR.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (g *GTDContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Code"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Code of message GTD: %w", ErrMissingParam)
+	}
+	if val, ok := values["Target"]; ok {
+		params = append(params, val)
+	}
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message GTD: %w", ErrMissingParam)
+	}
+	if val, ok := values["TR"]; ok {
+		params = append(params, "TR"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "Code", "Target", "Description", "TR":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message GTD: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return g.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (g *GTDContent) MarkDirty() {
+	g.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (g *GTDContent) AppendADC(buf []byte) ([]byte, error) {
+	if g.raw != nil && !g.dirty {
+		return append(buf, g.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, "GTD"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(g.Code), 10)
+	if g.TR.IsSet {
+		if !g.Target.IsSet {
+			return buf[:n], fmt.Errorf("marshalling param Target of message GTD: %w", ErrMissingParam)
+		}
+		buf = append(buf, ' ')
+		buf = appendEscaped(buf, g.Target.Value)
+	}
+	if g.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message GTD: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, g.Description)
+	if g.TR.IsSet {
+		buf = append(buf, " TR"...)
+		buf = strconv.AppendInt(buf, int64(g.TR.Value), 10)
+	}
+	buf = appendFlags(buf, g.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (g *GTDContent) EncodeTo(buf []byte) []byte {
+	if g.raw != nil && !g.dirty {
+		return append(buf, g.raw...)
+	}
+
+	buf = append(buf, "GTD"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(g.Code), 10)
+	if g.TR.IsSet {
+		buf = append(buf, ' ')
+		buf = appendEscaped(buf, g.Target.Value)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, g.Description)
+	if g.TR.IsSet {
+		buf = append(buf, " TR"...)
+		buf = strconv.AppendInt(buf, int64(g.TR.Value), 10)
+	}
+	buf = encodeFlags(buf, g.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (g *GTDContent) ADCString() string {
+	if g.raw != nil && !g.dirty {
+		return string(g.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(g.WireSize())
+	builder.WriteString("GTD")
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(g.Code))
+	if g.TR.IsSet {
+		if !g.Target.IsSet {
+			return ""
+		}
+		builder.WriteByte(' ')
+		builder.WriteString(escapeValue(g.Target.Value))
+	}
+	if g.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(g.Description))
+	if g.TR.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TR" + strconv.Itoa(g.TR.Value))
+	}
+	writeFlags(&builder, g.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (g *GTDContent) Validate() error {
+	var errs []error
+	for _, name := range g.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message GTD: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(g.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message GTD: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (g *GTDContent) MissingRequired() []string {
+	var missing []string
+	if g.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var gtdDescriptor = MessageDescriptor{
+	Command: "GTD",
+	Named: []ParamDescriptor{{
+		DisplayName: "TR",
+		FlagName:    "TR",
+		Name:        "TR",
+		Required:    false,
+		Type:        "int",
+	}},
+	Positional: []ParamDescriptor{{
+		DisplayName: "Code",
+		Name:        "Code",
+		Required:    true,
+		Type:        "int",
+	}, {
+		DisplayName: "Target",
+		Name:        "Target",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (g *GTDContent) Descriptor() MessageDescriptor {
+	return gtdDescriptor
+}
+
+// Command returns the command of the content, i.e. GTD.
+func (g *GTDContent) Command() string {
+	return "GTD"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (g *GTDContent) MsgType() byte {
+	return g.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (g *GTDContent) MarshalADC() ([]byte, error) {
+	return g.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (g *GTDContent) WireSize() int {
+	if g.raw != nil && !g.dirty {
+		return len(g.raw)
+	}
+
+	n := len("GTD")
+
+	n += 1 + len(strconv.Itoa(g.Code))
+	if g.TR.IsSet {
+		n += 1 + len(escapeValue(g.Target.Value))
+	}
+	n += 1 + len(escapeValue(g.Description))
+	if g.TR.IsSet {
+		n += 1 + len("TR"+strconv.Itoa(g.TR.Value))
+	}
+	n += flagsSize(g.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (g *GTDContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := g.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (g *GTDContent) Equal(other *GTDContent) bool {
+	return equalParams(g, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (g *GTDContent) EqualIgnoring(other *GTDContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Code") && g.Code != other.Code {
+		return false
+	}
+	if !isIgnored(ignore, "Target") && (g.Target.IsSet != other.Target.IsSet || g.Target.IsSet && g.Target.Value != other.Target.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "Description") && g.Description != other.Description {
+		return false
+	}
+	if !isIgnored(ignore, "TR") && (g.TR.IsSet != other.TR.IsSet || g.TR.IsSet && g.TR.Value != other.TR.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(g.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (g *GTDContent) HashKey() string {
+	return hashKey(g)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (g *GTDContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(g, line, mode, func(params []string) (ParamAccessor, error) {
+		var other GTDContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (g *GTDContent) Redacted() *GTDContent {
+	redacted := *g
+	if g.Flags != nil {
+		redacted.Flags = g.UnknownFlags()
+	}
+	redacted.raw = nil
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (g *GTDContent) Clone() *GTDContent {
+	clone := *g
+	if g.Flags != nil {
+		clone.Flags = g.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), g.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (g *GTDContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 5)
+	attrs = append(attrs, slog.String("command", "GTD"))
+	attrs = append(attrs, slog.Any("Code", g.Code))
+	if g.Target.IsSet {
+		attrs = append(attrs, slog.Any("Target", g.Target.Value))
+	}
+	attrs = append(attrs, slog.Any("Description", g.Description))
+	if g.TR.IsSet {
+		attrs = append(attrs, slog.Any("TR", g.TR.Value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (g *GTDContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("GTD")
+	fmt.Fprintf(&sb, " Code=%v", g.Code)
+	if g.Target.IsSet {
+		fmt.Fprintf(&sb, " Target=%q", g.Target.Value)
+	}
+	fmt.Fprintf(&sb, " Description=%q", g.Description)
+	if g.TR.IsSet {
+		fmt.Fprintf(&sb, " TR=%v", g.TR.Value)
+	}
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (g *GTDContent) Labels() map[string]string {
+	labels := make(map[string]string, 4)
+	labels["Code"] = fmt.Sprint(g.Code)
+	if g.Target.IsSet {
+		labels["Target"] = fmt.Sprint(g.Target.Value)
+	}
+	labels["Description"] = fmt.Sprint(g.Description)
+	if g.TR.IsSet {
+		labels["TR"] = fmt.Sprint(g.TR.Value)
+	}
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (g *GTDContent) SetOptionalCount() int {
+	var n int
+	if g.Target.IsSet {
+		n++
+	}
+	if g.TR.IsSet {
+		n++
+	}
+	return n
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (g *GTDContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "GTDContent{Code:%v Target:%v Description:%v TR:%v Flags:%v}", g.Code, g.Target, g.Description, g.TR, g.Flags)
+		return
+	}
+
+	formatContent(f, verb, g.MarshalADC)
+}
+
+// GTDBuilder builds GTDContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type GTDBuilder struct {
+	content GTDContent
+	err     error
+}
+
+// NewGTDBuilder returns a builder of empty contents.
+func NewGTDBuilder() *GTDBuilder {
+	return &GTDBuilder{}
+}
+
+// Code sets the Code param.
+func (b *GTDBuilder) Code(value int) *GTDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.Code = value
+	b.content.codeStr = str
+	return b
+}
+
+// Target sets the Target param.
+func (b *GTDBuilder) Target(value string) *GTDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Target of message GTD: %w", err)
+		return b
+	}
+	b.content.Target.Set(value)
+	b.content.targetStr = str
+	return b
+}
+
+// Description sets the Description param.
+func (b *GTDBuilder) Description(value string) *GTDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message GTD: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// TR sets the TR param.
+func (b *GTDBuilder) TR(value int) *GTDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.TR.Set(value)
+	b.content.trStr = "TR" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *GTDBuilder) Build() (*GTDContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,107 @@
+package message
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestGTDContentNamedGet(t *testing.T) {
+	var g GTDContent
+	g.TR.Set(7)
+
+	for _, tc := range []struct {
+		flag GTDFlag
+		want string
+	}{{GTDFlagTR, "7"}} {
+		val, ok := g.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestGTDContentPosLen(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		var g GTDContent
+		if n&1 != 0 {
+			g.TR.Set(7)
+		}
+
+		if got, want := g.PosLen(), len(g.Positional()); got != want {
+			t.Errorf("sample %d: PosLen() = %d, want len(Positional()) = %d", n, got, want)
+		}
+	}
+}
+
+func TestGTDContentPosExcludesCommand(t *testing.T) {
+	var g GTDContent
+	g.Code = 7
+	g.Target.Set("gated")
+	g.Description = "p1"
+
+	buf, err := g.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != g.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], g.Command())
+	}
+
+	if got, want := g.PosAt(0), strconv.Itoa(g.Code); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := g.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := g.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzGTDContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var g GTDContent
+		if err := g.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := g.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded GTDContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&g) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package message
+
+import (
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	netip "net/netip"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestINFContentNamedGet(t *testing.T) {
+	var c INFContent
+	c.ID.Set(encoding.NewBase32Value([]byte{1, 2, 3}))
+	c.PD.Set([]byte{1, 2, 3})
+	c.I4.Set(netip.AddrFrom4([4]byte{192, 0, 2, 1}))
+	c.I6.Set(netip.AddrFrom4([4]byte{192, 0, 2, 1}))
+	c.U4.Set(7)
+	c.U6.Set(7)
+	c.SS.Set(7)
+	c.SF.Set(7)
+	c.VE.Set("sentinel")
+	c.US.Set(7)
+	c.DS.Set(7)
+	c.SL.Set(7)
+	c.AS.Set(7)
+	c.AM.Set(7)
+	c.EM.Set("sentinel")
+	c.NI.Set("sentinel")
+	c.DE.Set("sentinel")
+	c.HN.Set(7)
+	c.HR.Set(7)
+	c.HO.Set(7)
+	c.TO.Set("sentinel")
+	c.CT.Set(7)
+	c.AW.Set(7)
+	c.OP.Set(7)
+	c.SU.Set(encoding.Features{encoding.FeatureTCP4})
+
+	for _, tc := range []struct {
+		flag INFFlag
+		want string
+	}{{INFFlagID, "AEBAG"}, {INFFlagPD, "AEBAG"}, {INFFlagI4, "192.0.2.1"}, {INFFlagI6, "192.0.2.1"}, {INFFlagU4, "7"}, {INFFlagU6, "7"}, {INFFlagSS, "7"}, {INFFlagSF, "7"}, {INFFlagVE, "sentinel"}, {INFFlagUS, "7"}, {INFFlagDS, "7"}, {INFFlagSL, "7"}, {INFFlagAS, "7"}, {INFFlagAM, "7"}, {INFFlagEM, "sentinel"}, {INFFlagNI, "sentinel"}, {INFFlagDE, "sentinel"}, {INFFlagHN, "7"}, {INFFlagHR, "7"}, {INFFlagHO, "7"}, {INFFlagTO, "sentinel"}, {INFFlagCT, "7"}, {INFFlagAW, "7"}, {INFFlagOP, "7"}, {INFFlagSU, "TCP4"}} {
+		val, ok := c.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func FuzzINFContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var c INFContent
+		if err := c.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := c.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded INFContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		// MarshalADC drops deprecated params.
+		if !(c.OP.IsSet) {
+			if !decoded.Equal(&c) {
+				t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+			}
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
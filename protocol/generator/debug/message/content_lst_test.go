@@ -0,0 +1,82 @@
+package message
+
+import (
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestLSTContentPosLen(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		var l LSTContent
+		l.Items = make([]string, n)
+
+		if got, want := l.PosLen(), len(l.Positional()); got != want {
+			t.Errorf("sample %d: PosLen() = %d, want len(Positional()) = %d", n, got, want)
+		}
+	}
+}
+
+func TestLSTContentPosExcludesCommand(t *testing.T) {
+	var l LSTContent
+
+	buf, err := l.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != l.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], l.Command())
+	}
+
+	positional := l.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := l.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzLSTContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var l LSTContent
+		if err := l.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := l.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded LSTContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&l) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
@@ -0,0 +1,1127 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	maybe "github.com/seoester/adcl/protocol/maybe"
+	"io"
+	slog "log/slog"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type MIXFlag string
+
+const (
+	MIXFlagNI MIXFlag = "NI"
+	MIXFlagSV MIXFlag = "SV"
+	MIXFlagPR MIXFlag = "PR"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f MIXFlag) String() string {
+	switch f {
+	case MIXFlagNI:
+		return "MIXFlagNI"
+	case MIXFlagSV:
+		return "MIXFlagSV"
+	case MIXFlagPR:
+		return "MIXFlagPR"
+	}
+	return "MIXFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f MIXFlag) IsKnown() bool {
+	switch f {
+	case MIXFlagNI, MIXFlagSV, MIXFlagPR:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &MIXContent{}
+var _ ADCMarshaler = &MIXContent{}
+var _ ADCUnmarshaler = &MIXContent{}
+var _ io.WriterTo = &MIXContent{}
+var _ fmt.Formatter = &MIXContent{}
+var _ fmt.Stringer = &MIXContent{}
+var _ slog.LogValuer = &MIXContent{}
+
+// MIXContent is the content of MIX messages.
+type MIXContent struct {
+	Code    int `adc:"Code,positional" json:"code"`
+	codeStr string
+
+	Items    []string `adc:"Items,positional" json:"items"`
+	itemsStr []string
+
+	Description    string `adc:"Description,positional" json:"description"`
+	descriptionStr string
+
+	NI    maybe.String `adc:"NI" json:"ni,omitempty"`
+	niStr string
+
+	SV    maybe.Int `adc:"SV" json:"sv,omitempty"`
+	svStr string
+
+	PR    maybe.String `adc:"PR" json:"pr,omitempty"`
+	prStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (m *MIXContent) Positional() []string {
+	return m.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (m *MIXContent) AppendPositional(dst []string) []string {
+	dst = append(dst, strconv.Itoa(m.Code))
+	for _, val := range m.Items {
+		dst = append(dst, escapeValue(val))
+	}
+	dst = append(dst, escapeValue(m.Description))
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (m *MIXContent) PosLen() int {
+	return 2 + len(m.Items)
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (m *MIXContent) PosAt(i int) string {
+	switch {
+	case i < 0:
+		panic(fmt.Sprintf("MIX.PosAt: index %d out of range [0,%d)", i, m.PosLen()))
+	case i == 0:
+		return strconv.Itoa(m.Code)
+	case i < 1+len(m.Items):
+		return escapeValue(m.Items[i-1])
+	case i == 1+len(m.Items):
+		return escapeValue(m.Description)
+	default:
+		panic(fmt.Sprintf("MIX.PosAt: index %d out of range [0,%d)", i, m.PosLen()))
+	}
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (m *MIXContent) Named() map[string]string {
+	params := m.UnknownFlags()
+
+	if m.NI.IsSet {
+		params["NI"] = escapeValue(m.NI.Value)
+	}
+	if m.SV.IsSet {
+		params["SV"] = strconv.Itoa(m.SV.Value)
+	}
+	if m.PR.IsSet {
+		params["PR"] = escapeValue(m.PR.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (m *MIXContent) NamedGet(key string) (string, bool) {
+	switch MIXFlag(key) {
+	case MIXFlagNI:
+		if m.NI.IsSet {
+			return escapeValue(m.NI.Value), true
+		}
+		return "", false
+	case MIXFlagSV:
+		if m.SV.IsSet {
+			return strconv.Itoa(m.SV.Value), true
+		}
+		return "", false
+	case MIXFlagPR:
+		if m.PR.IsSet {
+			return escapeValue(m.PR.Value), true
+		}
+		return "", false
+	}
+
+	return m.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (m *MIXContent) NamedAll(key string) []string {
+	if val, ok := m.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetNI returns the decoded value of the NI param and whether it is set.
+func (m *MIXContent) GetNI() (string, bool) {
+	return m.NI.Value, m.NI.IsSet
+}
+
+// GetSV returns the decoded value of the SV param and whether it is set.
+func (m *MIXContent) GetSV() (int, bool) {
+	return m.SV.Value, m.SV.IsSet
+}
+
+// GetPR returns the decoded value of the PR param and whether it is set.
+func (m *MIXContent) GetPR() (string, bool) {
+	return m.PR.Value, m.PR.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (m *MIXContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Code":
+		return strconv.Itoa(m.Code), true
+	case "Items":
+		if len(m.Items) > 0 {
+			return escapeValue(m.Items[0]), true
+		}
+	case "Description":
+		return escapeValue(m.Description), true
+	}
+
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (m *MIXContent) ParseInto(params []string, opts *ParseOptions) error {
+	*m = MIXContent{}
+	m.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message MIX: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 2 {
+		return fmt.Errorf("parsing message MIX: %w", ErrMissingParam)
+	}
+
+	rest := plain
+	end := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			rest--
+		}
+		if !isNamedParam(param) || end+rest < 2 {
+			end++
+		}
+	}
+	if end < 2 {
+		return fmt.Errorf("parsing message MIX: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case isNamedParam(param) && (pos+plain >= 2):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message MIX: %w", param[:2], err)
+			}
+			switch MIXFlag(param[:2]) {
+			case MIXFlagNI:
+				if err := opts.checkDuplicateFlag(m.niStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message MIX: %w", param[:2], err)
+				}
+				m.niStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param NI of message MIX: %w", err)
+				}
+				m.NI.Set(val)
+			case MIXFlagSV:
+				if err := opts.checkDuplicateFlag(m.svStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message MIX: %w", param[:2], err)
+				}
+				m.svStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SV of message MIX: %w", err)
+				}
+				m.SV.Set(val)
+			case MIXFlagPR:
+				if err := opts.checkDuplicateFlag(m.prStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message MIX: %w", param[:2], err)
+				}
+				m.prStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param PR of message MIX: %w", err)
+				}
+				m.PR.Set(val)
+			default:
+				if m.Flags == nil {
+					m.Flags = make(map[string]string)
+				}
+				m.Flags[param[:2]] = param[2:]
+			}
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Code of message MIX: %w", err)
+			}
+			m.codeStr = param
+			val, err := strconv.Atoi(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Code of message MIX: %w", err)
+			}
+			m.Code = val
+		case pos < end-1:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Items of message MIX: %w", err)
+			}
+			m.itemsStr = append(m.itemsStr, param)
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Items of message MIX: %w", err)
+			}
+			m.Items = append(m.Items, val)
+		case pos == end-1:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message MIX: %w", err)
+			}
+			m.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message MIX: %w", err)
+			}
+			m.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message MIX: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if m.Description == "" {
+		return fmt.Errorf("parsing param Description of message MIX: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// MIXContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func MIXContentFromAccessor(pa ParamAccessor) (*MIXContent, error) {
+	var m MIXContent
+	if err := m.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (m *MIXContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message MIX: %w", err)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (m *MIXContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "MIX" {
+		return fmt.Errorf("parsing message MIX: %w", ErrCommandMismatch)
+	}
+
+	return m.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (m *MIXContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message MIX: %w", err)
+	}
+	if err := m.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	m.raw = rawLine(line)
+	m.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (m *MIXContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, m.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (m *MIXContent) SetNamedAll(named map[string]string) error {
+	decoded := *m
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*m = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (m *MIXContent) setNamedAll(named map[string]string) error {
+	m.dirty = true
+	var zero MIXContent
+	m.NI = zero.NI
+	m.niStr = zero.niStr
+	m.SV = zero.SV
+	m.svStr = zero.svStr
+	m.PR = zero.PR
+	m.prStr = zero.prStr
+	m.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message MIX: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch MIXFlag(param[:2]) {
+		case MIXFlagNI:
+			m.niStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param NI of message MIX: %w", err)
+			}
+			m.NI.Set(val)
+		case MIXFlagSV:
+			m.svStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SV of message MIX: %w", err)
+			}
+			m.SV.Set(val)
+		case MIXFlagPR:
+			m.prStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param PR of message MIX: %w", err)
+			}
+			m.PR.Set(val)
+		default:
+			if m.Flags == nil {
+				m.Flags = make(map[string]string)
+			}
+			m.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (m *MIXContent) ToMap() map[string]string {
+	values := m.UnknownFlags()
+	values["Code"] = strconv.Itoa(m.Code)
+	itemsStrs := make([]string, len(m.Items))
+	for i, val := range m.Items {
+		itemsStrs[i] = escapeValue(val)
+	}
+	values["Items"] = joinValues(itemsStrs, 0)
+	values["Description"] = escapeValue(m.Description)
+	if m.NI.IsSet {
+		values["NI"] = escapeValue(m.NI.Value)
+	}
+	if m.SV.IsSet {
+		values["SV"] = strconv.Itoa(m.SV.Value)
+	}
+	if m.PR.IsSet {
+		values["PR"] = escapeValue(m.PR.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (m *MIXContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Code"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Code of message MIX: %w", ErrMissingParam)
+	}
+	if val, ok := values["Items"]; ok {
+		params = append(params, splitValues(val)...)
+	} else {
+		return fmt.Errorf("converting param Items of message MIX: %w", ErrMissingParam)
+	}
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message MIX: %w", ErrMissingParam)
+	}
+	if val, ok := values["NI"]; ok {
+		params = append(params, "NI"+val)
+	}
+	if val, ok := values["SV"]; ok {
+		params = append(params, "SV"+val)
+	}
+	if val, ok := values["PR"]; ok {
+		params = append(params, "PR"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "Code", "Items", "Description", "NI", "SV", "PR":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message MIX: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (m *MIXContent) MarkDirty() {
+	m.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (m *MIXContent) AppendADC(buf []byte) ([]byte, error) {
+	if m.raw != nil && !m.dirty {
+		return append(buf, m.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, "MIX"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(m.Code), 10)
+	for _, val := range m.Items {
+		buf = append(buf, ' ')
+		buf = appendEscaped(buf, val)
+	}
+	if m.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message MIX: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, m.Description)
+	if m.NI.IsSet {
+		buf = append(buf, " NI"...)
+		buf = appendEscaped(buf, m.NI.Value)
+	}
+	if m.SV.IsSet {
+		buf = append(buf, " SV"...)
+		buf = strconv.AppendInt(buf, int64(m.SV.Value), 10)
+	}
+	if m.PR.IsSet {
+		buf = append(buf, " PR"...)
+		buf = appendEscaped(buf, m.PR.Value)
+	}
+	buf = appendFlags(buf, m.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (m *MIXContent) EncodeTo(buf []byte) []byte {
+	if m.raw != nil && !m.dirty {
+		return append(buf, m.raw...)
+	}
+
+	buf = append(buf, "MIX"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(m.Code), 10)
+	for _, val := range m.Items {
+		buf = append(buf, ' ')
+		buf = appendEscaped(buf, val)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, m.Description)
+	if m.NI.IsSet {
+		buf = append(buf, " NI"...)
+		buf = appendEscaped(buf, m.NI.Value)
+	}
+	if m.SV.IsSet {
+		buf = append(buf, " SV"...)
+		buf = strconv.AppendInt(buf, int64(m.SV.Value), 10)
+	}
+	if m.PR.IsSet {
+		buf = append(buf, " PR"...)
+		buf = appendEscaped(buf, m.PR.Value)
+	}
+	buf = encodeFlags(buf, m.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (m *MIXContent) ADCString() string {
+	if m.raw != nil && !m.dirty {
+		return string(m.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(m.WireSize())
+	builder.WriteString("MIX")
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(m.Code))
+	for _, val := range m.Items {
+		builder.WriteByte(' ')
+		builder.WriteString(escapeValue(val))
+	}
+	if m.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(m.Description))
+	if m.NI.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("NI" + escapeValue(m.NI.Value))
+	}
+	if m.SV.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SV" + strconv.Itoa(m.SV.Value))
+	}
+	if m.PR.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("PR" + escapeValue(m.PR.Value))
+	}
+	writeFlags(&builder, m.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (m *MIXContent) Validate() error {
+	var errs []error
+	for _, name := range m.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message MIX: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(m.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message MIX: %w", err))
+	}
+
+	if m.SV.IsSet {
+		switch m.SV.Value {
+		case 0, 1, 2:
+		default:
+			errs = append(errs, fmt.Errorf("validating param SV of message MIX: %w, must be one of 0, 1, 2", ErrValueNotAllowed))
+		}
+	}
+	if m.PR.IsSet {
+		switch m.PR.Value {
+		case "ADC/1.0":
+		default:
+			errs = append(errs, fmt.Errorf("validating param PR of message MIX: %w, PR must name a supported protocol revision", ErrValueNotAllowed))
+		}
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (m *MIXContent) MissingRequired() []string {
+	var missing []string
+	if m.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var mixDescriptor = MessageDescriptor{
+	Command: "MIX",
+	Named: []ParamDescriptor{{
+		DisplayName: "NI",
+		FlagName:    "NI",
+		Name:        "NI",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "SV",
+		FlagName:    "SV",
+		Name:        "SV",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "PR",
+		FlagName:    "PR",
+		Name:        "PR",
+		Required:    false,
+		Type:        "string",
+	}},
+	Phases: PhaseNormal,
+	Positional: []ParamDescriptor{{
+		DisplayName: "Code",
+		Name:        "Code",
+		Required:    true,
+		Type:        "int",
+	}, {
+		DisplayName: "Items",
+		Name:        "Items",
+		Required:    true,
+		Type:        "string",
+	}, {
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+	Types: "BDH",
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (m *MIXContent) Descriptor() MessageDescriptor {
+	return mixDescriptor
+}
+
+// Command returns the command of the content, i.e. MIX.
+func (m *MIXContent) Command() string {
+	return "MIX"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (m *MIXContent) MsgType() byte {
+	return m.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (m *MIXContent) MarshalADC() ([]byte, error) {
+	return m.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (m *MIXContent) WireSize() int {
+	if m.raw != nil && !m.dirty {
+		return len(m.raw)
+	}
+
+	n := len("MIX")
+
+	n += 1 + len(strconv.Itoa(m.Code))
+	for _, val := range m.Items {
+		n += 1 + len(escapeValue(val))
+	}
+	n += 1 + len(escapeValue(m.Description))
+	if m.NI.IsSet {
+		n += 1 + len("NI"+escapeValue(m.NI.Value))
+	}
+	if m.SV.IsSet {
+		n += 1 + len("SV"+strconv.Itoa(m.SV.Value))
+	}
+	if m.PR.IsSet {
+		n += 1 + len("PR"+escapeValue(m.PR.Value))
+	}
+	n += flagsSize(m.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (m *MIXContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := m.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (m *MIXContent) Equal(other *MIXContent) bool {
+	return equalParams(m, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (m *MIXContent) EqualIgnoring(other *MIXContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Code") && m.Code != other.Code {
+		return false
+	}
+	if !isIgnored(ignore, "Items") {
+		if len(m.Items) != len(other.Items) {
+			return false
+		}
+		for i, val := range m.Items {
+			if val != other.Items[i] {
+				return false
+			}
+		}
+	}
+	if !isIgnored(ignore, "Description") && m.Description != other.Description {
+		return false
+	}
+	if !isIgnored(ignore, "NI") && (m.NI.IsSet != other.NI.IsSet || m.NI.IsSet && m.NI.Value != other.NI.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SV") && (m.SV.IsSet != other.SV.IsSet || m.SV.IsSet && m.SV.Value != other.SV.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "PR") && (m.PR.IsSet != other.PR.IsSet || m.PR.IsSet && m.PR.Value != other.PR.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(m.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (m *MIXContent) HashKey() string {
+	return hashKey(m)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (m *MIXContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(m, line, mode, func(params []string) (ParamAccessor, error) {
+		var other MIXContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (m *MIXContent) Redacted() *MIXContent {
+	redacted := *m
+	if m.Flags != nil {
+		redacted.Flags = m.UnknownFlags()
+	}
+	redacted.raw = nil
+	redacted.itemsStr = append([]string(nil), m.itemsStr...)
+	redacted.Items = append([]string(nil), m.Items...)
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (m *MIXContent) Clone() *MIXContent {
+	clone := *m
+	if m.Flags != nil {
+		clone.Flags = m.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), m.raw...)
+	clone.itemsStr = append([]string(nil), m.itemsStr...)
+	clone.Items = append([]string(nil), m.Items...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (m *MIXContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 7)
+	attrs = append(attrs, slog.String("command", "MIX"))
+	attrs = append(attrs, slog.Any("Code", m.Code))
+	attrs = append(attrs, slog.Any("Items", m.Items))
+	attrs = append(attrs, slog.Any("Description", m.Description))
+	if m.NI.IsSet {
+		attrs = append(attrs, slog.Any("NI", m.NI.Value))
+	}
+	if m.SV.IsSet {
+		attrs = append(attrs, slog.Any("SV", m.SV.Value))
+	}
+	if m.PR.IsSet {
+		attrs = append(attrs, slog.Any("PR", m.PR.Value))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (m *MIXContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("MIX")
+	fmt.Fprintf(&sb, " Code=%v", m.Code)
+	fmt.Fprintf(&sb, " Items=%q", m.Items)
+	fmt.Fprintf(&sb, " Description=%q", m.Description)
+	if m.NI.IsSet {
+		fmt.Fprintf(&sb, " NI=%q", m.NI.Value)
+	}
+	if m.SV.IsSet {
+		fmt.Fprintf(&sb, " SV=%v", m.SV.Value)
+	}
+	if m.PR.IsSet {
+		fmt.Fprintf(&sb, " PR=%q", m.PR.Value)
+	}
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (m *MIXContent) Labels() map[string]string {
+	labels := make(map[string]string, 6)
+	labels["Code"] = fmt.Sprint(m.Code)
+	labels["Items"] = fmt.Sprint(m.Items)
+	labels["Description"] = fmt.Sprint(m.Description)
+	if m.NI.IsSet {
+		labels["NI"] = fmt.Sprint(m.NI.Value)
+	}
+	if m.SV.IsSet {
+		labels["SV"] = fmt.Sprint(m.SV.Value)
+	}
+	if m.PR.IsSet {
+		labels["PR"] = fmt.Sprint(m.PR.Value)
+	}
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (m *MIXContent) SetOptionalCount() int {
+	var n int
+	if m.NI.IsSet {
+		n++
+	}
+	if m.SV.IsSet {
+		n++
+	}
+	if m.PR.IsSet {
+		n++
+	}
+	return n
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (m *MIXContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "MIXContent{Code:%v Items:%v Description:%v NI:%v SV:%v PR:%v Flags:%v}", m.Code, m.Items, m.Description, m.NI, m.SV, m.PR, m.Flags)
+		return
+	}
+
+	formatContent(f, verb, m.MarshalADC)
+}
+
+// MIXBuilder builds MIXContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type MIXBuilder struct {
+	content MIXContent
+	err     error
+}
+
+// NewMIXBuilder returns a builder of empty contents.
+func NewMIXBuilder() *MIXBuilder {
+	return &MIXBuilder{}
+}
+
+// Code sets the Code param.
+func (b *MIXBuilder) Code(value int) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.Code = value
+	b.content.codeStr = str
+	return b
+}
+
+// Items replaces all values of the Items param.
+func (b *MIXBuilder) Items(values ...string) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, val := range values {
+		str, err := encoding.EncodeToADCString(val)
+		if err != nil {
+			b.err = fmt.Errorf("building param Items of message MIX: %w", err)
+			return b
+		}
+		strs = append(strs, str)
+	}
+	b.content.Items = append([]string{}, values...)
+	b.content.itemsStr = strs
+	return b
+}
+
+// Description sets the Description param.
+func (b *MIXBuilder) Description(value string) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message MIX: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// NI sets the NI param.
+func (b *MIXBuilder) NI(value string) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param NI of message MIX: %w", err)
+		return b
+	}
+	b.content.NI.Set(value)
+	b.content.niStr = "NI" + str
+	return b
+}
+
+// SV sets the SV param.
+func (b *MIXBuilder) SV(value int) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SV.Set(value)
+	b.content.svStr = "SV" + str
+	return b
+}
+
+// PR sets the PR param.
+func (b *MIXBuilder) PR(value string) *MIXBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param PR of message MIX: %w", err)
+		return b
+	}
+	b.content.PR.Set(value)
+	b.content.prStr = "PR" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *MIXBuilder) Build() (*MIXContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,173 @@
+package message
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestMIXContentNamedGet(t *testing.T) {
+	var m MIXContent
+	m.NI.Set("sentinel")
+	m.SV.Set(7)
+	m.PR.Set("sentinel")
+
+	for _, tc := range []struct {
+		flag MIXFlag
+		want string
+	}{{MIXFlagNI, "sentinel"}, {MIXFlagSV, "7"}, {MIXFlagPR, "sentinel"}} {
+		val, ok := m.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestMIXContentPosLen(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		var m MIXContent
+		m.Items = make([]string, n)
+
+		if got, want := m.PosLen(), len(m.Positional()); got != want {
+			t.Errorf("sample %d: PosLen() = %d, want len(Positional()) = %d", n, got, want)
+		}
+	}
+}
+
+func TestMIXContentPosExcludesCommand(t *testing.T) {
+	var m MIXContent
+	m.Code = 7
+	m.Description = "p1"
+
+	buf, err := m.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != m.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], m.Command())
+	}
+
+	if got, want := m.PosAt(0), strconv.Itoa(m.Code); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := m.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := m.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func TestMIXContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"MIX 7 a b\\sc desc NInick", map[string]string{
+			"Code":        "7",
+			"Description": "desc",
+			"Items":       "[a b c]",
+			"NI":          "nick",
+		}},
+		{"MIX 7 desc", map[string]string{
+			"Code":        "7",
+			"Description": "desc",
+		}},
+	}
+
+	values := func(m *MIXContent) map[string]string {
+		values := make(map[string]string)
+		values["Code"] = fmt.Sprint(m.Code)
+		if len(m.Items) > 0 {
+			values["Items"] = fmt.Sprint(m.Items)
+		}
+		values["Description"] = fmt.Sprint(m.Description)
+		if m.NI.IsSet {
+			values["NI"] = fmt.Sprint(m.NI.Value)
+		}
+		if m.SV.IsSet {
+			values["SV"] = fmt.Sprint(m.SV.Value)
+		}
+		if m.PR.IsSet {
+			values["PR"] = fmt.Sprint(m.PR.Value)
+		}
+		return values
+	}
+
+	for _, example := range examples {
+		var m MIXContent
+		if err := m.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&m)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := m.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzMIXContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("7 a b\\sc desc NInick")
+	f.Add("7 desc")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var m MIXContent
+		if err := m.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := m.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded MIXContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&m) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
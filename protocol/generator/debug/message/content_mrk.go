@@ -0,0 +1,699 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	slog "log/slog"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type MRKFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f MRKFlag) String() string {
+	return "MRKFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f MRKFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &MRKContent{}
+var _ ADCMarshaler = &MRKContent{}
+var _ ADCUnmarshaler = &MRKContent{}
+var _ io.WriterTo = &MRKContent{}
+var _ fmt.Formatter = &MRKContent{}
+var _ fmt.Stringer = &MRKContent{}
+var _ slog.LogValuer = &MRKContent{}
+
+// MRKContent is the content of MRK messages.
+type MRKContent struct {
+	Code    int `adc:"Code,positional" json:"code"`
+	codeStr string
+
+	Description    string `adc:"Description,positional" json:"description"`
+	descriptionStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (m *MRKContent) Positional() []string {
+	return m.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (m *MRKContent) AppendPositional(dst []string) []string {
+	return append(dst, strconv.Itoa(m.Code), "V2", escapeValue(m.Description))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (m *MRKContent) PosLen() int {
+	return 3
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (m *MRKContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return strconv.Itoa(m.Code)
+	case 1:
+		return "V2"
+	case 2:
+		return escapeValue(m.Description)
+	default:
+		panic(fmt.Sprintf("MRK.PosAt: index %d out of range [0,%d)", i, m.PosLen()))
+	}
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (m *MRKContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Code":
+		return strconv.Itoa(m.Code), true
+	case "Marker":
+		return "V2", true
+	case "Description":
+		return escapeValue(m.Description), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (m *MRKContent) PositionalValues() (int, string) {
+	return m.Code, m.Description
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (m *MRKContent) ParseInto(params []string, opts *ParseOptions) error {
+	*m = MRKContent{}
+	m.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message MRK: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 3 {
+		return fmt.Errorf("parsing message MRK: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case isNamedParam(param) && (pos+plain >= 3) && !(pos == 1 && param == "V2"):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message MRK: %w", param[:2], err)
+			}
+			if m.Flags == nil {
+				m.Flags = make(map[string]string)
+			}
+			m.Flags[param[:2]] = param[2:]
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Code of message MRK: %w", err)
+			}
+			m.codeStr = param
+			val, err := strconv.Atoi(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Code of message MRK: %w", err)
+			}
+			m.Code = val
+		case pos == 1:
+			if param != "V2" {
+				return fmt.Errorf("parsing param Marker of message MRK: %w", ErrConstMismatch)
+			}
+		case pos == 2:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message MRK: %w", err)
+			}
+			m.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message MRK: %w", err)
+			}
+			m.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message MRK: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 3 {
+		return fmt.Errorf("parsing message MRK: %w", ErrMissingParam)
+	}
+
+	if m.Description == "" {
+		return fmt.Errorf("parsing param Description of message MRK: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// MRKContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func MRKContentFromAccessor(pa ParamAccessor) (*MRKContent, error) {
+	var m MRKContent
+	if err := m.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (m *MRKContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message MRK: %w", err)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (m *MRKContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "MRK" {
+		return fmt.Errorf("parsing message MRK: %w", ErrCommandMismatch)
+	}
+
+	return m.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (m *MRKContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message MRK: %w", err)
+	}
+	if err := m.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	m.raw = rawLine(line)
+	m.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (m *MRKContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, m.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (m *MRKContent) SetNamedAll(named map[string]string) error {
+	decoded := *m
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*m = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (m *MRKContent) setNamedAll(named map[string]string) error {
+	m.dirty = true
+	m.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message MRK: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if m.Flags == nil {
+			m.Flags = make(map[string]string)
+		}
+		m.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (m *MRKContent) ToMap() map[string]string {
+	values := m.UnknownFlags()
+	values["Code"] = strconv.Itoa(m.Code)
+	values["Description"] = escapeValue(m.Description)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (m *MRKContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Code"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Code of message MRK: %w", ErrMissingParam)
+	}
+	params = append(params, "V2")
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message MRK: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "Code", "Description":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message MRK: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (m *MRKContent) MarkDirty() {
+	m.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (m *MRKContent) AppendADC(buf []byte) ([]byte, error) {
+	if m.raw != nil && !m.dirty {
+		return append(buf, m.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, "MRK"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(m.Code), 10)
+	buf = append(buf, ' ')
+	buf = append(buf, "V2"...)
+	if m.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message MRK: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, m.Description)
+	buf = appendFlags(buf, m.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (m *MRKContent) EncodeTo(buf []byte) []byte {
+	if m.raw != nil && !m.dirty {
+		return append(buf, m.raw...)
+	}
+
+	buf = append(buf, "MRK"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(m.Code), 10)
+	buf = append(buf, ' ')
+	buf = append(buf, "V2"...)
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, m.Description)
+	buf = encodeFlags(buf, m.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (m *MRKContent) ADCString() string {
+	if m.raw != nil && !m.dirty {
+		return string(m.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(m.WireSize())
+	builder.WriteString("MRK")
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(m.Code))
+	builder.WriteByte(' ')
+	builder.WriteString("V2")
+	if m.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(m.Description))
+	writeFlags(&builder, m.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (m *MRKContent) Validate() error {
+	var errs []error
+	for _, name := range m.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message MRK: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(m.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message MRK: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (m *MRKContent) MissingRequired() []string {
+	var missing []string
+	if m.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var mrkDescriptor = MessageDescriptor{
+	Command: "MRK",
+	Positional: []ParamDescriptor{{
+		DisplayName: "Code",
+		Name:        "Code",
+		Required:    true,
+		Type:        "int",
+	}, {
+		DisplayName: "Marker",
+		Name:        "Marker",
+		Required:    true,
+		Type:        "string",
+	}, {
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (m *MRKContent) Descriptor() MessageDescriptor {
+	return mrkDescriptor
+}
+
+// Command returns the command of the content, i.e. MRK.
+func (m *MRKContent) Command() string {
+	return "MRK"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (m *MRKContent) MsgType() byte {
+	return m.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (m *MRKContent) MarshalADC() ([]byte, error) {
+	return m.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (m *MRKContent) WireSize() int {
+	if m.raw != nil && !m.dirty {
+		return len(m.raw)
+	}
+
+	n := len("MRK")
+
+	n += 1 + len(strconv.Itoa(m.Code))
+	n += 1 + len("V2")
+	n += 1 + len(escapeValue(m.Description))
+	n += flagsSize(m.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (m *MRKContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := m.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (m *MRKContent) Equal(other *MRKContent) bool {
+	return equalParams(m, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (m *MRKContent) EqualIgnoring(other *MRKContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Code") && m.Code != other.Code {
+		return false
+	}
+	if !isIgnored(ignore, "Description") && m.Description != other.Description {
+		return false
+	}
+
+	return equalFlagsIgnoring(m.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (m *MRKContent) HashKey() string {
+	return hashKey(m)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (m *MRKContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(m, line, mode, func(params []string) (ParamAccessor, error) {
+		var other MRKContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (m *MRKContent) Redacted() *MRKContent {
+	redacted := *m
+	if m.Flags != nil {
+		redacted.Flags = m.UnknownFlags()
+	}
+	redacted.raw = nil
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (m *MRKContent) Clone() *MRKContent {
+	clone := *m
+	if m.Flags != nil {
+		clone.Flags = m.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), m.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (m *MRKContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	attrs = append(attrs, slog.String("command", "MRK"))
+	attrs = append(attrs, slog.Any("Code", m.Code))
+	attrs = append(attrs, slog.Any("Description", m.Description))
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (m *MRKContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("MRK")
+	fmt.Fprintf(&sb, " Code=%v", m.Code)
+	fmt.Fprintf(&sb, " Description=%q", m.Description)
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (m *MRKContent) Labels() map[string]string {
+	labels := make(map[string]string, 2)
+	labels["Code"] = fmt.Sprint(m.Code)
+	labels["Description"] = fmt.Sprint(m.Description)
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (m *MRKContent) SetOptionalCount() int {
+	return 0
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (m *MRKContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "MRKContent{Code:%v Description:%v Flags:%v}", m.Code, m.Description, m.Flags)
+		return
+	}
+
+	formatContent(f, verb, m.MarshalADC)
+}
+
+// MRKBuilder builds MRKContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type MRKBuilder struct {
+	content MRKContent
+	err     error
+}
+
+// NewMRKBuilder returns a builder of empty contents.
+func NewMRKBuilder() *MRKBuilder {
+	return &MRKBuilder{}
+}
+
+// Code sets the Code param.
+func (b *MRKBuilder) Code(value int) *MRKBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.Code = value
+	b.content.codeStr = str
+	return b
+}
+
+// Description sets the Description param.
+func (b *MRKBuilder) Description(value string) *MRKBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message MRK: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *MRKBuilder) Build() (*MRKContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
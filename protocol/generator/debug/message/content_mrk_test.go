@@ -0,0 +1,86 @@
+package message
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestMRKContentPosLen(t *testing.T) {
+	var m MRKContent
+
+	if got, want := m.PosLen(), len(m.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestMRKContentPosExcludesCommand(t *testing.T) {
+	var m MRKContent
+	m.Code = 7
+	m.Description = "p1"
+
+	buf, err := m.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != m.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], m.Command())
+	}
+
+	if got, want := m.PosAt(0), strconv.Itoa(m.Code); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := m.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := m.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzMRKContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var m MRKContent
+		if err := m.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := m.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded MRKContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&m) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package message
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestMSGContentNamedGet(t *testing.T) {
+	var m MSGContent
+	m.TS.Set(time.Unix(7, 0))
+
+	for _, tc := range []struct {
+		flag MSGFlag
+		want string
+	}{{MSGFlagTS, "7"}} {
+		val, ok := m.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestMSGContentPosLen(t *testing.T) {
+	var m MSGContent
+
+	if got, want := m.PosLen(), len(m.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestMSGContentPosExcludesCommand(t *testing.T) {
+	var m MSGContent
+	m.Text = "p0"
+
+	buf, err := m.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != m.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], m.Command())
+	}
+
+	if got, want := m.PosAt(0), escapeValue(m.Text); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := m.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := m.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzMSGContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var m MSGContent
+		if err := m.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := m.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded MSGContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&m) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
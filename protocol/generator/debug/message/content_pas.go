@@ -0,0 +1,637 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	slog "log/slog"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type PASFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f PASFlag) String() string {
+	return "PASFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f PASFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &PASContent{}
+var _ ADCMarshaler = &PASContent{}
+var _ ADCUnmarshaler = &PASContent{}
+var _ io.WriterTo = &PASContent{}
+var _ fmt.Formatter = &PASContent{}
+var _ fmt.Stringer = &PASContent{}
+var _ slog.LogValuer = &PASContent{}
+
+// PASContent is the content of PAS messages. Password hash, sent in response
+// to a password request of the hub. See ADC § 5.3.4.
+type PASContent struct {
+	Password    *encoding.Base32Value `adc:"Password,positional" json:"password"`
+	passwordStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (p *PASContent) Positional() []string {
+	return p.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (p *PASContent) AppendPositional(dst []string) []string {
+	return append(dst, formatBase32(p.Password))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (p *PASContent) PosLen() int {
+	return 1
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (p *PASContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return formatBase32(p.Password)
+	default:
+		panic(fmt.Sprintf("PAS.PosAt: index %d out of range [0,%d)", i, p.PosLen()))
+	}
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (p *PASContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Password":
+		return formatBase32(p.Password), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (p *PASContent) PositionalValues() *encoding.Base32Value {
+	return p.Password
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (p *PASContent) ParseInto(params []string, opts *ParseOptions) error {
+	*p = PASContent{}
+	p.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message PAS: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 1 {
+		return fmt.Errorf("parsing message PAS: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 1 && isNamedParam(param) && (pos+plain >= 1):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				p.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message PAS: %w", param[:2], err)
+			}
+			if p.Flags == nil {
+				p.Flags = make(map[string]string)
+			}
+			p.Flags[param[:2]] = param[2:]
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				p.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Password of message PAS: %w", err)
+			}
+			p.passwordStr = param
+			val, err := encoding.ParseBase32Value(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Password of message PAS: %w", err)
+			}
+			p.Password = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message PAS: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 1 {
+		return fmt.Errorf("parsing message PAS: %w", ErrMissingParam)
+	}
+
+	if formatBase32(p.Password) == "" {
+		return fmt.Errorf("parsing param Password of message PAS: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// PASContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func PASContentFromAccessor(pa ParamAccessor) (*PASContent, error) {
+	var p PASContent
+	if err := p.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (p *PASContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message PAS: %w", err)
+	}
+
+	return p.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (p *PASContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "PAS" {
+		return fmt.Errorf("parsing message PAS: %w", ErrCommandMismatch)
+	}
+
+	return p.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (p *PASContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message PAS: %w", err)
+	}
+	if err := p.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	p.raw = rawLine(line)
+	p.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (p *PASContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, p.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (p *PASContent) SetNamedAll(named map[string]string) error {
+	decoded := *p
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*p = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (p *PASContent) setNamedAll(named map[string]string) error {
+	p.dirty = true
+	p.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message PAS: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if p.Flags == nil {
+			p.Flags = make(map[string]string)
+		}
+		p.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (p *PASContent) ToMap() map[string]string {
+	values := p.UnknownFlags()
+	values["Password"] = formatBase32(p.Password)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (p *PASContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Password"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Password of message PAS: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "Password":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message PAS: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return p.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (p *PASContent) MarkDirty() {
+	p.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (p *PASContent) AppendADC(buf []byte) ([]byte, error) {
+	if p.raw != nil && !p.dirty {
+		return append(buf, p.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, "PAS"...)
+
+	if formatBase32(p.Password) == "" {
+		return buf[:n], fmt.Errorf("marshalling param Password of message PAS: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = append(buf, formatBase32(p.Password)...)
+	buf = appendFlags(buf, p.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (p *PASContent) EncodeTo(buf []byte) []byte {
+	if p.raw != nil && !p.dirty {
+		return append(buf, p.raw...)
+	}
+
+	buf = append(buf, "PAS"...)
+
+	buf = append(buf, ' ')
+	buf = append(buf, formatBase32(p.Password)...)
+	buf = encodeFlags(buf, p.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (p *PASContent) ADCString() string {
+	if p.raw != nil && !p.dirty {
+		return string(p.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(p.WireSize())
+	builder.WriteString("PAS")
+
+	if formatBase32(p.Password) == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(formatBase32(p.Password))
+	writeFlags(&builder, p.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (p *PASContent) Validate() error {
+	var errs []error
+	for _, name := range p.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message PAS: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(p.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message PAS: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (p *PASContent) MissingRequired() []string {
+	var missing []string
+	if formatBase32(p.Password) == "" {
+		missing = append(missing, "Password")
+	}
+	return missing
+}
+
+var pasDescriptor = MessageDescriptor{
+	Command: "PAS",
+	Positional: []ParamDescriptor{{
+		DisplayName: "Password",
+		Name:        "Password",
+		Required:    true,
+		Type:        "base32",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (p *PASContent) Descriptor() MessageDescriptor {
+	return pasDescriptor
+}
+
+// Command returns the command of the content, i.e. PAS.
+func (p *PASContent) Command() string {
+	return "PAS"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (p *PASContent) MsgType() byte {
+	return p.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (p *PASContent) MarshalADC() ([]byte, error) {
+	return p.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (p *PASContent) WireSize() int {
+	if p.raw != nil && !p.dirty {
+		return len(p.raw)
+	}
+
+	n := len("PAS")
+
+	n += 1 + len(formatBase32(p.Password))
+	n += flagsSize(p.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (p *PASContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := p.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (p *PASContent) Equal(other *PASContent) bool {
+	return equalParams(p, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (p *PASContent) EqualIgnoring(other *PASContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Password") && formatBase32(p.Password) != formatBase32(other.Password) {
+		return false
+	}
+
+	return equalFlagsIgnoring(p.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (p *PASContent) HashKey() string {
+	return hashKey(p)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (p *PASContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(p, line, mode, func(params []string) (ParamAccessor, error) {
+		var other PASContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (p *PASContent) Redacted() *PASContent {
+	redacted := *p
+	if p.Flags != nil {
+		redacted.Flags = p.UnknownFlags()
+	}
+	redacted.raw = nil
+	var zero PASContent
+	if formatBase32(p.Password) != "" {
+		redacted.Password = zero.Password
+	}
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (p *PASContent) Clone() *PASContent {
+	clone := *p
+	if p.Flags != nil {
+		clone.Flags = p.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), p.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (p *PASContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.String("command", "PAS"))
+	attrs = append(attrs, slog.String("Password", "***"))
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (p *PASContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("PAS")
+	sb.WriteString(" Password=***")
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (p *PASContent) Labels() map[string]string {
+	labels := make(map[string]string, 0)
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (p *PASContent) SetOptionalCount() int {
+	return 0
+}
+
+// marshalRedacted marshals the content as AppendADC does into a new buffer,
+// masking the values of sensitive params.
+func (p *PASContent) marshalRedacted() ([]byte, error) {
+	buf := make([]byte, 0, p.WireSize())
+	n := len(buf)
+	buf = append(buf, "PAS"...)
+
+	if formatBase32(p.Password) == "" {
+		return buf[:n], fmt.Errorf("marshalling param Password of message PAS: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = append(buf, "***"...)
+	buf = appendFlags(buf, p.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (p *PASContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "PASContent{Password:*** Flags:%v}", p.Flags)
+		return
+	}
+
+	formatContent(f, verb, p.marshalRedacted)
+}
+
+// PASBuilder builds PASContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type PASBuilder struct {
+	content PASContent
+	err     error
+}
+
+// NewPASBuilder returns a builder of empty contents.
+func NewPASBuilder() *PASBuilder {
+	return &PASBuilder{}
+}
+
+// Password sets the Password param.
+func (b *PASBuilder) Password(value *encoding.Base32Value) *PASBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.Password = value
+	b.content.passwordStr = str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *PASBuilder) Build() (*PASContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,85 @@
+package message
+
+import (
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestPASContentPosLen(t *testing.T) {
+	var p PASContent
+
+	if got, want := p.PosLen(), len(p.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestPASContentPosExcludesCommand(t *testing.T) {
+	var p PASContent
+	p.Password = encoding.NewBase32Value([]byte{1, 2, 3})
+
+	buf, err := p.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != p.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], p.Command())
+	}
+
+	if got, want := p.PosAt(0), formatBase32(p.Password); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := p.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := p.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func FuzzPASContentDecode(f *testing.F) {
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var p PASContent
+		if err := p.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := p.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded PASContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&p) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
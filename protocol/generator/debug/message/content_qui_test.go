@@ -0,0 +1,156 @@
+package message
+
+import (
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestQUIContentNamedGet(t *testing.T) {
+	var q QUIContent
+	q.TL.Set(7 * time.Second)
+	q.MS.Set("sentinel")
+
+	for _, tc := range []struct {
+		flag QUIFlag
+		want string
+	}{{QUIFlagTL, "7"}, {QUIFlagMS, "sentinel"}} {
+		val, ok := q.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestQUIContentPosLen(t *testing.T) {
+	var q QUIContent
+
+	if got, want := q.PosLen(), len(q.Positional()); got != want {
+		t.Errorf("PosLen() = %d, want len(Positional()) = %d", got, want)
+	}
+}
+
+func TestQUIContentPosExcludesCommand(t *testing.T) {
+	var q QUIContent
+	q.SID = encoding.NewBase32Value([]byte{1, 2, 3})
+
+	buf, err := q.MarshalADC()
+	if err != nil {
+		t.Fatalf("MarshalADC() failed: %v", err)
+	}
+	tokens := strings.Split(strings.TrimSuffix(string(buf), "\n"), " ")
+	if tokens[0] != q.Command() {
+		t.Errorf("MarshalADC() starts with %q, want the command %q", tokens[0], q.Command())
+	}
+
+	if got, want := q.PosAt(0), formatBase32(q.SID); got != want {
+		t.Errorf("PosAt(0) = %q, want the first positional %q", got, want)
+	}
+
+	positional := q.Positional()
+	for i, param := range positional {
+		if i+1 >= len(tokens) || tokens[i+1] != param {
+			t.Errorf("Positional()[%d] = %q, want token %d of MarshalADC()", i, param, i+1)
+		}
+		if got := q.PosAt(i); got != param {
+			t.Errorf("PosAt(%d) = %q, want Positional()[%d] = %q", i, got, i, param)
+		}
+	}
+}
+
+func TestQUIContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"QUI AAAB TL-1 MSbanned", map[string]string{
+			"MS":  "banned",
+			"SID": "AAAB",
+			"TL":  "-1s",
+		}},
+	}
+
+	values := func(q *QUIContent) map[string]string {
+		values := make(map[string]string)
+		values["SID"] = fmt.Sprint(q.SID)
+		if q.TL.IsSet {
+			values["TL"] = fmt.Sprint(q.TL.Value)
+		}
+		if q.MS.IsSet {
+			values["MS"] = fmt.Sprint(q.MS.Value)
+		}
+		return values
+	}
+
+	for _, example := range examples {
+		var q QUIContent
+		if err := q.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&q)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := q.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzQUIContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("AAAB TL-1 MSbanned")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var q QUIContent
+		if err := q.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := q.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded QUIContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&q) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
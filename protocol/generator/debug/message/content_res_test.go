@@ -0,0 +1,137 @@
+package message
+
+import (
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestRESContentNamedGet(t *testing.T) {
+	var r RESContent
+	r.FN = "sentinel"
+	r.SI = 7
+	r.SL.Set(7)
+	r.TO = "sentinel"
+	r.TR.Set(encoding.TTH{})
+	r.TD.Set(7)
+
+	for _, tc := range []struct {
+		flag RESFlag
+		want string
+	}{{RESFlagFN, "sentinel"}, {RESFlagSI, "7"}, {RESFlagSL, "7"}, {RESFlagTO, "sentinel"}, {RESFlagTR, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}, {RESFlagTD, "7"}} {
+		val, ok := r.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestRESContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"RES FNdir/some\\sfile SI1024 SL3 TOtoken", map[string]string{
+			"FN": "dir/some file",
+			"SI": "1024",
+			"SL": "3",
+			"TO": "token",
+		}},
+		{"RES FNfile SI0 TOtoken TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ TD2", map[string]string{
+			"FN": "file",
+			"SI": "0",
+			"TD": "2",
+			"TO": "token",
+			"TR": "LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ",
+		}},
+	}
+
+	values := func(r *RESContent) map[string]string {
+		values := make(map[string]string)
+		values["FN"] = fmt.Sprint(r.FN)
+		values["SI"] = fmt.Sprint(r.SI)
+		if r.SL.IsSet {
+			values["SL"] = fmt.Sprint(r.SL.Value)
+		}
+		values["TO"] = fmt.Sprint(r.TO)
+		if r.TR.IsSet {
+			values["TR"] = fmt.Sprint(r.TR.Value)
+		}
+		if r.TD.IsSet {
+			values["TD"] = fmt.Sprint(r.TD.Value)
+		}
+		return values
+	}
+
+	for _, example := range examples {
+		var r RESContent
+		if err := r.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&r)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := r.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzRESContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("FNdir/some\\sfile SI1024 SL3 TOtoken")
+	f.Add("FNfile SI0 TOtoken TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ TD2")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var r RESContent
+		if err := r.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := r.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded RESContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&r) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package message
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+func TestSCHContentNamedGet(t *testing.T) {
+	var s SCHContent
+	s.AN = []string{"sentinel"}
+	s.NO = []string{"sentinel"}
+	s.EX = []string{"sentinel"}
+	s.TO.Set("sentinel")
+
+	for _, tc := range []struct {
+		flag SCHFlag
+		want string
+	}{{SCHFlagAN, "sentinel"}, {SCHFlagNO, "sentinel"}, {SCHFlagEX, "sentinel"}, {SCHFlagTO, "sentinel"}} {
+		val, ok := s.NamedGet(string(tc.flag))
+		if !ok || val != tc.want {
+			t.Errorf("NamedGet(%q) = %q, %t, want %q, true", tc.flag, val, ok, tc.want)
+		}
+	}
+}
+
+func TestSCHContentExamples(t *testing.T) {
+	examples := []struct {
+		line   string
+		values map[string]string
+	}{
+		{"SCH ANsome ANfile NOold EXmkv EXmp4 TOtoken", map[string]string{
+			"AN": "[some file]",
+			"EX": "[mkv mp4]",
+			"NO": "[old]",
+			"TO": "token",
+		}},
+	}
+
+	values := func(s *SCHContent) map[string]string {
+		values := make(map[string]string)
+		if len(s.AN) > 0 {
+			values["AN"] = fmt.Sprint(s.AN)
+		}
+		if len(s.NO) > 0 {
+			values["NO"] = fmt.Sprint(s.NO)
+		}
+		if len(s.EX) > 0 {
+			values["EX"] = fmt.Sprint(s.EX)
+		}
+		if s.TO.IsSet {
+			values["TO"] = fmt.Sprint(s.TO.Value)
+		}
+		return values
+	}
+
+	for _, example := range examples {
+		var s SCHContent
+		if err := s.UnmarshalADC([]byte(example.line + "\n")); err != nil {
+			t.Errorf("UnmarshalADC(%q) failed: %v", example.line, err)
+			continue
+		}
+
+		got := values(&s)
+		for name, want := range example.values {
+			if val, ok := got[name]; !ok || val != want {
+				t.Errorf("%q: param %s = %q, %t, want %q, true", example.line, name, val, ok, want)
+			}
+		}
+		for name := range got {
+			if _, ok := example.values[name]; !ok {
+				t.Errorf("%q: param %s is set, but has no expected value", example.line, name)
+			}
+		}
+
+		buf, err := s.MarshalADC()
+		if err != nil {
+			t.Errorf("%q: MarshalADC() failed: %v", example.line, err)
+		} else if string(buf) != example.line+"\n" {
+			t.Errorf("MarshalADC() = %q, want %q", buf, example.line+"\n")
+		}
+	}
+}
+
+func FuzzSCHContentDecode(f *testing.F) {
+	f.Add("")
+	f.Add("ANsome ANfile NOold EXmkv EXmp4 TOtoken")
+
+	f.Fuzz(func(t *testing.T, params string) {
+		if strings.Contains(params, "\n") {
+			// Lines never contain newlines.
+			t.Skip()
+		}
+
+		var tokens []string
+		if params != "" {
+			tokens = strings.Split(params, " ")
+		}
+		var s SCHContent
+		if err := s.ParseInto(tokens, nil); err != nil {
+			return
+		}
+
+		buf, err := s.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for params %q: %v", params, err)
+		}
+		var decoded SCHContent
+		if err := decoded.UnmarshalADC(buf); err != nil {
+			t.Fatalf("UnmarshalADC(%q) failed: %v", buf, err)
+		}
+		if !decoded.Equal(&s) {
+			t.Errorf("UnmarshalADC(%q) differs from the content parsed from params %q", buf, params)
+		}
+
+		again, err := decoded.MarshalADC()
+		if err != nil {
+			t.Fatalf("MarshalADC() failed for line %q: %v", buf, err)
+		}
+		if string(again) != string(buf) {
+			t.Errorf("MarshalADC() = %q, want %q", again, buf)
+		}
+	})
+}
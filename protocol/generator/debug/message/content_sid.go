@@ -0,0 +1,620 @@
+package message
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	slog "log/slog"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type SIDFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f SIDFlag) String() string {
+	return "SIDFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f SIDFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &SIDContent{}
+var _ ADCMarshaler = &SIDContent{}
+var _ ADCUnmarshaler = &SIDContent{}
+var _ io.WriterTo = &SIDContent{}
+var _ fmt.Formatter = &SIDContent{}
+var _ fmt.Stringer = &SIDContent{}
+var _ slog.LogValuer = &SIDContent{}
+
+// SIDContent is the content of SID messages.
+type SIDContent struct {
+	SID    *encoding.Base32Value `adc:"SID,positional" json:"sid"`
+	sidStr string
+
+	ContentBase
+
+	// raw is the line parsed by UnmarshalADC, which is marshalled verbatim
+	// unless dirty is set.
+	raw []byte
+	// dirty is set if the content has been modified since raw was stored.
+	// Methods modifying the content set dirty or reset raw, direct writes to
+	// the fields must be followed by MarkDirty.
+	dirty bool
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (s *SIDContent) Positional() []string {
+	return s.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (s *SIDContent) AppendPositional(dst []string) []string {
+	return append(dst, formatBase32(s.SID))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (s *SIDContent) PosLen() int {
+	return 1
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (s *SIDContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return formatBase32(s.SID)
+	default:
+		panic(fmt.Sprintf("SID.PosAt: index %d out of range [0,%d)", i, s.PosLen()))
+	}
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (s *SIDContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "SID":
+		return formatBase32(s.SID), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (s *SIDContent) PositionalValues() *encoding.Base32Value {
+	return s.SID
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (s *SIDContent) ParseInto(params []string, opts *ParseOptions) error {
+	*s = SIDContent{}
+	s.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message SID: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 1 {
+		return fmt.Errorf("parsing message SID: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 1 && isNamedParam(param) && (pos+plain >= 1):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message SID: %w", param[:2], err)
+			}
+			if s.Flags == nil {
+				s.Flags = make(map[string]string)
+			}
+			s.Flags[param[:2]] = param[2:]
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param SID of message SID: %w", err)
+			}
+			s.sidStr = param
+			val, err := encoding.ParseBase32Value(param)
+			if err != nil {
+				return fmt.Errorf("parsing param SID of message SID: %w", err)
+			}
+			s.SID = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message SID: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 1 {
+		return fmt.Errorf("parsing message SID: %w", ErrMissingParam)
+	}
+
+	if formatBase32(s.SID) == "" {
+		return fmt.Errorf("parsing param SID of message SID: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// SIDContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func SIDContentFromAccessor(pa ParamAccessor) (*SIDContent, error) {
+	var s SIDContent
+	if err := s.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (s *SIDContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message SID: %w", err)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (s *SIDContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "SID" {
+		return fmt.Errorf("parsing message SID: %w", ErrCommandMismatch)
+	}
+
+	return s.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (s *SIDContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message SID: %w", err)
+	}
+	if err := s.ParseTokens(tokens); err != nil {
+		return err
+	}
+
+	s.raw = rawLine(line)
+	s.dirty = false
+	return nil
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (s *SIDContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, s.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (s *SIDContent) SetNamedAll(named map[string]string) error {
+	decoded := *s
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*s = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (s *SIDContent) setNamedAll(named map[string]string) error {
+	s.dirty = true
+	s.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message SID: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if s.Flags == nil {
+			s.Flags = make(map[string]string)
+		}
+		s.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (s *SIDContent) ToMap() map[string]string {
+	values := s.UnknownFlags()
+	values["SID"] = formatBase32(s.SID)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (s *SIDContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["SID"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param SID of message SID: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "SID":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message SID: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// MarkDirty causes MarshalADC to regenerate the line from the params instead
+// of returning the line parsed by UnmarshalADC. It must be called after
+// modifying the fields of the content directly, including Flags. Methods
+// modifying the content, such as SetNamedAll, Decode and FromMap, do not
+// require it.
+func (s *SIDContent) MarkDirty() {
+	s.dirty = true
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (s *SIDContent) AppendADC(buf []byte) ([]byte, error) {
+	if s.raw != nil && !s.dirty {
+		return append(buf, s.raw...), nil
+	}
+
+	n := len(buf)
+	buf = append(buf, "SID"...)
+
+	if formatBase32(s.SID) == "" {
+		return buf[:n], fmt.Errorf("marshalling param SID of message SID: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = append(buf, formatBase32(s.SID)...)
+	buf = appendFlags(buf, s.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// EncodeTo is the unchecked variant of AppendADC. It appends the content in
+// the ADC wire format to buf and returns the extended buffer, but does not
+// check for missing required params or mismatching lengths. Incomplete
+// contents result in malformed lines, so the content must pass Validate.
+// The values are encoded from the fields as by AppendADC. Numbers, strings
+// and IP addresses are encoded without allocating if buf has sufficient
+// capacity, see WireSize.
+func (s *SIDContent) EncodeTo(buf []byte) []byte {
+	if s.raw != nil && !s.dirty {
+		return append(buf, s.raw...)
+	}
+
+	buf = append(buf, "SID"...)
+
+	buf = append(buf, ' ')
+	buf = append(buf, formatBase32(s.SID)...)
+	buf = encodeFlags(buf, s.Flags)
+
+	return append(buf, '\n')
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (s *SIDContent) ADCString() string {
+	if s.raw != nil && !s.dirty {
+		return string(s.raw)
+	}
+
+	var builder strings.Builder
+	builder.Grow(s.WireSize())
+	builder.WriteString("SID")
+
+	if formatBase32(s.SID) == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(formatBase32(s.SID))
+	writeFlags(&builder, s.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (s *SIDContent) Validate() error {
+	var errs []error
+	for _, name := range s.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message SID: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(s.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message SID: %w", err))
+	}
+
+	if s.SID != nil && len(s.SID.String()) != 4 {
+		errs = append(errs, fmt.Errorf("validating param SID of message SID: %w, must be 4 characters long", ErrInvalidLength))
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (s *SIDContent) MissingRequired() []string {
+	var missing []string
+	if formatBase32(s.SID) == "" {
+		missing = append(missing, "SID")
+	}
+	return missing
+}
+
+var sidDescriptor = MessageDescriptor{
+	Command: "SID",
+	Phases:  PhaseProtocol,
+	Positional: []ParamDescriptor{{
+		DisplayName: "SID",
+		Name:        "SID",
+		Required:    true,
+		Type:        "base32",
+	}},
+	Types: "I",
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (s *SIDContent) Descriptor() MessageDescriptor {
+	return sidDescriptor
+}
+
+// Command returns the command of the content, i.e. SID.
+func (s *SIDContent) Command() string {
+	return "SID"
+}
+
+// MsgType returns the message type, the only type the message is valid in.
+func (s *SIDContent) MsgType() byte {
+	return 'I'
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (s *SIDContent) MarshalADC() ([]byte, error) {
+	return s.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (s *SIDContent) WireSize() int {
+	if s.raw != nil && !s.dirty {
+		return len(s.raw)
+	}
+
+	n := len("SID")
+
+	n += 1 + len(formatBase32(s.SID))
+	n += flagsSize(s.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (s *SIDContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := s.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (s *SIDContent) Equal(other *SIDContent) bool {
+	return equalParams(s, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (s *SIDContent) EqualIgnoring(other *SIDContent, ignore ...string) bool {
+	if !isIgnored(ignore, "SID") && formatBase32(s.SID) != formatBase32(other.SID) {
+		return false
+	}
+
+	return equalFlagsIgnoring(s.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (s *SIDContent) HashKey() string {
+	return hashKey(s)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (s *SIDContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(s, line, mode, func(params []string) (ParamAccessor, error) {
+		var other SIDContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (s *SIDContent) Redacted() *SIDContent {
+	redacted := *s
+	if s.Flags != nil {
+		redacted.Flags = s.UnknownFlags()
+	}
+	redacted.raw = nil
+
+	return &redacted
+}
+
+// Clone returns a deep copy of the content, which does not share memory with
+// the content.
+func (s *SIDContent) Clone() *SIDContent {
+	clone := *s
+	if s.Flags != nil {
+		clone.Flags = s.UnknownFlags()
+	}
+	clone.raw = append([]byte(nil), s.raw...)
+
+	return &clone
+}
+
+// LogValue implements slog.LogValuer. The value is a group of the command and
+// the params, omitting unset optional params. Sensitive params are masked.
+func (s *SIDContent) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.String("command", "SID"))
+	attrs = append(attrs, slog.Any("SID", s.SID))
+
+	return slog.GroupValue(attrs...)
+}
+
+// String returns a human-readable representation of the content, consisting of
+// the command and the params labelled by their names, e.g. for debugging.
+// Unset optional params are omitted and sensitive params are masked.
+func (s *SIDContent) String() string {
+	var sb strings.Builder
+	sb.WriteString("SID")
+	fmt.Fprintf(&sb, " SID=%v", s.SID)
+
+	return sb.String()
+}
+
+// Labels returns the values of the params keyed by their display names, e.g.
+// for labelling metrics. Unset optional params and sensitive params are
+// omitted.
+func (s *SIDContent) Labels() map[string]string {
+	labels := make(map[string]string, 1)
+	labels["SID"] = fmt.Sprint(s.SID)
+
+	return labels
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (s *SIDContent) SetOptionalCount() int {
+	return 0
+}
+
+// Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted
+// wire form, %x and %X its hex encoding and %+v the params labelled by their
+// names. Sensitive params are masked.
+func (s *SIDContent) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "SIDContent{SID:%v Flags:%v}", s.SID, s.Flags)
+		return
+	}
+
+	formatContent(f, verb, s.MarshalADC)
+}
+
+// SIDBuilder builds SIDContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type SIDBuilder struct {
+	content SIDContent
+	err     error
+}
+
+// NewSIDBuilder returns a builder of empty contents.
+func NewSIDBuilder() *SIDBuilder {
+	return &SIDBuilder{}
+}
+
+// SID sets the SID param.
+func (b *SIDBuilder) SID(value *encoding.Base32Value) *SIDBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.SID = value
+	b.content.sidStr = str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *SIDBuilder) Build() (*SIDContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,45 @@
+package message_test
+
+import (
+	"errors"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Decode", func() {
+	It("should set the fields and the escaped values", func() {
+		var cnt MIXContent
+		err := cnt.Decode([]string{"7", "a", "b", "some\\sdesc"}, map[string]string{"NI": "nick", "XY": "1"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.Code).Should(Equal(7))
+		Ω(cnt.Items).Should(Equal([]string{"a", "b"}))
+		Ω(cnt.Description).Should(Equal("some desc"))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"XY": "1"}))
+		Ω(cnt.ADCString()).Should(Equal("MIX 7 a b some\\sdesc NInick XY1\n"))
+	})
+
+	It("should return ErrMissingParam for missing required params", func() {
+		var cnt RESContent
+		err := cnt.Decode(nil, map[string]string{"FN": "file", "TO": "token"})
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+	})
+
+	It("should return conversion errors", func() {
+		var cnt MIXContent
+		err := cnt.Decode([]string{"seven", "a", "desc"}, nil)
+		var numErr *strconv.NumError
+		Ω(errors.As(err, &numErr)).Should(BeTrue())
+	})
+
+	It("should return ErrMalformedFlag for keys which are not flag names", func() {
+		var cnt MIXContent
+		err := cnt.Decode([]string{"7", "a", "desc"}, map[string]string{"NIC": "nick"})
+		Ω(errors.Is(err, ErrMalformedFlag)).Should(BeTrue())
+	})
+})
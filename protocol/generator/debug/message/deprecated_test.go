@@ -0,0 +1,43 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("DecodeWarnings()", func() {
+	It("should decode deprecated params and report them", func() {
+		var cnt INFContent
+		err := cnt.UnmarshalADC([]byte("INF NIpeer OP1\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.OP.IsSet).Should(BeTrue())
+		Ω(cnt.OP.Value).Should(Equal(1))
+
+		var warner DecodeWarner = &cnt
+		Ω(warner.DecodeWarnings()).Should(Equal([]string{
+			"param OP of message INF is deprecated: superseded by CT",
+		}))
+	})
+
+	It("should not report absent deprecated params", func() {
+		var cnt INFContent
+		err := cnt.UnmarshalADC([]byte("INF NIpeer CT4\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.DecodeWarnings()).Should(BeEmpty())
+	})
+
+	It("should drop deprecated params when encoding", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"NIpeer", "OP1"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("INF NIpeer\n")))
+		Ω(cnt.WireSize()).Should(Equal(len("INF NIpeer\n")))
+
+		op, ok := cnt.NamedGet("OP")
+		Ω(ok).Should(BeTrue())
+		Ω(op).Should(Equal("1"))
+	})
+})
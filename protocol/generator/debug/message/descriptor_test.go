@@ -0,0 +1,35 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Descriptor()", func() {
+	It("should describe positional parameters", func() {
+		var cnt SIDContent
+		desc := cnt.Descriptor()
+		Ω(desc.Command).Should(Equal("SID"))
+		Ω(desc.Named).Should(BeEmpty())
+		Ω(desc.Positional).Should(Equal([]ParamDescriptor{
+			{Name: "SID", DisplayName: "SID", Type: "base32", Required: true},
+		}))
+	})
+
+	It("should report the display name if set", func() {
+		var cnt RESContent
+		desc := cnt.Descriptor()
+		Ω(desc.Named[0].Name).Should(Equal("FN"))
+		Ω(desc.Named[0].DisplayName).Should(Equal("File name"))
+		Ω(desc.Named[0].FlagName).Should(Equal("FN"))
+	})
+
+	It("should fall back to the protocol name", func() {
+		var cnt RESContent
+		desc := cnt.Descriptor()
+		Ω(desc.Named[1].Name).Should(Equal("SI"))
+		Ω(desc.Named[1].DisplayName).Should(Equal("SI"))
+	})
+})
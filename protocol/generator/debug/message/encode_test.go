@@ -0,0 +1,83 @@
+package message_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+// parseINF returns an INF content with several params and unknown flags,
+// which are encoded by EncodeTo.
+func parseINF() *INFContent {
+	var cnt INFContent
+	err := cnt.ParseInto([]string{"NIpeer", "SS1024", "SF3", "I4192.0.2.1", "XXext", "AAa", "ZZz"}, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	return &cnt
+}
+
+var _ = Describe("EncodeTo()", func() {
+	It("should append the output of MarshalADC", func() {
+		cnt := parseINF()
+		want, err := cnt.MarshalADC()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.EncodeTo([]byte("prefix "))).Should(Equal(append([]byte("prefix "), want...)))
+	})
+
+	It("should sort more flags than fit on the stack", func() {
+		var cnt SIDContent
+		flags := []string{"AAa", "ABb", "ACc", "ADd", "AEe", "AFf", "AGg", "AHh", "AIi"}
+		Ω(cnt.ParseInto(append([]string{"AAAB"}, flags...), nil)).Should(Succeed())
+
+		Ω(string(cnt.EncodeTo(nil))).Should(Equal("SID AAAB AAa ABb ACc ADd AEe AFf AGg AHh AIi\n"))
+	})
+
+	It("should not check the content", func() {
+		var cnt MIXContent
+		_, err := cnt.MarshalADC()
+		Ω(err).Should(HaveOccurred())
+
+		Ω(string(cnt.EncodeTo(nil))).Should(Equal("MIX 0 \n"))
+	})
+
+	It("should not allocate for built contents given a sufficient buffer", func() {
+		cnt, err := NewMIXBuilder().Code(7).Items("a", "b").Description("desc").NI("nick").Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		buf := make([]byte, 0, cnt.WireSize())
+
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = cnt.EncodeTo(buf[:0])
+		})
+		Ω(allocs).Should(BeZero())
+		Ω(string(buf)).Should(Equal("MIX 7 a b desc NInick\n"))
+	})
+
+	It("should not allocate given a sufficient buffer", func() {
+		cnt := parseINF()
+		buf := make([]byte, 0, cnt.WireSize())
+
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = cnt.EncodeTo(buf[:0])
+		})
+		Ω(allocs).Should(BeZero())
+	})
+})
+
+func BenchmarkEncodeTo(b *testing.B) {
+	var cnt INFContent
+	err := cnt.ParseInto([]string{"NIpeer", "SS1024", "SF3", "I4192.0.2.1", "XXext"}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := make([]byte, 0, cnt.WireSize())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = cnt.EncodeTo(buf[:0])
+	}
+}
@@ -0,0 +1,40 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Enums", func() {
+	It("should name the constants", func() {
+		Ω(SeverityRecoverable.String()).Should(Equal("SeverityRecoverable"))
+		Ω(Severity(7).String()).Should(Equal("Severity(7)"))
+		Ω(SeverityFatal.IsKnown()).Should(BeTrue())
+		Ω(Severity(7).IsKnown()).Should(BeFalse())
+	})
+
+	It("should parse params of the enum type", func() {
+		var cnt STAContent
+		Ω(cnt.ParseInto([]string{"1", "desc"}, nil)).Should(Succeed())
+		Ω(cnt.Severity).Should(Equal(SeverityRecoverable))
+	})
+
+	It("should reject values not declared by the enum", func() {
+		var cnt STAContent
+		err := cnt.ParseInto([]string{"7", "desc"}, nil)
+		Ω(errors.Is(err, ErrValueNotAllowed)).Should(BeTrue())
+	})
+
+	It("should validate values not declared by the enum", func() {
+		_, err := NewSTABuilder().Severity(Severity(7)).Description("desc").Build()
+		Ω(errors.Is(err, ErrValueNotAllowed)).Should(BeTrue())
+
+		cnt, err := NewSTABuilder().Severity(SeverityFatal).Description("desc").Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.ADCString()).Should(Equal("STA 2 desc\n"))
+	})
+})
@@ -0,0 +1,164 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Error variables related to comparing message content.
+var (
+	ErrCommandMismatch = errors.New("line holds a different command")
+)
+
+// EqualMode selects the semantics of the EqualBytes methods of content
+// types.
+type EqualMode int
+
+const (
+	// EqualSemantic parses the line and compares the result with Equal. The
+	// order of named params is not significant.
+	EqualSemantic EqualMode = iota
+	// EqualExact marshals the content and compares the result byte by byte
+	// with the line.
+	EqualExact
+)
+
+// equalParams returns true if a and b have the same positional params and
+// the same named params. Params are compared in their escaped form.
+func equalParams(a, b ParamAccessor) bool {
+	if a.PosLen() != b.PosLen() {
+		return false
+	}
+	for i := 0; i < a.PosLen(); i++ {
+		if a.PosAt(i) != b.PosAt(i) {
+			return false
+		}
+	}
+
+	aNamed, bNamed := a.Named(), b.Named()
+	if len(aNamed) != len(bNamed) {
+		return false
+	}
+	for key, val := range aNamed {
+		if bVal, ok := bNamed[key]; !ok || bVal != val {
+			return false
+		}
+
+		aAll, bAll := a.NamedAll(key), b.NamedAll(key)
+		if len(aAll) != len(bAll) {
+			return false
+		}
+		for i := range aAll {
+			if aAll[i] != bAll[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// equalBytes implements the EqualBytes methods of content types. parse is
+// called with the params of line in EqualSemantic mode and returns the
+// parsed content.
+func equalBytes(c content, line []byte, mode EqualMode, parse func(params []string) (ParamAccessor, error)) (bool, error) {
+	if mode == EqualExact {
+		buf, err := c.MarshalADC()
+		if err != nil {
+			return false, err
+		}
+
+		return bytes.Equal(buf, line), nil
+	}
+
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return false, err
+	}
+	if tokens[0] != c.Command() {
+		return false, ErrCommandMismatch
+	}
+
+	other, err := parse(tokens[1:])
+	if err != nil {
+		return false, err
+	}
+
+	return equalParams(c, other), nil
+}
+
+// hashKey implements the HashKey methods of content types. The key consists
+// of the command and the positional params, followed by the named params
+// sorted by name, all in their escaped form. As escaped params contain
+// neither spaces nor newlines, the key is unambiguous and consistent with
+// equalParams.
+func hashKey(c content) string {
+	var b strings.Builder
+	b.WriteString(c.Command())
+	for i := 0; i < c.PosLen(); i++ {
+		b.WriteByte(' ')
+		b.WriteString(c.PosAt(i))
+	}
+
+	named := c.Named()
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteByte('\n')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(name)
+		b.WriteString(named[name])
+	}
+
+	return b.String()
+}
+
+// isIgnored returns true if name is contained in ignore.
+func isIgnored(ignore []string, name string) bool {
+	for _, ignored := range ignore {
+		if ignored == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalStrs returns true if a and b hold the same values in the same order.
+func equalStrs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalFlagsIgnoring returns true if the flags a and b are equal, apart from
+// the flags named by ignore.
+func equalFlagsIgnoring(a, b map[string]string, ignore []string) bool {
+	for name, val := range a {
+		if bVal, ok := b[name]; (!ok || bVal != val) && !isIgnored(ignore, name) {
+			return false
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok && !isIgnored(ignore, name) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,157 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Equality", func() {
+	var cnt MIXContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"1", "a", "desc", "NInick", "SV1"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Equal()", func() {
+		It("should ignore the order of named params", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "SV1", "NInick"}, nil)).Should(Succeed())
+			Ω(cnt.Equal(&other)).Should(BeTrue())
+		})
+
+		It("should detect differing params", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "NInick"}, nil)).Should(Succeed())
+			Ω(cnt.Equal(&other)).Should(BeFalse())
+		})
+
+		It("should ignore metadata", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "NInick", "SV1"}, &ParseOptions{Compressed: true})).
+				Should(Succeed())
+			Ω(cnt.Equal(&other)).Should(BeTrue())
+		})
+	})
+
+	Describe("EqualIgnoring()", func() {
+		It("should ignore the named params", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "NIother", "SV1"}, nil)).Should(Succeed())
+			Ω(cnt.EqualIgnoring(&other)).Should(BeFalse())
+			Ω(cnt.EqualIgnoring(&other, "NI")).Should(BeTrue())
+			Ω(cnt.EqualIgnoring(&other, "SV")).Should(BeFalse())
+		})
+
+		It("should ignore positional params and unknown flags", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "b", "other", "NInick", "SV1", "XXext"}, nil)).Should(Succeed())
+			Ω(cnt.EqualIgnoring(&other, "Items", "Description")).Should(BeFalse())
+			Ω(cnt.EqualIgnoring(&other, "Items", "Description", "XX")).Should(BeTrue())
+		})
+
+		It("should treat unknown names as a no-op", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "SV1", "NInick"}, nil)).Should(Succeed())
+			Ω(cnt.EqualIgnoring(&other, "Unknown")).Should(BeTrue())
+		})
+	})
+
+	Describe("HashKey()", func() {
+		It("should return the same key for equal contents", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "SV1", "NInick"}, &ParseOptions{Compressed: true})).
+				Should(Succeed())
+			Ω(cnt.Equal(&other)).Should(BeTrue())
+			Ω(other.HashKey()).Should(Equal(cnt.HashKey()))
+		})
+
+		It("should return different keys for differing contents", func() {
+			var other MIXContent
+			Ω(other.ParseInto([]string{"1", "a", "desc", "NInick", "SV2"}, nil)).Should(Succeed())
+			Ω(other.HashKey()).ShouldNot(Equal(cnt.HashKey()))
+
+			Ω(other.ParseInto([]string{"1", "b", "desc", "NInick", "SV1"}, nil)).Should(Succeed())
+			Ω(other.HashKey()).ShouldNot(Equal(cnt.HashKey()))
+		})
+
+		It("should deduplicate contents in a map", func() {
+			seen := make(map[string]struct{})
+			for _, params := range [][]string{
+				{"1", "a", "desc", "NInick", "SV1"},
+				{"1", "a", "desc", "SV1", "NInick"},
+				{"2", "a", "desc"},
+			} {
+				var other MIXContent
+				Ω(other.ParseInto(params, nil)).Should(Succeed())
+				seen[other.HashKey()] = struct{}{}
+			}
+			Ω(seen).Should(HaveLen(2))
+		})
+	})
+
+	Describe("EqualBytes()", func() {
+		matching := []byte("MIX 1 a desc NInick SV1\n")
+		reordered := []byte("MIX 1 a desc SV1 NInick\n")
+
+		Context("in EqualSemantic mode", func() {
+			It("should match an identical line", func() {
+				Ω(cnt.EqualBytes(matching, EqualSemantic)).Should(BeTrue())
+			})
+
+			It("should match a semantically equal line", func() {
+				Ω(cnt.EqualBytes(reordered, EqualSemantic)).Should(BeTrue())
+			})
+
+			It("should not match a line with differing params", func() {
+				Ω(cnt.EqualBytes([]byte("MIX 2 a desc NInick SV1\n"), EqualSemantic)).Should(BeFalse())
+			})
+
+			It("should return an error for a line holding another command", func() {
+				_, err := cnt.EqualBytes([]byte("LST 1 a desc NInick SV1\n"), EqualSemantic)
+				Ω(err).Should(Equal(ErrCommandMismatch))
+			})
+		})
+
+		Context("in EqualExact mode", func() {
+			It("should match an identical line", func() {
+				Ω(cnt.EqualBytes(matching, EqualExact)).Should(BeTrue())
+			})
+
+			It("should not match a semantically equal line", func() {
+				Ω(cnt.EqualBytes(reordered, EqualExact)).Should(BeFalse())
+			})
+		})
+	})
+})
+
+var _ = Describe("CacheKey()", func() {
+	It("should be shared by results only differing in params not part of the key", func() {
+		var a, b RESContent
+		Ω(a.ParseInto([]string{"FNfile", "SI42", "TOtoken", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)).Should(Succeed())
+		Ω(b.ParseInto([]string{"FNother\\sfile", "SI7", "TOtoken", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ", "TD3"}, nil)).Should(Succeed())
+		Ω(a.CacheKey()).Should(Equal(b.CacheKey()))
+	})
+
+	It("should differ for results differing in key parts", func() {
+		var a, b RESContent
+		Ω(a.ParseInto([]string{"FNfile", "SI42", "TOtoken", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)).Should(Succeed())
+		Ω(b.ParseInto([]string{"FNfile", "SI42", "TOtoken"}, nil)).Should(Succeed())
+		Ω(a.CacheKey()).ShouldNot(Equal(b.CacheKey()))
+
+		Ω(b.ParseInto([]string{"FNfile", "SI42", "TOother", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)).Should(Succeed())
+		Ω(a.CacheKey()).ShouldNot(Equal(b.CacheKey()))
+	})
+
+	It("should keep the flag names of all named key parts", func() {
+		var cnt RESContent
+		Ω(cnt.ParseInto([]string{"FNfile", "SI42", "TOto\\sken", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)).Should(Succeed())
+		Ω(cnt.CacheKey()).Should(Equal("TOto\\sken TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"))
+
+		cnt.TR.Unset()
+		cnt.TO = "changed"
+		Ω(cnt.CacheKey()).Should(Equal("TOchanged "))
+	})
+})
@@ -0,0 +1,67 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Command families", func() {
+	It("should store the concrete command when parsing tokens", func() {
+		var cnt EXFContent
+		Ω(cnt.UnmarshalADC([]byte("EXA desc NInick\n"))).Should(Succeed())
+		Ω(cnt.Command()).Should(Equal("EXA"))
+		Ω(cnt.Description).Should(Equal("desc"))
+
+		Ω(cnt.ParseTokens([]string{"EX7", "desc"})).Should(Succeed())
+		Ω(cnt.Command()).Should(Equal("EX7"))
+	})
+
+	It("should reject commands not matching the pattern", func() {
+		var cnt EXFContent
+		err := cnt.ParseTokens([]string{"EYA", "desc"})
+		Ω(errors.Is(err, ErrCommandMismatch)).Should(BeTrue())
+
+		err = cnt.SetCommand("EXa")
+		Ω(errors.Is(err, ErrCommandMismatch)).Should(BeTrue())
+	})
+
+	It("should parse frames of any command of the family", func() {
+		cnt, err := ParseFrame([]byte("HEXB desc\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&EXFContent{}))
+		Ω(cnt.(*EXFContent).Command()).Should(Equal("EXB"))
+
+		_, err = ParseFrame([]byte("HEYB desc\n"))
+		Ω(err).Should(Equal(ErrUnknownCommand))
+	})
+
+	It("should marshal the concrete command", func() {
+		var cnt EXFContent
+		Ω(cnt.ParseInto([]string{"desc"}, nil)).Should(Succeed())
+		Ω(cnt.SetCommand("EXC")).Should(Succeed())
+
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("EXC desc\n")))
+		Ω(cnt.WireSize()).Should(Equal(len("EXC desc\n")))
+	})
+
+	It("should fail to marshal contents without a command", func() {
+		var cnt EXFContent
+		Ω(cnt.ParseInto([]string{"desc"}, nil)).Should(Succeed())
+		_, err := cnt.MarshalADC()
+		Ω(errors.Is(err, ErrMissingCommand)).Should(BeTrue())
+	})
+
+	It("should compare the commands of contents", func() {
+		var a, b EXFContent
+		Ω(a.UnmarshalADC([]byte("EXA desc\n"))).Should(Succeed())
+		Ω(b.UnmarshalADC([]byte("EXB desc\n"))).Should(Succeed())
+		Ω(a.Equal(&b)).Should(BeFalse())
+
+		Ω(b.SetCommand("EXA")).Should(Succeed())
+		Ω(a.Equal(&b)).Should(BeTrue())
+	})
+})
@@ -0,0 +1,72 @@
+package message_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Format()", func() {
+	var cnt MIXContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"1", "a", "desc", "NInick"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should format the wire form for %s and %v", func() {
+		Ω(fmt.Sprintf("%s", &cnt)).Should(Equal("MIX 1 a desc NInick"))
+		Ω(fmt.Sprintf("%v", &cnt)).Should(Equal("MIX 1 a desc NInick"))
+	})
+
+	It("should format the quoted wire form for %q", func() {
+		Ω(fmt.Sprintf("%q", &cnt)).Should(Equal(`"MIX 1 a desc NInick"`))
+	})
+
+	It("should format a field-labeled dump for %+v", func() {
+		Ω(fmt.Sprintf("%+v", &cnt)).Should(Equal(
+			"MIXContent{Code:1 Items:[a] Description:desc NI:{nick true} SV:{0 false} PR:{ false} Flags:map[]}",
+		))
+	})
+
+	It("should format the hex of the marshalled bytes for %x and %X", func() {
+		expected := hex.EncodeToString([]byte("MIX 1 a desc NInick\n"))
+		Ω(fmt.Sprintf("%x", &cnt)).Should(Equal(expected))
+		Ω(fmt.Sprintf("%X", &cnt)).Should(Equal(strings.ToUpper(expected)))
+	})
+
+	It("should mask sensitive params for all verbs", func() {
+		var res RESContent
+		err := res.ParseInto([]string{"FNfile", "SI42", "TOsecret", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(fmt.Sprint(&res)).Should(Equal("RES FNfile SI42 TO*** TR***"))
+		Ω(fmt.Sprintf("%s", &res)).Should(Equal("RES FNfile SI42 TO*** TR***"))
+		Ω(fmt.Sprintf("%q", &res)).Should(Equal(`"RES FNfile SI42 TO*** TR***"`))
+		Ω(fmt.Sprintf("%+v", &res)).Should(ContainSubstring("TO:*** TR:***"))
+		Ω(fmt.Sprintf("%x", &res)).Should(Equal(hex.EncodeToString([]byte("RES FNfile SI42 TO*** TR***\n"))))
+
+		for _, verb := range []string{"%v", "%s", "%q", "%+v", "%x"} {
+			out := fmt.Sprintf(verb, &res)
+			Ω(out).ShouldNot(ContainSubstring("secret"))
+			Ω(out).ShouldNot(ContainSubstring("LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"))
+			Ω(out).ShouldNot(ContainSubstring(hex.EncodeToString([]byte("secret"))))
+		}
+
+		var pas PASContent
+		Ω(pas.ParseInto([]string{"LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)).Should(Succeed())
+		Ω(fmt.Sprintf("%v", &pas)).Should(Equal("PAS ***"))
+		Ω(fmt.Sprintf("%+v", &pas)).Should(Equal("PASContent{Password:*** Flags:map[]}"))
+	})
+
+	It("should format the error if the content cannot be marshalled", func() {
+		var sid SIDContent
+		Ω(fmt.Sprintf("%s", &sid)).Should(HavePrefix("%!s("))
+		Ω(fmt.Sprintf("%s", &sid)).Should(ContainSubstring(ErrMissingParam.Error()))
+	})
+})
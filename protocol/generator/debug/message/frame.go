@@ -0,0 +1,326 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/seoester/adcl/protocol/encoding"
+)
+
+// Error variables related to building and parsing message frames.
+var (
+	ErrUnsupportedType = errors.New("message type not supported by frame builder and parser")
+	ErrMissingSID      = errors.New("SID required by message type missing")
+	ErrSurplusSID      = errors.New("SID provided which is not part of the header of the message type")
+	ErrMalformedFrame  = errors.New("malformed message frame")
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrUnexpectedType  = errors.New("message type not valid for command")
+	ErrUnexpectedPhase = errors.New("command not valid in protocol phase")
+	ErrKeepAlive       = errors.New("empty keep-alive line")
+)
+
+// Type is the message type, i.e. the first character of a message. It
+// determines the additional header fields of the message.
+type Type byte
+
+const (
+	TypeBroadcast        Type = 'B'
+	TypeClientmessage    Type = 'C'
+	TypeDirectmessage    Type = 'D'
+	TypeEchomessage      Type = 'E'
+	TypeFeaturebroadcast Type = 'F'
+	TypeHubmessage       Type = 'H'
+	TypeInfomessage      Type = 'I'
+	TypeUDPmessage       Type = 'U'
+)
+
+// Phase is a set of protocol phases. The phases determine which commands
+// may be sent.
+type Phase uint8
+
+const (
+	PhaseProtocol Phase = 1 << iota
+	PhaseIdentify
+	PhaseVerify
+	PhaseNormal
+	PhaseData
+)
+
+// FrameContent is the content of a message framed by FrameBuilder. It is
+// implemented by all generated content types.
+type FrameContent interface {
+	ParamAccessor
+	MarshalADC() ([]byte, error)
+}
+
+// FrameBuilder builds complete message frames, i.e. the header followed by
+// the marshalled content. A FrameBuilder can be used to frame any number of
+// contents with the same header fields.
+//
+// Featurebroadcast and UDPmessage types are not supported.
+type FrameBuilder struct {
+	Type Type
+	// MySID is the SID of the sender. It is required by the Broadcast,
+	// Directmessage and Echomessage types.
+	MySID *encoding.Base32Value
+	// TargetSID is the SID of the recipient. It is required by the
+	// Directmessage and Echomessage types.
+	TargetSID *encoding.Base32Value
+}
+
+// Build marshals content and returns the full message frame, including the
+// trailing newline.
+func (f *FrameBuilder) Build(content FrameContent) ([]byte, error) {
+	err := f.checkHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := content.MarshalADC()
+	if err != nil {
+		return nil, err
+	}
+
+	// body starts with the three character command, followed by the
+	// params.
+	buf := make([]byte, 0, len(body)+11)
+	buf = append(buf, byte(f.Type))
+	buf = append(buf, body[:3]...)
+	if f.MySID != nil {
+		buf = append(buf, ' ')
+		buf = append(buf, f.MySID.String()...)
+	}
+	if f.TargetSID != nil {
+		buf = append(buf, ' ')
+		buf = append(buf, f.TargetSID.String()...)
+	}
+	buf = append(buf, body[3:]...)
+
+	return buf, nil
+}
+
+// FrameParser parses complete message frames, i.e. the header followed by the
+// content. The zero value is ready to use.
+type FrameParser struct {
+	// RawUnknown causes frames with unknown commands to be parsed into a
+	// RawContent. By default, ErrUnknownCommand is returned.
+	RawUnknown bool
+	// Options are passed to the ParseInto method of the content.
+	Options *ParseOptions
+	// Lenient causes empty lines, which are sent as keep-alives, to be
+	// reported as ErrKeepAlive instead of ErrMalformedFrame. Additionally,
+	// a single trailing space before the terminator is ignored instead of
+	// being parsed as an empty param.
+	Lenient bool
+	// Phase is the current protocol phase. If set, frames with commands not
+	// valid in the phase are rejected with ErrUnexpectedPhase. Commands
+	// valid in any phase and unknown commands are always accepted.
+	Phase Phase
+}
+
+// ParseFrame parses line, a complete message frame, using the default
+// FrameParser.
+func ParseFrame(line []byte) (ParamAccessor, error) {
+	var p FrameParser
+	return p.Parse(line)
+}
+
+// Parse parses line, a complete message frame, into the content type of its
+// command. The header fields are checked, but not returned.
+func (p *FrameParser) Parse(line []byte) (ParamAccessor, error) {
+	if p.Lenient {
+		if len(line) == 0 || len(line) == 1 && line[0] == '\n' {
+			return nil, ErrKeepAlive
+		}
+		line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+	}
+
+	msgType, command, err := PeekCommand(line)
+	if err != nil {
+		return nil, err
+	}
+
+	numSIDs, err := headerSIDs(Type(msgType))
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := splitTokens(string(line), 1+numSIDs, p.Options)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) < 1+numSIDs {
+		return nil, ErrMalformedFrame
+	}
+	for _, sid := range tokens[1 : 1+numSIDs] {
+		_, err := encoding.ParseBase32Value(sid)
+		if err != nil {
+			return nil, ErrMalformedFrame
+		}
+	}
+
+	params := tokens[1+numSIDs:]
+
+	newContent, ok := lookupContent(command)
+	if !ok {
+		if !p.RawUnknown {
+			return nil, ErrUnknownCommand
+		}
+		if err := p.Options.checkCounts(params); err != nil {
+			return nil, err
+		}
+
+		return newRawContent(params), nil
+	}
+
+	cnt := newContent()
+	if err := checkMsgType(cnt, msgType); err != nil {
+		return nil, err
+	}
+	if phases := cnt.Descriptor().Phases; p.Phase != 0 && phases != 0 && phases&p.Phase == 0 {
+		return nil, ErrUnexpectedPhase
+	}
+
+	err = cnt.ParseInto(params, p.Options)
+	if err != nil {
+		return nil, err
+	}
+	cnt.setMsgType(msgType)
+	cnt.setCommand(command)
+
+	return cnt, nil
+}
+
+// DecodeAll parses the frames of data, one per line, using the default
+// FrameParser.
+func DecodeAll(data []byte) ([]ParamAccessor, []error) {
+	var p FrameParser
+	return p.DecodeAll(data)
+}
+
+// DecodeAll parses the frames of data, one per line, e.g. the contents of a
+// capture file. Unlike Parse, it does not stop at the first error: the
+// contents of all lines parsed successfully are returned in order, along with
+// an error for each line failing to parse, which names the (1-based) line
+// number. A final line lacking the terminator is parsed as well.
+func (p *FrameParser) DecodeAll(data []byte) ([]ParamAccessor, []error) {
+	var (
+		contents []ParamAccessor
+		errs     []error
+	)
+
+	for lineNo := 1; len(data) > 0; lineNo++ {
+		end := bytes.IndexByte(data, '\n') + 1
+		if end == 0 {
+			end = len(data)
+		}
+		line := data[:end]
+		data = data[end:]
+
+		cnt, err := p.Parse(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNo, err))
+			continue
+		}
+		contents = append(contents, cnt)
+	}
+
+	return contents, errs
+}
+
+// PeekCommand returns the message type and the command of line, a complete
+// message frame, without parsing the remainder of the frame. ErrMalformedFrame
+// is returned if line does not start with a message type followed by a
+// command.
+func PeekCommand(line []byte) (msgType byte, command string, err error) {
+	if len(line) < 4 || len(line) > 4 && line[4] != ' ' && line[4] != '\n' {
+		return 0, "", ErrMalformedFrame
+	}
+
+	switch Type(line[0]) {
+	case TypeBroadcast, TypeClientmessage, TypeDirectmessage, TypeEchomessage,
+		TypeFeaturebroadcast, TypeHubmessage, TypeInfomessage, TypeUDPmessage:
+	default:
+		return 0, "", ErrMalformedFrame
+	}
+
+	if !encoding.IsUpperAlpha(line[1]) ||
+		!encoding.IsUpperAlphaNum(line[2]) ||
+		!encoding.IsUpperAlphaNum(line[3]) {
+		return 0, "", ErrMalformedFrame
+	}
+
+	return line[0], string(line[1:4]), nil
+}
+
+// newRawContent returns a RawContent holding the (escaped) params.
+func newRawContent(params []string) *RawContent {
+	raw := &RawContent{
+		NamedParams: make(map[string]string),
+	}
+
+	for _, param := range params {
+		if !isNamedParam(param) {
+			raw.PositionalParams = append(raw.PositionalParams, param)
+			continue
+		}
+
+		name, val := param[:2], param[2:]
+		first, ok := raw.NamedParams[name]
+		switch {
+		case !ok:
+			raw.NamedParams[name] = val
+		case raw.RepeatedParams[name] == nil:
+			if raw.RepeatedParams == nil {
+				raw.RepeatedParams = make(map[string][]string)
+			}
+			raw.RepeatedParams[name] = []string{first, val}
+		default:
+			raw.RepeatedParams[name] = append(raw.RepeatedParams[name], val)
+		}
+	}
+
+	return raw
+}
+
+// headerSIDs returns the number of SIDs in the header of messages of the
+// type.
+func headerSIDs(typ Type) (int, error) {
+	switch typ {
+	case TypeBroadcast:
+		return 1, nil
+	case TypeDirectmessage, TypeEchomessage:
+		return 2, nil
+	case TypeClientmessage, TypeHubmessage, TypeInfomessage:
+		return 0, nil
+	default:
+		return 0, ErrUnsupportedType
+	}
+}
+
+// checkHeader checks that the SIDs provided match the header fields of the
+// message type.
+func (f *FrameBuilder) checkHeader() error {
+	var needsMySID, needsTargetSID bool
+
+	switch f.Type {
+	case TypeBroadcast:
+		needsMySID = true
+	case TypeDirectmessage, TypeEchomessage:
+		needsMySID = true
+		needsTargetSID = true
+	case TypeClientmessage, TypeHubmessage, TypeInfomessage:
+	default:
+		return ErrUnsupportedType
+	}
+
+	if needsMySID && f.MySID == nil || needsTargetSID && f.TargetSID == nil {
+		return ErrMissingSID
+	}
+	if !needsMySID && f.MySID != nil || !needsTargetSID && f.TargetSID != nil {
+		return ErrSurplusSID
+	}
+
+	return nil
+}
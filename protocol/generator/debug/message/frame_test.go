@@ -0,0 +1,297 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/seoester/adcl/protocol/encoding"
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("FrameBuilder", func() {
+	var (
+		cnt       LSTContent
+		mySID     *encoding.Base32Value
+		targetSID *encoding.Base32Value
+	)
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"first", "second"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		mySID, err = encoding.ParseBase32Value("AAAB")
+		Ω(err).ShouldNot(HaveOccurred())
+		targetSID, err = encoding.ParseBase32Value("AAAC")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should build a broadcast frame with the SID of the sender", func() {
+		b := FrameBuilder{
+			Type:  TypeBroadcast,
+			MySID: mySID,
+		}
+		Ω(b.Build(&cnt)).Should(Equal([]byte("BLST AAAB first second\n")))
+	})
+
+	It("should build a direct frame with the SIDs of sender and recipient", func() {
+		b := FrameBuilder{
+			Type:      TypeDirectmessage,
+			MySID:     mySID,
+			TargetSID: targetSID,
+		}
+		Ω(b.Build(&cnt)).Should(Equal([]byte("DLST AAAB AAAC first second\n")))
+	})
+
+	It("should build a hub frame without SIDs", func() {
+		b := FrameBuilder{
+			Type: TypeHubmessage,
+		}
+		Ω(b.Build(&cnt)).Should(Equal([]byte("HLST first second\n")))
+	})
+
+	It("should return ErrMissingSID if a SID required by the type is missing", func() {
+		b := FrameBuilder{
+			Type:  TypeDirectmessage,
+			MySID: mySID,
+		}
+		_, err := b.Build(&cnt)
+		Ω(err).Should(Equal(ErrMissingSID))
+	})
+
+	It("should return ErrSurplusSID if a SID not part of the header is provided", func() {
+		b := FrameBuilder{
+			Type:      TypeBroadcast,
+			MySID:     mySID,
+			TargetSID: targetSID,
+		}
+		_, err := b.Build(&cnt)
+		Ω(err).Should(Equal(ErrSurplusSID))
+	})
+
+	It("should return ErrUnsupportedType for unsupported types", func() {
+		b := FrameBuilder{
+			Type: TypeUDPmessage,
+		}
+		_, err := b.Build(&cnt)
+		Ω(err).Should(Equal(ErrUnsupportedType))
+	})
+})
+
+var _ = Describe("ParseFrame()", func() {
+	It("should parse a frame of a known command into its content type", func() {
+		cnt, err := ParseFrame([]byte("BMIX AAAB 1 a desc NInick\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&MIXContent{}))
+
+		mix := cnt.(*MIXContent)
+		Ω(mix.Code).Should(Equal(1))
+		Ω(mix.Items).Should(Equal([]string{"a"}))
+		Ω(mix.Description).Should(Equal("desc"))
+		Ω(mix.NI.Value).Should(Equal("nick"))
+	})
+
+	It("should skip the SIDs of the header", func() {
+		cnt, err := ParseFrame([]byte("DLST AAAB AAAC first second\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Positional()).Should(Equal([]string{"first", "second"}))
+	})
+
+	It("should return ErrUnknownCommand for an unknown command", func() {
+		_, err := ParseFrame([]byte("HXYZ first NInick\n"))
+		Ω(err).Should(Equal(ErrUnknownCommand))
+	})
+
+	It("should return a RawContent for an unknown command if RawUnknown is set", func() {
+		p := FrameParser{RawUnknown: true}
+		cnt, err := p.Parse([]byte("HXYZ first NInick\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(Equal(&RawContent{
+			PositionalParams: []string{"first"},
+			NamedParams:      map[string]string{"NI": "nick"},
+		}))
+	})
+
+	It("should return ErrMalformedFrame for a malformed type and command token", func() {
+		_, err := ParseFrame([]byte("HMIXX 1 a desc\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+
+		_, err = ParseFrame([]byte("Hmix 1 a desc\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+
+		_, err = ParseFrame([]byte("\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+	})
+
+	It("should return ErrMalformedFrame for an invalid header SID", func() {
+		_, err := ParseFrame([]byte("BMIX a 1 a desc\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+	})
+
+	It("should return ErrUnsupportedType for unsupported types", func() {
+		_, err := ParseFrame([]byte("UMIX 1 a desc\n"))
+		Ω(err).Should(Equal(ErrUnsupportedType))
+	})
+})
+
+var _ = Describe("FrameParser.Lenient", func() {
+	It("should report empty keep-alive lines as ErrKeepAlive", func() {
+		p := FrameParser{Lenient: true}
+		_, err := p.Parse([]byte("\n"))
+		Ω(err).Should(Equal(ErrKeepAlive))
+
+		_, err = p.Parse(nil)
+		Ω(err).Should(Equal(ErrKeepAlive))
+	})
+
+	It("should ignore a trailing space before the terminator", func() {
+		p := FrameParser{Lenient: true}
+		cnt, err := p.Parse([]byte("HLST first second \n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Positional()).Should(Equal([]string{"first", "second"}))
+
+		cnt, err = p.Parse([]byte("HLST \n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Positional()).Should(BeEmpty())
+	})
+
+	It("should parse empty lines and trailing spaces strictly by default", func() {
+		_, err := ParseFrame([]byte("\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+
+		cnt, err := ParseFrame([]byte("HLST first second \n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Positional()).Should(Equal([]string{"first", "second", ""}))
+	})
+})
+
+var _ = Describe("PeekCommand()", func() {
+	It("should return the message type and command", func() {
+		msgType, command, err := PeekCommand([]byte("BMIX AAAB 1 a desc\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(msgType).Should(Equal(byte('B')))
+		Ω(command).Should(Equal("MIX"))
+	})
+
+	It("should accept a frame without params", func() {
+		msgType, command, err := PeekCommand([]byte("HSUP\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(msgType).Should(Equal(byte('H')))
+		Ω(command).Should(Equal("SUP"))
+	})
+
+	It("should return ErrMalformedFrame for lines shorter than four bytes", func() {
+		_, _, err := PeekCommand([]byte("BMI"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+
+		_, _, err = PeekCommand(nil)
+		Ω(err).Should(Equal(ErrMalformedFrame))
+	})
+
+	It("should return ErrMalformedFrame for lines missing the message type", func() {
+		_, _, err := PeekCommand([]byte("MIX 1 a desc\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+	})
+
+	It("should return ErrMalformedFrame for commands longer than three characters", func() {
+		_, _, err := PeekCommand([]byte("BMIXX 1\n"))
+		Ω(err).Should(Equal(ErrMalformedFrame))
+	})
+})
+
+var _ = Describe("MsgType()", func() {
+	It("should report the message type of the parsed frame", func() {
+		cnt, err := ParseFrame([]byte("DMIX AAAB AAAC 1 a desc\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.(*MIXContent).MsgType()).Should(Equal(byte('D')))
+
+		cnt, err = ParseFrame([]byte("HMIX 1 a desc\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.(*MIXContent).MsgType()).Should(Equal(byte('H')))
+	})
+
+	It("should report 0 for contents not parsed from a frame", func() {
+		var cnt MIXContent
+		Ω(cnt.ParseInto([]string{"1", "a", "desc"}, nil)).Should(Succeed())
+		Ω(cnt.MsgType()).Should(Equal(byte(0)))
+	})
+
+	It("should report the only type of messages valid in a single type", func() {
+		var cnt SIDContent
+		Ω(cnt.MsgType()).Should(Equal(byte('I')))
+	})
+
+	It("should return ErrUnexpectedType for types the message is not valid in", func() {
+		_, err := ParseFrame([]byte("IMIX 1 a desc\n"))
+		Ω(err).Should(Equal(ErrUnexpectedType))
+
+		_, err = ParseFrame([]byte("BSID AAAB AAAC\n"))
+		Ω(err).Should(Equal(ErrUnexpectedType))
+	})
+})
+
+var _ = Describe("FrameParser.Phase", func() {
+	It("should accept commands valid in the phase", func() {
+		p := FrameParser{Phase: PhaseNormal}
+		cnt, err := p.Parse([]byte("HMIX 1 a desc\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&MIXContent{}))
+
+		p = FrameParser{Phase: PhaseProtocol}
+		_, err = p.Parse([]byte("ISID AAAB\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should return ErrUnexpectedPhase for commands not valid in the phase", func() {
+		p := FrameParser{Phase: PhaseIdentify}
+		_, err := p.Parse([]byte("ISID AAAB\n"))
+		Ω(err).Should(Equal(ErrUnexpectedPhase))
+
+		p = FrameParser{Phase: PhaseIdentify}
+		_, err = p.Parse([]byte("HMIX 1 a desc\n"))
+		Ω(err).Should(Equal(ErrUnexpectedPhase))
+	})
+
+	It("should accept commands valid in any phase", func() {
+		p := FrameParser{Phase: PhaseVerify}
+		_, err := p.Parse([]byte("HLST first second\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should not check phases if no phase is set", func() {
+		_, err := ParseFrame([]byte("ISID AAAB\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+})
+
+var _ = Describe("DecodeAll()", func() {
+	It("should decode the valid lines and report errors with line numbers", func() {
+		data := []byte("HLST first second\n" +
+			"HXYZ unknown\n" +
+			"ISID AAAB\n" +
+			"garbage\n" +
+			"HMIX 1 a desc")
+
+		contents, errs := DecodeAll(data)
+		Ω(contents).Should(HaveLen(3))
+		Ω(contents[0]).Should(BeAssignableToTypeOf(&LSTContent{}))
+		Ω(contents[1]).Should(BeAssignableToTypeOf(&SIDContent{}))
+		Ω(contents[2]).Should(BeAssignableToTypeOf(&MIXContent{}))
+		Ω(contents[2].Positional()).Should(Equal([]string{"1", "a", "desc"}))
+
+		Ω(errs).Should(HaveLen(2))
+		Ω(errs[0].Error()).Should(HavePrefix("line 2: "))
+		Ω(errors.Is(errs[0], ErrUnknownCommand)).Should(BeTrue())
+		Ω(errs[1].Error()).Should(HavePrefix("line 4: "))
+		Ω(errors.Is(errs[1], ErrMalformedFrame)).Should(BeTrue())
+	})
+
+	It("should apply the options of the FrameParser", func() {
+		p := FrameParser{RawUnknown: true}
+		contents, errs := p.DecodeAll([]byte("HXYZ unknown\nHLST first\n"))
+		Ω(errs).Should(BeEmpty())
+		Ω(contents).Should(HaveLen(2))
+		Ω(contents[0]).Should(BeAssignableToTypeOf(&RawContent{}))
+	})
+})
@@ -0,0 +1,84 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Gated positional params", func() {
+	var cnt GTDContent
+
+	Context("with the gate set", func() {
+		BeforeEach(func() {
+			err := cnt.ParseInto([]string{"1", "target", "desc", "TR1"}, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should parse the gated param", func() {
+			Ω(cnt.Target.IsSet).Should(BeTrue())
+			Ω(cnt.Target.Value).Should(Equal("target"))
+			Ω(cnt.Description).Should(Equal("desc"))
+		})
+
+		It("should include the gated param in the positional layout", func() {
+			Ω(cnt.PosLen()).Should(Equal(3))
+			Ω(cnt.PosAt(0)).Should(Equal("1"))
+			Ω(cnt.PosAt(1)).Should(Equal("target"))
+			Ω(cnt.PosAt(2)).Should(Equal("desc"))
+			Ω(cnt.Positional()).Should(Equal([]string{"1", "target", "desc"}))
+			Ω(func() { cnt.PosAt(3) }).Should(Panic())
+		})
+
+		It("should marshal the gated param", func() {
+			Ω(cnt.MarshalADC()).Should(Equal([]byte("GTD 1 target desc TR1\n")))
+		})
+
+		It("should return ErrMissingParam if the gated param is missing", func() {
+			err := cnt.ParseInto([]string{"1", "desc", "TR1"}, nil)
+			Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+		})
+
+		It("should parse the gate interleaved with the positionals", func() {
+			Ω(cnt.ParseInto([]string{"1", "TR1", "target", "desc"}, nil)).Should(Succeed())
+			Ω(cnt.Target.Value).Should(Equal("target"))
+			Ω(cnt.Description).Should(Equal("desc"))
+		})
+	})
+
+	Context("with the gate not set", func() {
+		BeforeEach(func() {
+			err := cnt.ParseInto([]string{"1", "desc"}, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should not set the gated param", func() {
+			Ω(cnt.Target.IsSet).Should(BeFalse())
+			Ω(cnt.Description).Should(Equal("desc"))
+		})
+
+		It("should shift subsequent params in the positional layout", func() {
+			Ω(cnt.PosLen()).Should(Equal(2))
+			Ω(cnt.PosAt(0)).Should(Equal("1"))
+			Ω(cnt.PosAt(1)).Should(Equal("desc"))
+			Ω(cnt.Positional()).Should(Equal([]string{"1", "desc"}))
+			Ω(func() { cnt.PosAt(2) }).Should(Panic())
+
+			_, ok := cnt.PosByName("Target")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("should not marshal the gated param", func() {
+			Ω(cnt.MarshalADC()).Should(Equal([]byte("GTD 1 desc\n")))
+		})
+
+		It("should parse positionals matching the gate as positionals", func() {
+			Ω(cnt.ParseInto([]string{"1", "TRUE"}, nil)).Should(Succeed())
+			Ω(cnt.Target.IsSet).Should(BeFalse())
+			Ω(cnt.TR.IsSet).Should(BeFalse())
+			Ω(cnt.Description).Should(Equal("TRUE"))
+			Ω(cnt.MarshalADC()).Should(Equal([]byte("GTD 1 TRUE\n")))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+package message_test
+
+import (
+	"log/slog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("LogValue()", func() {
+	// groupAttrs returns the attrs of the group value v keyed by attr key.
+	groupAttrs := func(v slog.Value) map[string]slog.Value {
+		Ω(v.Kind()).Should(Equal(slog.KindGroup))
+
+		attrs := make(map[string]slog.Value)
+		for _, attr := range v.Group() {
+			attrs[attr.Key] = attr.Value
+		}
+		return attrs
+	}
+
+	It("should return a group of the command and the params", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"7", "a", "b", "desc", "NInick"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		attrs := groupAttrs(cnt.LogValue())
+		Ω(attrs).Should(HaveLen(5))
+		Ω(attrs["command"].String()).Should(Equal("MIX"))
+		Ω(attrs["Code"].Int64()).Should(Equal(int64(7)))
+		Ω(attrs["Items"].Any()).Should(Equal([]string{"a", "b"}))
+		Ω(attrs["Description"].String()).Should(Equal("desc"))
+		Ω(attrs["NI"].String()).Should(Equal("nick"))
+	})
+
+	It("should omit unset optional params", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"7", "desc"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		attrs := groupAttrs(cnt.LogValue())
+		Ω(attrs).ShouldNot(HaveKey("NI"))
+		Ω(attrs).ShouldNot(HaveKey("SV"))
+		Ω(attrs).ShouldNot(HaveKey("PR"))
+	})
+
+	It("should mask sensitive params", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile", "SI42", "TOsecret", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		attrs := groupAttrs(cnt.LogValue())
+		Ω(attrs["FN"].String()).Should(Equal("file"))
+		Ω(attrs["TO"].String()).Should(Equal("***"))
+		Ω(attrs["TR"].String()).Should(Equal("***"))
+	})
+})
+
+var _ = Describe("Labels()", func() {
+	It("should key positional and named params by their display names", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile\\sname", "SI42", "TOtoken", "TD3"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.Labels()).Should(Equal(map[string]string{
+			"File name": "file name",
+			"SI":        "42",
+			"TD":        "3",
+		}))
+	})
+
+	It("should include positional params", func() {
+		var cnt BITContent
+		err := cnt.ParseInto([]string{"3", "desc"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.Labels()).Should(Equal(map[string]string{
+			"Status":      "3",
+			"Description": "desc",
+		}))
+	})
+})
+
+var _ = Describe("String()", func() {
+	It("should print the command and the params labelled by their names", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"7", "a", "b\\sc", "desc", "NInick"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(cnt.String()).Should(Equal(`MIX Code=7 Items=["a" "b c"] Description="desc" NI="nick"`))
+	})
+
+	It("should mask sensitive params", func() {
+		var pas PASContent
+		Ω(pas.ParseInto([]string{"AAAQEAYEAUDAOCAJ"}, nil)).Should(Succeed())
+		Ω(pas.String()).Should(Equal("PAS Password=***"))
+
+		var res RESContent
+		err := res.ParseInto([]string{"FNfile", "SI42", "TOsecret"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(res.String()).Should(Equal(`RES FN="file" SI=42 TO=***`))
+	})
+})
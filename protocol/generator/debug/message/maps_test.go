@@ -0,0 +1,90 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+// mapContent is implemented by all generated content types.
+type mapContent interface {
+	ParseInto(params []string, opts *ParseOptions) error
+	MarshalADC() ([]byte, error)
+	ToMap() map[string]string
+	FromMap(values map[string]string) error
+}
+
+var _ = Describe("ToMap()", func() {
+	It("should key values by param and flag names", func() {
+		var mix MIXContent
+		Ω(mix.ParseInto([]string{"1", "a", "b\\sc", "description", "NInick", "XXext"}, nil)).Should(Succeed())
+
+		Ω(mix.ToMap()).Should(Equal(map[string]string{
+			"Code":        "1",
+			"Items":       "a b\\sc",
+			"Description": "description",
+			"NI":          "nick",
+			"XX":          "ext",
+		}))
+	})
+
+	It("should join the values of repeated named params", func() {
+		var sch SCHContent
+		Ω(sch.ParseInto([]string{"ANsome", "ANfile", "TOtoken"}, nil)).Should(Succeed())
+
+		Ω(sch.ToMap()).Should(Equal(map[string]string{"AN": "some file", "TO": "token"}))
+	})
+
+	It("should leave out const params", func() {
+		var mrk MRKContent
+		Ω(mrk.ParseInto([]string{"1", "V2", "marked"}, nil)).Should(Succeed())
+
+		Ω(mrk.ToMap()).Should(Equal(map[string]string{"Code": "1", "Description": "marked"}))
+	})
+})
+
+var _ = Describe("FromMap()", func() {
+	It("should restore the content returned by ToMap", func() {
+		for _, c := range []struct {
+			cnt    mapContent
+			params []string
+		}{
+			{&MIXContent{}, []string{"1", "a", "b\\sc", "description", "NInick", "XXext"}},
+			{&SCHContent{}, []string{"ANsome", "ANfile", "NOold", "TOtoken"}},
+			{&MRKContent{}, []string{"1", "V2", "marked"}},
+			{&BITContent{}, []string{"5", "bits\\sset"}},
+			{&GTDContent{}, []string{"1", "target\\s", "desc", "TR2"}},
+			{&GTDContent{}, []string{"1", "desc"}},
+		} {
+			cnt := c.cnt
+			Ω(cnt.ParseInto(c.params, nil)).Should(Succeed())
+			want, err := cnt.MarshalADC()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			values := cnt.ToMap()
+			Ω(cnt.FromMap(values)).Should(Succeed())
+			got, err := cnt.MarshalADC()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(got)).Should(Equal(string(want)))
+		}
+	})
+
+	It("should check the values as by ParseInto", func() {
+		var mix MIXContent
+		err := mix.FromMap(map[string]string{"Code": "x", "Items": "a", "Description": "d"})
+		Ω(err).Should(HaveOccurred())
+
+		err = mix.FromMap(map[string]string{"Items": "a", "Description": "d"})
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("param Code of message MIX"))
+	})
+
+	It("should reject keys which are neither params nor flags", func() {
+		var mix MIXContent
+		err := mix.FromMap(map[string]string{"Code": "1", "Items": "a", "Description": "d", "Nick": "x"})
+		Ω(errors.Is(err, ErrMalformedFlag)).Should(BeTrue())
+	})
+})
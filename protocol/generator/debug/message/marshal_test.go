@@ -0,0 +1,211 @@
+package message_test
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Marshalling", func() {
+	var cnt RESContent
+
+	BeforeEach(func() {
+		err := cnt.ParseInto([]string{"FNfile\\sname", "SI42", "TOtoken", "TD3", "FIext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("MarshalADC()", func() {
+		It("should marshal command and parameters", func() {
+			Ω(cnt.MarshalADC()).Should(Equal([]byte("RES FNfile\\sname SI42 TOtoken TD3 FIext\n")))
+		})
+
+		It("should return an error if a required parameter is missing", func() {
+			var sid SIDContent
+			_, err := sid.MarshalADC()
+			Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+		})
+
+		It("should encode modified fields", func() {
+			var mix MIXContent
+			Ω(mix.ParseInto([]string{"7", "a", "desc"}, nil)).Should(Succeed())
+			mix.Code = 9
+			mix.Description = "new desc"
+			mix.NI.IsSet = true
+			mix.NI.Value = "nick"
+
+			Ω(mix.Validate()).Should(Succeed())
+			Ω(mix.MarshalADC()).Should(Equal([]byte("MIX 9 a new\\sdesc NInick\n")))
+			Ω(mix.WireSize()).Should(Equal(len("MIX 9 a new\\sdesc NInick\n")))
+			Ω(mix.ADCString()).Should(Equal("MIX 9 a new\\sdesc NInick\n"))
+		})
+
+		It("should encode contents constructed from fields", func() {
+			mix := MIXContent{Code: 3, Description: "d"}
+			Ω(mix.MarshalADC()).Should(Equal([]byte("MIX 3 d\n")))
+
+			mix = MIXContent{Code: 3}
+			_, err := mix.MarshalADC()
+			Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+			Ω(err).Should(MatchError(ContainSubstring("param Description")))
+		})
+
+		It("should write set optional params with empty values", func() {
+			var inf INFContent
+			inf.I4.IsSet = true
+
+			Ω(inf.MarshalADC()).Should(Equal([]byte("INF I4\n")))
+			Ω(inf.Validate()).Should(Succeed())
+		})
+	})
+
+	Describe("AppendADC()", func() {
+		It("should append the output of MarshalADC()", func() {
+			buf, err := cnt.AppendADC([]byte("prefix "))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(buf)).Should(Equal("prefix RES FNfile\\sname SI42 TOtoken TD3 FIext\n"))
+		})
+
+		It("should return the buffer with its original length on error", func() {
+			mix := MIXContent{Code: 3}
+			buf, err := mix.AppendADC([]byte("prefix "))
+			Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+			Ω(string(buf)).Should(Equal("prefix "))
+
+			var exf EXFContent
+			buf, err = exf.AppendADC([]byte("prefix "))
+			Ω(errors.Is(err, ErrMissingCommand)).Should(BeTrue())
+			Ω(string(buf)).Should(Equal("prefix "))
+		})
+	})
+
+	Describe("ParseTokens()", func() {
+		It("should parse the same content as UnmarshalADC()", func() {
+			line := []byte("MIX 7 a\\sb c final\\ndesc NInick SV1 XXext\n")
+
+			var unmarshalled, parsed MIXContent
+			Ω(unmarshalled.UnmarshalADC(line)).Should(Succeed())
+			Ω(parsed.ParseTokens([]string{"MIX", "7", "a\\sb", "c", "final\\ndesc", "NInick", "SV1", "XXext"})).Should(Succeed())
+
+			// Only unmarshalled holds the raw line.
+			Ω(parsed.Equal(&unmarshalled)).Should(BeTrue())
+			Ω(parsed.MarshalADC()).Should(Equal(line))
+			Ω(parsed.Items).Should(Equal([]string{"a b", "c"}))
+			Ω(parsed.Description).Should(Equal("final\ndesc"))
+		})
+
+		It("should return ErrCommandMismatch if the first token is not the command", func() {
+			var mix MIXContent
+			Ω(errors.Is(mix.ParseTokens([]string{"RES", "7", "desc"}), ErrCommandMismatch)).Should(BeTrue())
+			Ω(errors.Is(mix.ParseTokens(nil), ErrCommandMismatch)).Should(BeTrue())
+		})
+	})
+
+	Describe("ParseADCInto()", func() {
+		It("should parse the first message and report the bytes consumed", func() {
+			data := []byte("MIX 7 a desc NInick\nMIX 8 b")
+
+			var mix MIXContent
+			n, err := mix.ParseADCInto(data)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(n).Should(Equal(len("MIX 7 a desc NInick\n")))
+			Ω(mix.Code).Should(Equal(7))
+			Ω(mix.NI.Value).Should(Equal("nick"))
+
+			n, err = mix.ParseADCInto(data[n:])
+			Ω(err).Should(Equal(ErrIncomplete))
+			Ω(n).Should(Equal(0))
+		})
+
+		It("should consume messages which fail to parse", func() {
+			var mix MIXContent
+			n, err := mix.ParseADCInto([]byte("RES FNfile\nMIX 7 desc\n"))
+			Ω(errors.Is(err, ErrCommandMismatch)).Should(BeTrue())
+			Ω(n).Should(Equal(len("RES FNfile\n")))
+		})
+	})
+
+	Describe("ADCString()", func() {
+		It("should return the output of MarshalADC() as a string", func() {
+			var mix MIXContent
+			Ω(mix.ParseInto([]string{"7", "a\\sb", "final\\ndesc", "NInick", "XXext"}, nil)).Should(Succeed())
+			var mrk MRKContent
+			Ω(mrk.ParseInto([]string{"1", "V2", "marked"}, nil)).Should(Succeed())
+
+			contents := []interface {
+				MarshalADC() ([]byte, error)
+				ADCString() string
+			}{&cnt, &mix, &mrk, &SIDContent{}}
+
+			for _, c := range contents {
+				buf, _ := c.MarshalADC()
+				Ω(c.ADCString()).Should(Equal(string(buf)))
+			}
+		})
+	})
+
+	Describe("WireSize()", func() {
+		It("should equal the length of the output of MarshalADC()", func() {
+			var mix MIXContent
+			Ω(mix.ParseInto([]string{"7", "a\\sb", "c\\\\d", "final\\ndesc", "NInick\\sname", "SV1", "XXext\\s"}, nil)).Should(Succeed())
+			var gtd GTDContent
+			Ω(gtd.ParseInto([]string{"1", "target\\s", "desc", "TR2"}, nil)).Should(Succeed())
+			var bit BITContent
+			Ω(bit.ParseInto([]string{"5", "bits\\sset"}, nil)).Should(Succeed())
+			var mrk MRKContent
+			Ω(mrk.ParseInto([]string{"1", "V2", "marked"}, nil)).Should(Succeed())
+			var lst LSTContent
+
+			contents := []interface {
+				MarshalADC() ([]byte, error)
+				WireSize() int
+			}{&cnt, &mix, &gtd, &bit, &mrk, &lst}
+
+			for _, c := range contents {
+				buf, err := c.MarshalADC()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.WireSize()).Should(Equal(len(buf)))
+			}
+		})
+	})
+
+	Describe("WriteTo()", func() {
+		It("should write the output of MarshalADC()", func() {
+			expected, err := cnt.MarshalADC()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var buf bytes.Buffer
+			n, err := cnt.WriteTo(&buf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(buf.Bytes()).Should(Equal(expected))
+			Ω(n).Should(Equal(int64(len(expected))))
+		})
+	})
+})
+
+var _ = Describe("ADCMarshaler and ADCUnmarshaler", func() {
+	It("should be implemented by all content types", func() {
+		contents := []interface{}{
+			&SIDContent{}, &RESContent{}, &LSTContent{}, &MIXContent{}, &BITContent{}, &GTDContent{},
+		}
+
+		for _, cnt := range contents {
+			_, isMarshaler := cnt.(ADCMarshaler)
+			Ω(isMarshaler).Should(BeTrue())
+			_, isUnmarshaler := cnt.(ADCUnmarshaler)
+			Ω(isUnmarshaler).Should(BeTrue())
+		}
+	})
+
+	It("should allow round-tripping through the interfaces", func() {
+		var u ADCUnmarshaler = &LSTContent{}
+		Ω(u.ParseInto([]string{"first", "second"}, nil)).Should(Succeed())
+
+		m, ok := u.(ADCMarshaler)
+		Ω(ok).Should(BeTrue())
+		Ω(m.MarshalADC()).Should(Equal([]byte("LST first second\n")))
+	})
+})
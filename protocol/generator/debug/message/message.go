@@ -1,5 +1,43 @@
 package message
 
+//go:generate go run ..
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/seoester/adcl/protocol/encoding"
+)
+
+// Error variables related to parsing and validating message content.
+var (
+	ErrMissingParam       = errors.New("required parameter missing")
+	ErrSurplusPositional  = errors.New("surplus positional parameter, message has more positional parameters than expected")
+	ErrValueNotAllowed    = errors.New("value not allowed")
+	ErrOutOfRange         = errors.New("value out of range")
+	ErrInvalidLength      = errors.New("encoded value has an invalid length")
+	ErrUnknownBits        = errors.New("bitmask has unknown bits set")
+	ErrUnescapedSeparator = errors.New("value contains unescaped separator")
+	ErrInvalidUTF8        = errors.New("value is not valid UTF-8")
+	ErrMalformedFlag      = errors.New("flag name is not two characters long")
+	ErrConstMismatch      = errors.New("token differs from the fixed value of the param")
+	ErrDuplicateFlag      = errors.New("flag of single-valued param repeated")
+	ErrTooManyParams      = errors.New("message has too many parameters")
+	ErrLengthMismatch     = errors.New("values and escaped values of multi-valued parameter differ in length")
+	ErrIncomplete         = errors.New("data does not hold a complete message")
+	ErrMissingCommand     = errors.New("command of message family missing")
+	ErrOneOf              = errors.New("not exactly one of the parameters present")
+)
+
+// ParamAccessor provides access to the (escaped) params of a content. Named
+// params may be repeated, e.g. the AN terms of SCH. Named and NamedGet hold
+// the first value of repeated params, NamedAll returns all values.
 type ParamAccessor interface {
 	Positional() []string
 	PosLen() int
@@ -7,4 +45,853 @@ type ParamAccessor interface {
 
 	Named() map[string]string
 	NamedGet(key string) (string, bool)
+	NamedAll(key string) []string
+}
+
+// ADCMarshaler is implemented by all generated content types. MarshalADC
+// returns the marshalled content, i.e. the command followed by the params and
+// the trailing newline.
+type ADCMarshaler interface {
+	MarshalADC() ([]byte, error)
+}
+
+// DecodeWarner is implemented by the content types of messages with
+// deprecated params. DecodeWarnings returns a warning for each deprecated
+// param present in the content, which allows reporting legacy peers.
+type DecodeWarner interface {
+	DecodeWarnings() []string
+}
+
+// ADCUnmarshaler is implemented by all generated content types. ParseInto
+// replaces the content by the params, the tokens of a message following the
+// header.
+type ADCUnmarshaler interface {
+	ParseInto(params []string, opts *ParseOptions) error
+}
+
+// ContentBase is embedded in all generated content types. It holds the flags
+// not mapped to a param and implements the accessors of those flags. Content
+// types with named params override Named and NamedGet.
+type ContentBase struct {
+	Flags map[string]string
+
+	// msgType is the message type of the frame the content has been parsed
+	// from.
+	msgType byte
+}
+
+// Named returns a copy of the flags not mapped to a param, like the Named
+// methods of contents with named params. Modifying the returned map does not
+// affect the content.
+func (b *ContentBase) Named() map[string]string {
+	return b.UnknownFlags()
+}
+
+// NamedGet returns the value of the flag key, if present.
+func (b *ContentBase) NamedGet(key string) (string, bool) {
+	val, ok := b.Flags[key]
+	return val, ok
+}
+
+// NamedAll returns the value of the flag key as a single element slice, nil
+// if the flag is not present. Flags not mapped to a param are not repeated.
+func (b *ContentBase) NamedAll(key string) []string {
+	val, ok := b.Flags[key]
+	if !ok {
+		return nil
+	}
+
+	return []string{val}
+}
+
+func (b *ContentBase) setMsgType(msgType byte) {
+	b.msgType = msgType
+}
+
+// setCommand is a no-op for contents of a single command. It is overridden by
+// contents of command families, which store the command.
+func (b *ContentBase) setCommand(command string) {}
+
+// UnknownFlags returns a copy of the flags not mapped to a param. Modifying
+// the returned map does not affect the content.
+func (b *ContentBase) UnknownFlags() map[string]string {
+	flags := make(map[string]string, len(b.Flags))
+	for key, val := range b.Flags {
+		flags[key] = val
+	}
+
+	return flags
+}
+
+var _ ParamAccessor = &RawContent{}
+
+// RawContent is an untyped message content, holding the (escaped) positional
+// and named parameters as decoded from the message. NamedParams holds the
+// first value of each named parameter, RepeatedParams holds all values of the
+// named parameters occurring more than once.
+type RawContent struct {
+	PositionalParams []string
+	NamedParams      map[string]string
+	RepeatedParams   map[string][]string
+}
+
+func (r *RawContent) Positional() []string {
+	return r.PositionalParams
+}
+
+func (r *RawContent) PosLen() int {
+	return len(r.PositionalParams)
+}
+
+func (r *RawContent) PosAt(i int) string {
+	return r.PositionalParams[i]
+}
+
+// Named returns a copy of the named params. Modifying the returned map does
+// not affect the content.
+func (r *RawContent) Named() map[string]string {
+	named := make(map[string]string, len(r.NamedParams))
+	for key, val := range r.NamedParams {
+		named[key] = val
+	}
+
+	return named
+}
+
+func (r *RawContent) NamedGet(key string) (string, bool) {
+	val, ok := r.NamedParams[key]
+	return val, ok
+}
+
+func (r *RawContent) NamedAll(key string) []string {
+	if vals, ok := r.RepeatedParams[key]; ok {
+		return vals
+	}
+
+	val, ok := r.NamedParams[key]
+	if !ok {
+		return nil
+	}
+
+	return []string{val}
+}
+
+// accessorParams returns the (escaped) params of pa, i.e. the positional
+// params followed by all values of the named params sorted by name, as
+// passed to the ParseInto methods of content types.
+func accessorParams(pa ParamAccessor) []string {
+	named := pa.Named()
+	params := make([]string, 0, pa.PosLen()+len(named))
+	for i := 0; i < pa.PosLen(); i++ {
+		params = append(params, pa.PosAt(i))
+	}
+
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, val := range pa.NamedAll(name) {
+			params = append(params, name+val)
+		}
+	}
+
+	return params
+}
+
+// Content is implemented by all generated content types. Use a type switch
+// or type assertion to access the fields of the content type.
+type Content interface {
+	ParamAccessor
+	ParseInto(params []string, opts *ParseOptions) error
+	MarshalADC() ([]byte, error)
+	Descriptor() MessageDescriptor
+	Command() string
+}
+
+// content is implemented by all generated content types.
+type content interface {
+	Content
+	setMsgType(msgType byte)
+	setCommand(command string)
+}
+
+// contentTypes maps commands to functions allocating the content type of the
+// command. All generated content types register themselves.
+var contentTypes = make(map[string]func() content)
+
+// contentPatterns holds the content types of command families, which are
+// registered by command pattern.
+var contentPatterns []contentPattern
+
+type contentPattern struct {
+	pattern    string
+	newContent func() content
+}
+
+func registerContent(command string, newContent func() content) {
+	if strings.IndexByte(command, '?') >= 0 {
+		contentPatterns = append(contentPatterns, contentPattern{command, newContent})
+		return
+	}
+
+	contentTypes[command] = newContent
+}
+
+// lookupContent returns the function allocating the content type of command.
+// Commands registered verbatim take precedence over command patterns.
+func lookupContent(command string) (func() content, bool) {
+	if newContent, ok := contentTypes[command]; ok {
+		return newContent, true
+	}
+
+	for _, p := range contentPatterns {
+		if matchCommand(p.pattern, command) {
+			return p.newContent, true
+		}
+	}
+
+	return nil, false
+}
+
+// NewContent returns an empty content of the content type registered for
+// command, e.g. a *INFContent for "INF". The command of contents of command
+// families is set to command. ErrUnknownCommand is returned if no content
+// type is registered for command.
+func NewContent(command string) (Content, error) {
+	newContent, ok := lookupContent(command)
+	if !ok {
+		return nil, ErrUnknownCommand
+	}
+
+	cnt := newContent()
+	cnt.setCommand(command)
+	return cnt, nil
+}
+
+// NewContentForType returns an empty content as NewContent does, for
+// messages of the message type msgType. ErrUnexpectedType is returned if
+// the command is not valid for msgType.
+func NewContentForType(msgType Type, command string) (Content, error) {
+	newContent, ok := lookupContent(command)
+	if !ok {
+		return nil, ErrUnknownCommand
+	}
+
+	cnt := newContent()
+	if err := checkMsgType(cnt, byte(msgType)); err != nil {
+		return nil, err
+	}
+	cnt.setMsgType(byte(msgType))
+	cnt.setCommand(command)
+	return cnt, nil
+}
+
+// checkMsgType returns ErrUnexpectedType if the message type msgType is not
+// valid for the content type of cnt.
+func checkMsgType(cnt content, msgType byte) error {
+	if types := cnt.Descriptor().Types; len(types) > 0 && strings.IndexByte(types, msgType) < 0 {
+		return ErrUnexpectedType
+	}
+
+	return nil
+}
+
+// Commands returns the commands of all registered content types in sorted
+// order. Command families are listed by their command patterns, e.g. "EX?".
+func Commands() []string {
+	commands := make([]string, 0, len(contentTypes)+len(contentPatterns))
+	for command := range contentTypes {
+		commands = append(commands, command)
+	}
+	for _, p := range contentPatterns {
+		commands = append(commands, p.pattern)
+	}
+	sort.Strings(commands)
+
+	return commands
+}
+
+// matchCommand returns true if command matches pattern. Each '?' of the
+// pattern matches any upper case letter or digit, all other characters match
+// themselves.
+func matchCommand(pattern, command string) bool {
+	if len(pattern) != len(command) {
+		return false
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '?' {
+			if !encoding.IsUpperAlphaNum(command[i]) {
+				return false
+			}
+		} else if pattern[i] != command[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MessageDescriptor describes the parameters of a message as specified by its
+// definition.
+type MessageDescriptor struct {
+	Command string
+	// Types lists the message types the message is valid in. Any type is
+	// valid if empty.
+	Types string
+	// Phases is the set of protocol phases the message is valid in. Any
+	// phase is valid if zero.
+	Phases     Phase
+	Positional []ParamDescriptor
+	Named      []ParamDescriptor
+}
+
+// ParamDescriptor describes a single parameter of a message.
+type ParamDescriptor struct {
+	// Name is the protocol name of the parameter.
+	Name string
+	// DisplayName is the name of the parameter intended for presentation to
+	// users. It equals Name if no display name has been defined.
+	DisplayName string
+	// FlagName is the two character name of a named parameter. It is empty
+	// for positional parameters.
+	FlagName string
+	Type     string
+	Required bool
+}
+
+// Default limits of the number of params of a message, used if the
+// corresponding field of ParseOptions is 0.
+const (
+	DefaultMaxPositionals = 1024
+	DefaultMaxNamed       = 1024
+)
+
+// ParseOptions configures how the ParseInto methods of content types handle
+// deviations from the message definition. A nil *ParseOptions is equivalent
+// to the zero value, which selects strict parsing.
+type ParseOptions struct {
+	// Lenient causes surplus positional parameters to be dropped. By
+	// default, i.e. in strict mode, ErrSurplusPositional is returned.
+	Lenient bool
+	// SurplusPositional is called with every positional parameter dropped
+	// in lenient mode. It may be nil.
+	SurplusPositional func(value string)
+	// MaxValueLength limits the length of (escaped) parameter values in
+	// bytes. Longer values are truncated and the Truncated field of the
+	// content is set. There is no limit if MaxValueLength is 0.
+	MaxValueLength int
+	// Compressed indicates that the message has been received while the
+	// stream was in compressed mode (ZON). It is exposed as the Compressed
+	// field of the content.
+	Compressed bool
+	// StrictUTF8 causes values which are not valid UTF-8 to be rejected
+	// with ErrInvalidUTF8. By default, values are not checked.
+	StrictUTF8 bool
+	// StrictFlags causes repeated flags of single-valued named params to be
+	// rejected with ErrDuplicateFlag. By default, the last value wins. Flags
+	// of multi-valued params and unknown flags are not checked.
+	StrictFlags bool
+	// MaxPositionals and MaxNamed limit the number of positional and named
+	// params of a message. Messages exceeding a limit are rejected with
+	// ErrTooManyParams before any params are parsed. DefaultMaxPositionals
+	// and DefaultMaxNamed are used if 0.
+	MaxPositionals int
+	MaxNamed       int
+}
+
+func (o *ParseOptions) compressed() bool {
+	return o != nil && o.Compressed
+}
+
+// checkUTF8 returns ErrInvalidUTF8 if StrictUTF8 is set and the escaped
+// value is not valid UTF-8. As escaping only affects ASCII characters, the
+// unescaped value is valid if and only if the escaped value is.
+func (o *ParseOptions) checkUTF8(value string) error {
+	if o == nil || !o.StrictUTF8 || utf8.ValidString(value) {
+		return nil
+	}
+
+	return ErrInvalidUTF8
+}
+
+// checkDuplicateFlag returns ErrDuplicateFlag if StrictFlags is set and prev,
+// the previously parsed (escaped) named param with the flag of param, is
+// non-empty. The error names both values.
+func (o *ParseOptions) checkDuplicateFlag(prev, param string) error {
+	if o == nil || !o.StrictFlags || prev == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w, values %q and %q", ErrDuplicateFlag, prev[2:], param[2:])
+}
+
+// checkCounts returns ErrTooManyParams if the (escaped) params exceed
+// MaxPositionals or MaxNamed. Params are classified as named by isNamedParam,
+// positionals matching the flag grammar are counted as named.
+func (o *ParseOptions) checkCounts(params []string) error {
+	maxPositionals, maxNamed := o.maxCounts()
+
+	if len(params) <= maxPositionals && len(params) <= maxNamed {
+		return nil
+	}
+
+	var numNamed int
+	for _, param := range params {
+		if isNamedParam(param) {
+			numNamed++
+		}
+	}
+
+	if numPositionals := len(params) - numNamed; numPositionals > maxPositionals {
+		return fmt.Errorf("%w, %d positional params exceed the limit of %d",
+			ErrTooManyParams, numPositionals, maxPositionals)
+	}
+	if numNamed > maxNamed {
+		return fmt.Errorf("%w, %d named params exceed the limit of %d",
+			ErrTooManyParams, numNamed, maxNamed)
+	}
+
+	return nil
+}
+
+// maxCounts returns the limits of the numbers of positional and named params.
+func (o *ParseOptions) maxCounts() (int, int) {
+	maxPositionals, maxNamed := DefaultMaxPositionals, DefaultMaxNamed
+	if o != nil && o.MaxPositionals > 0 {
+		maxPositionals = o.MaxPositionals
+	}
+	if o != nil && o.MaxNamed > 0 {
+		maxNamed = o.MaxNamed
+	}
+
+	return maxPositionals, maxNamed
+}
+
+// splitTokens splits line, a message optionally terminated by a newline, into
+// its tokens. numHeader is the number of header tokens preceding the params,
+// e.g. the command. ErrTooManyParams is returned before splitting if the
+// params exceed the MaxPositionals and MaxNamed of opts in total, so the
+// limits bound the memory allocated for the tokens. The limits of the
+// individual kinds of params are checked by checkCounts.
+func splitTokens(line string, numHeader int, opts *ParseOptions) ([]string, error) {
+	line = strings.TrimSuffix(line, "\n")
+
+	maxPositionals, maxNamed := opts.maxCounts()
+	if numParams := strings.Count(line, " ") + 1 - numHeader; numParams > maxPositionals+maxNamed {
+		return nil, fmt.Errorf("%w, %d params exceed the limit of %d",
+			ErrTooManyParams, numParams, maxPositionals+maxNamed)
+	}
+
+	return strings.Split(line, " "), nil
+}
+
+func (o *ParseOptions) surplusPositional(value string) error {
+	if o == nil || !o.Lenient {
+		return ErrSurplusPositional
+	}
+
+	if o.SurplusPositional != nil {
+		o.SurplusPositional(value)
+	}
+
+	return nil
+}
+
+// truncateValue truncates the escaped value to at most MaxValueLength bytes.
+// Neither escape sequences nor UTF-8 encoded characters are split. The second
+// return value is true if value has been truncated.
+func (o *ParseOptions) truncateValue(value string) (string, bool) {
+	if o == nil || o.MaxValueLength <= 0 || len(value) <= o.MaxValueLength {
+		return value, false
+	}
+
+	end := o.MaxValueLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	var backslashes int
+	for i := end - 1; i >= 0 && value[i] == '\\'; i-- {
+		backslashes++
+	}
+	if backslashes%2 == 1 {
+		end--
+	}
+
+	return value[:end], true
+}
+
+// isNamedParam returns true if the (escaped) token tok is a named parameter,
+// i.e. starts with a parameter name.
+func isNamedParam(tok string) bool {
+	return len(tok) >= 2 &&
+		encoding.IsUpperAlpha(tok[0]) &&
+		encoding.IsUpperAlphaNum(tok[1])
+}
+
+// hasNamedParam returns true if params contains a named parameter with the
+// name.
+func hasNamedParam(params []string, name string) bool {
+	for _, param := range params {
+		if isNamedParam(param) && param[:2] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidationError is returned by Validate, listing all constraint violations
+// of the content. errors.Is and errors.As match any of the violations.
+type ValidationError struct {
+	Errs []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	for _, err := range e.Errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *ValidationError) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validationError returns a ValidationError listing errs, or nil if errs is
+// empty.
+func validationError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errs: errs}
+}
+
+// joinParams returns the (escaped) positional params followed by the named
+// params, keyed by flag name, in the form expected by ParseInto. The named
+// params are ordered by name. ErrMalformedFlag is returned if a key is not a
+// flag name.
+func joinParams(positional []string, named map[string]string) ([]string, error) {
+	names := make([]string, 0, len(named))
+	for name := range named {
+		if !isNamedParam(name) || len(name) != 2 {
+			return nil, fmt.Errorf("flag %q: %w", name, ErrMalformedFlag)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(positional)+len(named))
+	params = append(params, positional...)
+	for _, name := range names {
+		params = append(params, name+named[name])
+	}
+
+	return params, nil
+}
+
+// joinValues joins the (escaped) values of a multi-valued param by spaces,
+// which escaped values do not contain. The first n bytes of each value, i.e.
+// the flag name of named params, are dropped.
+func joinValues(values []string, n int) string {
+	var b strings.Builder
+	for i, val := range values {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(val[n:])
+	}
+
+	return b.String()
+}
+
+// splitValues splits the values of a multi-valued param joined by
+// joinValues. The empty string holds no values.
+func splitValues(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return strings.Split(s, " ")
+}
+
+// checkEscaped returns ErrUnescapedSeparator if the escaped value contains a
+// raw space or newline, which would corrupt the marshalled message.
+func checkEscaped(value string) error {
+	if strings.ContainsAny(value, " \n") {
+		return ErrUnescapedSeparator
+	}
+
+	return nil
+}
+
+// valueEscaper escapes the separators and the escape character of values.
+var valueEscaper = strings.NewReplacer(
+	" ", "\\s",
+	"\n", "\\n",
+	"\\", "\\\\",
+)
+
+// escapeValue escapes the (decoded) value for the wire format. The value is
+// returned as is if it contains nothing to escape.
+func escapeValue(value string) string {
+	if !strings.ContainsAny(value, " \n\\") {
+		return value
+	}
+
+	return valueEscaper.Replace(value)
+}
+
+// appendEscaped appends the escaped (decoded) value to buf, like escapeValue
+// but without allocating if buf has sufficient capacity.
+func appendEscaped(buf []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case ' ':
+			buf = append(buf, '\\', 's')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		default:
+			buf = append(buf, value[i])
+		}
+	}
+
+	return buf
+}
+
+// formatBase32 returns the base32 parameter of val, the empty string if val
+// is nil.
+func formatBase32(val *encoding.Base32Value) string {
+	if val == nil {
+		return ""
+	}
+
+	return val.String()
+}
+
+// formatIP returns the IP parameter of ip, the empty string if ip is nil.
+func formatIP(ip net.IP) string {
+	if len(ip) == 0 {
+		return ""
+	}
+
+	return ip.String()
+}
+
+// appendIP appends the IP parameter of ip to buf, like formatIP.
+func appendIP(buf []byte, ip net.IP) []byte {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return append(buf, formatIP(ip)...)
+	}
+
+	return addr.Unmap().AppendTo(buf)
+}
+
+// formatAddr returns the address parameter of addr, the empty string if addr
+// is the zero Addr.
+func formatAddr(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+
+	return encoding.FormatAddr(addr)
+}
+
+// appendAddr appends the address parameter of addr to buf, like formatAddr.
+func appendAddr(buf []byte, addr netip.Addr) []byte {
+	if !addr.IsValid() {
+		return buf
+	}
+
+	return addr.Unmap().AppendTo(buf)
+}
+
+// checkFlagsEscaped performs checkEscaped on the values of all flags. The
+// error names the offending flag.
+func checkFlagsEscaped(flags map[string]string) error {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := checkEscaped(flags[name]); err != nil {
+			return fmt.Errorf("flag %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// appendFlags appends the flags as named parameters to buf. The flags are
+// ordered by name to keep the output deterministic.
+func appendFlags(buf []byte, flags map[string]string) []byte {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, flags[name]...)
+	}
+
+	return buf
+}
+
+// maxStackFlags is the number of flags encodeFlags sorts without allocating.
+const maxStackFlags = 8
+
+// encodeFlags appends the flags to buf like appendFlags. Up to maxStackFlags
+// flags are sorted on the stack, so that no memory is allocated apart from
+// growing buf.
+func encodeFlags(buf []byte, flags map[string]string) []byte {
+	if len(flags) > maxStackFlags {
+		return appendFlags(buf, flags)
+	}
+
+	var arr [maxStackFlags]string
+	names := arr[:0]
+	for name := range flags {
+		// Insertion sort, sort.Strings would move names to the heap.
+		i := len(names)
+		names = append(names, name)
+		for ; i > 0 && names[i-1] > name; i-- {
+			names[i] = names[i-1]
+		}
+		names[i] = name
+	}
+
+	for _, name := range names {
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, flags[name]...)
+	}
+
+	return buf
+}
+
+// writeFlags writes flags to b like appendFlags.
+func writeFlags(b *strings.Builder, flags map[string]string) {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.WriteByte(' ')
+		b.WriteString(name)
+		b.WriteString(flags[name])
+	}
+}
+
+// flagsSize returns the number of bytes appended by appendFlags for flags.
+func flagsSize(flags map[string]string) int {
+	n := 0
+	for name, value := range flags {
+		n += 1 + len(name) + len(value)
+	}
+
+	return n
+}
+
+// rawLine returns a copy of line terminated by a newline, as stored by
+// UnmarshalADC of content types generated with raw passthrough.
+func rawLine(line []byte) []byte {
+	raw := make([]byte, 0, len(line)+1)
+	raw = append(raw, line...)
+	if len(raw) == 0 || raw[len(raw)-1] != '\n' {
+		raw = append(raw, '\n')
+	}
+
+	return raw
+}
+
+// exactlyOne returns true if exactly one of present is true.
+func exactlyOne(present ...bool) bool {
+	n := 0
+	for _, p := range present {
+		if p {
+			n++
+		}
+	}
+
+	return n == 1
+}
+
+// checkBits returns ErrUnknownBits if the bitmask val has any bits set apart
+// from the n least significant bits.
+func checkBits(val int, n uint) error {
+	if val < 0 || val>>n != 0 {
+		return ErrUnknownBits
+	}
+
+	return nil
+}
+
+// bitmask returns the bitmask with the ith bit set if bits[i] is true.
+func bitmask(bits ...bool) int {
+	var val int
+	for i, bit := range bits {
+		if bit {
+			val |= 1 << uint(i)
+		}
+	}
+
+	return val
+}
+
+// formatContent formats the content marshalled by marshal for the verb. %s
+// and %v write the wire form, %q the quoted wire form, both omitting the
+// terminating newline. %x and %X write the hex encoding of the marshalled
+// bytes.
+func formatContent(f fmt.State, verb rune, marshal func() ([]byte, error)) {
+	buf, err := marshal()
+	if err != nil {
+		fmt.Fprintf(f, "%%!%c(%v)", verb, err)
+		return
+	}
+
+	switch verb {
+	case 's', 'v':
+		f.Write(bytes.TrimSuffix(buf, []byte{'\n'}))
+	case 'q':
+		fmt.Fprintf(f, "%q", bytes.TrimSuffix(buf, []byte{'\n'}))
+	case 'x':
+		fmt.Fprintf(f, "%x", buf)
+	case 'X':
+		fmt.Fprintf(f, "%X", buf)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, bytes.TrimSuffix(buf, []byte{'\n'}))
+	}
 }
@@ -0,0 +1,486 @@
+package message_test
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/seoester/adcl/protocol/encoding"
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("ParseInto", func() {
+	Describe("Surplus positional parameters", func() {
+		params := []string{"AAAB", "surplus"}
+
+		It("should return an error in strict mode", func() {
+			var cnt SIDContent
+			err := cnt.ParseInto(params, nil)
+			Ω(err).Should(MatchError(ContainSubstring(ErrSurplusPositional.Error())))
+
+			err = cnt.ParseInto(params, &ParseOptions{})
+			Ω(err).Should(MatchError(ContainSubstring(ErrSurplusPositional.Error())))
+		})
+
+		It("should drop the parameter in lenient mode", func() {
+			var cnt SIDContent
+			err := cnt.ParseInto(params, &ParseOptions{Lenient: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(cnt.Positional()).Should(Equal([]string{"AAAB"}))
+			Ω(cnt.SID.String()).Should(Equal("AAAB"))
+		})
+
+		It("should call the SurplusPositional hook in lenient mode", func() {
+			var dropped []string
+			opts := &ParseOptions{
+				Lenient: true,
+				SurplusPositional: func(value string) {
+					dropped = append(dropped, value)
+				},
+			}
+
+			var cnt SIDContent
+			err := cnt.ParseInto(params, opts)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(dropped).Should(Equal([]string{"surplus"}))
+		})
+	})
+
+	It("should parse named parameters", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile\\sname", "SI42", "TOtoken", "FIext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.FN).Should(Equal("file name"))
+		Ω(cnt.SI).Should(Equal(42))
+		Ω(cnt.SL.IsSet).Should(BeFalse())
+		val, ok := cnt.NamedGet("FN")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("file\\sname"))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"FI": "ext"}))
+	})
+
+	It("should return an error if a required parameter is missing", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile", "SI42"}, nil)
+		Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+
+		var sid SIDContent
+		err = sid.ParseInto(nil, nil)
+		Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+	})
+
+	It("should reject empty values of required params, as AppendADC does", func() {
+		var sta STAContent
+		err := sta.UnmarshalADC([]byte("STA 0 \n"))
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("param Description"))
+
+		var res RESContent
+		err = res.ParseInto([]string{"FN", "SI42", "TOtoken"}, nil)
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("param FN"))
+	})
+})
+
+var _ = Describe("ParseInto() with dynamic multiplicity", func() {
+	It("should assign all non-named positionals to the dynamic param", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "a", "b\\sc", "description", "NInick"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Code).Should(Equal(1))
+		Ω(cnt.Items).Should(Equal([]string{"a", "b c"}))
+		Ω(cnt.Description).Should(Equal("description"))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.Positional()).Should(Equal([]string{"1", "a", "b\\sc", "description"}))
+	})
+
+	It("should accept an empty dynamic param", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "description"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Items).Should(BeEmpty())
+		Ω(cnt.PosLen()).Should(Equal(2))
+	})
+
+	It("should return an error if a static positional is missing", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1"}, nil)
+		Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+	})
+})
+
+var _ = Describe("ParseInto() with MaxValueLength", func() {
+	It("should truncate values exceeding the limit", func() {
+		var cnt MIXContent
+		opts := &ParseOptions{MaxValueLength: 4}
+		err := cnt.ParseInto([]string{"1", "description", "NInickname"}, opts)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Truncated).Should(BeTrue())
+		Ω(cnt.Description).Should(Equal("desc"))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.Named()).Should(HaveKeyWithValue("NI", "nick"))
+	})
+
+	It("should not split escape sequences or characters", func() {
+		var cnt MIXContent
+		opts := &ParseOptions{MaxValueLength: 4}
+		err := cnt.ParseInto([]string{"1", "abc\\sd", "NIabcñ"}, opts)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Truncated).Should(BeTrue())
+		Ω(cnt.Description).Should(Equal("abc"))
+		Ω(cnt.NI.Value).Should(Equal("abc"))
+	})
+
+	It("should not set Truncated if all values are within the limit", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc"}, &ParseOptions{MaxValueLength: 4})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Truncated).Should(BeFalse())
+	})
+
+	It("should not limit values by default", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "description"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Truncated).Should(BeFalse())
+		Ω(cnt.Description).Should(Equal("description"))
+	})
+})
+
+var _ = Describe("ParseInto() with Compressed", func() {
+	It("should set Compressed if the option is set", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc"}, &ParseOptions{Compressed: true})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Compressed).Should(BeTrue())
+	})
+
+	It("should clear Compressed if the option is not set", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc"}, &ParseOptions{Compressed: true})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = cnt.ParseInto([]string{"1", "desc"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Compressed).Should(BeFalse())
+	})
+
+	It("should not affect marshalling", func() {
+		var plain, compressed MIXContent
+		Ω(plain.ParseInto([]string{"1", "desc"}, nil)).Should(Succeed())
+		Ω(compressed.ParseInto([]string{"1", "desc"}, &ParseOptions{Compressed: true})).Should(Succeed())
+
+		expected, err := plain.MarshalADC()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(compressed.MarshalADC()).Should(Equal(expected))
+	})
+})
+
+var _ = Describe("ParseInto() with StrictUTF8", func() {
+	strict := &ParseOptions{StrictUTF8: true}
+
+	It("should accept valid UTF-8 values", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "d\\sé", "NIニック"}, strict)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Description).Should(Equal("d é"))
+		Ω(cnt.NI.Value).Should(Equal("ニック"))
+	})
+
+	It("should reject invalid byte sequences naming the param", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc\xff"}, strict)
+		Ω(errors.Is(err, ErrInvalidUTF8)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("param Description"))
+
+		err = cnt.ParseInto([]string{"1", "desc", "NI\xc3"}, strict)
+		Ω(errors.Is(err, ErrInvalidUTF8)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("flag NI"))
+	})
+
+	It("should reject invalid byte sequences in unknown flags", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc", "XX\xff"}, strict)
+		Ω(errors.Is(err, ErrInvalidUTF8)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("flag XX"))
+	})
+
+	It("should not check unknown flags by default", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "desc", "XX\xff"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Flags["XX"]).Should(Equal("\xff"))
+	})
+})
+
+var _ = Describe("ParseInto() with StrictFlags", func() {
+	strict := &ParseOptions{StrictFlags: true}
+
+	It("should reject repeated flags naming the flag and both values", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"SS1024", "SS2048"}, strict)
+		Ω(errors.Is(err, ErrDuplicateFlag)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("flag SS"))
+		Ω(err.Error()).Should(ContainSubstring(`"1024" and "2048"`))
+	})
+
+	It("should let the last value win by default", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"SS1024", "SS2048"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.SS.Value).Should(Equal(2048))
+	})
+
+	It("should not check unknown flags", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"XXa", "XXb"}, strict)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Flags["XX"]).Should(Equal("b"))
+	})
+})
+
+var _ = Describe("ParseInto() with MaxPositionals and MaxNamed", func() {
+	It("should reject messages exceeding MaxPositionals", func() {
+		var cnt LSTContent
+		opts := &ParseOptions{MaxPositionals: 2}
+		Ω(cnt.ParseInto([]string{"a", "b"}, opts)).Should(Succeed())
+
+		err := cnt.ParseInto([]string{"a", "b", "c"}, opts)
+		Ω(errors.Is(err, ErrTooManyParams)).Should(BeTrue())
+		Ω(cnt.Items).Should(BeEmpty())
+	})
+
+	It("should reject messages exceeding MaxNamed", func() {
+		var cnt INFContent
+		err := cnt.ParseInto([]string{"NInick", "XXa", "YYb"}, &ParseOptions{MaxNamed: 2})
+		Ω(errors.Is(err, ErrTooManyParams)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("3 named params"))
+	})
+
+	It("should apply the default limits", func() {
+		params := make([]string, DefaultMaxPositionals+1)
+		for i := range params {
+			params[i] = "item"
+		}
+
+		var cnt LSTContent
+		Ω(errors.Is(cnt.ParseInto(params, nil), ErrTooManyParams)).Should(BeTrue())
+		Ω(cnt.ParseInto(params[1:], nil)).Should(Succeed())
+	})
+
+	It("should reject lines exceeding the limits before splitting them", func() {
+		p := FrameParser{Options: &ParseOptions{MaxPositionals: 2, MaxNamed: 2}}
+		_, err := p.Parse([]byte("BLST AAAA a b c d NIe\n"))
+		Ω(errors.Is(err, ErrTooManyParams)).Should(BeTrue())
+		Ω(err.Error()).Should(ContainSubstring("5 params exceed the limit of 4"))
+
+		cnt, err := p.Parse([]byte("BLST AAAA a b\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Positional()).Should(Equal([]string{"a", "b"}))
+
+		var lst LSTContent
+		line := "LST" + strings.Repeat(" item", DefaultMaxPositionals+DefaultMaxNamed+1) + "\n"
+		Ω(errors.Is(lst.UnmarshalADC([]byte(line)), ErrTooManyParams)).Should(BeTrue())
+	})
+})
+
+var _ = Describe("ParseInto() with interleaved named params", func() {
+	It("should decode a flag preceding the last positional", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "a", "NInick", "description", "XXext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Code).Should(Equal(1))
+		Ω(cnt.Items).Should(Equal([]string{"a"}))
+		Ω(cnt.Description).Should(Equal("description"))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+
+	It("should decode flags interleaved with static positionals", func() {
+		var cnt BITContent
+		err := cnt.ParseInto([]string{"1", "XXext", "description"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Status.Fatal).Should(BeTrue())
+		Ω(cnt.Description).Should(Equal("description"))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+
+	It("should decode leading positionals matching the flag grammar as positionals", func() {
+		var cnt SIDContent
+		err := cnt.ParseInto([]string{"AAAB"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.SID.String()).Should(Equal("AAAB"))
+	})
+
+	It("should fill the static positionals before classifying tokens as named params", func() {
+		var bit BITContent
+		Ω(bit.ParseInto([]string{"1", "OK"}, nil)).Should(Succeed())
+		Ω(bit.Description).Should(Equal("OK"))
+		Ω(bit.Flags).Should(BeEmpty())
+
+		Ω(bit.ParseInto([]string{"1", "OK", "XXext"}, nil)).Should(Succeed())
+		Ω(bit.Description).Should(Equal("OK"))
+		Ω(bit.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+
+		var mix MIXContent
+		Ω(mix.ParseInto([]string{"1", "OK"}, nil)).Should(Succeed())
+		Ω(mix.Items).Should(BeEmpty())
+		Ω(mix.Description).Should(Equal("OK"))
+
+		Ω(mix.ParseInto([]string{"1", "OK", "NInick"}, nil)).Should(Succeed())
+		Ω(mix.Items).Should(BeEmpty())
+		Ω(mix.Description).Should(Equal("OK"))
+		Ω(mix.NI.Value).Should(Equal("nick"))
+	})
+
+	It("should return ErrMissingParam if positionals are missing", func() {
+		var cnt BITContent
+		err := cnt.ParseInto([]string{"1"}, nil)
+		Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+	})
+})
+
+var _ = Describe("ParseInto() with address params", func() {
+	It("should parse the addresses into netip.Addr values", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"I4127.0.0.1", "I6::1"}, nil)).Should(Succeed())
+
+		i4, ok := cnt.GetI4()
+		Ω(ok).Should(BeTrue())
+		Ω(i4).Should(Equal(netip.MustParseAddr("127.0.0.1")))
+		i6, ok := cnt.GetI6()
+		Ω(ok).Should(BeTrue())
+		Ω(i6).Should(Equal(netip.IPv6Loopback()))
+	})
+
+	It("should reject invalid addresses and zones", func() {
+		var cnt INFContent
+		for _, param := range []string{"I4127.0.0", "I6fe80::1%eth0"} {
+			err := cnt.ParseInto([]string{param}, nil)
+			Ω(errors.Is(err, encoding.ErrInvalidIP)).Should(BeTrue())
+		}
+	})
+
+	It("should format IPv4-mapped addresses as IPv4 addresses", func() {
+		cnt, err := NewINFBuilder().
+			I4(netip.MustParseAddr("::ffff:10.0.0.1")).
+			Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.ADCString()).Should(Equal("INF I410.0.0.1\n"))
+	})
+})
+
+var _ = Describe("ParseInto() with TTH params", func() {
+	const root = "LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"
+
+	It("should parse the root", func() {
+		var cnt RESContent
+		Ω(cnt.ParseInto([]string{"FNfile", "SI42", "TOtoken", "TR" + root}, nil)).Should(Succeed())
+		Ω(cnt.TR.IsSet).Should(BeTrue())
+		Ω(cnt.TR.Value.String()).Should(Equal(root))
+	})
+
+	It("should reject roots not in canonical base32 of 39 characters", func() {
+		for _, tr := range []string{"AAAB", root + "A", root[:38] + "R", strings.ToLower(root)} {
+			var cnt RESContent
+			err := cnt.ParseInto([]string{"FNfile", "SI42", "TOtoken", "TR" + tr}, nil)
+			Ω(errors.Is(err, encoding.ErrInvalidTTH)).Should(BeTrue(), tr)
+		}
+	})
+})
+
+var _ = Describe("ParseInto() with byte slice params", func() {
+	It("should decode the base32 value into the field", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"PDAEBAG"}, nil)).Should(Succeed())
+
+		pd, ok := cnt.GetPD()
+		Ω(ok).Should(BeTrue())
+		Ω(pd).Should(Equal([]byte{1, 2, 3}))
+	})
+
+	It("should reject invalid base32 values", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"PDaebag"}, nil)).ShouldNot(Succeed())
+	})
+
+	It("should encode the field", func() {
+		cnt, err := NewINFBuilder().PD([]byte{1, 2, 3}).Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.ADCString()).Should(Equal("INF PDAEBAG\n"))
+	})
+})
+
+var _ = Describe("ParseInto() with timestamp and duration params", func() {
+	It("should convert the seconds into typed fields", func() {
+		var msg MSGContent
+		Ω(msg.ParseInto([]string{"hello", "TS1234567890"}, nil)).Should(Succeed())
+		Ω(msg.TS.Value).Should(Equal(time.Date(2009, time.February, 13, 23, 31, 30, 0, time.UTC)))
+
+		var qui QUIContent
+		Ω(qui.ParseInto([]string{"AAAB", "TL-1"}, nil)).Should(Succeed())
+		Ω(qui.TL.Value).Should(Equal(-time.Second))
+	})
+
+	It("should reject values which are not integral numbers of seconds", func() {
+		var msg MSGContent
+		err := msg.ParseInto([]string{"hello", "TS1.5"}, nil)
+		Ω(errors.Is(err, encoding.ErrInvalidTimestamp)).Should(BeTrue())
+
+		var qui QUIContent
+		err = qui.ParseInto([]string{"AAAB", "TL99999999999"}, nil)
+		Ω(errors.Is(err, encoding.ErrInvalidDuration)).Should(BeTrue())
+	})
+
+	It("should encode the fields as seconds", func() {
+		msg, err := NewMSGBuilder().
+			Text("hello").
+			TS(time.Unix(1234567890, 500)).
+			Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(msg.ADCString()).Should(Equal("MSG hello TS1234567890\n"))
+
+		sid, err := encoding.ParseBase32Value("AAAB")
+		Ω(err).ShouldNot(HaveOccurred())
+		qui, err := NewQUIBuilder().SID(sid).TL(90 * time.Second).Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(qui.ADCString()).Should(Equal("QUI AAAB TL90\n"))
+	})
+})
+
+var _ = Describe("ParseInto() with feature list params", func() {
+	It("should parse the list into a set of features", func() {
+		var cnt INFContent
+		Ω(cnt.ParseInto([]string{"SUADC0,TCP4,XYZ1"}, nil)).Should(Succeed())
+
+		su, ok := cnt.GetSU()
+		Ω(ok).Should(BeTrue())
+		Ω(su).Should(Equal(encoding.Features{encoding.FeatureADC0, encoding.FeatureTCP4, "XYZ1"}))
+		Ω(su.Has(encoding.FeatureTCP4)).Should(BeTrue())
+		Ω(su.Has(encoding.FeatureUDP4)).Should(BeFalse())
+	})
+
+	It("should reject names which are not four upper case letters or digits", func() {
+		for _, value := range []string{"SUTCP", "SUTCP4,", "SUtcp4", "SUTCP4;UDP4"} {
+			var cnt INFContent
+			err := cnt.ParseInto([]string{value}, nil)
+			Ω(errors.Is(err, encoding.ErrInvalidFeatures)).Should(BeTrue(), value)
+		}
+	})
+
+	It("should encode the set in the order of the features", func() {
+		features := encoding.Features{encoding.FeatureTCP4}
+		features.Add(encoding.FeatureSEGA)
+		features.Add(encoding.FeatureTCP4)
+		features.Add(encoding.FeatureUDP4)
+		features.Remove(encoding.FeatureSEGA)
+
+		cnt, err := NewINFBuilder().U4(412).SU(features).Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.ADCString()).Should(Equal("INF U4412 SUTCP4,UDP4\n"))
+	})
+})
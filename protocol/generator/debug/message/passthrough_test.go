@@ -0,0 +1,84 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Raw passthrough", func() {
+	// line is not in the canonical form, as the flags are not ordered by
+	// name.
+	line := []byte("MIX 7 a c desc YYext NInick XXext\n")
+
+	var cnt MIXContent
+
+	BeforeEach(func() {
+		Ω(cnt.UnmarshalADC(line)).Should(Succeed())
+	})
+
+	It("should marshal unmodified contents byte for byte", func() {
+		Ω(cnt.MarshalADC()).Should(Equal(line))
+		Ω(cnt.ADCString()).Should(Equal(string(line)))
+		Ω(cnt.WireSize()).Should(Equal(len(line)))
+	})
+
+	It("should terminate the raw line by a newline", func() {
+		Ω(cnt.UnmarshalADC([]byte("MIX 7 desc YYext XXext"))).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 7 desc YYext XXext\n")))
+	})
+
+	It("should regenerate the line from the params after SetNamedAll", func() {
+		Ω(cnt.SetNamedAll(map[string]string{"NI": "other"})).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 7 a c desc NIother\n")))
+		Ω(cnt.WireSize()).Should(Equal(len("MIX 7 a c desc NIother\n")))
+	})
+
+	It("should regenerate the line from the params after MarkDirty", func() {
+		delete(cnt.Flags, "YY")
+		cnt.MarkDirty()
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 7 a c desc NInick XXext\n")))
+	})
+
+	It("should keep the raw line after direct writes until MarkDirty", func() {
+		cnt.Code = 8
+		Ω(cnt.MarshalADC()).Should(Equal(line))
+		cnt.MarkDirty()
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 a c desc NInick XXext YYext\n")))
+	})
+
+	It("should regenerate the line from the params after Decode", func() {
+		Ω(cnt.Decode([]string{"8", "desc"}, map[string]string{"NI": "other"})).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 desc NIother\n")))
+	})
+
+	It("should regenerate the line from the params after FromMap", func() {
+		values := cnt.ToMap()
+		values["Code"] = "8"
+		Ω(cnt.FromMap(values)).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 a c desc NInick XXext YYext\n")))
+	})
+
+	It("should regenerate the line from the params after ParseTokens", func() {
+		Ω(cnt.ParseTokens([]string{"MIX", "8", "desc"})).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 desc\n")))
+	})
+
+	It("should not keep a raw line in built contents", func() {
+		built, err := NewMIXBuilder().Code(8).Description("desc").Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(built.MarshalADC()).Should(Equal([]byte("MIX 8 desc\n")))
+	})
+
+	It("should not pass the raw line through ParseInto", func() {
+		Ω(cnt.ParseInto([]string{"8", "desc"}, nil)).Should(Succeed())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 desc\n")))
+	})
+
+	It("should not keep the raw line in redacted copies", func() {
+		var res RESContent
+		Ω(res.UnmarshalADC([]byte("RES FNfile SI1 TOsecret\n"))).Should(Succeed())
+		Ω(res.Redacted().MarshalADC()).ShouldNot(ContainSubstring("secret"))
+	})
+})
@@ -0,0 +1,119 @@
+package message_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("AppendPositional()", func() {
+	var mix MIXContent
+
+	BeforeEach(func() {
+		Ω(mix.ParseInto([]string{"1", "a", "b", "description"}, nil)).Should(Succeed())
+	})
+
+	It("should append the positionals to dst", func() {
+		dst := []string{"prefix"}
+		Ω(mix.AppendPositional(dst)).Should(Equal([]string{"prefix", "1", "a", "b", "description"}))
+	})
+
+	It("should reuse the buffer of dst", func() {
+		buf := make([]string, 0, 8)
+		positionals := mix.AppendPositional(buf)
+		Ω(&positionals[0]).Should(BeIdenticalTo(&buf[:1][0]))
+	})
+
+	It("should return the same positionals as Positional", func() {
+		var lst LSTContent
+		Ω(lst.ParseInto([]string{"a", "b"}, nil)).Should(Succeed())
+		Ω(lst.AppendPositional(nil)).Should(Equal(lst.Positional()))
+
+		var res RESContent
+		Ω(res.AppendPositional(nil)).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("PositionalValues()", func() {
+	It("should return the values of fixed layouts as a tuple", func() {
+		var mrk MRKContent
+		Ω(mrk.ParseInto([]string{"7", "V2", "desc\\sription"}, nil)).Should(Succeed())
+
+		code, description := mrk.PositionalValues()
+		Ω(code).Should(Equal(7))
+		Ω(description).Should(Equal("desc ription"))
+	})
+})
+
+func BenchmarkPositional(b *testing.B) {
+	var mix MIXContent
+	err := mix.ParseInto([]string{"1", "a", "b", "description"}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = mix.Positional()
+	}
+}
+
+func BenchmarkAppendPositional(b *testing.B) {
+	var mix MIXContent
+	err := mix.ParseInto([]string{"1", "a", "b", "description"}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var buf []string
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf = mix.AppendPositional(buf[:0])
+	}
+}
+
+// BenchmarkAppendStaticValues compares the approaches of AppendPositional to
+// append the values of a multi-valued param of static multiplicity: passing
+// each element as an argument and appending a slice of the str field.
+func BenchmarkAppendStaticValues(b *testing.B) {
+	values := make([]string, 16)
+
+	b.Run("Elements4", func(b *testing.B) {
+		var buf []string
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], values[0], values[1], values[2], values[3])
+		}
+	})
+
+	b.Run("Slice4", func(b *testing.B) {
+		var buf []string
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], values[:4]...)
+		}
+	})
+
+	b.Run("Elements16", func(b *testing.B) {
+		var buf []string
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], values[0], values[1], values[2], values[3],
+				values[4], values[5], values[6], values[7],
+				values[8], values[9], values[10], values[11],
+				values[12], values[13], values[14], values[15])
+		}
+	})
+
+	b.Run("Slice16", func(b *testing.B) {
+		var buf []string
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], values[:16]...)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("RawContent", func() {
+	var (
+		cnt      ParamAccessor
+		typedCnt MIXContent
+	)
+
+	BeforeEach(func() {
+		params := []string{"1", "a", "b", "desc", "NInick"}
+		err := typedCnt.ParseInto(params, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		cnt = &RawContent{
+			PositionalParams: []string{"1", "a", "b", "desc"},
+			NamedParams:      map[string]string{"NI": "nick"},
+		}
+	})
+
+	It("should expose the positional params", func() {
+		Ω(cnt.Positional()).Should(Equal(typedCnt.Positional()))
+		Ω(cnt.PosLen()).Should(Equal(typedCnt.PosLen()))
+		for i := 0; i < cnt.PosLen(); i++ {
+			Ω(cnt.PosAt(i)).Should(Equal(typedCnt.PosAt(i)))
+		}
+	})
+
+	It("should expose the named params", func() {
+		Ω(cnt.Named()).Should(Equal(map[string]string{"NI": "nick"}))
+
+		val, ok := cnt.NamedGet("NI")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("nick"))
+
+		_, ok = cnt.NamedGet("SV")
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should return a copy of the named params", func() {
+		named := cnt.Named()
+		named["NI"] = "other"
+		named["SV"] = "2"
+
+		Ω(cnt.Named()).Should(Equal(map[string]string{"NI": "nick"}))
+		Ω(typedCnt.Named()).Should(Equal(map[string]string{"NI": "nick"}))
+	})
+
+	It("should panic if PosAt() is called with an index out of range", func() {
+		Ω(func() { cnt.PosAt(cnt.PosLen()) }).Should(Panic())
+	})
+})
+
+var _ = Describe("FromAccessor()", func() {
+	It("should convert a RawContent into the typed content and back", func() {
+		raw := &RawContent{
+			PositionalParams: []string{"1", "a", "b", "desc\\sription"},
+			NamedParams:      map[string]string{"NI": "nick", "SV": "2", "XX": "ext"},
+		}
+
+		cnt, err := MIXContentFromAccessor(raw)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.Code).Should(Equal(1))
+		Ω(cnt.Items).Should(Equal([]string{"a", "b"}))
+		Ω(cnt.Description).Should(Equal("desc ription"))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.SV.Value).Should(Equal(2))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+
+		Ω(cnt.Positional()).Should(Equal(raw.Positional()))
+		Ω(cnt.Named()).Should(Equal(raw.Named()))
+	})
+
+	It("should reject accessors not matching the layout of the message", func() {
+		raw := &RawContent{PositionalParams: []string{"1"}}
+
+		_, err := MIXContentFromAccessor(raw)
+		Ω(err).Should(MatchError(ContainSubstring(ErrMissingParam.Error())))
+	})
+})
@@ -0,0 +1,68 @@
+package message_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/seoester/adcl/protocol/encoding"
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Redacted()", func() {
+	It("should mask sensitive params while preserving others", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile", "SI42", "TOsecret", "TRLWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ", "XXext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		redacted := cnt.Redacted()
+		Ω(redacted.FN).Should(Equal("file"))
+		Ω(redacted.SI).Should(Equal(42))
+		Ω(redacted.TO).Should(Equal("***"))
+		Ω(redacted.TR.IsSet).Should(BeTrue())
+		Ω(redacted.TR.Value).Should(BeZero())
+		Ω(redacted.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+
+		Ω(redacted.MarshalADC()).Should(Equal([]byte("RES FNfile SI42 TO*** TR" + strings.Repeat("A", 39) + " XXext\n")))
+
+		Ω(cnt.TO).Should(Equal("secret"))
+		Ω(cnt.TR.Value.String()).Should(Equal("LWPNACQDBZRYXW3VHJVCJ64QBZNGHOHHHZWCLNQ"))
+	})
+
+	It("should not mask absent sensitive params", func() {
+		var cnt RESContent
+		err := cnt.ParseInto([]string{"FNfile", "SI42", "TOsecret"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		redacted := cnt.Redacted()
+		Ω(redacted.TR.IsSet).Should(BeFalse())
+		Ω(redacted.MarshalADC()).Should(Equal([]byte("RES FNfile SI42 TO***\n")))
+	})
+
+	It("should not share memory with the content", func() {
+		var cnt MIXContent
+		err := cnt.ParseInto([]string{"1", "a", "b", "desc", "XXext"}, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		redacted := cnt.Redacted()
+		redacted.Items[0] = "changed"
+		redacted.Flags["XX"] = "changed"
+
+		Ω(cnt.Items).Should(Equal([]string{"a", "b"}))
+		Ω(cnt.Positional()).Should(Equal([]string{"1", "a", "b", "desc"}))
+		Ω(cnt.Flags).Should(Equal(map[string]string{"XX": "ext"}))
+	})
+
+	It("should copy the values of slice types", func() {
+		var cnt INFContent
+		Ω(cnt.UnmarshalADC([]byte("INF PDAEBAG SUTCP4\n"))).Should(Succeed())
+
+		redacted := cnt.Redacted()
+		redacted.PD.Value[0] = 0xff
+		redacted.SU.Value[0] = encoding.FeatureUDP4
+
+		Ω(cnt.PD.Value).Should(Equal([]byte{1, 2, 3}))
+		Ω(cnt.SU.Value).Should(Equal(encoding.Features{encoding.FeatureTCP4}))
+	})
+})
@@ -0,0 +1,59 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("NewContent()", func() {
+	It("should return an empty content of the content type of the command", func() {
+		cnt, err := NewContent("RES")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&RESContent{}))
+		Ω(cnt.Command()).Should(Equal("RES"))
+
+		Ω(cnt.ParseInto([]string{"FNfile", "SI1", "TOtoken"}, nil)).Should(Succeed())
+		Ω(cnt.(*RESContent).FN).Should(Equal("file"))
+	})
+
+	It("should set the command of families", func() {
+		cnt, err := NewContent("EXA")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&EXFContent{}))
+		Ω(cnt.Command()).Should(Equal("EXA"))
+	})
+
+	It("should return ErrUnknownCommand for unregistered commands", func() {
+		_, err := NewContent("ZZZ")
+		Ω(err).Should(Equal(ErrUnknownCommand))
+	})
+})
+
+var _ = Describe("NewContentForType()", func() {
+	It("should return contents valid for the message type", func() {
+		cnt, err := NewContentForType(TypeBroadcast, "MIX")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt).Should(BeAssignableToTypeOf(&MIXContent{}))
+
+		_, err = NewContentForType(TypeInfomessage, "RES")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should return ErrUnexpectedType for message types not valid for the command", func() {
+		_, err := NewContentForType(TypeClientmessage, "MIX")
+		Ω(err).Should(Equal(ErrUnexpectedType))
+	})
+})
+
+var _ = Describe("Commands()", func() {
+	It("should list the commands and command patterns in sorted order", func() {
+		commands := Commands()
+		Ω(commands).Should(ContainElement("INF"))
+		Ω(commands).Should(ContainElement("EX?"))
+		for i := 1; i < len(commands); i++ {
+			Ω(commands[i-1] < commands[i]).Should(BeTrue())
+		}
+	})
+})
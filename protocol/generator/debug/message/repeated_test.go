@@ -0,0 +1,77 @@
+package message_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("repeated named params", func() {
+	var cnt SCHContent
+
+	BeforeEach(func() {
+		cnt = SCHContent{}
+		Ω(cnt.ParseInto([]string{"ANsome", "ANfile", "NOold", "TOtoken"}, nil)).Should(Succeed())
+	})
+
+	It("should decode all values", func() {
+		Ω(cnt.AN).Should(Equal([]string{"some", "file"}))
+		Ω(cnt.NO).Should(Equal([]string{"old"}))
+		Ω(cnt.EX).Should(BeEmpty())
+	})
+
+	It("should return all values by NamedAll", func() {
+		Ω(cnt.NamedAll("AN")).Should(Equal([]string{"some", "file"}))
+		Ω(cnt.NamedAll("NO")).Should(Equal([]string{"old"}))
+		Ω(cnt.NamedAll("EX")).Should(BeNil())
+		Ω(cnt.NamedAll("TO")).Should(Equal([]string{"token"}))
+		Ω(cnt.NamedAll("ZZ")).Should(BeNil())
+	})
+
+	It("should return the first value by Named and NamedGet", func() {
+		val, ok := cnt.NamedGet("AN")
+		Ω(ok).Should(BeTrue())
+		Ω(val).Should(Equal("some"))
+		Ω(cnt.Named()).Should(HaveKeyWithValue("AN", "some"))
+	})
+
+	It("should encode all values", func() {
+		buf, err := cnt.MarshalADC()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(buf)).Should(Equal("SCH ANsome ANfile NOold TOtoken\n"))
+	})
+
+	It("should compare all values", func() {
+		var other SCHContent
+		Ω(other.ParseInto([]string{"ANsome", "ANpath", "NOold", "TOtoken"}, nil)).Should(Succeed())
+		Ω(cnt.Equal(&other)).Should(BeFalse())
+
+		Ω(other.ParseInto([]string{"ANsome", "ANfile", "NOold", "TOtoken"}, nil)).Should(Succeed())
+		Ω(cnt.Equal(&other)).Should(BeTrue())
+	})
+
+	It("should keep all values in raw contents", func() {
+		raw := &RawContent{
+			NamedParams:    map[string]string{"AN": "some", "TO": "token"},
+			RepeatedParams: map[string][]string{"AN": {"some", "file"}},
+		}
+		Ω(raw.NamedAll("AN")).Should(Equal([]string{"some", "file"}))
+		Ω(raw.NamedAll("TO")).Should(Equal([]string{"token"}))
+
+		sch, err := SCHContentFromAccessor(raw)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(sch.AN).Should(Equal([]string{"some", "file"}))
+	})
+
+	It("should collect repeated flags of unknown commands", func() {
+		p := FrameParser{RawUnknown: true}
+		pa, err := p.Parse([]byte("HZZZ ANsome ANfile ANdir TOtoken\n"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		raw := pa.(*RawContent)
+		Ω(raw.NamedParams).Should(HaveKeyWithValue("AN", "some"))
+		Ω(raw.NamedAll("AN")).Should(Equal([]string{"some", "file", "dir"}))
+		Ω(raw.NamedAll("TO")).Should(Equal([]string{"token"}))
+	})
+})
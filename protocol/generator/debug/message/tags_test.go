@@ -0,0 +1,53 @@
+package message_test
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+// fieldByTag returns the name of the field of the struct type typ whose tag
+// key has the value val.
+func fieldByTag(typ reflect.Type, key, val string) (string, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Tag.Get(key) == val {
+			return typ.Field(i).Name, true
+		}
+	}
+
+	return "", false
+}
+
+var _ = Describe("struct tags", func() {
+	It("should map wire names back to fields", func() {
+		name, ok := fieldByTag(reflect.TypeOf(INFContent{}), "adc", "NI")
+		Ω(ok).Should(BeTrue())
+		Ω(name).Should(Equal("NI"))
+
+		name, ok = fieldByTag(reflect.TypeOf(MIXContent{}), "adc", "Items,positional")
+		Ω(ok).Should(BeTrue())
+		Ω(name).Should(Equal("Items"))
+	})
+
+	It("should name fields in JSON", func() {
+		name, ok := fieldByTag(reflect.TypeOf(INFContent{}), "json", "nick,omitempty")
+		Ω(ok).Should(BeTrue())
+		Ω(name).Should(Equal("NI"))
+
+		name, ok = fieldByTag(reflect.TypeOf(MIXContent{}), "json", "code")
+		Ω(ok).Should(BeTrue())
+		Ω(name).Should(Equal("Code"))
+	})
+
+	It("should not tag unexported fields", func() {
+		typ := reflect.TypeOf(MIXContent{})
+		for i := 0; i < typ.NumField(); i++ {
+			if field := typ.Field(i); len(field.PkgPath) > 0 {
+				Ω(field.Tag).Should(BeEmpty())
+			}
+		}
+	})
+})
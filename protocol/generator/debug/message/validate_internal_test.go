@@ -0,0 +1,19 @@
+package message
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("str fields", func() {
+	It("should not affect the values encoded from the fields", func() {
+		var cnt MIXContent
+		Ω(cnt.ParseInto([]string{"1", "description"}, nil)).Should(Succeed())
+		cnt.descriptionStr = "raw description"
+		cnt.MarkDirty()
+
+		Ω(cnt.Validate()).Should(Succeed())
+		Ω(cnt.PosAt(1)).Should(Equal("description"))
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 1 description\n")))
+	})
+})
@@ -0,0 +1,156 @@
+package message_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/message"
+)
+
+var _ = Describe("Validate()", func() {
+	Describe("Allowed values", func() {
+		It("should accept an allowed value", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "description", "SV2"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(Succeed())
+		})
+
+		It("should accept an unset param", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "description"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(Succeed())
+		})
+
+		It("should reject a value not allowed", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "description", "SV5"}, nil)).Should(Succeed())
+
+			err := cnt.Validate()
+			Ω(err).Should(MatchError(ContainSubstring(ErrValueNotAllowed.Error())))
+			Ω(err).Should(MatchError(ContainSubstring("param SV")))
+			Ω(err).Should(MatchError(ContainSubstring("0, 1, 2")))
+		})
+
+		It("should include the custom validation message of the param", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "description", "PRADC/2.0"}, nil)).Should(Succeed())
+
+			err := cnt.Validate()
+			Ω(err).Should(MatchError(ContainSubstring(ErrValueNotAllowed.Error())))
+			Ω(err).Should(MatchError(ContainSubstring("PR must name a supported protocol revision")))
+			Ω(err).ShouldNot(MatchError(ContainSubstring("must be one of")))
+		})
+	})
+
+	Describe("Escaping", func() {
+		It("should accept escaped values", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "a\\sb", "XXc\\nd"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(Succeed())
+		})
+
+		It("should reject flags with unescaped separators", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "description"}, nil)).Should(Succeed())
+			cnt.Flags = map[string]string{"XX": "raw value"}
+
+			err := cnt.Validate()
+			Ω(err).Should(MatchError(ContainSubstring(ErrUnescapedSeparator.Error())))
+			Ω(err).Should(MatchError(ContainSubstring("flag XX")))
+		})
+	})
+
+	Describe("Ranges", func() {
+		It("should accept values within the range", func() {
+			var cnt RESContent
+			Ω(cnt.ParseInto([]string{"FNfile", "SI0", "SL1024", "TOtoken"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(Succeed())
+		})
+
+		It("should reject values out of range", func() {
+			var cnt RESContent
+			Ω(cnt.ParseInto([]string{"FNfile", "SI-1", "TOtoken"}, nil)).Should(Succeed())
+
+			err := cnt.Validate()
+			Ω(errors.Is(err, ErrOutOfRange)).Should(BeTrue())
+			Ω(err).Should(MatchError(ContainSubstring("param SI")))
+			Ω(err).Should(MatchError(ContainSubstring("must be at least 0")))
+
+			Ω(cnt.ParseInto([]string{"FNfile", "SI1", "SL1025", "TOtoken"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(MatchError(ContainSubstring("must be between 0 and 1024")))
+		})
+	})
+
+	Describe("Base32 lengths", func() {
+		It("should reject encoded values of another length", func() {
+			var cnt SIDContent
+			Ω(cnt.ParseInto([]string{"AAAB"}, nil)).Should(Succeed())
+			Ω(cnt.Validate()).Should(Succeed())
+
+			Ω(cnt.ParseInto([]string{"AAAAAAAB"}, nil)).Should(Succeed())
+			err := cnt.Validate()
+			Ω(errors.Is(err, ErrInvalidLength)).Should(BeTrue())
+			Ω(err).Should(MatchError(ContainSubstring("must be 4 characters long")))
+		})
+	})
+
+	Describe("Multiple violations", func() {
+		It("should list all violations", func() {
+			var cnt RESContent
+			Ω(cnt.SetNamedAll(map[string]string{"SI": "-1", "SL": "2000"})).Should(Succeed())
+
+			err := cnt.Validate()
+			var validationErr *ValidationError
+			Ω(errors.As(err, &validationErr)).Should(BeTrue())
+			Ω(validationErr.Errs).Should(HaveLen(4))
+			Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+			Ω(errors.Is(err, ErrOutOfRange)).Should(BeTrue())
+			Ω(err).Should(MatchError(ContainSubstring("param FN")))
+			Ω(err).Should(MatchError(ContainSubstring("param TO")))
+		})
+
+		It("should report missing required params", func() {
+			var cnt MIXContent
+			err := cnt.Validate()
+			Ω(errors.Is(err, ErrMissingParam)).Should(BeTrue())
+			Ω(err).Should(MatchError(ContainSubstring("param Description")))
+		})
+	})
+
+	Describe("Multi-valued params", func() {
+		It("should encode values appended to the field", func() {
+			var cnt MIXContent
+			Ω(cnt.ParseInto([]string{"1", "a", "b", "description"}, nil)).Should(Succeed())
+			cnt.Items = append(cnt.Items, "c d")
+			cnt.MarkDirty()
+
+			Ω(cnt.Validate()).Should(Succeed())
+			Ω(cnt.PosLen()).Should(Equal(5))
+			Ω(cnt.PosAt(3)).Should(Equal("c\\sd"))
+			Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 1 a b c\\sd description\n")))
+		})
+	})
+})
+
+var _ = Describe("MissingRequired()", func() {
+	It("should list all unset required params", func() {
+		var cnt MIXContent
+		Ω(cnt.MissingRequired()).Should(Equal([]string{"Description"}))
+
+		// Numbers are never missing, zero is a valid value.
+		var res RESContent
+		Ω(res.MissingRequired()).Should(Equal([]string{"FN", "TO"}))
+	})
+
+	It("should not list optional params", func() {
+		var cnt RESContent
+		Ω(cnt.ParseInto([]string{"FNfile", "SI42", "TOtoken"}, nil)).Should(Succeed())
+		Ω(cnt.MissingRequired()).Should(BeEmpty())
+
+		var gtd GTDContent
+		Ω(gtd.ParseInto([]string{"1", "desc"}, nil)).Should(Succeed())
+		Ω(gtd.MissingRequired()).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,87 @@
+//go:build noreflect
+
+package noreflect
+
+import "strconv"
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+// Severity is the severity of a status.
+type Severity int
+
+const (
+	SeveritySuccess     Severity = 0
+	SeverityRecoverable Severity = 1
+	SeverityFatal       Severity = 2
+)
+
+// String returns the name of the constant matching e, or the value wrapped
+// in the enum name if e is not known.
+func (e Severity) String() string {
+	switch e {
+	case SeveritySuccess:
+		return "SeveritySuccess"
+	case SeverityRecoverable:
+		return "SeverityRecoverable"
+	case SeverityFatal:
+		return "SeverityFatal"
+	}
+	return "Severity(" + strconv.Itoa(int(e)) + ")"
+}
+
+// IsKnown reports whether e is one of the constants of the enum.
+func (e Severity) IsKnown() bool {
+	switch e {
+	case SeveritySuccess, SeverityRecoverable, SeverityFatal:
+		return true
+	}
+	return false
+}
+
+func init() {
+	registerContent("SID", func() content {
+		return &SIDContent{}
+	})
+	registerContent("RES", func() content {
+		return &RESContent{}
+	})
+	registerContent("LST", func() content {
+		return &LSTContent{}
+	})
+	registerContent("MIX", func() content {
+		return &MIXContent{}
+	})
+	registerContent("BIT", func() content {
+		return &BITContent{}
+	})
+	registerContent("GTD", func() content {
+		return &GTDContent{}
+	})
+	registerContent("MRK", func() content {
+		return &MRKContent{}
+	})
+	registerContent("INF", func() content {
+		return &INFContent{}
+	})
+	registerContent("EX?", func() content {
+		return &EXFContent{}
+	})
+	registerContent("STA", func() content {
+		return &STAContent{}
+	})
+	registerContent("QUI", func() content {
+		return &QUIContent{}
+	})
+	registerContent("MSG", func() content {
+		return &MSGContent{}
+	})
+	registerContent("PAS", func() content {
+		return &PASContent{}
+	})
+	registerContent("DLD", func() content {
+		return &DLDContent{}
+	})
+	registerContent("SCH", func() content {
+		return &SCHContent{}
+	})
+}
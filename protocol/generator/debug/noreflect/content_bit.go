@@ -0,0 +1,576 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type BITFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f BITFlag) String() string {
+	return "BITFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f BITFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &BITContent{}
+var _ ADCMarshaler = &BITContent{}
+var _ ADCUnmarshaler = &BITContent{}
+var _ io.WriterTo = &BITContent{}
+
+// BITContent is the content of BIT messages.
+type BITContent struct {
+	Status struct {
+		Fatal       bool
+		Recoverable bool
+		Permanent   bool
+	}
+	statusStr string
+
+	Description    string
+	descriptionStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (b *BITContent) Positional() []string {
+	return b.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (b *BITContent) AppendPositional(dst []string) []string {
+	return append(dst, strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)), escapeValue(b.Description))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (b *BITContent) PosLen() int {
+	return 2
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (b *BITContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent))
+	case 1:
+		return escapeValue(b.Description)
+	default:
+		panic(fmt.Sprintf("BIT.PosAt: index %d out of range [0,%d)", i, b.PosLen()))
+	}
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (b *BITContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Status":
+		return strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)), true
+	case "Description":
+		return escapeValue(b.Description), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (b *BITContent) PositionalValues() (struct {
+	Fatal       bool
+	Recoverable bool
+	Permanent   bool
+}, string) {
+	return b.Status, b.Description
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (b *BITContent) ParseInto(params []string, opts *ParseOptions) error {
+	*b = BITContent{}
+	b.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message BIT: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 2 {
+		return fmt.Errorf("parsing message BIT: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case isNamedParam(param) && (pos+plain >= 2):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				b.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message BIT: %w", param[:2], err)
+			}
+			if b.Flags == nil {
+				b.Flags = make(map[string]string)
+			}
+			b.Flags[param[:2]] = param[2:]
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				b.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Status of message BIT: %w", err)
+			}
+			b.statusStr = param
+			val, err := strconv.Atoi(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Status of message BIT: %w", err)
+			}
+			err = checkBits(val, 3)
+			if err != nil {
+				return fmt.Errorf("parsing param Status of message BIT: %w", err)
+			}
+			b.Status.Fatal = val&1 != 0
+			b.Status.Recoverable = val&2 != 0
+			b.Status.Permanent = val&4 != 0
+		case pos == 1:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				b.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message BIT: %w", err)
+			}
+			b.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message BIT: %w", err)
+			}
+			b.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message BIT: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 2 {
+		return fmt.Errorf("parsing message BIT: %w", ErrMissingParam)
+	}
+
+	if b.Description == "" {
+		return fmt.Errorf("parsing param Description of message BIT: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// BITContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func BITContentFromAccessor(pa ParamAccessor) (*BITContent, error) {
+	var b BITContent
+	if err := b.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (b *BITContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message BIT: %w", err)
+	}
+
+	return b.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (b *BITContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "BIT" {
+		return fmt.Errorf("parsing message BIT: %w", ErrCommandMismatch)
+	}
+
+	return b.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (b *BITContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message BIT: %w", err)
+	}
+	return b.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (b *BITContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, b.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (b *BITContent) SetNamedAll(named map[string]string) error {
+	decoded := *b
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*b = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (b *BITContent) setNamedAll(named map[string]string) error {
+	b.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message BIT: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if b.Flags == nil {
+			b.Flags = make(map[string]string)
+		}
+		b.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (b *BITContent) ToMap() map[string]string {
+	values := b.UnknownFlags()
+	values["Status"] = strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent))
+	values["Description"] = escapeValue(b.Description)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (b *BITContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Status"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Status of message BIT: %w", ErrMissingParam)
+	}
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message BIT: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "Status", "Description":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message BIT: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return b.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (b *BITContent) AppendADC(buf []byte) ([]byte, error) {
+	n := len(buf)
+	buf = append(buf, "BIT"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)), 10)
+	if b.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message BIT: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, b.Description)
+	buf = appendFlags(buf, b.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (b *BITContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(b.WireSize())
+	builder.WriteString("BIT")
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)))
+	if b.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(b.Description))
+	writeFlags(&builder, b.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (b *BITContent) Validate() error {
+	var errs []error
+	for _, name := range b.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message BIT: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(b.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message BIT: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (b *BITContent) MissingRequired() []string {
+	var missing []string
+	if b.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var bitDescriptor = MessageDescriptor{
+	Command: "BIT",
+	Positional: []ParamDescriptor{{
+		DisplayName: "Status",
+		Name:        "Status",
+		Required:    true,
+		Type:        "int",
+	}, {
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (b *BITContent) Descriptor() MessageDescriptor {
+	return bitDescriptor
+}
+
+// Command returns the command of the content, i.e. BIT.
+func (b *BITContent) Command() string {
+	return "BIT"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (b *BITContent) MsgType() byte {
+	return b.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (b *BITContent) MarshalADC() ([]byte, error) {
+	return b.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (b *BITContent) WireSize() int {
+	n := len("BIT")
+
+	n += 1 + len(strconv.Itoa(bitmask(b.Status.Fatal, b.Status.Recoverable, b.Status.Permanent)))
+	n += 1 + len(escapeValue(b.Description))
+	n += flagsSize(b.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (b *BITContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := b.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (b *BITContent) Equal(other *BITContent) bool {
+	return equalParams(b, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (b *BITContent) EqualIgnoring(other *BITContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Status") && b.Status != other.Status {
+		return false
+	}
+	if !isIgnored(ignore, "Description") && b.Description != other.Description {
+		return false
+	}
+
+	return equalFlagsIgnoring(b.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (b *BITContent) HashKey() string {
+	return hashKey(b)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (b *BITContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(b, line, mode, func(params []string) (ParamAccessor, error) {
+		var other BITContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (b *BITContent) Redacted() *BITContent {
+	redacted := *b
+	if b.Flags != nil {
+		redacted.Flags = b.UnknownFlags()
+	}
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (b *BITContent) SetOptionalCount() int {
+	return 0
+}
+
+// BITBuilder builds BITContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type BITBuilder struct {
+	content BITContent
+	err     error
+}
+
+// NewBITBuilder returns a builder of empty contents.
+func NewBITBuilder() *BITBuilder {
+	return &BITBuilder{}
+}
+
+// Status sets the Status param.
+func (b *BITBuilder) Status(value struct {
+	Fatal       bool
+	Recoverable bool
+	Permanent   bool
+}) *BITBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(bitmask(value.Fatal, value.Recoverable, value.Permanent))
+	b.content.Status = value
+	b.content.statusStr = str
+	return b
+}
+
+// Description sets the Description param.
+func (b *BITBuilder) Description(value string) *BITBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message BIT: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *BITBuilder) Build() (*BITContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
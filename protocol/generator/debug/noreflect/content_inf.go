@@ -0,0 +1,2473 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	netip "net/netip"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type INFFlag string
+
+const (
+	INFFlagID INFFlag = "ID"
+	INFFlagPD INFFlag = "PD"
+	INFFlagI4 INFFlag = "I4"
+	INFFlagI6 INFFlag = "I6"
+	INFFlagU4 INFFlag = "U4"
+	INFFlagU6 INFFlag = "U6"
+	INFFlagSS INFFlag = "SS"
+	INFFlagSF INFFlag = "SF"
+	INFFlagVE INFFlag = "VE"
+	INFFlagUS INFFlag = "US"
+	INFFlagDS INFFlag = "DS"
+	INFFlagSL INFFlag = "SL"
+	INFFlagAS INFFlag = "AS"
+	INFFlagAM INFFlag = "AM"
+	INFFlagEM INFFlag = "EM"
+	INFFlagNI INFFlag = "NI"
+	INFFlagDE INFFlag = "DE"
+	INFFlagHN INFFlag = "HN"
+	INFFlagHR INFFlag = "HR"
+	INFFlagHO INFFlag = "HO"
+	INFFlagTO INFFlag = "TO"
+	INFFlagCT INFFlag = "CT"
+	INFFlagAW INFFlag = "AW"
+	// Deprecated: superseded by CT
+	INFFlagOP INFFlag = "OP"
+	INFFlagSU INFFlag = "SU"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f INFFlag) String() string {
+	switch f {
+	case INFFlagID:
+		return "INFFlagID"
+	case INFFlagPD:
+		return "INFFlagPD"
+	case INFFlagI4:
+		return "INFFlagI4"
+	case INFFlagI6:
+		return "INFFlagI6"
+	case INFFlagU4:
+		return "INFFlagU4"
+	case INFFlagU6:
+		return "INFFlagU6"
+	case INFFlagSS:
+		return "INFFlagSS"
+	case INFFlagSF:
+		return "INFFlagSF"
+	case INFFlagVE:
+		return "INFFlagVE"
+	case INFFlagUS:
+		return "INFFlagUS"
+	case INFFlagDS:
+		return "INFFlagDS"
+	case INFFlagSL:
+		return "INFFlagSL"
+	case INFFlagAS:
+		return "INFFlagAS"
+	case INFFlagAM:
+		return "INFFlagAM"
+	case INFFlagEM:
+		return "INFFlagEM"
+	case INFFlagNI:
+		return "INFFlagNI"
+	case INFFlagDE:
+		return "INFFlagDE"
+	case INFFlagHN:
+		return "INFFlagHN"
+	case INFFlagHR:
+		return "INFFlagHR"
+	case INFFlagHO:
+		return "INFFlagHO"
+	case INFFlagTO:
+		return "INFFlagTO"
+	case INFFlagCT:
+		return "INFFlagCT"
+	case INFFlagAW:
+		return "INFFlagAW"
+	case INFFlagOP:
+		return "INFFlagOP"
+	case INFFlagSU:
+		return "INFFlagSU"
+	}
+	return "INFFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f INFFlag) IsKnown() bool {
+	switch f {
+	case INFFlagID, INFFlagPD, INFFlagI4, INFFlagI6, INFFlagU4, INFFlagU6, INFFlagSS, INFFlagSF, INFFlagVE, INFFlagUS, INFFlagDS, INFFlagSL, INFFlagAS, INFFlagAM, INFFlagEM, INFFlagNI, INFFlagDE, INFFlagHN, INFFlagHR, INFFlagHO, INFFlagTO, INFFlagCT, INFFlagAW, INFFlagOP, INFFlagSU:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &INFContent{}
+var _ ADCMarshaler = &INFContent{}
+var _ ADCUnmarshaler = &INFContent{}
+var _ io.WriterTo = &INFContent{}
+var _ DecodeWarner = &INFContent{}
+
+// INFContent is the content of INF messages.
+type INFContent struct {
+	ID    Base32Value
+	idStr string
+
+	PD    Bytes
+	pdStr string
+
+	I4    Addr
+	i4Str string
+
+	I6    Addr
+	i6Str string
+
+	// Required if SU contains UDP4.
+	U4    Int
+	u4Str string
+
+	// Required if SU contains UDP6.
+	U6    Int
+	u6Str string
+
+	SS    Int
+	ssStr string
+
+	SF    Int
+	sfStr string
+
+	VE    String
+	veStr string
+
+	US    Int
+	usStr string
+
+	DS    Int
+	dsStr string
+
+	SL    Int
+	slStr string
+
+	AS    Int
+	asStr string
+
+	AM    Int
+	amStr string
+
+	EM    String
+	emStr string
+
+	NI    String
+	niStr string
+
+	DE    String
+	deStr string
+
+	HN    Int
+	hnStr string
+
+	HR    Int
+	hrStr string
+
+	HO    Int
+	hoStr string
+
+	TO    String
+	toStr string
+
+	CT    Int
+	ctStr string
+
+	AW    Int
+	awStr string
+
+	// Deprecated: superseded by CT
+	OP    Int
+	opStr string
+
+	SU    Features
+	suStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (c *INFContent) Positional() []string {
+	return c.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (c *INFContent) AppendPositional(dst []string) []string {
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (c *INFContent) PosLen() int {
+	return 0
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (c *INFContent) PosAt(i int) string {
+	panic(fmt.Sprintf("INF.PosAt: index %d out of range [0,%d)", i, c.PosLen()))
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (c *INFContent) Named() map[string]string {
+	params := c.UnknownFlags()
+
+	if c.ID.IsSet {
+		params["ID"] = formatBase32(c.ID.Value)
+	}
+	if c.PD.IsSet {
+		params["PD"] = encoding.EncodeToBase32String(c.PD.Value)
+	}
+	if c.I4.IsSet {
+		params["I4"] = formatAddr(c.I4.Value)
+	}
+	if c.I6.IsSet {
+		params["I6"] = formatAddr(c.I6.Value)
+	}
+	if c.U4.IsSet {
+		params["U4"] = strconv.Itoa(c.U4.Value)
+	}
+	if c.U6.IsSet {
+		params["U6"] = strconv.Itoa(c.U6.Value)
+	}
+	if c.SS.IsSet {
+		params["SS"] = strconv.Itoa(c.SS.Value)
+	}
+	if c.SF.IsSet {
+		params["SF"] = strconv.Itoa(c.SF.Value)
+	}
+	if c.VE.IsSet {
+		params["VE"] = escapeValue(c.VE.Value)
+	}
+	if c.US.IsSet {
+		params["US"] = strconv.Itoa(c.US.Value)
+	}
+	if c.DS.IsSet {
+		params["DS"] = strconv.Itoa(c.DS.Value)
+	}
+	if c.SL.IsSet {
+		params["SL"] = strconv.Itoa(c.SL.Value)
+	}
+	if c.AS.IsSet {
+		params["AS"] = strconv.Itoa(c.AS.Value)
+	}
+	if c.AM.IsSet {
+		params["AM"] = strconv.Itoa(c.AM.Value)
+	}
+	if c.EM.IsSet {
+		params["EM"] = escapeValue(c.EM.Value)
+	}
+	if c.NI.IsSet {
+		params["NI"] = escapeValue(c.NI.Value)
+	}
+	if c.DE.IsSet {
+		params["DE"] = escapeValue(c.DE.Value)
+	}
+	if c.HN.IsSet {
+		params["HN"] = strconv.Itoa(c.HN.Value)
+	}
+	if c.HR.IsSet {
+		params["HR"] = strconv.Itoa(c.HR.Value)
+	}
+	if c.HO.IsSet {
+		params["HO"] = strconv.Itoa(c.HO.Value)
+	}
+	if c.TO.IsSet {
+		params["TO"] = escapeValue(c.TO.Value)
+	}
+	if c.CT.IsSet {
+		params["CT"] = strconv.Itoa(c.CT.Value)
+	}
+	if c.AW.IsSet {
+		params["AW"] = strconv.Itoa(c.AW.Value)
+	}
+	if c.OP.IsSet {
+		params["OP"] = strconv.Itoa(c.OP.Value)
+	}
+	if c.SU.IsSet {
+		params["SU"] = c.SU.Value.String()
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (c *INFContent) NamedGet(key string) (string, bool) {
+	switch INFFlag(key) {
+	case INFFlagID:
+		if c.ID.IsSet {
+			return formatBase32(c.ID.Value), true
+		}
+		return "", false
+	case INFFlagPD:
+		if c.PD.IsSet {
+			return encoding.EncodeToBase32String(c.PD.Value), true
+		}
+		return "", false
+	case INFFlagI4:
+		if c.I4.IsSet {
+			return formatAddr(c.I4.Value), true
+		}
+		return "", false
+	case INFFlagI6:
+		if c.I6.IsSet {
+			return formatAddr(c.I6.Value), true
+		}
+		return "", false
+	case INFFlagU4:
+		if c.U4.IsSet {
+			return strconv.Itoa(c.U4.Value), true
+		}
+		return "", false
+	case INFFlagU6:
+		if c.U6.IsSet {
+			return strconv.Itoa(c.U6.Value), true
+		}
+		return "", false
+	case INFFlagSS:
+		if c.SS.IsSet {
+			return strconv.Itoa(c.SS.Value), true
+		}
+		return "", false
+	case INFFlagSF:
+		if c.SF.IsSet {
+			return strconv.Itoa(c.SF.Value), true
+		}
+		return "", false
+	case INFFlagVE:
+		if c.VE.IsSet {
+			return escapeValue(c.VE.Value), true
+		}
+		return "", false
+	case INFFlagUS:
+		if c.US.IsSet {
+			return strconv.Itoa(c.US.Value), true
+		}
+		return "", false
+	case INFFlagDS:
+		if c.DS.IsSet {
+			return strconv.Itoa(c.DS.Value), true
+		}
+		return "", false
+	case INFFlagSL:
+		if c.SL.IsSet {
+			return strconv.Itoa(c.SL.Value), true
+		}
+		return "", false
+	case INFFlagAS:
+		if c.AS.IsSet {
+			return strconv.Itoa(c.AS.Value), true
+		}
+		return "", false
+	case INFFlagAM:
+		if c.AM.IsSet {
+			return strconv.Itoa(c.AM.Value), true
+		}
+		return "", false
+	case INFFlagEM:
+		if c.EM.IsSet {
+			return escapeValue(c.EM.Value), true
+		}
+		return "", false
+	case INFFlagNI:
+		if c.NI.IsSet {
+			return escapeValue(c.NI.Value), true
+		}
+		return "", false
+	case INFFlagDE:
+		if c.DE.IsSet {
+			return escapeValue(c.DE.Value), true
+		}
+		return "", false
+	case INFFlagHN:
+		if c.HN.IsSet {
+			return strconv.Itoa(c.HN.Value), true
+		}
+		return "", false
+	case INFFlagHR:
+		if c.HR.IsSet {
+			return strconv.Itoa(c.HR.Value), true
+		}
+		return "", false
+	case INFFlagHO:
+		if c.HO.IsSet {
+			return strconv.Itoa(c.HO.Value), true
+		}
+		return "", false
+	case INFFlagTO:
+		if c.TO.IsSet {
+			return escapeValue(c.TO.Value), true
+		}
+		return "", false
+	case INFFlagCT:
+		if c.CT.IsSet {
+			return strconv.Itoa(c.CT.Value), true
+		}
+		return "", false
+	case INFFlagAW:
+		if c.AW.IsSet {
+			return strconv.Itoa(c.AW.Value), true
+		}
+		return "", false
+	case INFFlagOP:
+		if c.OP.IsSet {
+			return strconv.Itoa(c.OP.Value), true
+		}
+		return "", false
+	case INFFlagSU:
+		if c.SU.IsSet {
+			return c.SU.Value.String(), true
+		}
+		return "", false
+	}
+
+	return c.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (c *INFContent) NamedAll(key string) []string {
+	if val, ok := c.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetID returns the decoded value of the ID param and whether it is set.
+func (c *INFContent) GetID() (*encoding.Base32Value, bool) {
+	return c.ID.Value, c.ID.IsSet
+}
+
+// GetPD returns the decoded value of the PD param and whether it is set.
+func (c *INFContent) GetPD() ([]byte, bool) {
+	return c.PD.Value, c.PD.IsSet
+}
+
+// GetI4 returns the decoded value of the I4 param and whether it is set.
+func (c *INFContent) GetI4() (netip.Addr, bool) {
+	return c.I4.Value, c.I4.IsSet
+}
+
+// GetI6 returns the decoded value of the I6 param and whether it is set.
+func (c *INFContent) GetI6() (netip.Addr, bool) {
+	return c.I6.Value, c.I6.IsSet
+}
+
+// GetU4 returns the decoded value of the U4 param and whether it is set.
+func (c *INFContent) GetU4() (int, bool) {
+	return c.U4.Value, c.U4.IsSet
+}
+
+// GetU6 returns the decoded value of the U6 param and whether it is set.
+func (c *INFContent) GetU6() (int, bool) {
+	return c.U6.Value, c.U6.IsSet
+}
+
+// GetSS returns the decoded value of the SS param and whether it is set.
+func (c *INFContent) GetSS() (int, bool) {
+	return c.SS.Value, c.SS.IsSet
+}
+
+// GetSF returns the decoded value of the SF param and whether it is set.
+func (c *INFContent) GetSF() (int, bool) {
+	return c.SF.Value, c.SF.IsSet
+}
+
+// GetVE returns the decoded value of the VE param and whether it is set.
+func (c *INFContent) GetVE() (string, bool) {
+	return c.VE.Value, c.VE.IsSet
+}
+
+// GetUS returns the decoded value of the US param and whether it is set.
+func (c *INFContent) GetUS() (int, bool) {
+	return c.US.Value, c.US.IsSet
+}
+
+// GetDS returns the decoded value of the DS param and whether it is set.
+func (c *INFContent) GetDS() (int, bool) {
+	return c.DS.Value, c.DS.IsSet
+}
+
+// GetSL returns the decoded value of the SL param and whether it is set.
+func (c *INFContent) GetSL() (int, bool) {
+	return c.SL.Value, c.SL.IsSet
+}
+
+// GetAS returns the decoded value of the AS param and whether it is set.
+func (c *INFContent) GetAS() (int, bool) {
+	return c.AS.Value, c.AS.IsSet
+}
+
+// GetAM returns the decoded value of the AM param and whether it is set.
+func (c *INFContent) GetAM() (int, bool) {
+	return c.AM.Value, c.AM.IsSet
+}
+
+// GetEM returns the decoded value of the EM param and whether it is set.
+func (c *INFContent) GetEM() (string, bool) {
+	return c.EM.Value, c.EM.IsSet
+}
+
+// GetNI returns the decoded value of the NI param and whether it is set.
+func (c *INFContent) GetNI() (string, bool) {
+	return c.NI.Value, c.NI.IsSet
+}
+
+// GetDE returns the decoded value of the DE param and whether it is set.
+func (c *INFContent) GetDE() (string, bool) {
+	return c.DE.Value, c.DE.IsSet
+}
+
+// GetHN returns the decoded value of the HN param and whether it is set.
+func (c *INFContent) GetHN() (int, bool) {
+	return c.HN.Value, c.HN.IsSet
+}
+
+// GetHR returns the decoded value of the HR param and whether it is set.
+func (c *INFContent) GetHR() (int, bool) {
+	return c.HR.Value, c.HR.IsSet
+}
+
+// GetHO returns the decoded value of the HO param and whether it is set.
+func (c *INFContent) GetHO() (int, bool) {
+	return c.HO.Value, c.HO.IsSet
+}
+
+// GetTO returns the decoded value of the TO param and whether it is set.
+func (c *INFContent) GetTO() (string, bool) {
+	return c.TO.Value, c.TO.IsSet
+}
+
+// GetCT returns the decoded value of the CT param and whether it is set.
+func (c *INFContent) GetCT() (int, bool) {
+	return c.CT.Value, c.CT.IsSet
+}
+
+// GetAW returns the decoded value of the AW param and whether it is set.
+func (c *INFContent) GetAW() (int, bool) {
+	return c.AW.Value, c.AW.IsSet
+}
+
+// GetOP returns the decoded value of the OP param and whether it is set.
+func (c *INFContent) GetOP() (int, bool) {
+	return c.OP.Value, c.OP.IsSet
+}
+
+// GetSU returns the decoded value of the SU param and whether it is set.
+func (c *INFContent) GetSU() (encoding.Features, bool) {
+	return c.SU.Value, c.SU.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (c *INFContent) PosByName(name string) (string, bool) {
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (c *INFContent) ParseInto(params []string, opts *ParseOptions) error {
+	*c = INFContent{}
+	c.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message INF: %w", err)
+	}
+
+	for _, param := range params {
+		switch {
+		case isNamedParam(param):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				c.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+			}
+			switch INFFlag(param[:2]) {
+			case INFFlagID:
+				if err := opts.checkDuplicateFlag(c.idStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.idStr = param
+				val, err := encoding.ParseBase32Value(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param ID of message INF: %w", err)
+				}
+				c.ID.Set(val)
+			case INFFlagPD:
+				if err := opts.checkDuplicateFlag(c.pdStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.pdStr = param
+				val, err := encoding.DecodeBase32String(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param PD of message INF: %w", err)
+				}
+				c.PD.Set(val)
+			case INFFlagI4:
+				if err := opts.checkDuplicateFlag(c.i4Str, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.i4Str = param
+				val, err := encoding.ParseAddr(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param I4 of message INF: %w", err)
+				}
+				c.I4.Set(val)
+			case INFFlagI6:
+				if err := opts.checkDuplicateFlag(c.i6Str, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.i6Str = param
+				val, err := encoding.ParseAddr(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param I6 of message INF: %w", err)
+				}
+				c.I6.Set(val)
+			case INFFlagU4:
+				if err := opts.checkDuplicateFlag(c.u4Str, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.u4Str = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param U4 of message INF: %w", err)
+				}
+				c.U4.Set(val)
+			case INFFlagU6:
+				if err := opts.checkDuplicateFlag(c.u6Str, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.u6Str = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param U6 of message INF: %w", err)
+				}
+				c.U6.Set(val)
+			case INFFlagSS:
+				if err := opts.checkDuplicateFlag(c.ssStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.ssStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SS of message INF: %w", err)
+				}
+				c.SS.Set(val)
+			case INFFlagSF:
+				if err := opts.checkDuplicateFlag(c.sfStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.sfStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SF of message INF: %w", err)
+				}
+				c.SF.Set(val)
+			case INFFlagVE:
+				if err := opts.checkDuplicateFlag(c.veStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.veStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param VE of message INF: %w", err)
+				}
+				c.VE.Set(val)
+			case INFFlagUS:
+				if err := opts.checkDuplicateFlag(c.usStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.usStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param US of message INF: %w", err)
+				}
+				c.US.Set(val)
+			case INFFlagDS:
+				if err := opts.checkDuplicateFlag(c.dsStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.dsStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param DS of message INF: %w", err)
+				}
+				c.DS.Set(val)
+			case INFFlagSL:
+				if err := opts.checkDuplicateFlag(c.slStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.slStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SL of message INF: %w", err)
+				}
+				c.SL.Set(val)
+			case INFFlagAS:
+				if err := opts.checkDuplicateFlag(c.asStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.asStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param AS of message INF: %w", err)
+				}
+				c.AS.Set(val)
+			case INFFlagAM:
+				if err := opts.checkDuplicateFlag(c.amStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.amStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param AM of message INF: %w", err)
+				}
+				c.AM.Set(val)
+			case INFFlagEM:
+				if err := opts.checkDuplicateFlag(c.emStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.emStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param EM of message INF: %w", err)
+				}
+				c.EM.Set(val)
+			case INFFlagNI:
+				if err := opts.checkDuplicateFlag(c.niStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.niStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param NI of message INF: %w", err)
+				}
+				c.NI.Set(val)
+			case INFFlagDE:
+				if err := opts.checkDuplicateFlag(c.deStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.deStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param DE of message INF: %w", err)
+				}
+				c.DE.Set(val)
+			case INFFlagHN:
+				if err := opts.checkDuplicateFlag(c.hnStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.hnStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param HN of message INF: %w", err)
+				}
+				c.HN.Set(val)
+			case INFFlagHR:
+				if err := opts.checkDuplicateFlag(c.hrStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.hrStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param HR of message INF: %w", err)
+				}
+				c.HR.Set(val)
+			case INFFlagHO:
+				if err := opts.checkDuplicateFlag(c.hoStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.hoStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param HO of message INF: %w", err)
+				}
+				c.HO.Set(val)
+			case INFFlagTO:
+				if err := opts.checkDuplicateFlag(c.toStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.toStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TO of message INF: %w", err)
+				}
+				c.TO.Set(val)
+			case INFFlagCT:
+				if err := opts.checkDuplicateFlag(c.ctStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.ctStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param CT of message INF: %w", err)
+				}
+				c.CT.Set(val)
+			case INFFlagAW:
+				if err := opts.checkDuplicateFlag(c.awStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.awStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param AW of message INF: %w", err)
+				}
+				c.AW.Set(val)
+			case INFFlagOP:
+				if err := opts.checkDuplicateFlag(c.opStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.opStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param OP of message INF: %w", err)
+				}
+				c.OP.Set(val)
+			case INFFlagSU:
+				if err := opts.checkDuplicateFlag(c.suStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message INF: %w", param[:2], err)
+				}
+				c.suStr = param
+				val, err := encoding.ParseFeatures(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SU of message INF: %w", err)
+				}
+				c.SU.Set(val)
+			default:
+				if c.Flags == nil {
+					c.Flags = make(map[string]string)
+				}
+				c.Flags[param[:2]] = param[2:]
+			}
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message INF: %w", err)
+			}
+		}
+	}
+
+	if c.SU.IsSet && c.SU.Value.Has("UDP4") && !c.U4.IsSet {
+		return fmt.Errorf("parsing param U4 of message INF: %w, required if SU contains UDP4", ErrMissingParam)
+	}
+	if c.SU.IsSet && c.SU.Value.Has("UDP6") && !c.U6.IsSet {
+		return fmt.Errorf("parsing param U6 of message INF: %w, required if SU contains UDP6", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// INFContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func INFContentFromAccessor(pa ParamAccessor) (*INFContent, error) {
+	var c INFContent
+	if err := c.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (c *INFContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message INF: %w", err)
+	}
+
+	return c.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (c *INFContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "INF" {
+		return fmt.Errorf("parsing message INF: %w", ErrCommandMismatch)
+	}
+
+	return c.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (c *INFContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message INF: %w", err)
+	}
+	return c.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (c *INFContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, c.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (c *INFContent) SetNamedAll(named map[string]string) error {
+	decoded := *c
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*c = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (c *INFContent) setNamedAll(named map[string]string) error {
+	var zero INFContent
+	c.ID = zero.ID
+	c.idStr = zero.idStr
+	c.PD = zero.PD
+	c.pdStr = zero.pdStr
+	c.I4 = zero.I4
+	c.i4Str = zero.i4Str
+	c.I6 = zero.I6
+	c.i6Str = zero.i6Str
+	c.U4 = zero.U4
+	c.u4Str = zero.u4Str
+	c.U6 = zero.U6
+	c.u6Str = zero.u6Str
+	c.SS = zero.SS
+	c.ssStr = zero.ssStr
+	c.SF = zero.SF
+	c.sfStr = zero.sfStr
+	c.VE = zero.VE
+	c.veStr = zero.veStr
+	c.US = zero.US
+	c.usStr = zero.usStr
+	c.DS = zero.DS
+	c.dsStr = zero.dsStr
+	c.SL = zero.SL
+	c.slStr = zero.slStr
+	c.AS = zero.AS
+	c.asStr = zero.asStr
+	c.AM = zero.AM
+	c.amStr = zero.amStr
+	c.EM = zero.EM
+	c.emStr = zero.emStr
+	c.NI = zero.NI
+	c.niStr = zero.niStr
+	c.DE = zero.DE
+	c.deStr = zero.deStr
+	c.HN = zero.HN
+	c.hnStr = zero.hnStr
+	c.HR = zero.HR
+	c.hrStr = zero.hrStr
+	c.HO = zero.HO
+	c.hoStr = zero.hoStr
+	c.TO = zero.TO
+	c.toStr = zero.toStr
+	c.CT = zero.CT
+	c.ctStr = zero.ctStr
+	c.AW = zero.AW
+	c.awStr = zero.awStr
+	c.OP = zero.OP
+	c.opStr = zero.opStr
+	c.SU = zero.SU
+	c.suStr = zero.suStr
+	c.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message INF: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch INFFlag(param[:2]) {
+		case INFFlagID:
+			c.idStr = param
+			val, err := encoding.ParseBase32Value(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param ID of message INF: %w", err)
+			}
+			c.ID.Set(val)
+		case INFFlagPD:
+			c.pdStr = param
+			val, err := encoding.DecodeBase32String(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param PD of message INF: %w", err)
+			}
+			c.PD.Set(val)
+		case INFFlagI4:
+			c.i4Str = param
+			val, err := encoding.ParseAddr(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param I4 of message INF: %w", err)
+			}
+			c.I4.Set(val)
+		case INFFlagI6:
+			c.i6Str = param
+			val, err := encoding.ParseAddr(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param I6 of message INF: %w", err)
+			}
+			c.I6.Set(val)
+		case INFFlagU4:
+			c.u4Str = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param U4 of message INF: %w", err)
+			}
+			c.U4.Set(val)
+		case INFFlagU6:
+			c.u6Str = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param U6 of message INF: %w", err)
+			}
+			c.U6.Set(val)
+		case INFFlagSS:
+			c.ssStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SS of message INF: %w", err)
+			}
+			c.SS.Set(val)
+		case INFFlagSF:
+			c.sfStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SF of message INF: %w", err)
+			}
+			c.SF.Set(val)
+		case INFFlagVE:
+			c.veStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param VE of message INF: %w", err)
+			}
+			c.VE.Set(val)
+		case INFFlagUS:
+			c.usStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param US of message INF: %w", err)
+			}
+			c.US.Set(val)
+		case INFFlagDS:
+			c.dsStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param DS of message INF: %w", err)
+			}
+			c.DS.Set(val)
+		case INFFlagSL:
+			c.slStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SL of message INF: %w", err)
+			}
+			c.SL.Set(val)
+		case INFFlagAS:
+			c.asStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param AS of message INF: %w", err)
+			}
+			c.AS.Set(val)
+		case INFFlagAM:
+			c.amStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param AM of message INF: %w", err)
+			}
+			c.AM.Set(val)
+		case INFFlagEM:
+			c.emStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param EM of message INF: %w", err)
+			}
+			c.EM.Set(val)
+		case INFFlagNI:
+			c.niStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param NI of message INF: %w", err)
+			}
+			c.NI.Set(val)
+		case INFFlagDE:
+			c.deStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param DE of message INF: %w", err)
+			}
+			c.DE.Set(val)
+		case INFFlagHN:
+			c.hnStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param HN of message INF: %w", err)
+			}
+			c.HN.Set(val)
+		case INFFlagHR:
+			c.hrStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param HR of message INF: %w", err)
+			}
+			c.HR.Set(val)
+		case INFFlagHO:
+			c.hoStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param HO of message INF: %w", err)
+			}
+			c.HO.Set(val)
+		case INFFlagTO:
+			c.toStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TO of message INF: %w", err)
+			}
+			c.TO.Set(val)
+		case INFFlagCT:
+			c.ctStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param CT of message INF: %w", err)
+			}
+			c.CT.Set(val)
+		case INFFlagAW:
+			c.awStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param AW of message INF: %w", err)
+			}
+			c.AW.Set(val)
+		case INFFlagOP:
+			c.opStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param OP of message INF: %w", err)
+			}
+			c.OP.Set(val)
+		case INFFlagSU:
+			c.suStr = param
+			val, err := encoding.ParseFeatures(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SU of message INF: %w", err)
+			}
+			c.SU.Set(val)
+		default:
+			if c.Flags == nil {
+				c.Flags = make(map[string]string)
+			}
+			c.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (c *INFContent) ToMap() map[string]string {
+	values := c.UnknownFlags()
+	if c.ID.IsSet {
+		values["ID"] = formatBase32(c.ID.Value)
+	}
+	if c.PD.IsSet {
+		values["PD"] = encoding.EncodeToBase32String(c.PD.Value)
+	}
+	if c.I4.IsSet {
+		values["I4"] = formatAddr(c.I4.Value)
+	}
+	if c.I6.IsSet {
+		values["I6"] = formatAddr(c.I6.Value)
+	}
+	if c.U4.IsSet {
+		values["U4"] = strconv.Itoa(c.U4.Value)
+	}
+	if c.U6.IsSet {
+		values["U6"] = strconv.Itoa(c.U6.Value)
+	}
+	if c.SS.IsSet {
+		values["SS"] = strconv.Itoa(c.SS.Value)
+	}
+	if c.SF.IsSet {
+		values["SF"] = strconv.Itoa(c.SF.Value)
+	}
+	if c.VE.IsSet {
+		values["VE"] = escapeValue(c.VE.Value)
+	}
+	if c.US.IsSet {
+		values["US"] = strconv.Itoa(c.US.Value)
+	}
+	if c.DS.IsSet {
+		values["DS"] = strconv.Itoa(c.DS.Value)
+	}
+	if c.SL.IsSet {
+		values["SL"] = strconv.Itoa(c.SL.Value)
+	}
+	if c.AS.IsSet {
+		values["AS"] = strconv.Itoa(c.AS.Value)
+	}
+	if c.AM.IsSet {
+		values["AM"] = strconv.Itoa(c.AM.Value)
+	}
+	if c.EM.IsSet {
+		values["EM"] = escapeValue(c.EM.Value)
+	}
+	if c.NI.IsSet {
+		values["NI"] = escapeValue(c.NI.Value)
+	}
+	if c.DE.IsSet {
+		values["DE"] = escapeValue(c.DE.Value)
+	}
+	if c.HN.IsSet {
+		values["HN"] = strconv.Itoa(c.HN.Value)
+	}
+	if c.HR.IsSet {
+		values["HR"] = strconv.Itoa(c.HR.Value)
+	}
+	if c.HO.IsSet {
+		values["HO"] = strconv.Itoa(c.HO.Value)
+	}
+	if c.TO.IsSet {
+		values["TO"] = escapeValue(c.TO.Value)
+	}
+	if c.CT.IsSet {
+		values["CT"] = strconv.Itoa(c.CT.Value)
+	}
+	if c.AW.IsSet {
+		values["AW"] = strconv.Itoa(c.AW.Value)
+	}
+	if c.OP.IsSet {
+		values["OP"] = strconv.Itoa(c.OP.Value)
+	}
+	if c.SU.IsSet {
+		values["SU"] = c.SU.Value.String()
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (c *INFContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["ID"]; ok {
+		params = append(params, "ID"+val)
+	}
+	if val, ok := values["PD"]; ok {
+		params = append(params, "PD"+val)
+	}
+	if val, ok := values["I4"]; ok {
+		params = append(params, "I4"+val)
+	}
+	if val, ok := values["I6"]; ok {
+		params = append(params, "I6"+val)
+	}
+	if val, ok := values["U4"]; ok {
+		params = append(params, "U4"+val)
+	}
+	if val, ok := values["U6"]; ok {
+		params = append(params, "U6"+val)
+	}
+	if val, ok := values["SS"]; ok {
+		params = append(params, "SS"+val)
+	}
+	if val, ok := values["SF"]; ok {
+		params = append(params, "SF"+val)
+	}
+	if val, ok := values["VE"]; ok {
+		params = append(params, "VE"+val)
+	}
+	if val, ok := values["US"]; ok {
+		params = append(params, "US"+val)
+	}
+	if val, ok := values["DS"]; ok {
+		params = append(params, "DS"+val)
+	}
+	if val, ok := values["SL"]; ok {
+		params = append(params, "SL"+val)
+	}
+	if val, ok := values["AS"]; ok {
+		params = append(params, "AS"+val)
+	}
+	if val, ok := values["AM"]; ok {
+		params = append(params, "AM"+val)
+	}
+	if val, ok := values["EM"]; ok {
+		params = append(params, "EM"+val)
+	}
+	if val, ok := values["NI"]; ok {
+		params = append(params, "NI"+val)
+	}
+	if val, ok := values["DE"]; ok {
+		params = append(params, "DE"+val)
+	}
+	if val, ok := values["HN"]; ok {
+		params = append(params, "HN"+val)
+	}
+	if val, ok := values["HR"]; ok {
+		params = append(params, "HR"+val)
+	}
+	if val, ok := values["HO"]; ok {
+		params = append(params, "HO"+val)
+	}
+	if val, ok := values["TO"]; ok {
+		params = append(params, "TO"+val)
+	}
+	if val, ok := values["CT"]; ok {
+		params = append(params, "CT"+val)
+	}
+	if val, ok := values["AW"]; ok {
+		params = append(params, "AW"+val)
+	}
+	if val, ok := values["OP"]; ok {
+		params = append(params, "OP"+val)
+	}
+	if val, ok := values["SU"]; ok {
+		params = append(params, "SU"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "ID", "PD", "I4", "I6", "U4", "U6", "SS", "SF", "VE", "US", "DS", "SL", "AS", "AM", "EM", "NI", "DE", "HN", "HR", "HO", "TO", "CT", "AW", "OP", "SU":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message INF: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return c.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (c *INFContent) AppendADC(buf []byte) ([]byte, error) {
+	buf = append(buf, "INF"...)
+
+	if c.ID.IsSet {
+		buf = append(buf, " ID"...)
+		buf = append(buf, formatBase32(c.ID.Value)...)
+	}
+	if c.PD.IsSet {
+		buf = append(buf, " PD"...)
+		buf = append(buf, encoding.EncodeToBase32String(c.PD.Value)...)
+	}
+	if c.I4.IsSet {
+		buf = append(buf, " I4"...)
+		buf = appendAddr(buf, c.I4.Value)
+	}
+	if c.I6.IsSet {
+		buf = append(buf, " I6"...)
+		buf = appendAddr(buf, c.I6.Value)
+	}
+	if c.U4.IsSet {
+		buf = append(buf, " U4"...)
+		buf = strconv.AppendInt(buf, int64(c.U4.Value), 10)
+	}
+	if c.U6.IsSet {
+		buf = append(buf, " U6"...)
+		buf = strconv.AppendInt(buf, int64(c.U6.Value), 10)
+	}
+	if c.SS.IsSet {
+		buf = append(buf, " SS"...)
+		buf = strconv.AppendInt(buf, int64(c.SS.Value), 10)
+	}
+	if c.SF.IsSet {
+		buf = append(buf, " SF"...)
+		buf = strconv.AppendInt(buf, int64(c.SF.Value), 10)
+	}
+	if c.VE.IsSet {
+		buf = append(buf, " VE"...)
+		buf = appendEscaped(buf, c.VE.Value)
+	}
+	if c.US.IsSet {
+		buf = append(buf, " US"...)
+		buf = strconv.AppendInt(buf, int64(c.US.Value), 10)
+	}
+	if c.DS.IsSet {
+		buf = append(buf, " DS"...)
+		buf = strconv.AppendInt(buf, int64(c.DS.Value), 10)
+	}
+	if c.SL.IsSet {
+		buf = append(buf, " SL"...)
+		buf = strconv.AppendInt(buf, int64(c.SL.Value), 10)
+	}
+	if c.AS.IsSet {
+		buf = append(buf, " AS"...)
+		buf = strconv.AppendInt(buf, int64(c.AS.Value), 10)
+	}
+	if c.AM.IsSet {
+		buf = append(buf, " AM"...)
+		buf = strconv.AppendInt(buf, int64(c.AM.Value), 10)
+	}
+	if c.EM.IsSet {
+		buf = append(buf, " EM"...)
+		buf = appendEscaped(buf, c.EM.Value)
+	}
+	if c.NI.IsSet {
+		buf = append(buf, " NI"...)
+		buf = appendEscaped(buf, c.NI.Value)
+	}
+	if c.DE.IsSet {
+		buf = append(buf, " DE"...)
+		buf = appendEscaped(buf, c.DE.Value)
+	}
+	if c.HN.IsSet {
+		buf = append(buf, " HN"...)
+		buf = strconv.AppendInt(buf, int64(c.HN.Value), 10)
+	}
+	if c.HR.IsSet {
+		buf = append(buf, " HR"...)
+		buf = strconv.AppendInt(buf, int64(c.HR.Value), 10)
+	}
+	if c.HO.IsSet {
+		buf = append(buf, " HO"...)
+		buf = strconv.AppendInt(buf, int64(c.HO.Value), 10)
+	}
+	if c.TO.IsSet {
+		buf = append(buf, " TO"...)
+		buf = appendEscaped(buf, c.TO.Value)
+	}
+	if c.CT.IsSet {
+		buf = append(buf, " CT"...)
+		buf = strconv.AppendInt(buf, int64(c.CT.Value), 10)
+	}
+	if c.AW.IsSet {
+		buf = append(buf, " AW"...)
+		buf = strconv.AppendInt(buf, int64(c.AW.Value), 10)
+	}
+	if c.SU.IsSet {
+		buf = append(buf, " SU"...)
+		buf = append(buf, c.SU.Value.String()...)
+	}
+	buf = appendFlags(buf, c.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (c *INFContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(c.WireSize())
+	builder.WriteString("INF")
+
+	if c.ID.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("ID" + formatBase32(c.ID.Value))
+	}
+	if c.PD.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("PD" + encoding.EncodeToBase32String(c.PD.Value))
+	}
+	if c.I4.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("I4" + formatAddr(c.I4.Value))
+	}
+	if c.I6.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("I6" + formatAddr(c.I6.Value))
+	}
+	if c.U4.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("U4" + strconv.Itoa(c.U4.Value))
+	}
+	if c.U6.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("U6" + strconv.Itoa(c.U6.Value))
+	}
+	if c.SS.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SS" + strconv.Itoa(c.SS.Value))
+	}
+	if c.SF.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SF" + strconv.Itoa(c.SF.Value))
+	}
+	if c.VE.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("VE" + escapeValue(c.VE.Value))
+	}
+	if c.US.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("US" + strconv.Itoa(c.US.Value))
+	}
+	if c.DS.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("DS" + strconv.Itoa(c.DS.Value))
+	}
+	if c.SL.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SL" + strconv.Itoa(c.SL.Value))
+	}
+	if c.AS.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("AS" + strconv.Itoa(c.AS.Value))
+	}
+	if c.AM.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("AM" + strconv.Itoa(c.AM.Value))
+	}
+	if c.EM.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("EM" + escapeValue(c.EM.Value))
+	}
+	if c.NI.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("NI" + escapeValue(c.NI.Value))
+	}
+	if c.DE.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("DE" + escapeValue(c.DE.Value))
+	}
+	if c.HN.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("HN" + strconv.Itoa(c.HN.Value))
+	}
+	if c.HR.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("HR" + strconv.Itoa(c.HR.Value))
+	}
+	if c.HO.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("HO" + strconv.Itoa(c.HO.Value))
+	}
+	if c.TO.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TO" + escapeValue(c.TO.Value))
+	}
+	if c.CT.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("CT" + strconv.Itoa(c.CT.Value))
+	}
+	if c.AW.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("AW" + strconv.Itoa(c.AW.Value))
+	}
+	if c.SU.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SU" + c.SU.Value.String())
+	}
+	writeFlags(&builder, c.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (c *INFContent) Validate() error {
+	var errs []error
+	for _, name := range c.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message INF: %w", name, ErrMissingParam))
+	}
+	if c.SU.IsSet && c.SU.Value.Has("UDP4") && !c.U4.IsSet {
+		errs = append(errs, fmt.Errorf("validating param U4 of message INF: %w, required if SU contains UDP4", ErrMissingParam))
+	}
+	if c.SU.IsSet && c.SU.Value.Has("UDP6") && !c.U6.IsSet {
+		errs = append(errs, fmt.Errorf("validating param U6 of message INF: %w, required if SU contains UDP6", ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(c.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message INF: %w", err))
+	}
+
+	if c.ID.IsSet {
+		if c.ID.Value != nil && len(c.ID.Value.String()) != 39 {
+			errs = append(errs, fmt.Errorf("validating param ID of message INF: %w, must be 39 characters long", ErrInvalidLength))
+		}
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (c *INFContent) MissingRequired() []string {
+	return nil
+}
+
+var infDescriptor = MessageDescriptor{
+	Command: "INF",
+	Named: []ParamDescriptor{{
+		DisplayName: "ID",
+		FlagName:    "ID",
+		Name:        "ID",
+		Required:    false,
+		Type:        "base32",
+	}, {
+		DisplayName: "PD",
+		FlagName:    "PD",
+		Name:        "PD",
+		Required:    false,
+		Type:        "bytes",
+	}, {
+		DisplayName: "I4",
+		FlagName:    "I4",
+		Name:        "I4",
+		Required:    false,
+		Type:        "addr",
+	}, {
+		DisplayName: "I6",
+		FlagName:    "I6",
+		Name:        "I6",
+		Required:    false,
+		Type:        "addr",
+	}, {
+		DisplayName: "U4",
+		FlagName:    "U4",
+		Name:        "U4",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "U6",
+		FlagName:    "U6",
+		Name:        "U6",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "SS",
+		FlagName:    "SS",
+		Name:        "SS",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "SF",
+		FlagName:    "SF",
+		Name:        "SF",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "VE",
+		FlagName:    "VE",
+		Name:        "VE",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "US",
+		FlagName:    "US",
+		Name:        "US",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "DS",
+		FlagName:    "DS",
+		Name:        "DS",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "SL",
+		FlagName:    "SL",
+		Name:        "SL",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "AS",
+		FlagName:    "AS",
+		Name:        "AS",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "AM",
+		FlagName:    "AM",
+		Name:        "AM",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "EM",
+		FlagName:    "EM",
+		Name:        "EM",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "NI",
+		FlagName:    "NI",
+		Name:        "NI",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "DE",
+		FlagName:    "DE",
+		Name:        "DE",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "HN",
+		FlagName:    "HN",
+		Name:        "HN",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "HR",
+		FlagName:    "HR",
+		Name:        "HR",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "HO",
+		FlagName:    "HO",
+		Name:        "HO",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "TO",
+		FlagName:    "TO",
+		Name:        "TO",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "CT",
+		FlagName:    "CT",
+		Name:        "CT",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "AW",
+		FlagName:    "AW",
+		Name:        "AW",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "OP",
+		FlagName:    "OP",
+		Name:        "OP",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "SU",
+		FlagName:    "SU",
+		Name:        "SU",
+		Required:    false,
+		Type:        "features",
+	}},
+	Types: "BCI",
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (c *INFContent) Descriptor() MessageDescriptor {
+	return infDescriptor
+}
+
+// Command returns the command of the content, i.e. INF.
+func (c *INFContent) Command() string {
+	return "INF"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (c *INFContent) MsgType() byte {
+	return c.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (c *INFContent) MarshalADC() ([]byte, error) {
+	return c.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (c *INFContent) WireSize() int {
+	n := len("INF")
+
+	if c.ID.IsSet {
+		n += 1 + len("ID"+formatBase32(c.ID.Value))
+	}
+	if c.PD.IsSet {
+		n += 1 + len("PD"+encoding.EncodeToBase32String(c.PD.Value))
+	}
+	if c.I4.IsSet {
+		n += 1 + len("I4"+formatAddr(c.I4.Value))
+	}
+	if c.I6.IsSet {
+		n += 1 + len("I6"+formatAddr(c.I6.Value))
+	}
+	if c.U4.IsSet {
+		n += 1 + len("U4"+strconv.Itoa(c.U4.Value))
+	}
+	if c.U6.IsSet {
+		n += 1 + len("U6"+strconv.Itoa(c.U6.Value))
+	}
+	if c.SS.IsSet {
+		n += 1 + len("SS"+strconv.Itoa(c.SS.Value))
+	}
+	if c.SF.IsSet {
+		n += 1 + len("SF"+strconv.Itoa(c.SF.Value))
+	}
+	if c.VE.IsSet {
+		n += 1 + len("VE"+escapeValue(c.VE.Value))
+	}
+	if c.US.IsSet {
+		n += 1 + len("US"+strconv.Itoa(c.US.Value))
+	}
+	if c.DS.IsSet {
+		n += 1 + len("DS"+strconv.Itoa(c.DS.Value))
+	}
+	if c.SL.IsSet {
+		n += 1 + len("SL"+strconv.Itoa(c.SL.Value))
+	}
+	if c.AS.IsSet {
+		n += 1 + len("AS"+strconv.Itoa(c.AS.Value))
+	}
+	if c.AM.IsSet {
+		n += 1 + len("AM"+strconv.Itoa(c.AM.Value))
+	}
+	if c.EM.IsSet {
+		n += 1 + len("EM"+escapeValue(c.EM.Value))
+	}
+	if c.NI.IsSet {
+		n += 1 + len("NI"+escapeValue(c.NI.Value))
+	}
+	if c.DE.IsSet {
+		n += 1 + len("DE"+escapeValue(c.DE.Value))
+	}
+	if c.HN.IsSet {
+		n += 1 + len("HN"+strconv.Itoa(c.HN.Value))
+	}
+	if c.HR.IsSet {
+		n += 1 + len("HR"+strconv.Itoa(c.HR.Value))
+	}
+	if c.HO.IsSet {
+		n += 1 + len("HO"+strconv.Itoa(c.HO.Value))
+	}
+	if c.TO.IsSet {
+		n += 1 + len("TO"+escapeValue(c.TO.Value))
+	}
+	if c.CT.IsSet {
+		n += 1 + len("CT"+strconv.Itoa(c.CT.Value))
+	}
+	if c.AW.IsSet {
+		n += 1 + len("AW"+strconv.Itoa(c.AW.Value))
+	}
+	if c.SU.IsSet {
+		n += 1 + len("SU"+c.SU.Value.String())
+	}
+	n += flagsSize(c.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (c *INFContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := c.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (c *INFContent) Equal(other *INFContent) bool {
+	return equalParams(c, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (c *INFContent) EqualIgnoring(other *INFContent, ignore ...string) bool {
+	if !isIgnored(ignore, "ID") && (c.ID.IsSet != other.ID.IsSet || c.ID.IsSet && formatBase32(c.ID.Value) != formatBase32(other.ID.Value)) {
+		return false
+	}
+	if !isIgnored(ignore, "PD") && (c.PD.IsSet != other.PD.IsSet || c.PD.IsSet && encoding.EncodeToBase32String(c.PD.Value) != encoding.EncodeToBase32String(other.PD.Value)) {
+		return false
+	}
+	if !isIgnored(ignore, "I4") && (c.I4.IsSet != other.I4.IsSet || c.I4.IsSet && formatAddr(c.I4.Value) != formatAddr(other.I4.Value)) {
+		return false
+	}
+	if !isIgnored(ignore, "I6") && (c.I6.IsSet != other.I6.IsSet || c.I6.IsSet && formatAddr(c.I6.Value) != formatAddr(other.I6.Value)) {
+		return false
+	}
+	if !isIgnored(ignore, "U4") && (c.U4.IsSet != other.U4.IsSet || c.U4.IsSet && c.U4.Value != other.U4.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "U6") && (c.U6.IsSet != other.U6.IsSet || c.U6.IsSet && c.U6.Value != other.U6.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SS") && (c.SS.IsSet != other.SS.IsSet || c.SS.IsSet && c.SS.Value != other.SS.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SF") && (c.SF.IsSet != other.SF.IsSet || c.SF.IsSet && c.SF.Value != other.SF.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "VE") && (c.VE.IsSet != other.VE.IsSet || c.VE.IsSet && c.VE.Value != other.VE.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "US") && (c.US.IsSet != other.US.IsSet || c.US.IsSet && c.US.Value != other.US.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "DS") && (c.DS.IsSet != other.DS.IsSet || c.DS.IsSet && c.DS.Value != other.DS.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SL") && (c.SL.IsSet != other.SL.IsSet || c.SL.IsSet && c.SL.Value != other.SL.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "AS") && (c.AS.IsSet != other.AS.IsSet || c.AS.IsSet && c.AS.Value != other.AS.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "AM") && (c.AM.IsSet != other.AM.IsSet || c.AM.IsSet && c.AM.Value != other.AM.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "EM") && (c.EM.IsSet != other.EM.IsSet || c.EM.IsSet && c.EM.Value != other.EM.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "NI") && (c.NI.IsSet != other.NI.IsSet || c.NI.IsSet && c.NI.Value != other.NI.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "DE") && (c.DE.IsSet != other.DE.IsSet || c.DE.IsSet && c.DE.Value != other.DE.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "HN") && (c.HN.IsSet != other.HN.IsSet || c.HN.IsSet && c.HN.Value != other.HN.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "HR") && (c.HR.IsSet != other.HR.IsSet || c.HR.IsSet && c.HR.Value != other.HR.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "HO") && (c.HO.IsSet != other.HO.IsSet || c.HO.IsSet && c.HO.Value != other.HO.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "TO") && (c.TO.IsSet != other.TO.IsSet || c.TO.IsSet && c.TO.Value != other.TO.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "CT") && (c.CT.IsSet != other.CT.IsSet || c.CT.IsSet && c.CT.Value != other.CT.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "AW") && (c.AW.IsSet != other.AW.IsSet || c.AW.IsSet && c.AW.Value != other.AW.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "OP") && (c.OP.IsSet != other.OP.IsSet || c.OP.IsSet && c.OP.Value != other.OP.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "SU") && (c.SU.IsSet != other.SU.IsSet || c.SU.IsSet && c.SU.Value.String() != other.SU.Value.String()) {
+		return false
+	}
+
+	return equalFlagsIgnoring(c.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (c *INFContent) HashKey() string {
+	return hashKey(c)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (c *INFContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(c, line, mode, func(params []string) (ParamAccessor, error) {
+		var other INFContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (c *INFContent) Redacted() *INFContent {
+	redacted := *c
+	if c.Flags != nil {
+		redacted.Flags = c.UnknownFlags()
+	}
+	if c.PD.IsSet {
+		redacted.PD.Set(append([]byte(nil), c.PD.Value...))
+	}
+	if c.SU.IsSet {
+		redacted.SU.Set(append(encoding.Features(nil), c.SU.Value...))
+	}
+
+	return &redacted
+}
+
+// DecodeWarnings returns a warning for each deprecated param present in the
+// content. Contents decoded from messages of legacy peers hold deprecated
+// params.
+func (c *INFContent) DecodeWarnings() []string {
+	var warnings []string
+	if c.OP.IsSet {
+		warnings = append(warnings, "param OP of message INF is deprecated: superseded by CT")
+	}
+
+	return warnings
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (c *INFContent) SetOptionalCount() int {
+	var n int
+	if c.ID.IsSet {
+		n++
+	}
+	if c.PD.IsSet {
+		n++
+	}
+	if c.I4.IsSet {
+		n++
+	}
+	if c.I6.IsSet {
+		n++
+	}
+	if c.U4.IsSet {
+		n++
+	}
+	if c.U6.IsSet {
+		n++
+	}
+	if c.SS.IsSet {
+		n++
+	}
+	if c.SF.IsSet {
+		n++
+	}
+	if c.VE.IsSet {
+		n++
+	}
+	if c.US.IsSet {
+		n++
+	}
+	if c.DS.IsSet {
+		n++
+	}
+	if c.SL.IsSet {
+		n++
+	}
+	if c.AS.IsSet {
+		n++
+	}
+	if c.AM.IsSet {
+		n++
+	}
+	if c.EM.IsSet {
+		n++
+	}
+	if c.NI.IsSet {
+		n++
+	}
+	if c.DE.IsSet {
+		n++
+	}
+	if c.HN.IsSet {
+		n++
+	}
+	if c.HR.IsSet {
+		n++
+	}
+	if c.HO.IsSet {
+		n++
+	}
+	if c.TO.IsSet {
+		n++
+	}
+	if c.CT.IsSet {
+		n++
+	}
+	if c.AW.IsSet {
+		n++
+	}
+	if c.OP.IsSet {
+		n++
+	}
+	if c.SU.IsSet {
+		n++
+	}
+	return n
+}
+
+// INFBuilder builds INFContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type INFBuilder struct {
+	content INFContent
+	err     error
+}
+
+// NewINFBuilder returns a builder of empty contents.
+func NewINFBuilder() *INFBuilder {
+	return &INFBuilder{}
+}
+
+// ID sets the ID param.
+func (b *INFBuilder) ID(value *encoding.Base32Value) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.ID.Set(value)
+	b.content.idStr = "ID" + str
+	return b
+}
+
+// PD sets the PD param.
+func (b *INFBuilder) PD(value []byte) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := encoding.EncodeToBase32String(value)
+	b.content.PD.Set(value)
+	b.content.pdStr = "PD" + str
+	return b
+}
+
+// I4 sets the I4 param.
+func (b *INFBuilder) I4(value netip.Addr) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := encoding.FormatAddr(value)
+	b.content.I4.Set(value)
+	b.content.i4Str = "I4" + str
+	return b
+}
+
+// I6 sets the I6 param.
+func (b *INFBuilder) I6(value netip.Addr) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := encoding.FormatAddr(value)
+	b.content.I6.Set(value)
+	b.content.i6Str = "I6" + str
+	return b
+}
+
+// U4 sets the U4 param.
+func (b *INFBuilder) U4(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.U4.Set(value)
+	b.content.u4Str = "U4" + str
+	return b
+}
+
+// U6 sets the U6 param.
+func (b *INFBuilder) U6(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.U6.Set(value)
+	b.content.u6Str = "U6" + str
+	return b
+}
+
+// SS sets the SS param.
+func (b *INFBuilder) SS(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SS.Set(value)
+	b.content.ssStr = "SS" + str
+	return b
+}
+
+// SF sets the SF param.
+func (b *INFBuilder) SF(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SF.Set(value)
+	b.content.sfStr = "SF" + str
+	return b
+}
+
+// VE sets the VE param.
+func (b *INFBuilder) VE(value string) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param VE of message INF: %w", err)
+		return b
+	}
+	b.content.VE.Set(value)
+	b.content.veStr = "VE" + str
+	return b
+}
+
+// US sets the US param.
+func (b *INFBuilder) US(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.US.Set(value)
+	b.content.usStr = "US" + str
+	return b
+}
+
+// DS sets the DS param.
+func (b *INFBuilder) DS(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.DS.Set(value)
+	b.content.dsStr = "DS" + str
+	return b
+}
+
+// SL sets the SL param.
+func (b *INFBuilder) SL(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SL.Set(value)
+	b.content.slStr = "SL" + str
+	return b
+}
+
+// AS sets the AS param.
+func (b *INFBuilder) AS(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.AS.Set(value)
+	b.content.asStr = "AS" + str
+	return b
+}
+
+// AM sets the AM param.
+func (b *INFBuilder) AM(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.AM.Set(value)
+	b.content.amStr = "AM" + str
+	return b
+}
+
+// EM sets the EM param.
+func (b *INFBuilder) EM(value string) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param EM of message INF: %w", err)
+		return b
+	}
+	b.content.EM.Set(value)
+	b.content.emStr = "EM" + str
+	return b
+}
+
+// NI sets the NI param.
+func (b *INFBuilder) NI(value string) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param NI of message INF: %w", err)
+		return b
+	}
+	b.content.NI.Set(value)
+	b.content.niStr = "NI" + str
+	return b
+}
+
+// DE sets the DE param.
+func (b *INFBuilder) DE(value string) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param DE of message INF: %w", err)
+		return b
+	}
+	b.content.DE.Set(value)
+	b.content.deStr = "DE" + str
+	return b
+}
+
+// HN sets the HN param.
+func (b *INFBuilder) HN(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.HN.Set(value)
+	b.content.hnStr = "HN" + str
+	return b
+}
+
+// HR sets the HR param.
+func (b *INFBuilder) HR(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.HR.Set(value)
+	b.content.hrStr = "HR" + str
+	return b
+}
+
+// HO sets the HO param.
+func (b *INFBuilder) HO(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.HO.Set(value)
+	b.content.hoStr = "HO" + str
+	return b
+}
+
+// TO sets the TO param.
+func (b *INFBuilder) TO(value string) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param TO of message INF: %w", err)
+		return b
+	}
+	b.content.TO.Set(value)
+	b.content.toStr = "TO" + str
+	return b
+}
+
+// CT sets the CT param.
+func (b *INFBuilder) CT(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.CT.Set(value)
+	b.content.ctStr = "CT" + str
+	return b
+}
+
+// AW sets the AW param.
+func (b *INFBuilder) AW(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.AW.Set(value)
+	b.content.awStr = "AW" + str
+	return b
+}
+
+// OP sets the OP param.
+func (b *INFBuilder) OP(value int) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.OP.Set(value)
+	b.content.opStr = "OP" + str
+	return b
+}
+
+// SU sets the SU param.
+func (b *INFBuilder) SU(value encoding.Features) *INFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.SU.Set(value)
+	b.content.suStr = "SU" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *INFBuilder) Build() (*INFContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
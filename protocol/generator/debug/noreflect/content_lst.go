@@ -0,0 +1,500 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type LSTFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f LSTFlag) String() string {
+	return "LSTFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f LSTFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &LSTContent{}
+var _ ADCMarshaler = &LSTContent{}
+var _ ADCUnmarshaler = &LSTContent{}
+var _ io.WriterTo = &LSTContent{}
+
+// LSTContent is the content of LST messages.
+type LSTContent struct {
+	Items    []string
+	itemsStr []string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (l *LSTContent) Positional() []string {
+	return l.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (l *LSTContent) AppendPositional(dst []string) []string {
+	for _, val := range l.Items {
+		dst = append(dst, escapeValue(val))
+	}
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (l *LSTContent) PosLen() int {
+	return len(l.Items)
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (l *LSTContent) PosAt(i int) string {
+	if i < 0 || i >= len(l.Items) {
+		panic(fmt.Sprintf("LST.PosAt: index %d out of range [0,%d)", i, l.PosLen()))
+	}
+
+	return escapeValue(l.Items[i])
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (l *LSTContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Items":
+		if len(l.Items) > 0 {
+			return escapeValue(l.Items[0]), true
+		}
+	}
+
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (l *LSTContent) ParseInto(params []string, opts *ParseOptions) error {
+	*l = LSTContent{}
+	l.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message LST: %w", err)
+	}
+
+	end := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			end++
+		}
+	}
+
+	pos := 0
+	for _, param := range params {
+		switch {
+		case isNamedParam(param):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				l.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message LST: %w", param[:2], err)
+			}
+			if l.Flags == nil {
+				l.Flags = make(map[string]string)
+			}
+			l.Flags[param[:2]] = param[2:]
+			continue
+		case pos < end:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				l.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Items of message LST: %w", err)
+			}
+			l.itemsStr = append(l.itemsStr, param)
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Items of message LST: %w", err)
+			}
+			l.Items = append(l.Items, val)
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message LST: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	return nil
+}
+
+// LSTContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func LSTContentFromAccessor(pa ParamAccessor) (*LSTContent, error) {
+	var l LSTContent
+	if err := l.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (l *LSTContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message LST: %w", err)
+	}
+
+	return l.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (l *LSTContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "LST" {
+		return fmt.Errorf("parsing message LST: %w", ErrCommandMismatch)
+	}
+
+	return l.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (l *LSTContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message LST: %w", err)
+	}
+	return l.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (l *LSTContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, l.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (l *LSTContent) SetNamedAll(named map[string]string) error {
+	decoded := *l
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*l = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (l *LSTContent) setNamedAll(named map[string]string) error {
+	l.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message LST: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if l.Flags == nil {
+			l.Flags = make(map[string]string)
+		}
+		l.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (l *LSTContent) ToMap() map[string]string {
+	values := l.UnknownFlags()
+	itemsStrs := make([]string, len(l.Items))
+	for i, val := range l.Items {
+		itemsStrs[i] = escapeValue(val)
+	}
+	values["Items"] = joinValues(itemsStrs, 0)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (l *LSTContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Items"]; ok {
+		params = append(params, splitValues(val)...)
+	} else {
+		return fmt.Errorf("converting param Items of message LST: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "Items":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message LST: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return l.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (l *LSTContent) AppendADC(buf []byte) ([]byte, error) {
+	buf = append(buf, "LST"...)
+
+	for _, val := range l.Items {
+		buf = append(buf, ' ')
+		buf = appendEscaped(buf, val)
+	}
+	buf = appendFlags(buf, l.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (l *LSTContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(l.WireSize())
+	builder.WriteString("LST")
+
+	for _, val := range l.Items {
+		builder.WriteByte(' ')
+		builder.WriteString(escapeValue(val))
+	}
+	writeFlags(&builder, l.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (l *LSTContent) Validate() error {
+	var errs []error
+	for _, name := range l.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message LST: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(l.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message LST: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (l *LSTContent) MissingRequired() []string {
+	return nil
+}
+
+var lstDescriptor = MessageDescriptor{
+	Command: "LST",
+	Positional: []ParamDescriptor{{
+		DisplayName: "Items",
+		Name:        "Items",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (l *LSTContent) Descriptor() MessageDescriptor {
+	return lstDescriptor
+}
+
+// Command returns the command of the content, i.e. LST.
+func (l *LSTContent) Command() string {
+	return "LST"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (l *LSTContent) MsgType() byte {
+	return l.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (l *LSTContent) MarshalADC() ([]byte, error) {
+	return l.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (l *LSTContent) WireSize() int {
+	n := len("LST")
+
+	for _, val := range l.Items {
+		n += 1 + len(escapeValue(val))
+	}
+	n += flagsSize(l.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (l *LSTContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := l.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (l *LSTContent) Equal(other *LSTContent) bool {
+	return equalParams(l, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (l *LSTContent) EqualIgnoring(other *LSTContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Items") {
+		if len(l.Items) != len(other.Items) {
+			return false
+		}
+		for i, val := range l.Items {
+			if val != other.Items[i] {
+				return false
+			}
+		}
+	}
+
+	return equalFlagsIgnoring(l.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (l *LSTContent) HashKey() string {
+	return hashKey(l)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (l *LSTContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(l, line, mode, func(params []string) (ParamAccessor, error) {
+		var other LSTContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (l *LSTContent) Redacted() *LSTContent {
+	redacted := *l
+	if l.Flags != nil {
+		redacted.Flags = l.UnknownFlags()
+	}
+	redacted.itemsStr = append([]string(nil), l.itemsStr...)
+	redacted.Items = append([]string(nil), l.Items...)
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (l *LSTContent) SetOptionalCount() int {
+	return 0
+}
+
+// LSTBuilder builds LSTContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type LSTBuilder struct {
+	content LSTContent
+	err     error
+}
+
+// NewLSTBuilder returns a builder of empty contents.
+func NewLSTBuilder() *LSTBuilder {
+	return &LSTBuilder{}
+}
+
+// Items replaces all values of the Items param.
+func (b *LSTBuilder) Items(values ...string) *LSTBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, val := range values {
+		str, err := encoding.EncodeToADCString(val)
+		if err != nil {
+			b.err = fmt.Errorf("building param Items of message LST: %w", err)
+			return b
+		}
+		strs = append(strs, str)
+	}
+	b.content.Items = append([]string{}, values...)
+	b.content.itemsStr = strs
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *LSTBuilder) Build() (*LSTContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
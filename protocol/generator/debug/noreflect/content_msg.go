@@ -0,0 +1,632 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type MSGFlag string
+
+const (
+	MSGFlagTS MSGFlag = "TS"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f MSGFlag) String() string {
+	switch f {
+	case MSGFlagTS:
+		return "MSGFlagTS"
+	}
+	return "MSGFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f MSGFlag) IsKnown() bool {
+	switch f {
+	case MSGFlagTS:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &MSGContent{}
+var _ ADCMarshaler = &MSGContent{}
+var _ ADCUnmarshaler = &MSGContent{}
+var _ io.WriterTo = &MSGContent{}
+
+// MSGContent is the content of MSG messages.
+type MSGContent struct {
+	Text    string
+	textStr string
+
+	TS    Time
+	tsStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (m *MSGContent) Positional() []string {
+	return m.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (m *MSGContent) AppendPositional(dst []string) []string {
+	return append(dst, escapeValue(m.Text))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (m *MSGContent) PosLen() int {
+	return 1
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (m *MSGContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return escapeValue(m.Text)
+	default:
+		panic(fmt.Sprintf("MSG.PosAt: index %d out of range [0,%d)", i, m.PosLen()))
+	}
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (m *MSGContent) Named() map[string]string {
+	params := m.UnknownFlags()
+
+	if m.TS.IsSet {
+		params["TS"] = encoding.FormatTimestamp(m.TS.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (m *MSGContent) NamedGet(key string) (string, bool) {
+	switch MSGFlag(key) {
+	case MSGFlagTS:
+		if m.TS.IsSet {
+			return encoding.FormatTimestamp(m.TS.Value), true
+		}
+		return "", false
+	}
+
+	return m.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (m *MSGContent) NamedAll(key string) []string {
+	if val, ok := m.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetTS returns the decoded value of the TS param and whether it is set.
+func (m *MSGContent) GetTS() (time.Time, bool) {
+	return m.TS.Value, m.TS.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (m *MSGContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Text":
+		return escapeValue(m.Text), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (m *MSGContent) PositionalValues() string {
+	return m.Text
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (m *MSGContent) ParseInto(params []string, opts *ParseOptions) error {
+	*m = MSGContent{}
+	m.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message MSG: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 1 {
+		return fmt.Errorf("parsing message MSG: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 1 && isNamedParam(param) && (pos+plain >= 1):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message MSG: %w", param[:2], err)
+			}
+			switch MSGFlag(param[:2]) {
+			case MSGFlagTS:
+				if err := opts.checkDuplicateFlag(m.tsStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message MSG: %w", param[:2], err)
+				}
+				m.tsStr = param
+				val, err := encoding.ParseTimestamp(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TS of message MSG: %w", err)
+				}
+				m.TS.Set(val)
+			default:
+				if m.Flags == nil {
+					m.Flags = make(map[string]string)
+				}
+				m.Flags[param[:2]] = param[2:]
+			}
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				m.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Text of message MSG: %w", err)
+			}
+			m.textStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Text of message MSG: %w", err)
+			}
+			m.Text = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message MSG: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 1 {
+		return fmt.Errorf("parsing message MSG: %w", ErrMissingParam)
+	}
+
+	if m.Text == "" {
+		return fmt.Errorf("parsing param Text of message MSG: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// MSGContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func MSGContentFromAccessor(pa ParamAccessor) (*MSGContent, error) {
+	var m MSGContent
+	if err := m.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (m *MSGContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message MSG: %w", err)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (m *MSGContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "MSG" {
+		return fmt.Errorf("parsing message MSG: %w", ErrCommandMismatch)
+	}
+
+	return m.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (m *MSGContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message MSG: %w", err)
+	}
+	return m.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (m *MSGContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, m.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (m *MSGContent) SetNamedAll(named map[string]string) error {
+	decoded := *m
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*m = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (m *MSGContent) setNamedAll(named map[string]string) error {
+	var zero MSGContent
+	m.TS = zero.TS
+	m.tsStr = zero.tsStr
+	m.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message MSG: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch MSGFlag(param[:2]) {
+		case MSGFlagTS:
+			m.tsStr = param
+			val, err := encoding.ParseTimestamp(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TS of message MSG: %w", err)
+			}
+			m.TS.Set(val)
+		default:
+			if m.Flags == nil {
+				m.Flags = make(map[string]string)
+			}
+			m.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (m *MSGContent) ToMap() map[string]string {
+	values := m.UnknownFlags()
+	values["Text"] = escapeValue(m.Text)
+	if m.TS.IsSet {
+		values["TS"] = encoding.FormatTimestamp(m.TS.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (m *MSGContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Text"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Text of message MSG: %w", ErrMissingParam)
+	}
+	if val, ok := values["TS"]; ok {
+		params = append(params, "TS"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "Text", "TS":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message MSG: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return m.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (m *MSGContent) AppendADC(buf []byte) ([]byte, error) {
+	n := len(buf)
+	buf = append(buf, "MSG"...)
+
+	if m.Text == "" {
+		return buf[:n], fmt.Errorf("marshalling param Text of message MSG: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, m.Text)
+	if m.TS.IsSet {
+		buf = append(buf, " TS"...)
+		buf = strconv.AppendInt(buf, m.TS.Value.Unix(), 10)
+	}
+	buf = appendFlags(buf, m.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (m *MSGContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(m.WireSize())
+	builder.WriteString("MSG")
+
+	if m.Text == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(m.Text))
+	if m.TS.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TS" + encoding.FormatTimestamp(m.TS.Value))
+	}
+	writeFlags(&builder, m.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (m *MSGContent) Validate() error {
+	var errs []error
+	for _, name := range m.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message MSG: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(m.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message MSG: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (m *MSGContent) MissingRequired() []string {
+	var missing []string
+	if m.Text == "" {
+		missing = append(missing, "Text")
+	}
+	return missing
+}
+
+var msgDescriptor = MessageDescriptor{
+	Command: "MSG",
+	Named: []ParamDescriptor{{
+		DisplayName: "Timestamp",
+		FlagName:    "TS",
+		Name:        "TS",
+		Required:    false,
+		Type:        "timestamp",
+	}},
+	Positional: []ParamDescriptor{{
+		DisplayName: "Text",
+		Name:        "Text",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (m *MSGContent) Descriptor() MessageDescriptor {
+	return msgDescriptor
+}
+
+// Command returns the command of the content, i.e. MSG.
+func (m *MSGContent) Command() string {
+	return "MSG"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (m *MSGContent) MsgType() byte {
+	return m.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (m *MSGContent) MarshalADC() ([]byte, error) {
+	return m.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (m *MSGContent) WireSize() int {
+	n := len("MSG")
+
+	n += 1 + len(escapeValue(m.Text))
+	if m.TS.IsSet {
+		n += 1 + len("TS"+encoding.FormatTimestamp(m.TS.Value))
+	}
+	n += flagsSize(m.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (m *MSGContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := m.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (m *MSGContent) Equal(other *MSGContent) bool {
+	return equalParams(m, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (m *MSGContent) EqualIgnoring(other *MSGContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Text") && m.Text != other.Text {
+		return false
+	}
+	if !isIgnored(ignore, "TS") && (m.TS.IsSet != other.TS.IsSet || m.TS.IsSet && encoding.FormatTimestamp(m.TS.Value) != encoding.FormatTimestamp(other.TS.Value)) {
+		return false
+	}
+
+	return equalFlagsIgnoring(m.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (m *MSGContent) HashKey() string {
+	return hashKey(m)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (m *MSGContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(m, line, mode, func(params []string) (ParamAccessor, error) {
+		var other MSGContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (m *MSGContent) Redacted() *MSGContent {
+	redacted := *m
+	if m.Flags != nil {
+		redacted.Flags = m.UnknownFlags()
+	}
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (m *MSGContent) SetOptionalCount() int {
+	var n int
+	if m.TS.IsSet {
+		n++
+	}
+	return n
+}
+
+// MSGBuilder builds MSGContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type MSGBuilder struct {
+	content MSGContent
+	err     error
+}
+
+// NewMSGBuilder returns a builder of empty contents.
+func NewMSGBuilder() *MSGBuilder {
+	return &MSGBuilder{}
+}
+
+// Text sets the Text param.
+func (b *MSGBuilder) Text(value string) *MSGBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Text of message MSG: %w", err)
+		return b
+	}
+	b.content.Text = value
+	b.content.textStr = str
+	return b
+}
+
+// TS sets the TS param.
+func (b *MSGBuilder) TS(value time.Time) *MSGBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := encoding.FormatTimestamp(value)
+	b.content.TS.Set(value)
+	b.content.tsStr = "TS" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *MSGBuilder) Build() (*MSGContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
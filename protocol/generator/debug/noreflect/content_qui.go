@@ -0,0 +1,715 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type QUIFlag string
+
+const (
+	QUIFlagTL QUIFlag = "TL"
+	QUIFlagMS QUIFlag = "MS"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f QUIFlag) String() string {
+	switch f {
+	case QUIFlagTL:
+		return "QUIFlagTL"
+	case QUIFlagMS:
+		return "QUIFlagMS"
+	}
+	return "QUIFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f QUIFlag) IsKnown() bool {
+	switch f {
+	case QUIFlagTL, QUIFlagMS:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &QUIContent{}
+var _ ADCMarshaler = &QUIContent{}
+var _ ADCUnmarshaler = &QUIContent{}
+var _ io.WriterTo = &QUIContent{}
+
+// QUIContent is the content of QUI messages. Notification that a client has
+// disconnected. See ADC § 5.3.17.
+type QUIContent struct {
+	SID    *encoding.Base32Value
+	sidStr string
+
+	TL    Duration
+	tlStr string
+
+	MS    String
+	msStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (q *QUIContent) Positional() []string {
+	return q.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (q *QUIContent) AppendPositional(dst []string) []string {
+	return append(dst, formatBase32(q.SID))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (q *QUIContent) PosLen() int {
+	return 1
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (q *QUIContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return formatBase32(q.SID)
+	default:
+		panic(fmt.Sprintf("QUI.PosAt: index %d out of range [0,%d)", i, q.PosLen()))
+	}
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (q *QUIContent) Named() map[string]string {
+	params := q.UnknownFlags()
+
+	if q.TL.IsSet {
+		params["TL"] = encoding.FormatDuration(q.TL.Value)
+	}
+	if q.MS.IsSet {
+		params["MS"] = escapeValue(q.MS.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (q *QUIContent) NamedGet(key string) (string, bool) {
+	switch QUIFlag(key) {
+	case QUIFlagTL:
+		if q.TL.IsSet {
+			return encoding.FormatDuration(q.TL.Value), true
+		}
+		return "", false
+	case QUIFlagMS:
+		if q.MS.IsSet {
+			return escapeValue(q.MS.Value), true
+		}
+		return "", false
+	}
+
+	return q.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (q *QUIContent) NamedAll(key string) []string {
+	if val, ok := q.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetTL returns the decoded value of the TL param and whether it is set.
+func (q *QUIContent) GetTL() (time.Duration, bool) {
+	return q.TL.Value, q.TL.IsSet
+}
+
+// GetMS returns the decoded value of the MS param and whether it is set.
+func (q *QUIContent) GetMS() (string, bool) {
+	return q.MS.Value, q.MS.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (q *QUIContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "SID":
+		return formatBase32(q.SID), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (q *QUIContent) PositionalValues() *encoding.Base32Value {
+	return q.SID
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (q *QUIContent) ParseInto(params []string, opts *ParseOptions) error {
+	*q = QUIContent{}
+	q.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message QUI: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 1 {
+		return fmt.Errorf("parsing message QUI: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 1 && isNamedParam(param) && (pos+plain >= 1):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				q.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message QUI: %w", param[:2], err)
+			}
+			switch QUIFlag(param[:2]) {
+			case QUIFlagTL:
+				if err := opts.checkDuplicateFlag(q.tlStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message QUI: %w", param[:2], err)
+				}
+				q.tlStr = param
+				val, err := encoding.ParseDuration(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TL of message QUI: %w", err)
+				}
+				q.TL.Set(val)
+			case QUIFlagMS:
+				if err := opts.checkDuplicateFlag(q.msStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message QUI: %w", param[:2], err)
+				}
+				q.msStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param MS of message QUI: %w", err)
+				}
+				q.MS.Set(val)
+			default:
+				if q.Flags == nil {
+					q.Flags = make(map[string]string)
+				}
+				q.Flags[param[:2]] = param[2:]
+			}
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				q.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param SID of message QUI: %w", err)
+			}
+			q.sidStr = param
+			val, err := encoding.ParseBase32Value(param)
+			if err != nil {
+				return fmt.Errorf("parsing param SID of message QUI: %w", err)
+			}
+			q.SID = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message QUI: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 1 {
+		return fmt.Errorf("parsing message QUI: %w", ErrMissingParam)
+	}
+
+	if formatBase32(q.SID) == "" {
+		return fmt.Errorf("parsing param SID of message QUI: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// QUIContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func QUIContentFromAccessor(pa ParamAccessor) (*QUIContent, error) {
+	var q QUIContent
+	if err := q.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (q *QUIContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message QUI: %w", err)
+	}
+
+	return q.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (q *QUIContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "QUI" {
+		return fmt.Errorf("parsing message QUI: %w", ErrCommandMismatch)
+	}
+
+	return q.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (q *QUIContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message QUI: %w", err)
+	}
+	return q.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (q *QUIContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, q.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (q *QUIContent) SetNamedAll(named map[string]string) error {
+	decoded := *q
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*q = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (q *QUIContent) setNamedAll(named map[string]string) error {
+	var zero QUIContent
+	q.TL = zero.TL
+	q.tlStr = zero.tlStr
+	q.MS = zero.MS
+	q.msStr = zero.msStr
+	q.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message QUI: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch QUIFlag(param[:2]) {
+		case QUIFlagTL:
+			q.tlStr = param
+			val, err := encoding.ParseDuration(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TL of message QUI: %w", err)
+			}
+			q.TL.Set(val)
+		case QUIFlagMS:
+			q.msStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param MS of message QUI: %w", err)
+			}
+			q.MS.Set(val)
+		default:
+			if q.Flags == nil {
+				q.Flags = make(map[string]string)
+			}
+			q.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (q *QUIContent) ToMap() map[string]string {
+	values := q.UnknownFlags()
+	values["SID"] = formatBase32(q.SID)
+	if q.TL.IsSet {
+		values["TL"] = encoding.FormatDuration(q.TL.Value)
+	}
+	if q.MS.IsSet {
+		values["MS"] = escapeValue(q.MS.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (q *QUIContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["SID"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param SID of message QUI: %w", ErrMissingParam)
+	}
+	if val, ok := values["TL"]; ok {
+		params = append(params, "TL"+val)
+	}
+	if val, ok := values["MS"]; ok {
+		params = append(params, "MS"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "SID", "TL", "MS":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message QUI: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return q.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (q *QUIContent) AppendADC(buf []byte) ([]byte, error) {
+	n := len(buf)
+	buf = append(buf, "QUI"...)
+
+	if formatBase32(q.SID) == "" {
+		return buf[:n], fmt.Errorf("marshalling param SID of message QUI: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = append(buf, formatBase32(q.SID)...)
+	if q.TL.IsSet {
+		buf = append(buf, " TL"...)
+		buf = strconv.AppendInt(buf, int64(q.TL.Value/time.Second), 10)
+	}
+	if q.MS.IsSet {
+		buf = append(buf, " MS"...)
+		buf = appendEscaped(buf, q.MS.Value)
+	}
+	buf = appendFlags(buf, q.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (q *QUIContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(q.WireSize())
+	builder.WriteString("QUI")
+
+	if formatBase32(q.SID) == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(formatBase32(q.SID))
+	if q.TL.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TL" + encoding.FormatDuration(q.TL.Value))
+	}
+	if q.MS.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("MS" + escapeValue(q.MS.Value))
+	}
+	writeFlags(&builder, q.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (q *QUIContent) Validate() error {
+	var errs []error
+	for _, name := range q.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message QUI: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(q.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message QUI: %w", err))
+	}
+
+	if q.SID != nil && len(q.SID.String()) != 4 {
+		errs = append(errs, fmt.Errorf("validating param SID of message QUI: %w, must be 4 characters long", ErrInvalidLength))
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (q *QUIContent) MissingRequired() []string {
+	var missing []string
+	if formatBase32(q.SID) == "" {
+		missing = append(missing, "SID")
+	}
+	return missing
+}
+
+var quiDescriptor = MessageDescriptor{
+	Command: "QUI",
+	Named: []ParamDescriptor{{
+		DisplayName: "Time left",
+		FlagName:    "TL",
+		Name:        "TL",
+		Required:    false,
+		Type:        "duration",
+	}, {
+		DisplayName: "MS",
+		FlagName:    "MS",
+		Name:        "MS",
+		Required:    false,
+		Type:        "string",
+	}},
+	Positional: []ParamDescriptor{{
+		DisplayName: "SID",
+		Name:        "SID",
+		Required:    true,
+		Type:        "base32",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (q *QUIContent) Descriptor() MessageDescriptor {
+	return quiDescriptor
+}
+
+// Command returns the command of the content, i.e. QUI.
+func (q *QUIContent) Command() string {
+	return "QUI"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (q *QUIContent) MsgType() byte {
+	return q.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (q *QUIContent) MarshalADC() ([]byte, error) {
+	return q.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (q *QUIContent) WireSize() int {
+	n := len("QUI")
+
+	n += 1 + len(formatBase32(q.SID))
+	if q.TL.IsSet {
+		n += 1 + len("TL"+encoding.FormatDuration(q.TL.Value))
+	}
+	if q.MS.IsSet {
+		n += 1 + len("MS"+escapeValue(q.MS.Value))
+	}
+	n += flagsSize(q.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (q *QUIContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := q.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (q *QUIContent) Equal(other *QUIContent) bool {
+	return equalParams(q, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (q *QUIContent) EqualIgnoring(other *QUIContent, ignore ...string) bool {
+	if !isIgnored(ignore, "SID") && formatBase32(q.SID) != formatBase32(other.SID) {
+		return false
+	}
+	if !isIgnored(ignore, "TL") && (q.TL.IsSet != other.TL.IsSet || q.TL.IsSet && encoding.FormatDuration(q.TL.Value) != encoding.FormatDuration(other.TL.Value)) {
+		return false
+	}
+	if !isIgnored(ignore, "MS") && (q.MS.IsSet != other.MS.IsSet || q.MS.IsSet && q.MS.Value != other.MS.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(q.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (q *QUIContent) HashKey() string {
+	return hashKey(q)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (q *QUIContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(q, line, mode, func(params []string) (ParamAccessor, error) {
+		var other QUIContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (q *QUIContent) Redacted() *QUIContent {
+	redacted := *q
+	if q.Flags != nil {
+		redacted.Flags = q.UnknownFlags()
+	}
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (q *QUIContent) SetOptionalCount() int {
+	var n int
+	if q.TL.IsSet {
+		n++
+	}
+	if q.MS.IsSet {
+		n++
+	}
+	return n
+}
+
+// QUIBuilder builds QUIContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type QUIBuilder struct {
+	content QUIContent
+	err     error
+}
+
+// NewQUIBuilder returns a builder of empty contents.
+func NewQUIBuilder() *QUIBuilder {
+	return &QUIBuilder{}
+}
+
+// SID sets the SID param.
+func (b *QUIBuilder) SID(value *encoding.Base32Value) *QUIBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.SID = value
+	b.content.sidStr = str
+	return b
+}
+
+// TL sets the TL param.
+func (b *QUIBuilder) TL(value time.Duration) *QUIBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := encoding.FormatDuration(value)
+	b.content.TL.Set(value)
+	b.content.tlStr = "TL" + str
+	return b
+}
+
+// MS sets the MS param.
+func (b *QUIBuilder) MS(value string) *QUIBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param MS of message QUI: %w", err)
+		return b
+	}
+	b.content.MS.Set(value)
+	b.content.msStr = "MS" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *QUIBuilder) Build() (*QUIContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,954 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type RESFlag string
+
+const (
+	// RESFlagFN - Full filename including path in share, using / as the path
+	// separator.
+	RESFlagFN RESFlag = "FN"
+	RESFlagSI RESFlag = "SI"
+	RESFlagSL RESFlag = "SL"
+	RESFlagTO RESFlag = "TO"
+	RESFlagTR RESFlag = "TR"
+	RESFlagTD RESFlag = "TD"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f RESFlag) String() string {
+	switch f {
+	case RESFlagFN:
+		return "RESFlagFN"
+	case RESFlagSI:
+		return "RESFlagSI"
+	case RESFlagSL:
+		return "RESFlagSL"
+	case RESFlagTO:
+		return "RESFlagTO"
+	case RESFlagTR:
+		return "RESFlagTR"
+	case RESFlagTD:
+		return "RESFlagTD"
+	}
+	return "RESFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f RESFlag) IsKnown() bool {
+	switch f {
+	case RESFlagFN, RESFlagSI, RESFlagSL, RESFlagTO, RESFlagTR, RESFlagTD:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &RESContent{}
+var _ ADCMarshaler = &RESContent{}
+var _ ADCUnmarshaler = &RESContent{}
+var _ io.WriterTo = &RESContent{}
+
+// RESContent is the content of RES messages. Search result, sent in response
+// to a search request matching files of the client. See ADC § 5.3.8.
+type RESContent struct {
+	// FN - Full filename including path in share, using / as the path separator.
+	FN    string
+	fnStr string
+
+	SI    int
+	siStr string
+
+	SL    Int
+	slStr string
+
+	TO    string
+	toStr string
+
+	TR    TTH
+	trStr string
+
+	TD    Int
+	tdStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// FI, FO, DA; EXT § 3.27 ASCH - Extended searching capability (EXT v1.0.8)
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (r *RESContent) Positional() []string {
+	return r.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (r *RESContent) AppendPositional(dst []string) []string {
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (r *RESContent) PosLen() int {
+	return 0
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (r *RESContent) PosAt(i int) string {
+	panic(fmt.Sprintf("RES.PosAt: index %d out of range [0,%d)", i, r.PosLen()))
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (r *RESContent) Named() map[string]string {
+	params := r.UnknownFlags()
+
+	if r.FN != "" {
+		params["FN"] = escapeValue(r.FN)
+	}
+	params["SI"] = strconv.Itoa(r.SI)
+	if r.SL.IsSet {
+		params["SL"] = strconv.Itoa(r.SL.Value)
+	}
+	if r.TO != "" {
+		params["TO"] = escapeValue(r.TO)
+	}
+	if r.TR.IsSet {
+		params["TR"] = r.TR.Value.String()
+	}
+	if r.TD.IsSet {
+		params["TD"] = strconv.Itoa(r.TD.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (r *RESContent) NamedGet(key string) (string, bool) {
+	switch RESFlag(key) {
+	case RESFlagFN:
+		if r.FN != "" {
+			return escapeValue(r.FN), true
+		}
+		return "", false
+	case RESFlagSI:
+		return strconv.Itoa(r.SI), true
+	case RESFlagSL:
+		if r.SL.IsSet {
+			return strconv.Itoa(r.SL.Value), true
+		}
+		return "", false
+	case RESFlagTO:
+		if r.TO != "" {
+			return escapeValue(r.TO), true
+		}
+		return "", false
+	case RESFlagTR:
+		if r.TR.IsSet {
+			return r.TR.Value.String(), true
+		}
+		return "", false
+	case RESFlagTD:
+		if r.TD.IsSet {
+			return strconv.Itoa(r.TD.Value), true
+		}
+		return "", false
+	}
+
+	return r.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (r *RESContent) NamedAll(key string) []string {
+	if val, ok := r.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetFN returns the decoded value of the FN param and whether it is set.
+func (r *RESContent) GetFN() (string, bool) {
+	return r.FN, r.FN != ""
+}
+
+// GetSI returns the decoded value of the SI param and whether it is set.
+func (r *RESContent) GetSI() (int, bool) {
+	return r.SI, true
+}
+
+// GetSL returns the decoded value of the SL param and whether it is set.
+func (r *RESContent) GetSL() (int, bool) {
+	return r.SL.Value, r.SL.IsSet
+}
+
+// GetTO returns the decoded value of the TO param and whether it is set.
+func (r *RESContent) GetTO() (string, bool) {
+	return r.TO, r.TO != ""
+}
+
+// GetTR returns the decoded value of the TR param and whether it is set.
+func (r *RESContent) GetTR() (encoding.TTH, bool) {
+	return r.TR.Value, r.TR.IsSet
+}
+
+// GetTD returns the decoded value of the TD param and whether it is set.
+func (r *RESContent) GetTD() (int, bool) {
+	return r.TD.Value, r.TD.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (r *RESContent) PosByName(name string) (string, bool) {
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (r *RESContent) ParseInto(params []string, opts *ParseOptions) error {
+	*r = RESContent{}
+	r.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message RES: %w", err)
+	}
+
+	for _, param := range params {
+		switch {
+		case isNamedParam(param):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				r.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+			}
+			switch RESFlag(param[:2]) {
+			case RESFlagFN:
+				if err := opts.checkDuplicateFlag(r.fnStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.fnStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param FN of message RES: %w", err)
+				}
+				r.FN = val
+			case RESFlagSI:
+				if err := opts.checkDuplicateFlag(r.siStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.siStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SI of message RES: %w", err)
+				}
+				r.SI = val
+			case RESFlagSL:
+				if err := opts.checkDuplicateFlag(r.slStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.slStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param SL of message RES: %w", err)
+				}
+				r.SL.Set(val)
+			case RESFlagTO:
+				if err := opts.checkDuplicateFlag(r.toStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.toStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TO of message RES: %w", err)
+				}
+				r.TO = val
+			case RESFlagTR:
+				if err := opts.checkDuplicateFlag(r.trStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.trStr = param
+				val, err := encoding.ParseTTH(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TR of message RES: %w", err)
+				}
+				r.TR.Set(val)
+			case RESFlagTD:
+				if err := opts.checkDuplicateFlag(r.tdStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message RES: %w", param[:2], err)
+				}
+				r.tdStr = param
+				val, err := strconv.Atoi(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TD of message RES: %w", err)
+				}
+				r.TD.Set(val)
+			default:
+				if r.Flags == nil {
+					r.Flags = make(map[string]string)
+				}
+				r.Flags[param[:2]] = param[2:]
+			}
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message RES: %w", err)
+			}
+		}
+	}
+
+	if r.fnStr == "" || r.FN == "" {
+		return fmt.Errorf("parsing param FN of message RES: %w", ErrMissingParam)
+	}
+	if r.siStr == "" {
+		return fmt.Errorf("parsing param SI of message RES: %w", ErrMissingParam)
+	}
+	if r.toStr == "" || r.TO == "" {
+		return fmt.Errorf("parsing param TO of message RES: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// RESContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func RESContentFromAccessor(pa ParamAccessor) (*RESContent, error) {
+	var r RESContent
+	if err := r.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (r *RESContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message RES: %w", err)
+	}
+
+	return r.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (r *RESContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "RES" {
+		return fmt.Errorf("parsing message RES: %w", ErrCommandMismatch)
+	}
+
+	return r.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (r *RESContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message RES: %w", err)
+	}
+	return r.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (r *RESContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, r.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (r *RESContent) SetNamedAll(named map[string]string) error {
+	decoded := *r
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*r = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (r *RESContent) setNamedAll(named map[string]string) error {
+	var zero RESContent
+	r.FN = zero.FN
+	r.fnStr = zero.fnStr
+	r.SI = zero.SI
+	r.siStr = zero.siStr
+	r.SL = zero.SL
+	r.slStr = zero.slStr
+	r.TO = zero.TO
+	r.toStr = zero.toStr
+	r.TR = zero.TR
+	r.trStr = zero.trStr
+	r.TD = zero.TD
+	r.tdStr = zero.tdStr
+	r.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message RES: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch RESFlag(param[:2]) {
+		case RESFlagFN:
+			r.fnStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param FN of message RES: %w", err)
+			}
+			r.FN = val
+		case RESFlagSI:
+			r.siStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SI of message RES: %w", err)
+			}
+			r.SI = val
+		case RESFlagSL:
+			r.slStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param SL of message RES: %w", err)
+			}
+			r.SL.Set(val)
+		case RESFlagTO:
+			r.toStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TO of message RES: %w", err)
+			}
+			r.TO = val
+		case RESFlagTR:
+			r.trStr = param
+			val, err := encoding.ParseTTH(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TR of message RES: %w", err)
+			}
+			r.TR.Set(val)
+		case RESFlagTD:
+			r.tdStr = param
+			val, err := strconv.Atoi(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TD of message RES: %w", err)
+			}
+			r.TD.Set(val)
+		default:
+			if r.Flags == nil {
+				r.Flags = make(map[string]string)
+			}
+			r.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (r *RESContent) ToMap() map[string]string {
+	values := r.UnknownFlags()
+	if r.FN != "" {
+		values["FN"] = escapeValue(r.FN)
+	}
+	values["SI"] = strconv.Itoa(r.SI)
+	if r.SL.IsSet {
+		values["SL"] = strconv.Itoa(r.SL.Value)
+	}
+	if r.TO != "" {
+		values["TO"] = escapeValue(r.TO)
+	}
+	if r.TR.IsSet {
+		values["TR"] = r.TR.Value.String()
+	}
+	if r.TD.IsSet {
+		values["TD"] = strconv.Itoa(r.TD.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (r *RESContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["FN"]; ok {
+		params = append(params, "FN"+val)
+	}
+	if val, ok := values["SI"]; ok {
+		params = append(params, "SI"+val)
+	}
+	if val, ok := values["SL"]; ok {
+		params = append(params, "SL"+val)
+	}
+	if val, ok := values["TO"]; ok {
+		params = append(params, "TO"+val)
+	}
+	if val, ok := values["TR"]; ok {
+		params = append(params, "TR"+val)
+	}
+	if val, ok := values["TD"]; ok {
+		params = append(params, "TD"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "FN", "SI", "SL", "TO", "TR", "TD":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message RES: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return r.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (r *RESContent) AppendADC(buf []byte) ([]byte, error) {
+	n := len(buf)
+	buf = append(buf, "RES"...)
+
+	if r.FN == "" {
+		return buf[:n], fmt.Errorf("marshalling param FN of message RES: %w", ErrMissingParam)
+	}
+	buf = append(buf, " FN"...)
+	buf = appendEscaped(buf, r.FN)
+	buf = append(buf, " SI"...)
+	buf = strconv.AppendInt(buf, int64(r.SI), 10)
+	if r.SL.IsSet {
+		buf = append(buf, " SL"...)
+		buf = strconv.AppendInt(buf, int64(r.SL.Value), 10)
+	}
+	if r.TO == "" {
+		return buf[:n], fmt.Errorf("marshalling param TO of message RES: %w", ErrMissingParam)
+	}
+	buf = append(buf, " TO"...)
+	buf = appendEscaped(buf, r.TO)
+	if r.TR.IsSet {
+		buf = append(buf, " TR"...)
+		buf = append(buf, r.TR.Value.String()...)
+	}
+	if r.TD.IsSet {
+		buf = append(buf, " TD"...)
+		buf = strconv.AppendInt(buf, int64(r.TD.Value), 10)
+	}
+	buf = appendFlags(buf, r.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (r *RESContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(r.WireSize())
+	builder.WriteString("RES")
+
+	if r.FN == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString("FN" + escapeValue(r.FN))
+	builder.WriteByte(' ')
+	builder.WriteString("SI" + strconv.Itoa(r.SI))
+	if r.SL.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("SL" + strconv.Itoa(r.SL.Value))
+	}
+	if r.TO == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString("TO" + escapeValue(r.TO))
+	if r.TR.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TR" + r.TR.Value.String())
+	}
+	if r.TD.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TD" + strconv.Itoa(r.TD.Value))
+	}
+	writeFlags(&builder, r.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (r *RESContent) Validate() error {
+	var errs []error
+	for _, name := range r.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message RES: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(r.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message RES: %w", err))
+	}
+
+	if r.SI < 0 {
+		errs = append(errs, fmt.Errorf("validating param SI of message RES: %w, must be at least 0", ErrOutOfRange))
+	}
+	if r.SL.IsSet {
+		if r.SL.Value < 0 || r.SL.Value > 1024 {
+			errs = append(errs, fmt.Errorf("validating param SL of message RES: %w, must be between 0 and 1024", ErrOutOfRange))
+		}
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (r *RESContent) MissingRequired() []string {
+	var missing []string
+	if r.FN == "" {
+		missing = append(missing, "FN")
+	}
+	if r.TO == "" {
+		missing = append(missing, "TO")
+	}
+	return missing
+}
+
+var resDescriptor = MessageDescriptor{
+	Command: "RES",
+	Named: []ParamDescriptor{{
+		DisplayName: "File name",
+		FlagName:    "FN",
+		Name:        "FN",
+		Required:    true,
+		Type:        "string",
+	}, {
+		DisplayName: "SI",
+		FlagName:    "SI",
+		Name:        "SI",
+		Required:    true,
+		Type:        "int",
+	}, {
+		DisplayName: "SL",
+		FlagName:    "SL",
+		Name:        "SL",
+		Required:    false,
+		Type:        "int",
+	}, {
+		DisplayName: "TO",
+		FlagName:    "TO",
+		Name:        "TO",
+		Required:    true,
+		Type:        "string",
+	}, {
+		DisplayName: "TR",
+		FlagName:    "TR",
+		Name:        "TR",
+		Required:    false,
+		Type:        "tth",
+	}, {
+		DisplayName: "TD",
+		FlagName:    "TD",
+		Name:        "TD",
+		Required:    false,
+		Type:        "int",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (r *RESContent) Descriptor() MessageDescriptor {
+	return resDescriptor
+}
+
+// Command returns the command of the content, i.e. RES.
+func (r *RESContent) Command() string {
+	return "RES"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (r *RESContent) MsgType() byte {
+	return r.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (r *RESContent) MarshalADC() ([]byte, error) {
+	return r.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (r *RESContent) WireSize() int {
+	n := len("RES")
+
+	n += 1 + len("FN"+escapeValue(r.FN))
+	n += 1 + len("SI"+strconv.Itoa(r.SI))
+	if r.SL.IsSet {
+		n += 1 + len("SL"+strconv.Itoa(r.SL.Value))
+	}
+	n += 1 + len("TO"+escapeValue(r.TO))
+	if r.TR.IsSet {
+		n += 1 + len("TR"+r.TR.Value.String())
+	}
+	if r.TD.IsSet {
+		n += 1 + len("TD"+strconv.Itoa(r.TD.Value))
+	}
+	n += flagsSize(r.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (r *RESContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := r.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (r *RESContent) Equal(other *RESContent) bool {
+	return equalParams(r, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (r *RESContent) EqualIgnoring(other *RESContent, ignore ...string) bool {
+	if !isIgnored(ignore, "FN") && r.FN != other.FN {
+		return false
+	}
+	if !isIgnored(ignore, "SI") && r.SI != other.SI {
+		return false
+	}
+	if !isIgnored(ignore, "SL") && (r.SL.IsSet != other.SL.IsSet || r.SL.IsSet && r.SL.Value != other.SL.Value) {
+		return false
+	}
+	if !isIgnored(ignore, "TO") && r.TO != other.TO {
+		return false
+	}
+	if !isIgnored(ignore, "TR") && (r.TR.IsSet != other.TR.IsSet || r.TR.IsSet && r.TR.Value.String() != other.TR.Value.String()) {
+		return false
+	}
+	if !isIgnored(ignore, "TD") && (r.TD.IsSet != other.TD.IsSet || r.TD.IsSet && r.TD.Value != other.TD.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(r.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (r *RESContent) HashKey() string {
+	return hashKey(r)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (r *RESContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(r, line, mode, func(params []string) (ParamAccessor, error) {
+		var other RESContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (r *RESContent) Redacted() *RESContent {
+	redacted := *r
+	if r.Flags != nil {
+		redacted.Flags = r.UnknownFlags()
+	}
+	var zero RESContent
+	if r.TO != "" {
+		redacted.TO = "***"
+	}
+	if r.TR.IsSet {
+		redacted.TR.Set(zero.TR.Value)
+	}
+
+	return &redacted
+}
+
+// CacheKey returns the key identifying the message in caches, composed of
+// the (escaped) tokens of the cache key parts as written by AppendADC.
+func (r *RESContent) CacheKey() string {
+	var trPart string
+	if r.TR.IsSet {
+		trPart = "TR" + r.TR.Value.String()
+	}
+
+	return "TO" + escapeValue(r.TO) + " " + trPart
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (r *RESContent) SetOptionalCount() int {
+	var n int
+	if r.SL.IsSet {
+		n++
+	}
+	if r.TR.IsSet {
+		n++
+	}
+	if r.TD.IsSet {
+		n++
+	}
+	return n
+}
+
+// RESBuilder builds RESContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type RESBuilder struct {
+	content RESContent
+	err     error
+}
+
+// NewRESBuilder returns a builder of empty contents.
+func NewRESBuilder() *RESBuilder {
+	return &RESBuilder{}
+}
+
+// FN sets the FN param.
+func (b *RESBuilder) FN(value string) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param FN of message RES: %w", err)
+		return b
+	}
+	b.content.FN = value
+	b.content.fnStr = "FN" + str
+	return b
+}
+
+// SI sets the SI param.
+func (b *RESBuilder) SI(value int) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SI = value
+	b.content.siStr = "SI" + str
+	return b
+}
+
+// SL sets the SL param.
+func (b *RESBuilder) SL(value int) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.SL.Set(value)
+	b.content.slStr = "SL" + str
+	return b
+}
+
+// TO sets the TO param.
+func (b *RESBuilder) TO(value string) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param TO of message RES: %w", err)
+		return b
+	}
+	b.content.TO = value
+	b.content.toStr = "TO" + str
+	return b
+}
+
+// TR sets the TR param.
+func (b *RESBuilder) TR(value encoding.TTH) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := value.String()
+	b.content.TR.Set(value)
+	b.content.trStr = "TR" + str
+	return b
+}
+
+// TD sets the TD param.
+func (b *RESBuilder) TD(value int) *RESBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(value)
+	b.content.TD.Set(value)
+	b.content.tdStr = "TD" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *RESBuilder) Build() (*RESContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,857 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type SCHFlag string
+
+const (
+	// SCHFlagAN - Search terms all of which must be contained in the full path of
+	// a file.
+	SCHFlagAN SCHFlag = "AN"
+	// SCHFlagNO - Search terms none of which may be contained in the full path of
+	// a file.
+	SCHFlagNO SCHFlag = "NO"
+	// SCHFlagEX - File extensions, one of which a file must have.
+	SCHFlagEX SCHFlag = "EX"
+	// SCHFlagTO - Token identifying the search, to be included in the results.
+	SCHFlagTO SCHFlag = "TO"
+)
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f SCHFlag) String() string {
+	switch f {
+	case SCHFlagAN:
+		return "SCHFlagAN"
+	case SCHFlagNO:
+		return "SCHFlagNO"
+	case SCHFlagEX:
+		return "SCHFlagEX"
+	case SCHFlagTO:
+		return "SCHFlagTO"
+	}
+	return "SCHFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f SCHFlag) IsKnown() bool {
+	switch f {
+	case SCHFlagAN, SCHFlagNO, SCHFlagEX, SCHFlagTO:
+		return true
+	}
+	return false
+}
+
+var _ ParamAccessor = &SCHContent{}
+var _ ADCMarshaler = &SCHContent{}
+var _ ADCUnmarshaler = &SCHContent{}
+var _ io.WriterTo = &SCHContent{}
+
+// SCHContent is the content of SCH messages. Search request, matching files by
+// the search terms. See ADC § 5.3.7.
+type SCHContent struct {
+	// AN - Search terms all of which must be contained in the full path of a file.
+	AN    []string
+	anStr []string
+
+	// NO - Search terms none of which may be contained in the full path of a file.
+	NO    []string
+	noStr []string
+
+	// EX - File extensions, one of which a file must have.
+	EX    []string
+	exStr []string
+
+	// TO - Token identifying the search, to be included in the results.
+	TO    String
+	toStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (s *SCHContent) Positional() []string {
+	return s.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (s *SCHContent) AppendPositional(dst []string) []string {
+	return dst
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (s *SCHContent) PosLen() int {
+	return 0
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (s *SCHContent) PosAt(i int) string {
+	panic(fmt.Sprintf("SCH.PosAt: index %d out of range [0,%d)", i, s.PosLen()))
+}
+
+// Named returns the (escaped) values of the named params set in the content,
+// keyed by flag name, including the flags not mapped to a param. Modifying the
+// returned map does not affect the content.
+func (s *SCHContent) Named() map[string]string {
+	params := s.UnknownFlags()
+
+	if len(s.AN) > 0 {
+		params["AN"] = escapeValue(s.AN[0])
+	}
+	if len(s.NO) > 0 {
+		params["NO"] = escapeValue(s.NO[0])
+	}
+	if len(s.EX) > 0 {
+		params["EX"] = escapeValue(s.EX[0])
+	}
+	if s.TO.IsSet {
+		params["TO"] = escapeValue(s.TO.Value)
+	}
+
+	return params
+}
+
+// NamedGet returns the (escaped) value of the named param key and whether it
+// is set. The first value of multi-valued params is returned.
+func (s *SCHContent) NamedGet(key string) (string, bool) {
+	switch SCHFlag(key) {
+	case SCHFlagAN:
+		if len(s.AN) > 0 {
+			return escapeValue(s.AN[0]), true
+		}
+		return "", false
+	case SCHFlagNO:
+		if len(s.NO) > 0 {
+			return escapeValue(s.NO[0]), true
+		}
+		return "", false
+	case SCHFlagEX:
+		if len(s.EX) > 0 {
+			return escapeValue(s.EX[0]), true
+		}
+		return "", false
+	case SCHFlagTO:
+		if s.TO.IsSet {
+			return escapeValue(s.TO.Value), true
+		}
+		return "", false
+	}
+
+	return s.ContentBase.NamedGet(key)
+}
+
+// NamedAll returns the (escaped) values of the named param key, one value per
+// occurrence of the flag. nil is returned if the param is not present.
+func (s *SCHContent) NamedAll(key string) []string {
+	switch SCHFlag(key) {
+	case SCHFlagAN:
+		if len(s.AN) == 0 {
+			return nil
+		}
+		values := make([]string, len(s.AN))
+		for i, val := range s.AN {
+			values[i] = escapeValue(val)
+		}
+		return values
+	case SCHFlagNO:
+		if len(s.NO) == 0 {
+			return nil
+		}
+		values := make([]string, len(s.NO))
+		for i, val := range s.NO {
+			values[i] = escapeValue(val)
+		}
+		return values
+	case SCHFlagEX:
+		if len(s.EX) == 0 {
+			return nil
+		}
+		values := make([]string, len(s.EX))
+		for i, val := range s.EX {
+			values[i] = escapeValue(val)
+		}
+		return values
+	}
+
+	if val, ok := s.NamedGet(key); ok {
+		return []string{val}
+	}
+	return nil
+}
+
+// GetAN returns the decoded value of the AN param and whether it is set.
+func (s *SCHContent) GetAN() ([]string, bool) {
+	return s.AN, len(s.AN) > 0
+}
+
+// GetNO returns the decoded value of the NO param and whether it is set.
+func (s *SCHContent) GetNO() ([]string, bool) {
+	return s.NO, len(s.NO) > 0
+}
+
+// GetEX returns the decoded value of the EX param and whether it is set.
+func (s *SCHContent) GetEX() ([]string, bool) {
+	return s.EX, len(s.EX) > 0
+}
+
+// GetTO returns the decoded value of the TO param and whether it is set.
+func (s *SCHContent) GetTO() (string, bool) {
+	return s.TO.Value, s.TO.IsSet
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (s *SCHContent) PosByName(name string) (string, bool) {
+	return "", false
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (s *SCHContent) ParseInto(params []string, opts *ParseOptions) error {
+	*s = SCHContent{}
+	s.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message SCH: %w", err)
+	}
+
+	for _, param := range params {
+		switch {
+		case isNamedParam(param):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message SCH: %w", param[:2], err)
+			}
+			switch SCHFlag(param[:2]) {
+			case SCHFlagAN:
+				s.anStr = append(s.anStr, param)
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param AN of message SCH: %w", err)
+				}
+				s.AN = append(s.AN, val)
+			case SCHFlagNO:
+				s.noStr = append(s.noStr, param)
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param NO of message SCH: %w", err)
+				}
+				s.NO = append(s.NO, val)
+			case SCHFlagEX:
+				s.exStr = append(s.exStr, param)
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param EX of message SCH: %w", err)
+				}
+				s.EX = append(s.EX, val)
+			case SCHFlagTO:
+				if err := opts.checkDuplicateFlag(s.toStr, param); err != nil {
+					return fmt.Errorf("parsing flag %s of message SCH: %w", param[:2], err)
+				}
+				s.toStr = param
+				val, err := encoding.DecodeADCString(param[2:])
+				if err != nil {
+					return fmt.Errorf("parsing param TO of message SCH: %w", err)
+				}
+				s.TO.Set(val)
+			default:
+				if s.Flags == nil {
+					s.Flags = make(map[string]string)
+				}
+				s.Flags[param[:2]] = param[2:]
+			}
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message SCH: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SCHContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func SCHContentFromAccessor(pa ParamAccessor) (*SCHContent, error) {
+	var s SCHContent
+	if err := s.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (s *SCHContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message SCH: %w", err)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (s *SCHContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "SCH" {
+		return fmt.Errorf("parsing message SCH: %w", ErrCommandMismatch)
+	}
+
+	return s.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (s *SCHContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message SCH: %w", err)
+	}
+	return s.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (s *SCHContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, s.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (s *SCHContent) SetNamedAll(named map[string]string) error {
+	decoded := *s
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*s = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (s *SCHContent) setNamedAll(named map[string]string) error {
+	var zero SCHContent
+	s.AN = zero.AN
+	s.anStr = zero.anStr
+	s.NO = zero.NO
+	s.noStr = zero.noStr
+	s.EX = zero.EX
+	s.exStr = zero.exStr
+	s.TO = zero.TO
+	s.toStr = zero.toStr
+	s.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message SCH: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		switch SCHFlag(param[:2]) {
+		case SCHFlagAN:
+			s.anStr = append(s.anStr, param)
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param AN of message SCH: %w", err)
+			}
+			s.AN = append(s.AN, val)
+		case SCHFlagNO:
+			s.noStr = append(s.noStr, param)
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param NO of message SCH: %w", err)
+			}
+			s.NO = append(s.NO, val)
+		case SCHFlagEX:
+			s.exStr = append(s.exStr, param)
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param EX of message SCH: %w", err)
+			}
+			s.EX = append(s.EX, val)
+		case SCHFlagTO:
+			s.toStr = param
+			val, err := encoding.DecodeADCString(param[2:])
+			if err != nil {
+				return fmt.Errorf("parsing param TO of message SCH: %w", err)
+			}
+			s.TO.Set(val)
+		default:
+			if s.Flags == nil {
+				s.Flags = make(map[string]string)
+			}
+			s.Flags[param[:2]] = param[2:]
+		}
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (s *SCHContent) ToMap() map[string]string {
+	values := s.UnknownFlags()
+	if len(s.AN) > 0 {
+		anStrs := make([]string, len(s.AN))
+		for i, val := range s.AN {
+			anStrs[i] = escapeValue(val)
+		}
+		values["AN"] = joinValues(anStrs, 0)
+	}
+	if len(s.NO) > 0 {
+		noStrs := make([]string, len(s.NO))
+		for i, val := range s.NO {
+			noStrs[i] = escapeValue(val)
+		}
+		values["NO"] = joinValues(noStrs, 0)
+	}
+	if len(s.EX) > 0 {
+		exStrs := make([]string, len(s.EX))
+		for i, val := range s.EX {
+			exStrs[i] = escapeValue(val)
+		}
+		values["EX"] = joinValues(exStrs, 0)
+	}
+	if s.TO.IsSet {
+		values["TO"] = escapeValue(s.TO.Value)
+	}
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (s *SCHContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["AN"]; ok {
+		for _, value := range splitValues(val) {
+			params = append(params, "AN"+value)
+		}
+	}
+	if val, ok := values["NO"]; ok {
+		for _, value := range splitValues(val) {
+			params = append(params, "NO"+value)
+		}
+	}
+	if val, ok := values["EX"]; ok {
+		for _, value := range splitValues(val) {
+			params = append(params, "EX"+value)
+		}
+	}
+	if val, ok := values["TO"]; ok {
+		params = append(params, "TO"+val)
+	}
+	for key, val := range values {
+		switch key {
+		case "AN", "NO", "EX", "TO":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message SCH: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (s *SCHContent) AppendADC(buf []byte) ([]byte, error) {
+	buf = append(buf, "SCH"...)
+
+	for _, val := range s.AN {
+		buf = append(buf, " AN"...)
+		buf = appendEscaped(buf, val)
+	}
+	for _, val := range s.NO {
+		buf = append(buf, " NO"...)
+		buf = appendEscaped(buf, val)
+	}
+	for _, val := range s.EX {
+		buf = append(buf, " EX"...)
+		buf = appendEscaped(buf, val)
+	}
+	if s.TO.IsSet {
+		buf = append(buf, " TO"...)
+		buf = appendEscaped(buf, s.TO.Value)
+	}
+	buf = appendFlags(buf, s.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (s *SCHContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(s.WireSize())
+	builder.WriteString("SCH")
+
+	for _, val := range s.AN {
+		builder.WriteByte(' ')
+		builder.WriteString("AN" + escapeValue(val))
+	}
+	for _, val := range s.NO {
+		builder.WriteByte(' ')
+		builder.WriteString("NO" + escapeValue(val))
+	}
+	for _, val := range s.EX {
+		builder.WriteByte(' ')
+		builder.WriteString("EX" + escapeValue(val))
+	}
+	if s.TO.IsSet {
+		builder.WriteByte(' ')
+		builder.WriteString("TO" + escapeValue(s.TO.Value))
+	}
+	writeFlags(&builder, s.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (s *SCHContent) Validate() error {
+	var errs []error
+	for _, name := range s.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message SCH: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(s.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message SCH: %w", err))
+	}
+
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (s *SCHContent) MissingRequired() []string {
+	return nil
+}
+
+var schDescriptor = MessageDescriptor{
+	Command: "SCH",
+	Named: []ParamDescriptor{{
+		DisplayName: "AN",
+		FlagName:    "AN",
+		Name:        "AN",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "NO",
+		FlagName:    "NO",
+		Name:        "NO",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "EX",
+		FlagName:    "EX",
+		Name:        "EX",
+		Required:    false,
+		Type:        "string",
+	}, {
+		DisplayName: "TO",
+		FlagName:    "TO",
+		Name:        "TO",
+		Required:    false,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (s *SCHContent) Descriptor() MessageDescriptor {
+	return schDescriptor
+}
+
+// Command returns the command of the content, i.e. SCH.
+func (s *SCHContent) Command() string {
+	return "SCH"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (s *SCHContent) MsgType() byte {
+	return s.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (s *SCHContent) MarshalADC() ([]byte, error) {
+	return s.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (s *SCHContent) WireSize() int {
+	n := len("SCH")
+
+	for _, val := range s.AN {
+		n += 1 + len("AN"+escapeValue(val))
+	}
+	for _, val := range s.NO {
+		n += 1 + len("NO"+escapeValue(val))
+	}
+	for _, val := range s.EX {
+		n += 1 + len("EX"+escapeValue(val))
+	}
+	if s.TO.IsSet {
+		n += 1 + len("TO"+escapeValue(s.TO.Value))
+	}
+	n += flagsSize(s.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (s *SCHContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := s.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (s *SCHContent) Equal(other *SCHContent) bool {
+	return equalParams(s, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (s *SCHContent) EqualIgnoring(other *SCHContent, ignore ...string) bool {
+	if !isIgnored(ignore, "AN") {
+		if len(s.AN) != len(other.AN) {
+			return false
+		}
+		for i, val := range s.AN {
+			if val != other.AN[i] {
+				return false
+			}
+		}
+	}
+	if !isIgnored(ignore, "NO") {
+		if len(s.NO) != len(other.NO) {
+			return false
+		}
+		for i, val := range s.NO {
+			if val != other.NO[i] {
+				return false
+			}
+		}
+	}
+	if !isIgnored(ignore, "EX") {
+		if len(s.EX) != len(other.EX) {
+			return false
+		}
+		for i, val := range s.EX {
+			if val != other.EX[i] {
+				return false
+			}
+		}
+	}
+	if !isIgnored(ignore, "TO") && (s.TO.IsSet != other.TO.IsSet || s.TO.IsSet && s.TO.Value != other.TO.Value) {
+		return false
+	}
+
+	return equalFlagsIgnoring(s.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (s *SCHContent) HashKey() string {
+	return hashKey(s)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (s *SCHContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(s, line, mode, func(params []string) (ParamAccessor, error) {
+		var other SCHContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (s *SCHContent) Redacted() *SCHContent {
+	redacted := *s
+	if s.Flags != nil {
+		redacted.Flags = s.UnknownFlags()
+	}
+	redacted.anStr = append([]string(nil), s.anStr...)
+	redacted.AN = append([]string(nil), s.AN...)
+	redacted.noStr = append([]string(nil), s.noStr...)
+	redacted.NO = append([]string(nil), s.NO...)
+	redacted.exStr = append([]string(nil), s.exStr...)
+	redacted.EX = append([]string(nil), s.EX...)
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (s *SCHContent) SetOptionalCount() int {
+	var n int
+	if s.TO.IsSet {
+		n++
+	}
+	return n
+}
+
+// SCHBuilder builds SCHContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type SCHBuilder struct {
+	content SCHContent
+	err     error
+}
+
+// NewSCHBuilder returns a builder of empty contents.
+func NewSCHBuilder() *SCHBuilder {
+	return &SCHBuilder{}
+}
+
+// AN replaces all values of the AN param.
+func (b *SCHBuilder) AN(values ...string) *SCHBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, val := range values {
+		str, err := encoding.EncodeToADCString(val)
+		if err != nil {
+			b.err = fmt.Errorf("building param AN of message SCH: %w", err)
+			return b
+		}
+		strs = append(strs, "AN"+str)
+	}
+	b.content.AN = append([]string{}, values...)
+	b.content.anStr = strs
+	return b
+}
+
+// NO replaces all values of the NO param.
+func (b *SCHBuilder) NO(values ...string) *SCHBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, val := range values {
+		str, err := encoding.EncodeToADCString(val)
+		if err != nil {
+			b.err = fmt.Errorf("building param NO of message SCH: %w", err)
+			return b
+		}
+		strs = append(strs, "NO"+str)
+	}
+	b.content.NO = append([]string{}, values...)
+	b.content.noStr = strs
+	return b
+}
+
+// EX replaces all values of the EX param.
+func (b *SCHBuilder) EX(values ...string) *SCHBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, val := range values {
+		str, err := encoding.EncodeToADCString(val)
+		if err != nil {
+			b.err = fmt.Errorf("building param EX of message SCH: %w", err)
+			return b
+		}
+		strs = append(strs, "EX"+str)
+	}
+	b.content.EX = append([]string{}, values...)
+	b.content.exStr = strs
+	return b
+}
+
+// TO sets the TO param.
+func (b *SCHBuilder) TO(value string) *SCHBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param TO of message SCH: %w", err)
+		return b
+	}
+	b.content.TO.Set(value)
+	b.content.toStr = "TO" + str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *SCHBuilder) Build() (*SCHContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,564 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+type STAFlag string
+
+// String returns the name of the flag constant matching f, or the flag
+// wrapped in the flag type name if f is not known.
+func (f STAFlag) String() string {
+	return "STAFlag(" + string(f) + ")"
+}
+
+// IsKnown reports whether f is one of the flag constants of the message.
+func (f STAFlag) IsKnown() bool {
+	return false
+}
+
+var _ ParamAccessor = &STAContent{}
+var _ ADCMarshaler = &STAContent{}
+var _ ADCUnmarshaler = &STAContent{}
+var _ io.WriterTo = &STAContent{}
+
+// STAContent is the content of STA messages.
+type STAContent struct {
+	Severity    Severity
+	severityStr string
+
+	Description    string
+	descriptionStr string
+
+	ContentBase
+
+	// Truncated is set if ParseInto truncated a value exceeding the
+	// MaxValueLength of the ParseOptions.
+	Truncated bool
+	// Compressed is set by ParseInto if the Compressed option of the
+	// ParseOptions is set. It is not part of the marshalled content.
+	Compressed bool
+
+	// No known additional flags.
+}
+
+// Positional returns the (escaped) positional params. The command is not a
+// positional param, it is only emitted and consumed by MarshalADC and
+// UnmarshalADC.
+func (s *STAContent) Positional() []string {
+	return s.AppendPositional(nil)
+}
+
+// AppendPositional appends the (escaped) positional params to dst and
+// returns the extended slice.
+func (s *STAContent) AppendPositional(dst []string) []string {
+	return append(dst, strconv.Itoa(int(s.Severity)), escapeValue(s.Description))
+}
+
+// PosLen returns the number of positional params, excluding the command.
+func (s *STAContent) PosLen() int {
+	return 2
+}
+
+// PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is
+// the first param following the command.
+func (s *STAContent) PosAt(i int) string {
+	switch i {
+	case 0:
+		return strconv.Itoa(int(s.Severity))
+	case 1:
+		return escapeValue(s.Description)
+	default:
+		panic(fmt.Sprintf("STA.PosAt: index %d out of range [0,%d)", i, s.PosLen()))
+	}
+}
+
+// PosByName returns the (escaped) value of the positional param name and
+// whether it is present. The first value of multi-valued params is returned.
+func (s *STAContent) PosByName(name string) (string, bool) {
+	switch name {
+	case "Severity":
+		return strconv.Itoa(int(s.Severity)), true
+	case "Description":
+		return escapeValue(s.Description), true
+	}
+
+	return "", false
+}
+
+// PositionalValues returns the positional params as a tuple of their values.
+func (s *STAContent) PositionalValues() (Severity, string) {
+	return s.Severity, s.Description
+}
+
+// ParseInto replaces the content by params, the (escaped) tokens of the
+// message following the command. Both the fields and the escaped values are
+// set. Flags not mapped to a param are stored in Flags. opts configures the
+// handling of deviations from the definition, nil selects strict parsing.
+// Required params with values AppendADC considers missing, such as empty
+// strings, are rejected with ErrMissingParam.
+func (s *STAContent) ParseInto(params []string, opts *ParseOptions) error {
+	*s = STAContent{}
+	s.Compressed = opts.compressed()
+
+	if err := opts.checkCounts(params); err != nil {
+		return fmt.Errorf("parsing message STA: %w", err)
+	}
+
+	plain := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain++
+		}
+	}
+
+	if len(params) < 2 {
+		return fmt.Errorf("parsing message STA: %w", ErrMissingParam)
+	}
+
+	pos := 0
+	for _, param := range params {
+		if !isNamedParam(param) {
+			plain--
+		}
+
+		switch {
+		case pos >= 2 && isNamedParam(param) && (pos+plain >= 2):
+			if val, ok := opts.truncateValue(param[2:]); ok {
+				param = param[:2] + val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param[2:]); err != nil {
+				return fmt.Errorf("parsing flag %s of message STA: %w", param[:2], err)
+			}
+			if s.Flags == nil {
+				s.Flags = make(map[string]string)
+			}
+			s.Flags[param[:2]] = param[2:]
+			continue
+		case pos == 0:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Severity of message STA: %w", err)
+			}
+			s.severityStr = param
+			val, err := strconv.Atoi(param)
+			if err == nil && !Severity(val).IsKnown() {
+				err = ErrValueNotAllowed
+			}
+			if err != nil {
+				return fmt.Errorf("parsing param Severity of message STA: %w", err)
+			}
+			s.Severity = Severity(val)
+		case pos == 1:
+			if val, ok := opts.truncateValue(param); ok {
+				param = val
+				s.Truncated = true
+			}
+			if err := opts.checkUTF8(param); err != nil {
+				return fmt.Errorf("parsing param Description of message STA: %w", err)
+			}
+			s.descriptionStr = param
+			val, err := encoding.DecodeADCString(param)
+			if err != nil {
+				return fmt.Errorf("parsing param Description of message STA: %w", err)
+			}
+			s.Description = val
+		default:
+			if err := opts.surplusPositional(param); err != nil {
+				return fmt.Errorf("parsing message STA: %w", err)
+			}
+		}
+
+		pos++
+	}
+
+	if pos < 2 {
+		return fmt.Errorf("parsing message STA: %w", ErrMissingParam)
+	}
+
+	if s.Description == "" {
+		return fmt.Errorf("parsing param Description of message STA: %w", ErrMissingParam)
+	}
+
+	return nil
+}
+
+// STAContentFromAccessor returns the content held by pa, e.g. a RawContent of
+// the command. The params of pa are parsed and checked as by ParseInto.
+func STAContentFromAccessor(pa ParamAccessor) (*STAContent, error) {
+	var s STAContent
+	if err := s.ParseInto(accessorParams(pa), nil); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Decode parses the (escaped) positional params and the (escaped) named
+// params, keyed by flag name, into the content. Both the fields and the
+// escaped values are set and checked as by ParseInto.
+func (s *STAContent) Decode(positional []string, named map[string]string) error {
+	params, err := joinParams(positional, named)
+	if err != nil {
+		return fmt.Errorf("decoding message STA: %w", err)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// ParseTokens parses tokens, the (escaped) tokens of the message starting
+// with the command, e.g. as split by an upstream framer.
+func (s *STAContent) ParseTokens(tokens []string) error {
+	if len(tokens) == 0 || tokens[0] != "STA" {
+		return fmt.Errorf("parsing message STA: %w", ErrCommandMismatch)
+	}
+
+	return s.ParseInto(tokens[1:], nil)
+}
+
+// UnmarshalADC parses line, the message as returned by MarshalADC.
+func (s *STAContent) UnmarshalADC(line []byte) error {
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return fmt.Errorf("parsing message STA: %w", err)
+	}
+	return s.ParseTokens(tokens)
+}
+
+// ParseADCInto parses the first message of data, which is terminated by a
+// newline, and returns the number of bytes consumed including the terminator.
+// ErrIncomplete is returned if data does not hold a complete message. The
+// message is consumed even if parsing fails.
+func (s *STAContent) ParseADCInto(data []byte) (int, error) {
+	end := bytes.IndexByte(data, '\n')
+	if end < 0 {
+		return 0, ErrIncomplete
+	}
+
+	return end + 1, s.UnmarshalADC(data[:end+1])
+}
+
+// SetNamedAll replaces all named params by the (escaped) values of named,
+// keyed by flag name. Flags not mapped to a param are stored in Flags. The
+// content is not modified if an error is returned.
+func (s *STAContent) SetNamedAll(named map[string]string) error {
+	decoded := *s
+	if err := decoded.setNamedAll(named); err != nil {
+		return err
+	}
+
+	*s = decoded
+	return nil
+}
+
+// setNamedAll implements SetNamedAll, the content is modified even if an
+// error is returned.
+func (s *STAContent) setNamedAll(named map[string]string) error {
+	s.Flags = nil
+
+	for key, value := range named {
+		if len(key) != 2 {
+			return fmt.Errorf("setting named params of message STA: %w", ErrMalformedFlag)
+		}
+		param := key + value
+		if s.Flags == nil {
+			s.Flags = make(map[string]string)
+		}
+		s.Flags[param[:2]] = param[2:]
+	}
+
+	return nil
+}
+
+// ToMap returns the (escaped) values of the params set in the content, keyed
+// by the names of positional params and the flag names of named params. The
+// values of multi-valued params are separated by spaces. Flags not mapped to
+// a param are included as well.
+func (s *STAContent) ToMap() map[string]string {
+	values := s.UnknownFlags()
+	values["Severity"] = strconv.Itoa(int(s.Severity))
+	values["Description"] = escapeValue(s.Description)
+
+	return values
+}
+
+// FromMap replaces the content by the (escaped) values of params, keyed as
+// returned by ToMap. The values are parsed and checked as by ParseInto. Keys
+// not naming a param are stored in Flags, if they are flag names.
+func (s *STAContent) FromMap(values map[string]string) error {
+	params := make([]string, 0, len(values))
+	if val, ok := values["Severity"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Severity of message STA: %w", ErrMissingParam)
+	}
+	if val, ok := values["Description"]; ok {
+		params = append(params, val)
+	} else {
+		return fmt.Errorf("converting param Description of message STA: %w", ErrMissingParam)
+	}
+	for key, val := range values {
+		switch key {
+		case "Severity", "Description":
+			continue
+		}
+		if !isNamedParam(key) || len(key) != 2 {
+			return fmt.Errorf("converting message STA: key %q: %w", key, ErrMalformedFlag)
+		}
+		params = append(params, key+val)
+	}
+
+	return s.ParseInto(params, nil)
+}
+
+// AppendADC appends the content in the ADC wire format to buf and returns
+// the extended buffer. The command is followed by the (escaped) positional
+// and named params and terminated by a newline. The values are encoded from
+// the fields, as are those returned by the other accessors. An error is
+// returned if a required param is missing, together with buf truncated to
+// its original length.
+func (s *STAContent) AppendADC(buf []byte) ([]byte, error) {
+	n := len(buf)
+	buf = append(buf, "STA"...)
+
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(int(s.Severity)), 10)
+	if s.Description == "" {
+		return buf[:n], fmt.Errorf("marshalling param Description of message STA: %w", ErrMissingParam)
+	}
+	buf = append(buf, ' ')
+	buf = appendEscaped(buf, s.Description)
+	buf = appendFlags(buf, s.Flags)
+
+	return append(buf, '\n'), nil
+}
+
+// ADCString returns the output of MarshalADC as a string, without copying
+// it. The empty string is returned if MarshalADC fails.
+func (s *STAContent) ADCString() string {
+	var builder strings.Builder
+	builder.Grow(s.WireSize())
+	builder.WriteString("STA")
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.Itoa(int(s.Severity)))
+	if s.Description == "" {
+		return ""
+	}
+	builder.WriteByte(' ')
+	builder.WriteString(escapeValue(s.Description))
+	writeFlags(&builder, s.Flags)
+	builder.WriteByte('\n')
+
+	return builder.String()
+}
+
+// Validate checks the params against the constraints of the message, such as
+// required params and allowed values. All violations are listed by the
+// returned ValidationError.
+func (s *STAContent) Validate() error {
+	var errs []error
+	for _, name := range s.MissingRequired() {
+		errs = append(errs, fmt.Errorf("validating param %s of message STA: %w", name, ErrMissingParam))
+	}
+	if err := checkFlagsEscaped(s.Flags); err != nil {
+		errs = append(errs, fmt.Errorf("validating flags of message STA: %w", err))
+	}
+
+	if !s.Severity.IsKnown() {
+		errs = append(errs, fmt.Errorf("validating param Severity of message STA: %w, must be one of 0, 1, 2", ErrValueNotAllowed))
+	}
+	return validationError(errs)
+}
+
+// MissingRequired returns the names of all required params which are not
+// set.
+func (s *STAContent) MissingRequired() []string {
+	var missing []string
+	if s.Description == "" {
+		missing = append(missing, "Description")
+	}
+	return missing
+}
+
+var staDescriptor = MessageDescriptor{
+	Command: "STA",
+	Positional: []ParamDescriptor{{
+		DisplayName: "Severity",
+		Name:        "Severity",
+		Required:    true,
+		Type:        "Severity",
+	}, {
+		DisplayName: "Description",
+		Name:        "Description",
+		Required:    true,
+		Type:        "string",
+	}},
+}
+
+// Descriptor returns the descriptor of the message, which describes its
+// params.
+func (s *STAContent) Descriptor() MessageDescriptor {
+	return staDescriptor
+}
+
+// Command returns the command of the content, i.e. STA.
+func (s *STAContent) Command() string {
+	return "STA"
+}
+
+// MsgType returns the message type of the frame the content has been parsed
+// from, or 0 if the content has not been parsed from a frame.
+func (s *STAContent) MsgType() byte {
+	return s.msgType
+}
+
+// MarshalADC returns the content in the ADC wire format, see AppendADC.
+func (s *STAContent) MarshalADC() ([]byte, error) {
+	return s.AppendADC(nil)
+}
+
+// WireSize returns the number of bytes of the output of MarshalADC, without
+// marshalling the content. Missing required params are not detected.
+func (s *STAContent) WireSize() int {
+	n := len("STA")
+
+	n += 1 + len(strconv.Itoa(int(s.Severity)))
+	n += 1 + len(escapeValue(s.Description))
+	n += flagsSize(s.Flags)
+
+	return n + 1
+}
+
+// WriteTo writes the content in the ADC wire format to w, see AppendADC.
+func (s *STAContent) WriteTo(w io.Writer) (int64, error) {
+	buf, err := s.AppendADC(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// Equal returns true if the content and other have the same (escaped)
+// positional and named params, including the flags not mapped to a param.
+func (s *STAContent) Equal(other *STAContent) bool {
+	return equalParams(s, other)
+}
+
+// EqualIgnoring returns true if the content and other are equal according to
+// Equal, apart from the params and unknown flags named by ignore. Names
+// neither naming a param nor a flag are ignored.
+func (s *STAContent) EqualIgnoring(other *STAContent, ignore ...string) bool {
+	if !isIgnored(ignore, "Severity") && s.Severity != other.Severity {
+		return false
+	}
+	if !isIgnored(ignore, "Description") && s.Description != other.Description {
+		return false
+	}
+
+	return equalFlagsIgnoring(s.Flags, other.Flags, ignore)
+}
+
+// HashKey returns a canonical key of the content, e.g. for deduplicating
+// messages in a map. Contents equal according to Equal share the same key.
+func (s *STAContent) HashKey() string {
+	return hashKey(s)
+}
+
+// EqualBytes returns true if the content equals line, a message as returned
+// by MarshalADC. mode selects whether line is parsed and compared by Equal or
+// compared to the output of MarshalADC byte by byte.
+func (s *STAContent) EqualBytes(line []byte, mode EqualMode) (bool, error) {
+	return equalBytes(s, line, mode, func(params []string) (ParamAccessor, error) {
+		var other STAContent
+		err := other.ParseInto(params, nil)
+		return &other, err
+	})
+}
+
+// Redacted returns a copy of the content with the values of sensitive params
+// masked, e.g. for logging. Strings are replaced by "***", other values by their
+// zero value. The copy does not share memory with the content.
+func (s *STAContent) Redacted() *STAContent {
+	redacted := *s
+	if s.Flags != nil {
+		redacted.Flags = s.UnknownFlags()
+	}
+
+	return &redacted
+}
+
+// SetOptionalCount returns the number of optional params which are set.
+func (s *STAContent) SetOptionalCount() int {
+	return 0
+}
+
+// STABuilder builds STAContent values. Its setters maintain both the fields
+// and the escaped values of the params. The first error encountered by a
+// setter is returned by Build.
+type STABuilder struct {
+	content STAContent
+	err     error
+}
+
+// NewSTABuilder returns a builder of empty contents.
+func NewSTABuilder() *STABuilder {
+	return &STABuilder{}
+}
+
+// Severity sets the Severity param.
+func (b *STABuilder) Severity(value Severity) *STABuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str := strconv.Itoa(int(value))
+	b.content.Severity = value
+	b.content.severityStr = str
+	return b
+}
+
+// Description sets the Description param.
+func (b *STABuilder) Description(value string) *STABuilder {
+	if b.err != nil {
+		return b
+	}
+
+	str, err := encoding.EncodeToADCString(value)
+	if err != nil {
+		b.err = fmt.Errorf("building param Description of message STA: %w", err)
+		return b
+	}
+	b.content.Description = value
+	b.content.descriptionStr = str
+	return b
+}
+
+// Build returns the content if all setters succeeded and the content passes
+// Validate.
+func (b *STABuilder) Build() (*STAContent, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := b.content.Validate(); err != nil {
+		return nil, err
+	}
+
+	content := b.content
+	return &content, nil
+}
@@ -0,0 +1,4 @@
+// Package noreflect holds the content types of the debug definition generated
+// with NoReflect. The generated files and the tests are only built under the
+// noreflect build tag, e.g. by go test -tags noreflect.
+package noreflect
@@ -0,0 +1,1798 @@
+//go:build noreflect
+
+package noreflect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	encoding "github.com/seoester/adcl/protocol/encoding"
+	"io"
+	"net"
+	netip "net/netip"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Code generated by adcl/protocol/generator. DO NOT EDIT.
+
+// Addr holds an optional value, which is set if IsSet is true.
+type Addr struct {
+	Value netip.Addr
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Addr) Get() (netip.Addr, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Addr) GetDefault(def netip.Addr) netip.Addr {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Addr) Set(val netip.Addr) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Addr) Unset() {
+	m.IsSet = false
+}
+
+// Base32Value holds an optional value, which is set if IsSet is true.
+type Base32Value struct {
+	Value *encoding.Base32Value
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Base32Value) Get() (*encoding.Base32Value, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Base32Value) GetDefault(def *encoding.Base32Value) *encoding.Base32Value {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Base32Value) Set(val *encoding.Base32Value) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Base32Value) Unset() {
+	m.IsSet = false
+}
+
+// Bytes holds an optional value, which is set if IsSet is true.
+type Bytes struct {
+	Value []byte
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Bytes) Get() ([]byte, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Bytes) GetDefault(def []byte) []byte {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Bytes) Set(val []byte) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Bytes) Unset() {
+	m.IsSet = false
+}
+
+// Duration holds an optional value, which is set if IsSet is true.
+type Duration struct {
+	Value time.Duration
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Duration) Get() (time.Duration, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Duration) GetDefault(def time.Duration) time.Duration {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Duration) Set(val time.Duration) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Duration) Unset() {
+	m.IsSet = false
+}
+
+// Features holds an optional value, which is set if IsSet is true.
+type Features struct {
+	Value encoding.Features
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Features) Get() (encoding.Features, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Features) GetDefault(def encoding.Features) encoding.Features {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Features) Set(val encoding.Features) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Features) Unset() {
+	m.IsSet = false
+}
+
+// Int holds an optional value, which is set if IsSet is true.
+type Int struct {
+	Value int
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Int) Get() (int, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Int) GetDefault(def int) int {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Int) Set(val int) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Int) Unset() {
+	m.IsSet = false
+}
+
+// String holds an optional value, which is set if IsSet is true.
+type String struct {
+	Value string
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *String) Get() (string, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *String) GetDefault(def string) string {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *String) Set(val string) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *String) Unset() {
+	m.IsSet = false
+}
+
+// TTH holds an optional value, which is set if IsSet is true.
+type TTH struct {
+	Value encoding.TTH
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *TTH) Get() (encoding.TTH, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *TTH) GetDefault(def encoding.TTH) encoding.TTH {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *TTH) Set(val encoding.TTH) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *TTH) Unset() {
+	m.IsSet = false
+}
+
+// Time holds an optional value, which is set if IsSet is true.
+type Time struct {
+	Value time.Time
+	IsSet bool
+}
+
+// Get returns the value and whether it is set.
+func (m *Time) Get() (time.Time, bool) {
+	return m.Value, m.IsSet
+}
+
+// GetDefault returns the value if it is set, def otherwise.
+func (m *Time) GetDefault(def time.Time) time.Time {
+	if m.IsSet {
+		return m.Value
+	}
+	return def
+}
+
+// Set sets the value.
+func (m *Time) Set(val time.Time) {
+	m.Value = val
+	m.IsSet = true
+}
+
+// Unset marks the value as not set.
+func (m *Time) Unset() {
+	m.IsSet = false
+}
+
+// Error variables related to parsing and validating message content.
+var (
+	ErrMissingParam       = errors.New("required parameter missing")
+	ErrSurplusPositional  = errors.New("surplus positional parameter, message has more positional parameters than expected")
+	ErrValueNotAllowed    = errors.New("value not allowed")
+	ErrOutOfRange         = errors.New("value out of range")
+	ErrInvalidLength      = errors.New("encoded value has an invalid length")
+	ErrUnknownBits        = errors.New("bitmask has unknown bits set")
+	ErrUnescapedSeparator = errors.New("value contains unescaped separator")
+	ErrInvalidUTF8        = errors.New("value is not valid UTF-8")
+	ErrMalformedFlag      = errors.New("flag name is not two characters long")
+	ErrConstMismatch      = errors.New("token differs from the fixed value of the param")
+	ErrDuplicateFlag      = errors.New("flag of single-valued param repeated")
+	ErrTooManyParams      = errors.New("message has too many parameters")
+	ErrLengthMismatch     = errors.New("values and escaped values of multi-valued parameter differ in length")
+	ErrIncomplete         = errors.New("data does not hold a complete message")
+	ErrMissingCommand     = errors.New("command of message family missing")
+	ErrOneOf              = errors.New("not exactly one of the parameters present")
+)
+
+// ParamAccessor provides access to the (escaped) params of a content. Named
+// params may be repeated, e.g. the AN terms of SCH. Named and NamedGet hold
+// the first value of repeated params, NamedAll returns all values.
+type ParamAccessor interface {
+	Positional() []string
+	PosLen() int
+	PosAt(i int) string
+
+	Named() map[string]string
+	NamedGet(key string) (string, bool)
+	NamedAll(key string) []string
+}
+
+// ADCMarshaler is implemented by all generated content types. MarshalADC
+// returns the marshalled content, i.e. the command followed by the params and
+// the trailing newline.
+type ADCMarshaler interface {
+	MarshalADC() ([]byte, error)
+}
+
+// DecodeWarner is implemented by the content types of messages with
+// deprecated params. DecodeWarnings returns a warning for each deprecated
+// param present in the content, which allows reporting legacy peers.
+type DecodeWarner interface {
+	DecodeWarnings() []string
+}
+
+// ADCUnmarshaler is implemented by all generated content types. ParseInto
+// replaces the content by the params, the tokens of a message following the
+// header.
+type ADCUnmarshaler interface {
+	ParseInto(params []string, opts *ParseOptions) error
+}
+
+// ContentBase is embedded in all generated content types. It holds the flags
+// not mapped to a param and implements the accessors of those flags. Content
+// types with named params override Named and NamedGet.
+type ContentBase struct {
+	Flags map[string]string
+
+	// msgType is the message type of the frame the content has been parsed
+	// from.
+	msgType byte
+}
+
+// Named returns a copy of the flags not mapped to a param, like the Named
+// methods of contents with named params. Modifying the returned map does not
+// affect the content.
+func (b *ContentBase) Named() map[string]string {
+	return b.UnknownFlags()
+}
+
+// NamedGet returns the value of the flag key, if present.
+func (b *ContentBase) NamedGet(key string) (string, bool) {
+	val, ok := b.Flags[key]
+	return val, ok
+}
+
+// NamedAll returns the value of the flag key as a single element slice, nil
+// if the flag is not present. Flags not mapped to a param are not repeated.
+func (b *ContentBase) NamedAll(key string) []string {
+	val, ok := b.Flags[key]
+	if !ok {
+		return nil
+	}
+
+	return []string{val}
+}
+
+func (b *ContentBase) setMsgType(msgType byte) {
+	b.msgType = msgType
+}
+
+// setCommand is a no-op for contents of a single command. It is overridden by
+// contents of command families, which store the command.
+func (b *ContentBase) setCommand(command string) {}
+
+// UnknownFlags returns a copy of the flags not mapped to a param. Modifying
+// the returned map does not affect the content.
+func (b *ContentBase) UnknownFlags() map[string]string {
+	flags := make(map[string]string, len(b.Flags))
+	for key, val := range b.Flags {
+		flags[key] = val
+	}
+
+	return flags
+}
+
+var _ ParamAccessor = &RawContent{}
+
+// RawContent is an untyped message content, holding the (escaped) positional
+// and named parameters as decoded from the message. NamedParams holds the
+// first value of each named parameter, RepeatedParams holds all values of the
+// named parameters occurring more than once.
+type RawContent struct {
+	PositionalParams []string
+	NamedParams      map[string]string
+	RepeatedParams   map[string][]string
+}
+
+func (r *RawContent) Positional() []string {
+	return r.PositionalParams
+}
+
+func (r *RawContent) PosLen() int {
+	return len(r.PositionalParams)
+}
+
+func (r *RawContent) PosAt(i int) string {
+	return r.PositionalParams[i]
+}
+
+// Named returns a copy of the named params. Modifying the returned map does
+// not affect the content.
+func (r *RawContent) Named() map[string]string {
+	named := make(map[string]string, len(r.NamedParams))
+	for key, val := range r.NamedParams {
+		named[key] = val
+	}
+
+	return named
+}
+
+func (r *RawContent) NamedGet(key string) (string, bool) {
+	val, ok := r.NamedParams[key]
+	return val, ok
+}
+
+func (r *RawContent) NamedAll(key string) []string {
+	if vals, ok := r.RepeatedParams[key]; ok {
+		return vals
+	}
+
+	val, ok := r.NamedParams[key]
+	if !ok {
+		return nil
+	}
+
+	return []string{val}
+}
+
+// accessorParams returns the (escaped) params of pa, i.e. the positional
+// params followed by all values of the named params sorted by name, as
+// passed to the ParseInto methods of content types.
+func accessorParams(pa ParamAccessor) []string {
+	named := pa.Named()
+	params := make([]string, 0, pa.PosLen()+len(named))
+	for i := 0; i < pa.PosLen(); i++ {
+		params = append(params, pa.PosAt(i))
+	}
+
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, val := range pa.NamedAll(name) {
+			params = append(params, name+val)
+		}
+	}
+
+	return params
+}
+
+// Content is implemented by all generated content types. Use a type switch
+// or type assertion to access the fields of the content type.
+type Content interface {
+	ParamAccessor
+	ParseInto(params []string, opts *ParseOptions) error
+	MarshalADC() ([]byte, error)
+	Descriptor() MessageDescriptor
+	Command() string
+}
+
+// content is implemented by all generated content types.
+type content interface {
+	Content
+	setMsgType(msgType byte)
+	setCommand(command string)
+}
+
+// contentTypes maps commands to functions allocating the content type of the
+// command. All generated content types register themselves.
+var contentTypes = make(map[string]func() content)
+
+// contentPatterns holds the content types of command families, which are
+// registered by command pattern.
+var contentPatterns []contentPattern
+
+type contentPattern struct {
+	pattern    string
+	newContent func() content
+}
+
+func registerContent(command string, newContent func() content) {
+	if strings.IndexByte(command, '?') >= 0 {
+		contentPatterns = append(contentPatterns, contentPattern{command, newContent})
+		return
+	}
+
+	contentTypes[command] = newContent
+}
+
+// lookupContent returns the function allocating the content type of command.
+// Commands registered verbatim take precedence over command patterns.
+func lookupContent(command string) (func() content, bool) {
+	if newContent, ok := contentTypes[command]; ok {
+		return newContent, true
+	}
+
+	for _, p := range contentPatterns {
+		if matchCommand(p.pattern, command) {
+			return p.newContent, true
+		}
+	}
+
+	return nil, false
+}
+
+// NewContent returns an empty content of the content type registered for
+// command, e.g. a *INFContent for "INF". The command of contents of command
+// families is set to command. ErrUnknownCommand is returned if no content
+// type is registered for command.
+func NewContent(command string) (Content, error) {
+	newContent, ok := lookupContent(command)
+	if !ok {
+		return nil, ErrUnknownCommand
+	}
+
+	cnt := newContent()
+	cnt.setCommand(command)
+	return cnt, nil
+}
+
+// NewContentForType returns an empty content as NewContent does, for
+// messages of the message type msgType. ErrUnexpectedType is returned if
+// the command is not valid for msgType.
+func NewContentForType(msgType Type, command string) (Content, error) {
+	newContent, ok := lookupContent(command)
+	if !ok {
+		return nil, ErrUnknownCommand
+	}
+
+	cnt := newContent()
+	if err := checkMsgType(cnt, byte(msgType)); err != nil {
+		return nil, err
+	}
+	cnt.setMsgType(byte(msgType))
+	cnt.setCommand(command)
+	return cnt, nil
+}
+
+// checkMsgType returns ErrUnexpectedType if the message type msgType is not
+// valid for the content type of cnt.
+func checkMsgType(cnt content, msgType byte) error {
+	if types := cnt.Descriptor().Types; len(types) > 0 && strings.IndexByte(types, msgType) < 0 {
+		return ErrUnexpectedType
+	}
+
+	return nil
+}
+
+// Commands returns the commands of all registered content types in sorted
+// order. Command families are listed by their command patterns, e.g. "EX?".
+func Commands() []string {
+	commands := make([]string, 0, len(contentTypes)+len(contentPatterns))
+	for command := range contentTypes {
+		commands = append(commands, command)
+	}
+	for _, p := range contentPatterns {
+		commands = append(commands, p.pattern)
+	}
+	sort.Strings(commands)
+
+	return commands
+}
+
+// matchCommand returns true if command matches pattern. Each '?' of the
+// pattern matches any upper case letter or digit, all other characters match
+// themselves.
+func matchCommand(pattern, command string) bool {
+	if len(pattern) != len(command) {
+		return false
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '?' {
+			if !encoding.IsUpperAlphaNum(command[i]) {
+				return false
+			}
+		} else if pattern[i] != command[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MessageDescriptor describes the parameters of a message as specified by its
+// definition.
+type MessageDescriptor struct {
+	Command string
+	// Types lists the message types the message is valid in. Any type is
+	// valid if empty.
+	Types string
+	// Phases is the set of protocol phases the message is valid in. Any
+	// phase is valid if zero.
+	Phases     Phase
+	Positional []ParamDescriptor
+	Named      []ParamDescriptor
+}
+
+// ParamDescriptor describes a single parameter of a message.
+type ParamDescriptor struct {
+	// Name is the protocol name of the parameter.
+	Name string
+	// DisplayName is the name of the parameter intended for presentation to
+	// users. It equals Name if no display name has been defined.
+	DisplayName string
+	// FlagName is the two character name of a named parameter. It is empty
+	// for positional parameters.
+	FlagName string
+	Type     string
+	Required bool
+}
+
+// Default limits of the number of params of a message, used if the
+// corresponding field of ParseOptions is 0.
+const (
+	DefaultMaxPositionals = 1024
+	DefaultMaxNamed       = 1024
+)
+
+// ParseOptions configures how the ParseInto methods of content types handle
+// deviations from the message definition. A nil *ParseOptions is equivalent
+// to the zero value, which selects strict parsing.
+type ParseOptions struct {
+	// Lenient causes surplus positional parameters to be dropped. By
+	// default, i.e. in strict mode, ErrSurplusPositional is returned.
+	Lenient bool
+	// SurplusPositional is called with every positional parameter dropped
+	// in lenient mode. It may be nil.
+	SurplusPositional func(value string)
+	// MaxValueLength limits the length of (escaped) parameter values in
+	// bytes. Longer values are truncated and the Truncated field of the
+	// content is set. There is no limit if MaxValueLength is 0.
+	MaxValueLength int
+	// Compressed indicates that the message has been received while the
+	// stream was in compressed mode (ZON). It is exposed as the Compressed
+	// field of the content.
+	Compressed bool
+	// StrictUTF8 causes values which are not valid UTF-8 to be rejected
+	// with ErrInvalidUTF8. By default, values are not checked.
+	StrictUTF8 bool
+	// StrictFlags causes repeated flags of single-valued named params to be
+	// rejected with ErrDuplicateFlag. By default, the last value wins. Flags
+	// of multi-valued params and unknown flags are not checked.
+	StrictFlags bool
+	// MaxPositionals and MaxNamed limit the number of positional and named
+	// params of a message. Messages exceeding a limit are rejected with
+	// ErrTooManyParams before any params are parsed. DefaultMaxPositionals
+	// and DefaultMaxNamed are used if 0.
+	MaxPositionals int
+	MaxNamed       int
+}
+
+func (o *ParseOptions) compressed() bool {
+	return o != nil && o.Compressed
+}
+
+// checkUTF8 returns ErrInvalidUTF8 if StrictUTF8 is set and the escaped
+// value is not valid UTF-8. As escaping only affects ASCII characters, the
+// unescaped value is valid if and only if the escaped value is.
+func (o *ParseOptions) checkUTF8(value string) error {
+	if o == nil || !o.StrictUTF8 || utf8.ValidString(value) {
+		return nil
+	}
+
+	return ErrInvalidUTF8
+}
+
+// checkDuplicateFlag returns ErrDuplicateFlag if StrictFlags is set and prev,
+// the previously parsed (escaped) named param with the flag of param, is
+// non-empty. The error names both values.
+func (o *ParseOptions) checkDuplicateFlag(prev, param string) error {
+	if o == nil || !o.StrictFlags || prev == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w, values %q and %q", ErrDuplicateFlag, prev[2:], param[2:])
+}
+
+// checkCounts returns ErrTooManyParams if the (escaped) params exceed
+// MaxPositionals or MaxNamed. Params are classified as named by isNamedParam,
+// positionals matching the flag grammar are counted as named.
+func (o *ParseOptions) checkCounts(params []string) error {
+	maxPositionals, maxNamed := o.maxCounts()
+
+	if len(params) <= maxPositionals && len(params) <= maxNamed {
+		return nil
+	}
+
+	var numNamed int
+	for _, param := range params {
+		if isNamedParam(param) {
+			numNamed++
+		}
+	}
+
+	if numPositionals := len(params) - numNamed; numPositionals > maxPositionals {
+		return fmt.Errorf("%w, %d positional params exceed the limit of %d",
+			ErrTooManyParams, numPositionals, maxPositionals)
+	}
+	if numNamed > maxNamed {
+		return fmt.Errorf("%w, %d named params exceed the limit of %d",
+			ErrTooManyParams, numNamed, maxNamed)
+	}
+
+	return nil
+}
+
+// maxCounts returns the limits of the numbers of positional and named params.
+func (o *ParseOptions) maxCounts() (int, int) {
+	maxPositionals, maxNamed := DefaultMaxPositionals, DefaultMaxNamed
+	if o != nil && o.MaxPositionals > 0 {
+		maxPositionals = o.MaxPositionals
+	}
+	if o != nil && o.MaxNamed > 0 {
+		maxNamed = o.MaxNamed
+	}
+
+	return maxPositionals, maxNamed
+}
+
+// splitTokens splits line, a message optionally terminated by a newline, into
+// its tokens. numHeader is the number of header tokens preceding the params,
+// e.g. the command. ErrTooManyParams is returned before splitting if the
+// params exceed the MaxPositionals and MaxNamed of opts in total, so the
+// limits bound the memory allocated for the tokens. The limits of the
+// individual kinds of params are checked by checkCounts.
+func splitTokens(line string, numHeader int, opts *ParseOptions) ([]string, error) {
+	line = strings.TrimSuffix(line, "\n")
+
+	maxPositionals, maxNamed := opts.maxCounts()
+	if numParams := strings.Count(line, " ") + 1 - numHeader; numParams > maxPositionals+maxNamed {
+		return nil, fmt.Errorf("%w, %d params exceed the limit of %d",
+			ErrTooManyParams, numParams, maxPositionals+maxNamed)
+	}
+
+	return strings.Split(line, " "), nil
+}
+
+func (o *ParseOptions) surplusPositional(value string) error {
+	if o == nil || !o.Lenient {
+		return ErrSurplusPositional
+	}
+
+	if o.SurplusPositional != nil {
+		o.SurplusPositional(value)
+	}
+
+	return nil
+}
+
+// truncateValue truncates the escaped value to at most MaxValueLength bytes.
+// Neither escape sequences nor UTF-8 encoded characters are split. The second
+// return value is true if value has been truncated.
+func (o *ParseOptions) truncateValue(value string) (string, bool) {
+	if o == nil || o.MaxValueLength <= 0 || len(value) <= o.MaxValueLength {
+		return value, false
+	}
+
+	end := o.MaxValueLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	var backslashes int
+	for i := end - 1; i >= 0 && value[i] == '\\'; i-- {
+		backslashes++
+	}
+	if backslashes%2 == 1 {
+		end--
+	}
+
+	return value[:end], true
+}
+
+// isNamedParam returns true if the (escaped) token tok is a named parameter,
+// i.e. starts with a parameter name.
+func isNamedParam(tok string) bool {
+	return len(tok) >= 2 &&
+		encoding.IsUpperAlpha(tok[0]) &&
+		encoding.IsUpperAlphaNum(tok[1])
+}
+
+// hasNamedParam returns true if params contains a named parameter with the
+// name.
+func hasNamedParam(params []string, name string) bool {
+	for _, param := range params {
+		if isNamedParam(param) && param[:2] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidationError is returned by Validate, listing all constraint violations
+// of the content. errors.Is and errors.As match any of the violations.
+type ValidationError struct {
+	Errs []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	for _, err := range e.Errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *ValidationError) As(target interface{}) bool {
+	for _, err := range e.Errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validationError returns a ValidationError listing errs, or nil if errs is
+// empty.
+func validationError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errs: errs}
+}
+
+// joinParams returns the (escaped) positional params followed by the named
+// params, keyed by flag name, in the form expected by ParseInto. The named
+// params are ordered by name. ErrMalformedFlag is returned if a key is not a
+// flag name.
+func joinParams(positional []string, named map[string]string) ([]string, error) {
+	names := make([]string, 0, len(named))
+	for name := range named {
+		if !isNamedParam(name) || len(name) != 2 {
+			return nil, fmt.Errorf("flag %q: %w", name, ErrMalformedFlag)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(positional)+len(named))
+	params = append(params, positional...)
+	for _, name := range names {
+		params = append(params, name+named[name])
+	}
+
+	return params, nil
+}
+
+// joinValues joins the (escaped) values of a multi-valued param by spaces,
+// which escaped values do not contain. The first n bytes of each value, i.e.
+// the flag name of named params, are dropped.
+func joinValues(values []string, n int) string {
+	var b strings.Builder
+	for i, val := range values {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(val[n:])
+	}
+
+	return b.String()
+}
+
+// splitValues splits the values of a multi-valued param joined by
+// joinValues. The empty string holds no values.
+func splitValues(s string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return strings.Split(s, " ")
+}
+
+// checkEscaped returns ErrUnescapedSeparator if the escaped value contains a
+// raw space or newline, which would corrupt the marshalled message.
+func checkEscaped(value string) error {
+	if strings.ContainsAny(value, " \n") {
+		return ErrUnescapedSeparator
+	}
+
+	return nil
+}
+
+// valueEscaper escapes the separators and the escape character of values.
+var valueEscaper = strings.NewReplacer(
+	" ", "\\s",
+	"\n", "\\n",
+	"\\", "\\\\",
+)
+
+// escapeValue escapes the (decoded) value for the wire format. The value is
+// returned as is if it contains nothing to escape.
+func escapeValue(value string) string {
+	if !strings.ContainsAny(value, " \n\\") {
+		return value
+	}
+
+	return valueEscaper.Replace(value)
+}
+
+// appendEscaped appends the escaped (decoded) value to buf, like escapeValue
+// but without allocating if buf has sufficient capacity.
+func appendEscaped(buf []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case ' ':
+			buf = append(buf, '\\', 's')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		default:
+			buf = append(buf, value[i])
+		}
+	}
+
+	return buf
+}
+
+// formatBase32 returns the base32 parameter of val, the empty string if val
+// is nil.
+func formatBase32(val *encoding.Base32Value) string {
+	if val == nil {
+		return ""
+	}
+
+	return val.String()
+}
+
+// formatIP returns the IP parameter of ip, the empty string if ip is nil.
+func formatIP(ip net.IP) string {
+	if len(ip) == 0 {
+		return ""
+	}
+
+	return ip.String()
+}
+
+// appendIP appends the IP parameter of ip to buf, like formatIP.
+func appendIP(buf []byte, ip net.IP) []byte {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return append(buf, formatIP(ip)...)
+	}
+
+	return addr.Unmap().AppendTo(buf)
+}
+
+// formatAddr returns the address parameter of addr, the empty string if addr
+// is the zero Addr.
+func formatAddr(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+
+	return encoding.FormatAddr(addr)
+}
+
+// appendAddr appends the address parameter of addr to buf, like formatAddr.
+func appendAddr(buf []byte, addr netip.Addr) []byte {
+	if !addr.IsValid() {
+		return buf
+	}
+
+	return addr.Unmap().AppendTo(buf)
+}
+
+// checkFlagsEscaped performs checkEscaped on the values of all flags. The
+// error names the offending flag.
+func checkFlagsEscaped(flags map[string]string) error {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := checkEscaped(flags[name]); err != nil {
+			return fmt.Errorf("flag %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// appendFlags appends the flags as named parameters to buf. The flags are
+// ordered by name to keep the output deterministic.
+func appendFlags(buf []byte, flags map[string]string) []byte {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, flags[name]...)
+	}
+
+	return buf
+}
+
+// maxStackFlags is the number of flags encodeFlags sorts without allocating.
+const maxStackFlags = 8
+
+// encodeFlags appends the flags to buf like appendFlags. Up to maxStackFlags
+// flags are sorted on the stack, so that no memory is allocated apart from
+// growing buf.
+func encodeFlags(buf []byte, flags map[string]string) []byte {
+	if len(flags) > maxStackFlags {
+		return appendFlags(buf, flags)
+	}
+
+	var arr [maxStackFlags]string
+	names := arr[:0]
+	for name := range flags {
+		// Insertion sort, sort.Strings would move names to the heap.
+		i := len(names)
+		names = append(names, name)
+		for ; i > 0 && names[i-1] > name; i-- {
+			names[i] = names[i-1]
+		}
+		names[i] = name
+	}
+
+	for _, name := range names {
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, flags[name]...)
+	}
+
+	return buf
+}
+
+// writeFlags writes flags to b like appendFlags.
+func writeFlags(b *strings.Builder, flags map[string]string) {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.WriteByte(' ')
+		b.WriteString(name)
+		b.WriteString(flags[name])
+	}
+}
+
+// flagsSize returns the number of bytes appended by appendFlags for flags.
+func flagsSize(flags map[string]string) int {
+	n := 0
+	for name, value := range flags {
+		n += 1 + len(name) + len(value)
+	}
+
+	return n
+}
+
+// rawLine returns a copy of line terminated by a newline, as stored by
+// UnmarshalADC of content types generated with raw passthrough.
+func rawLine(line []byte) []byte {
+	raw := make([]byte, 0, len(line)+1)
+	raw = append(raw, line...)
+	if len(raw) == 0 || raw[len(raw)-1] != '\n' {
+		raw = append(raw, '\n')
+	}
+
+	return raw
+}
+
+// exactlyOne returns true if exactly one of present is true.
+func exactlyOne(present ...bool) bool {
+	n := 0
+	for _, p := range present {
+		if p {
+			n++
+		}
+	}
+
+	return n == 1
+}
+
+// checkBits returns ErrUnknownBits if the bitmask val has any bits set apart
+// from the n least significant bits.
+func checkBits(val int, n uint) error {
+	if val < 0 || val>>n != 0 {
+		return ErrUnknownBits
+	}
+
+	return nil
+}
+
+// bitmask returns the bitmask with the ith bit set if bits[i] is true.
+func bitmask(bits ...bool) int {
+	var val int
+	for i, bit := range bits {
+		if bit {
+			val |= 1 << uint(i)
+		}
+	}
+
+	return val
+}
+
+// formatContent formats the content marshalled by marshal for the verb. %s
+// and %v write the wire form, %q the quoted wire form, both omitting the
+// terminating newline. %x and %X write the hex encoding of the marshalled
+// bytes.
+func formatContent(f fmt.State, verb rune, marshal func() ([]byte, error)) {
+	buf, err := marshal()
+	if err != nil {
+		fmt.Fprintf(f, "%%!%c(%v)", verb, err)
+		return
+	}
+
+	switch verb {
+	case 's', 'v':
+		f.Write(bytes.TrimSuffix(buf, []byte{'\n'}))
+	case 'q':
+		fmt.Fprintf(f, "%q", bytes.TrimSuffix(buf, []byte{'\n'}))
+	case 'x':
+		fmt.Fprintf(f, "%x", buf)
+	case 'X':
+		fmt.Fprintf(f, "%X", buf)
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, bytes.TrimSuffix(buf, []byte{'\n'}))
+	}
+}
+
+// Error variables related to building and parsing message frames.
+var (
+	ErrUnsupportedType = errors.New("message type not supported by frame builder and parser")
+	ErrMissingSID      = errors.New("SID required by message type missing")
+	ErrSurplusSID      = errors.New("SID provided which is not part of the header of the message type")
+	ErrMalformedFrame  = errors.New("malformed message frame")
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrUnexpectedType  = errors.New("message type not valid for command")
+	ErrUnexpectedPhase = errors.New("command not valid in protocol phase")
+	ErrKeepAlive       = errors.New("empty keep-alive line")
+)
+
+// Type is the message type, i.e. the first character of a message. It
+// determines the additional header fields of the message.
+type Type byte
+
+const (
+	TypeBroadcast        Type = 'B'
+	TypeClientmessage    Type = 'C'
+	TypeDirectmessage    Type = 'D'
+	TypeEchomessage      Type = 'E'
+	TypeFeaturebroadcast Type = 'F'
+	TypeHubmessage       Type = 'H'
+	TypeInfomessage      Type = 'I'
+	TypeUDPmessage       Type = 'U'
+)
+
+// Phase is a set of protocol phases. The phases determine which commands
+// may be sent.
+type Phase uint8
+
+const (
+	PhaseProtocol Phase = 1 << iota
+	PhaseIdentify
+	PhaseVerify
+	PhaseNormal
+	PhaseData
+)
+
+// FrameContent is the content of a message framed by FrameBuilder. It is
+// implemented by all generated content types.
+type FrameContent interface {
+	ParamAccessor
+	MarshalADC() ([]byte, error)
+}
+
+// FrameBuilder builds complete message frames, i.e. the header followed by
+// the marshalled content. A FrameBuilder can be used to frame any number of
+// contents with the same header fields.
+//
+// Featurebroadcast and UDPmessage types are not supported.
+type FrameBuilder struct {
+	Type Type
+	// MySID is the SID of the sender. It is required by the Broadcast,
+	// Directmessage and Echomessage types.
+	MySID *encoding.Base32Value
+	// TargetSID is the SID of the recipient. It is required by the
+	// Directmessage and Echomessage types.
+	TargetSID *encoding.Base32Value
+}
+
+// Build marshals content and returns the full message frame, including the
+// trailing newline.
+func (f *FrameBuilder) Build(content FrameContent) ([]byte, error) {
+	err := f.checkHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := content.MarshalADC()
+	if err != nil {
+		return nil, err
+	}
+
+	// body starts with the three character command, followed by the
+	// params.
+	buf := make([]byte, 0, len(body)+11)
+	buf = append(buf, byte(f.Type))
+	buf = append(buf, body[:3]...)
+	if f.MySID != nil {
+		buf = append(buf, ' ')
+		buf = append(buf, f.MySID.String()...)
+	}
+	if f.TargetSID != nil {
+		buf = append(buf, ' ')
+		buf = append(buf, f.TargetSID.String()...)
+	}
+	buf = append(buf, body[3:]...)
+
+	return buf, nil
+}
+
+// FrameParser parses complete message frames, i.e. the header followed by the
+// content. The zero value is ready to use.
+type FrameParser struct {
+	// RawUnknown causes frames with unknown commands to be parsed into a
+	// RawContent. By default, ErrUnknownCommand is returned.
+	RawUnknown bool
+	// Options are passed to the ParseInto method of the content.
+	Options *ParseOptions
+	// Lenient causes empty lines, which are sent as keep-alives, to be
+	// reported as ErrKeepAlive instead of ErrMalformedFrame. Additionally,
+	// a single trailing space before the terminator is ignored instead of
+	// being parsed as an empty param.
+	Lenient bool
+	// Phase is the current protocol phase. If set, frames with commands not
+	// valid in the phase are rejected with ErrUnexpectedPhase. Commands
+	// valid in any phase and unknown commands are always accepted.
+	Phase Phase
+}
+
+// ParseFrame parses line, a complete message frame, using the default
+// FrameParser.
+func ParseFrame(line []byte) (ParamAccessor, error) {
+	var p FrameParser
+	return p.Parse(line)
+}
+
+// Parse parses line, a complete message frame, into the content type of its
+// command. The header fields are checked, but not returned.
+func (p *FrameParser) Parse(line []byte) (ParamAccessor, error) {
+	if p.Lenient {
+		if len(line) == 0 || len(line) == 1 && line[0] == '\n' {
+			return nil, ErrKeepAlive
+		}
+		line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+	}
+
+	msgType, command, err := PeekCommand(line)
+	if err != nil {
+		return nil, err
+	}
+
+	numSIDs, err := headerSIDs(Type(msgType))
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := splitTokens(string(line), 1+numSIDs, p.Options)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) < 1+numSIDs {
+		return nil, ErrMalformedFrame
+	}
+	for _, sid := range tokens[1 : 1+numSIDs] {
+		_, err := encoding.ParseBase32Value(sid)
+		if err != nil {
+			return nil, ErrMalformedFrame
+		}
+	}
+
+	params := tokens[1+numSIDs:]
+
+	newContent, ok := lookupContent(command)
+	if !ok {
+		if !p.RawUnknown {
+			return nil, ErrUnknownCommand
+		}
+		if err := p.Options.checkCounts(params); err != nil {
+			return nil, err
+		}
+
+		return newRawContent(params), nil
+	}
+
+	cnt := newContent()
+	if err := checkMsgType(cnt, msgType); err != nil {
+		return nil, err
+	}
+	if phases := cnt.Descriptor().Phases; p.Phase != 0 && phases != 0 && phases&p.Phase == 0 {
+		return nil, ErrUnexpectedPhase
+	}
+
+	err = cnt.ParseInto(params, p.Options)
+	if err != nil {
+		return nil, err
+	}
+	cnt.setMsgType(msgType)
+	cnt.setCommand(command)
+
+	return cnt, nil
+}
+
+// DecodeAll parses the frames of data, one per line, using the default
+// FrameParser.
+func DecodeAll(data []byte) ([]ParamAccessor, []error) {
+	var p FrameParser
+	return p.DecodeAll(data)
+}
+
+// DecodeAll parses the frames of data, one per line, e.g. the contents of a
+// capture file. Unlike Parse, it does not stop at the first error: the
+// contents of all lines parsed successfully are returned in order, along with
+// an error for each line failing to parse, which names the (1-based) line
+// number. A final line lacking the terminator is parsed as well.
+func (p *FrameParser) DecodeAll(data []byte) ([]ParamAccessor, []error) {
+	var (
+		contents []ParamAccessor
+		errs     []error
+	)
+
+	for lineNo := 1; len(data) > 0; lineNo++ {
+		end := bytes.IndexByte(data, '\n') + 1
+		if end == 0 {
+			end = len(data)
+		}
+		line := data[:end]
+		data = data[end:]
+
+		cnt, err := p.Parse(line)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNo, err))
+			continue
+		}
+		contents = append(contents, cnt)
+	}
+
+	return contents, errs
+}
+
+// PeekCommand returns the message type and the command of line, a complete
+// message frame, without parsing the remainder of the frame. ErrMalformedFrame
+// is returned if line does not start with a message type followed by a
+// command.
+func PeekCommand(line []byte) (msgType byte, command string, err error) {
+	if len(line) < 4 || len(line) > 4 && line[4] != ' ' && line[4] != '\n' {
+		return 0, "", ErrMalformedFrame
+	}
+
+	switch Type(line[0]) {
+	case TypeBroadcast, TypeClientmessage, TypeDirectmessage, TypeEchomessage,
+		TypeFeaturebroadcast, TypeHubmessage, TypeInfomessage, TypeUDPmessage:
+	default:
+		return 0, "", ErrMalformedFrame
+	}
+
+	if !encoding.IsUpperAlpha(line[1]) ||
+		!encoding.IsUpperAlphaNum(line[2]) ||
+		!encoding.IsUpperAlphaNum(line[3]) {
+		return 0, "", ErrMalformedFrame
+	}
+
+	return line[0], string(line[1:4]), nil
+}
+
+// newRawContent returns a RawContent holding the (escaped) params.
+func newRawContent(params []string) *RawContent {
+	raw := &RawContent{
+		NamedParams: make(map[string]string),
+	}
+
+	for _, param := range params {
+		if !isNamedParam(param) {
+			raw.PositionalParams = append(raw.PositionalParams, param)
+			continue
+		}
+
+		name, val := param[:2], param[2:]
+		first, ok := raw.NamedParams[name]
+		switch {
+		case !ok:
+			raw.NamedParams[name] = val
+		case raw.RepeatedParams[name] == nil:
+			if raw.RepeatedParams == nil {
+				raw.RepeatedParams = make(map[string][]string)
+			}
+			raw.RepeatedParams[name] = []string{first, val}
+		default:
+			raw.RepeatedParams[name] = append(raw.RepeatedParams[name], val)
+		}
+	}
+
+	return raw
+}
+
+// headerSIDs returns the number of SIDs in the header of messages of the
+// type.
+func headerSIDs(typ Type) (int, error) {
+	switch typ {
+	case TypeBroadcast:
+		return 1, nil
+	case TypeDirectmessage, TypeEchomessage:
+		return 2, nil
+	case TypeClientmessage, TypeHubmessage, TypeInfomessage:
+		return 0, nil
+	default:
+		return 0, ErrUnsupportedType
+	}
+}
+
+// checkHeader checks that the SIDs provided match the header fields of the
+// message type.
+func (f *FrameBuilder) checkHeader() error {
+	var needsMySID, needsTargetSID bool
+
+	switch f.Type {
+	case TypeBroadcast:
+		needsMySID = true
+	case TypeDirectmessage, TypeEchomessage:
+		needsMySID = true
+		needsTargetSID = true
+	case TypeClientmessage, TypeHubmessage, TypeInfomessage:
+	default:
+		return ErrUnsupportedType
+	}
+
+	if needsMySID && f.MySID == nil || needsTargetSID && f.TargetSID == nil {
+		return ErrMissingSID
+	}
+	if !needsMySID && f.MySID != nil || !needsTargetSID && f.TargetSID != nil {
+		return ErrSurplusSID
+	}
+
+	return nil
+}
+
+// Error variables related to comparing message content.
+var (
+	ErrCommandMismatch = errors.New("line holds a different command")
+)
+
+// EqualMode selects the semantics of the EqualBytes methods of content
+// types.
+type EqualMode int
+
+const (
+	// EqualSemantic parses the line and compares the result with Equal. The
+	// order of named params is not significant.
+	EqualSemantic EqualMode = iota
+	// EqualExact marshals the content and compares the result byte by byte
+	// with the line.
+	EqualExact
+)
+
+// equalParams returns true if a and b have the same positional params and
+// the same named params. Params are compared in their escaped form.
+func equalParams(a, b ParamAccessor) bool {
+	if a.PosLen() != b.PosLen() {
+		return false
+	}
+	for i := 0; i < a.PosLen(); i++ {
+		if a.PosAt(i) != b.PosAt(i) {
+			return false
+		}
+	}
+
+	aNamed, bNamed := a.Named(), b.Named()
+	if len(aNamed) != len(bNamed) {
+		return false
+	}
+	for key, val := range aNamed {
+		if bVal, ok := bNamed[key]; !ok || bVal != val {
+			return false
+		}
+
+		aAll, bAll := a.NamedAll(key), b.NamedAll(key)
+		if len(aAll) != len(bAll) {
+			return false
+		}
+		for i := range aAll {
+			if aAll[i] != bAll[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// equalBytes implements the EqualBytes methods of content types. parse is
+// called with the params of line in EqualSemantic mode and returns the
+// parsed content.
+func equalBytes(c content, line []byte, mode EqualMode, parse func(params []string) (ParamAccessor, error)) (bool, error) {
+	if mode == EqualExact {
+		buf, err := c.MarshalADC()
+		if err != nil {
+			return false, err
+		}
+
+		return bytes.Equal(buf, line), nil
+	}
+
+	tokens, err := splitTokens(string(line), 1, nil)
+	if err != nil {
+		return false, err
+	}
+	if tokens[0] != c.Command() {
+		return false, ErrCommandMismatch
+	}
+
+	other, err := parse(tokens[1:])
+	if err != nil {
+		return false, err
+	}
+
+	return equalParams(c, other), nil
+}
+
+// hashKey implements the HashKey methods of content types. The key consists
+// of the command and the positional params, followed by the named params
+// sorted by name, all in their escaped form. As escaped params contain
+// neither spaces nor newlines, the key is unambiguous and consistent with
+// equalParams.
+func hashKey(c content) string {
+	var b strings.Builder
+	b.WriteString(c.Command())
+	for i := 0; i < c.PosLen(); i++ {
+		b.WriteByte(' ')
+		b.WriteString(c.PosAt(i))
+	}
+
+	named := c.Named()
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteByte('\n')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(name)
+		b.WriteString(named[name])
+	}
+
+	return b.String()
+}
+
+// isIgnored returns true if name is contained in ignore.
+func isIgnored(ignore []string, name string) bool {
+	for _, ignored := range ignore {
+		if ignored == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalStrs returns true if a and b hold the same values in the same order.
+func equalStrs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalFlagsIgnoring returns true if the flags a and b are equal, apart from
+// the flags named by ignore.
+func equalFlagsIgnoring(a, b map[string]string, ignore []string) bool {
+	for name, val := range a {
+		if bVal, ok := b[name]; (!ok || bVal != val) && !isIgnored(ignore, name) {
+			return false
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok && !isIgnored(ignore, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Error variables related to LengthPrefixCodec.
+var (
+	ErrInvalidPrefixSize = errors.New("length prefix size must be 1, 2 or 4 bytes")
+	ErrFrameTooLong      = errors.New("frame too long for length prefix")
+)
+
+// DefaultMaxFrameLength is the maximum length of frames read and written by a
+// LengthPrefixCodec without MaxLength.
+const DefaultMaxFrameLength = 1 << 20
+
+// frameChunkSize is the length up to which frames are read into a buffer
+// allocated up front. Longer frames are read incrementally, so the memory
+// allocated is bounded by the bytes actually received.
+const frameChunkSize = 1 << 16
+
+// LengthPrefixCodec reads and writes message frames prefixed by their
+// length, as used by some ADC-over-TCP deployments. The length prefix
+// replaces the newline terminating each frame, frames are written without the
+// terminator and read frames do not contain it.
+type LengthPrefixCodec struct {
+	// PrefixSize is the size of the length prefix in bytes, one of 1, 2 or
+	// 4. The length is encoded in big-endian byte order.
+	PrefixSize int
+	// MaxLength limits the length of frames read and written. If zero,
+	// DefaultMaxFrameLength is used. The length is always limited by the
+	// maximum value of the prefix.
+	MaxLength int
+}
+
+// WriteFrame writes frame to w, prefixed by its length. A trailing newline
+// of frame, as appended by FrameBuilder, is not written.
+func (c *LengthPrefixCodec) WriteFrame(w io.Writer, frame []byte) error {
+	if n := len(frame); n > 0 && frame[n-1] == '\n' {
+		frame = frame[:n-1]
+	}
+
+	maxLength, err := c.maxLength()
+	if err != nil {
+		return err
+	}
+	if uint64(len(frame)) > maxLength {
+		return ErrFrameTooLong
+	}
+
+	buf := make([]byte, c.PrefixSize, c.PrefixSize+len(frame))
+	c.putLength(buf, len(frame))
+	buf = append(buf, frame...)
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// ReadFrame reads the next frame from r. Partial reads of the prefix and the
+// frame are continued until the frame is complete. io.EOF is returned if r
+// is exhausted before a frame starts, io.ErrUnexpectedEOF if it is exhausted
+// within a frame.
+func (c *LengthPrefixCodec) ReadFrame(r io.Reader) ([]byte, error) {
+	maxLength, err := c.maxLength()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, c.PrefixSize)
+	_, err = io.ReadFull(r, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	length := c.length(prefix)
+	if length > maxLength {
+		return nil, ErrFrameTooLong
+	}
+
+	if length > frameChunkSize {
+		return readFrameIncrementally(r, length)
+	}
+
+	frame := make([]byte, length)
+	_, err = io.ReadFull(r, frame)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// readFrameIncrementally reads the frame of the given length from r, growing
+// the buffer as the bytes of the frame are received.
+func readFrameIncrementally(r io.Reader, length uint64) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, r, int64(length))
+	if uint64(n) < length && (err == nil || err == io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// maxLength returns the maximum length of frames accepted by the codec.
+func (c *LengthPrefixCodec) maxLength() (uint64, error) {
+	var maxLength uint64
+
+	switch c.PrefixSize {
+	case 1:
+		maxLength = 1<<8 - 1
+	case 2:
+		maxLength = 1<<16 - 1
+	case 4:
+		maxLength = 1<<32 - 1
+	default:
+		return 0, ErrInvalidPrefixSize
+	}
+
+	limit := uint64(DefaultMaxFrameLength)
+	if c.MaxLength > 0 {
+		limit = uint64(c.MaxLength)
+	}
+	if limit < maxLength {
+		maxLength = limit
+	}
+
+	return maxLength, nil
+}
+
+// putLength encodes length into the prefix buf.
+func (c *LengthPrefixCodec) putLength(buf []byte, length int) {
+	switch c.PrefixSize {
+	case 1:
+		buf[0] = byte(length)
+	case 2:
+		binary.BigEndian.PutUint16(buf, uint16(length))
+	case 4:
+		binary.BigEndian.PutUint32(buf, uint32(length))
+	}
+}
+
+// length decodes the length from the prefix buf.
+func (c *LengthPrefixCodec) length(buf []byte) uint64 {
+	switch c.PrefixSize {
+	case 1:
+		return uint64(buf[0])
+	case 2:
+		return uint64(binary.BigEndian.Uint16(buf))
+	default:
+		return uint64(binary.BigEndian.Uint32(buf))
+	}
+}
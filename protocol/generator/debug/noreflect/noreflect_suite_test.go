@@ -0,0 +1,15 @@
+//go:build noreflect
+
+package noreflect_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestNoReflect(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generated NoReflect Suite")
+}
@@ -0,0 +1,45 @@
+//go:build noreflect
+
+package noreflect_test
+
+import (
+	"fmt"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/seoester/adcl/protocol/generator/debug/noreflect"
+)
+
+var _ = Describe("NoReflect", func() {
+	It("should omit the methods formatting values by reflection", func() {
+		var cnt interface{} = &MIXContent{}
+
+		_, ok := cnt.(fmt.Formatter)
+		Ω(ok).Should(BeFalse())
+		_, ok = cnt.(fmt.Stringer)
+		Ω(ok).Should(BeFalse())
+		_, ok = cnt.(slog.LogValuer)
+		Ω(ok).Should(BeFalse())
+		_, ok = cnt.(interface{ Labels() map[string]string })
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should parse and marshal contents", func() {
+		line := []byte("MIX 7 a c desc NInick XXext\n")
+
+		var cnt MIXContent
+		Ω(cnt.UnmarshalADC(line)).Should(Succeed())
+		Ω(cnt.Code).Should(Equal(7))
+		Ω(cnt.Items).Should(Equal([]string{"a", "c"}))
+		Ω(cnt.NI.Value).Should(Equal("nick"))
+		Ω(cnt.MarshalADC()).Should(Equal(line))
+	})
+
+	It("should build contents", func() {
+		cnt, err := NewMIXBuilder().Code(8).Description("new desc").Build()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(cnt.MarshalADC()).Should(Equal([]byte("MIX 8 new\\sdesc\n")))
+	})
+})
@@ -0,0 +1,335 @@
+package generator
+
+import (
+	"bytes"
+	"go/build/constraint"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to enums of definitions.
+var (
+	ErrUnknownEnum      = errors.New("enum not declared by the definition")
+	ErrEnumTypeMismatch = errors.New("enum type differs from param type")
+	ErrDuplicateEnum    = errors.New("enum declared more than once")
+	ErrInvalidEnum      = errors.New("enum name is not an exported identifier or enum type not comparable")
+	ErrOptionalEnum     = errors.New("optional enum params require a generic optional wrapper")
+)
+
+// SupportFileName is the name of the file holding the declarations shared by
+// all messages, as written by FileGenerator.RenderFiles.
+const SupportFileName = "adc_support.go"
+
+// FileGenerator generates the enums and the content types of all messages of
+// a definition. Each enum is emitted once as a named type with a block of
+// constants, which the Validate methods of params referencing the enum use.
+// Params whose type is the name of an enum have fields of the enum type.
+//
+// Render writes everything into a single file, RenderFiles splits the output
+// into one file per message and a shared support file.
+type FileGenerator struct {
+	// NoReflect restricts the generated code to not depend on reflection,
+	// see StructGenerator.
+	NoReflect bool
+
+	definition *Definition
+	// opts are passed to the struct generators of all messages.
+	opts        []Option
+	packageName string
+	importPath  string
+	buildExpr   constraint.Expr
+	helpers     bool
+
+	structGenerators []*StructGenerator
+	imports          []string
+}
+
+// NewFileGenerator returns a generator of the definition. opts configure the
+// generators of all messages of the definition.
+func NewFileGenerator(definition *Definition, opts ...Option) *FileGenerator {
+	// Options only affecting the file are read from a generator without
+	// message.
+	s := NewStructGenerator(nil, opts...)
+
+	return &FileGenerator{
+		NoReflect:   s.NoReflect,
+		definition:  definition,
+		opts:        opts,
+		packageName: s.packageName,
+		importPath:  s.importPath,
+		buildExpr:   s.buildExpr,
+		helpers:     s.helpers,
+	}
+}
+
+// Render generates the file containing the enums and the content types of
+// all messages and writes the formatted source code to w.
+func (f *FileGenerator) Render(w io.Writer) error {
+	err := f.prepare(false)
+	if err != nil {
+		return err
+	}
+
+	file := newFile(f.packageName, f.importPath, f.buildExpr)
+
+	f.generateEnums(file)
+
+	for _, s := range f.structGenerators {
+		s.generateDecls(file)
+	}
+
+	src, err := f.renderFile(file, f.imports)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+// RenderFiles generates one file per message, named by ContentFileName, and
+// the shared support file named SupportFileName. The support file contains
+// the enums and the registration of all content types. The returned map
+// holds the formatted source code by file name.
+//
+// The support declarations used by the content types, such as ParamAccessor,
+// the escaping helpers and the wrapper types of optional values, are only
+// written if WithHelpers is set. They are written to the file named
+// HelpersFileName. Otherwise they have to be provided by the package.
+func (f *FileGenerator) RenderFiles() (map[string][]byte, error) {
+	err := f.prepare(true)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(f.structGenerators)+1)
+
+	for _, s := range f.structGenerators {
+		file := newFile(f.packageName, f.importPath, f.buildExpr)
+		s.generateDecls(file)
+
+		src, err := f.renderFile(file, s.imports)
+		if err != nil {
+			return nil, err
+		}
+
+		files[ContentFileName(s.baseName())] = src
+	}
+
+	file := newFile(f.packageName, f.importPath, f.buildExpr)
+
+	f.generateEnums(file)
+
+	if len(f.structGenerators) > 0 {
+		file.Func().Id("init").Params().BlockFunc(func(group *jen.Group) {
+			for _, s := range f.structGenerators {
+				group.Add(s.generateRegistration())
+			}
+		})
+	}
+
+	src, err := f.renderFile(file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	files[SupportFileName] = src
+
+	if f.helpers {
+		src, err := f.renderHelpers(f.wrappedTypes())
+		if err != nil {
+			return nil, err
+		}
+
+		files[HelpersFileName] = src
+	}
+
+	return files, nil
+}
+
+// ContentFileName returns the name of the file holding the content type of
+// the command, as written by FileGenerator.RenderFiles.
+func ContentFileName(command string) string {
+	return "content_" + strings.ToLower(command) + ".go"
+}
+
+// renderFile renders file, adds the additional imports and returns the
+// formatted source code.
+func (f *FileGenerator) renderFile(file *jen.File, imports []string) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	err := file.Render(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := addImports(buf.Bytes(), imports)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.NoReflect {
+		err = checkNoReflection(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return src, nil
+}
+
+// prepare prepares the struct generators of all messages. If
+// sharedRegistration is set, the content types are not registered by their
+// own files.
+func (f *FileGenerator) prepare(sharedRegistration bool) error {
+	err := f.prepareEnums()
+	if err != nil {
+		return err
+	}
+
+	f.structGenerators = nil
+	f.imports = nil
+
+	seenImports := make(map[string]bool)
+
+	for _, message := range f.definition.Messages {
+		s := NewStructGenerator(message, f.opts...)
+		s.enums = f.definition.Enums
+		s.sharedRegistration = sharedRegistration
+
+		err := s.prepare()
+		if err != nil {
+			return err
+		}
+
+		for _, path := range s.imports {
+			if !seenImports[path] {
+				seenImports[path] = true
+				f.imports = append(f.imports, path)
+			}
+		}
+
+		f.structGenerators = append(f.structGenerators, s)
+	}
+
+	return nil
+}
+
+// prepareEnums checks that enum names are unique and that all enum values
+// are valid literals of the enum type.
+func (f *FileGenerator) prepareEnums() error {
+	seen := make(map[string]bool)
+
+	for _, enum := range f.definition.Enums {
+		if seen[enum.Name] {
+			return errors.Wrapf(ErrDuplicateEnum, "enum %s", enum.Name)
+		}
+		seen[enum.Name] = true
+
+		validType := enum.Type == "int" || enum.Type == "float" || enum.Type == "string"
+		if !token.IsIdentifier(enum.Name) || !token.IsExported(enum.Name) || !validType {
+			return errors.Wrapf(ErrInvalidEnum, "enum %s of type %s", enum.Name, enum.Type)
+		}
+
+		for _, enumValue := range enum.Values {
+			_, err := basicLitFromString(enum.Type, enumValue.Value)
+			if err != nil {
+				return errors.Wrapf(err, "invalid value %s of enum %s", enumValue.Name, enum.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *FileGenerator) generateEnums(file *jen.File) {
+	for _, enum := range f.definition.Enums {
+		f.generateEnum(file, enum)
+	}
+}
+
+func (f *FileGenerator) generateEnum(file *jen.File, enum *Enum) {
+	if len(enum.Comment) > 0 {
+		addComment(file, enum.Comment)
+	}
+	file.Type().Id(enum.Name).Add(basicGolangType(enum.Type))
+
+	file.Line()
+
+	file.Const().DefsFunc(func(group *jen.Group) {
+		for _, enumValue := range enum.Values {
+			if len(enumValue.Comment) > 0 {
+				addComment(group, enumValue.Comment)
+			}
+
+			// Errors have been checked by prepareEnums
+			lit, _ := basicLitFromString(enum.Type, enumValue.Value)
+			group.Id(enumConstName(enum, enumValue)).Id(enum.Name).Op("=").Add(lit)
+		}
+	})
+
+	file.Line()
+
+	receiver := jen.Id("e").Id(enum.Name)
+
+	file.Comment("String returns the name of the constant matching e, or the value wrapped")
+	file.Comment("in the enum name if e is not known.")
+	file.Func().Params(receiver).Id("String").Params().String().
+		BlockFunc(func(group *jen.Group) {
+			if len(enum.Values) > 0 {
+				group.Switch(jen.Id("e")).BlockFunc(func(group *jen.Group) {
+					for _, enumValue := range enum.Values {
+						group.Case(jen.Id(enumConstName(enum, enumValue))).Block(
+							jen.Return(jen.Lit(enumConstName(enum, enumValue))),
+						)
+					}
+				})
+			}
+
+			group.Return(jen.Lit(enum.Name + "(").Op("+").Add(enumFormat(enum, jen.Id("e"))).Op("+").Lit(")"))
+		})
+
+	file.Line()
+
+	file.Comment("IsKnown reports whether e is one of the constants of the enum.")
+	file.Func().Params(receiver).Id("IsKnown").Params().Bool().
+		BlockFunc(func(group *jen.Group) {
+			if len(enum.Values) > 0 {
+				group.Switch(jen.Id("e")).Block(
+					jen.CaseFunc(func(group *jen.Group) {
+						for _, enumValue := range enum.Values {
+							group.Id(enumConstName(enum, enumValue))
+						}
+					}).Block(
+						jen.Return(jen.True()),
+					),
+				)
+			}
+
+			group.Return(jen.False())
+		})
+
+	file.Line()
+}
+
+// enumFormat returns code evaluating to the textual form of value, a value of
+// the enum type.
+func enumFormat(enum *Enum, value jen.Code) jen.Code {
+	switch enum.Type {
+	case "int":
+		return jen.Qual("strconv", "Itoa").Call(jen.Int().Call(value))
+	case "float":
+		return jen.Qual("strconv", "FormatFloat").Call(jen.Float64().Call(value), jen.LitByte('f'), jen.Lit(-1), jen.Lit(64))
+	default:
+		return jen.String().Call(value)
+	}
+}
+
+// enumConstName returns the name of the constant of the enum value.
+func enumConstName(enum *Enum, enumValue *EnumValue) string {
+	return enum.Name + enumValue.Name
+}
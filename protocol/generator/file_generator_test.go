@@ -0,0 +1,241 @@
+package generator_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+var _ = Describe("generator.FileGenerator", func() {
+	var definition *generator.Definition
+
+	severityMessage := func(command string, enum string) *generator.Message {
+		return &generator.Message{
+			Command: command,
+			PositionalParams: []*generator.Param{
+				&generator.Param{
+					Mode:        generator.ParamModePositional,
+					Name:        "Severity",
+					Type:        "int",
+					Required:    true,
+					AllowedEnum: enum,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		definition = &generator.Definition{
+			Enums: []*generator.Enum{
+				&generator.Enum{
+					Name:    "Severity",
+					Type:    "int",
+					Comment: "Severity values of status codes.",
+					Values: []*generator.EnumValue{
+						&generator.EnumValue{Name: "Success", Value: "0"},
+						&generator.EnumValue{Name: "Recoverable", Value: "1"},
+						&generator.EnumValue{Name: "Fatal", Value: "2"},
+					},
+				},
+			},
+			Messages: []*generator.Message{
+				severityMessage("STA", "Severity"),
+				severityMessage("ERR", "Severity"),
+			},
+		}
+	})
+
+	It("should emit each enum once and reference it from params", func() {
+		buf := bytes.NewBuffer(nil)
+		err := generator.NewFileGenerator(definition).Render(buf)
+		Ω(err).ShouldNot(HaveOccurred())
+		src := buf.String()
+
+		_, err = parser.ParseFile(token.NewFileSet(), "", src, 0)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(strings.Count(src, "const (\n\tSeveritySuccess")).Should(Equal(1))
+		Ω(strings.Count(src, "type Severity int")).Should(Equal(1))
+		// IsKnown of the enum and Validate of both messages.
+		Ω(strings.Count(src, "case SeveritySuccess, SeverityRecoverable, SeverityFatal:")).Should(Equal(3))
+		Ω(strings.Count(src, "switch Severity(")).Should(Equal(2))
+		Ω(src).Should(ContainSubstring("type STAContent struct"))
+		Ω(src).Should(ContainSubstring("type ERRContent struct"))
+	})
+
+	It("should reject params referencing unknown enums", func() {
+		definition.Messages[1].PositionalParams[0].AllowedEnum = "Unknown"
+		err := generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownEnum))
+	})
+
+	It("should reject params of a type differing from the enum", func() {
+		definition.Messages[1].PositionalParams[0].Type = "string"
+		err := generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrEnumTypeMismatch))
+	})
+
+	It("should reject invalid enum values", func() {
+		definition.Enums[0].Values[0].Value = "zero"
+		err := generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("should type params named by an enum", func() {
+		definition.Messages[0].PositionalParams[0].Type = "Severity"
+		definition.Messages[0].PositionalParams[0].AllowedEnum = ""
+		files, err := generator.NewFileGenerator(definition).RenderFiles()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		src := string(files["content_sta.go"])
+		Ω(src).Should(MatchRegexp(`Severity\s+Severity\n`))
+		Ω(src).Should(ContainSubstring("!Severity(val).IsKnown()"))
+		checkPackage(files)
+	})
+
+	It("should reject optional params of an enum type without generic wrapper", func() {
+		definition.Messages[0].PositionalParams[0].Type = "Severity"
+		definition.Messages[0].PositionalParams[0].Required = false
+		err := generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrOptionalEnum))
+	})
+
+	It("should reject enums which are not exported or not comparable", func() {
+		definition.Enums[0].Name = "severity"
+		err := generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidEnum))
+
+		definition.Enums[0].Name = "Severity"
+		definition.Enums[0].Type = "ip"
+		err = generator.NewFileGenerator(definition).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidEnum))
+	})
+
+	It("should not resolve enums when rendering a single message", func() {
+		err := generator.NewStructGenerator(definition.Messages[0]).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownEnum))
+	})
+
+	Describe("RenderFiles()", func() {
+		BeforeEach(func() {
+			definition.Messages = append(definition.Messages, &testMessage)
+		})
+
+		It("should write one file per message and the support file", func() {
+			files, err := generator.NewFileGenerator(definition).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(files).Should(HaveLen(4))
+			Ω(files).Should(HaveKey("content_sta.go"))
+			Ω(files).Should(HaveKey("content_err.go"))
+			Ω(files).Should(HaveKey("content_tst.go"))
+			Ω(files).Should(HaveKey(generator.SupportFileName))
+
+			support := string(files[generator.SupportFileName])
+			Ω(support).Should(ContainSubstring("SeverityFatal"))
+			Ω(support).Should(ContainSubstring(`registerContent("TST"`))
+
+			for name, src := range files {
+				if name == generator.SupportFileName {
+					continue
+				}
+				Ω(string(src)).ShouldNot(ContainSubstring("SeverityFatal "))
+				Ω(string(src)).ShouldNot(ContainSubstring("registerContent"))
+			}
+		})
+
+		It("should produce files compiling as a package", func() {
+			files, err := generator.NewFileGenerator(definition).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			checkPackage(files)
+		})
+
+		Context("with helpers", func() {
+			It("should produce a self-contained package", func() {
+				files, err := generator.NewFileGenerator(definition, generator.WithHelpers()).RenderFiles()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(files).Should(HaveLen(5))
+				Ω(files).Should(HaveKey(generator.HelpersFileName))
+
+				helpers := string(files[generator.HelpersFileName])
+				Ω(helpers).Should(ContainSubstring("type ParamAccessor interface"))
+				Ω(helpers).Should(ContainSubstring("type Base32Value struct"))
+				Ω(helpers).Should(ContainSubstring("type IP struct"))
+				Ω(helpers).ShouldNot(ContainSubstring("go:generate"))
+
+				for _, src := range files {
+					Ω(string(src)).ShouldNot(ContainSubstring("protocol/maybe"))
+				}
+
+				checkPackage(files)
+			})
+
+			It("should produce identical files across runs", func() {
+				files, err := generator.NewFileGenerator(definition, generator.WithHelpers()).RenderFiles()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				again, err := generator.NewFileGenerator(definition, generator.WithHelpers()).RenderFiles()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(again).Should(Equal(files))
+			})
+		})
+	})
+})
+
+// checkPackage type checks the generated files as a package, completed by the
+// hand-written support declarations of the debug package unless the files
+// include the helpers file.
+func checkPackage(files map[string][]byte) {
+	fset := token.NewFileSet()
+	checkPackageWith(fset, files, importer.ForCompiler(fset, "source", nil))
+}
+
+// checkPackageWith type checks the generated files like checkPackage,
+// resolving imports by imp.
+func checkPackageWith(fset *token.FileSet, files map[string][]byte, imp types.Importer) {
+	var astFiles []*ast.File
+
+	for name, src := range files {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		astFiles = append(astFiles, file)
+	}
+
+	paths, err := filepath.Glob(filepath.Join("debug", "message", "*.go"))
+	Ω(err).ShouldNot(HaveOccurred())
+	if _, ok := files[generator.HelpersFileName]; ok {
+		paths = nil
+	}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if strings.HasPrefix(name, "content_") || strings.HasSuffix(name, "_test.go") ||
+			name == generator.SupportFileName {
+			continue
+		}
+
+		src, err := ioutil.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		file, err := parser.ParseFile(fset, name, src, 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		astFiles = append(astFiles, file)
+	}
+
+	conf := types.Config{Importer: imp}
+	_, err = conf.Check("message", fset, astFiles, nil)
+	Ω(err).ShouldNot(HaveOccurred())
+}
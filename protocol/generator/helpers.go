@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// HelpersFileName is the name of the file holding the support declarations
+// of the generated package, as written by FileGenerator.RenderFiles if
+// WithHelpers is set.
+const HelpersFileName = "helpers_gen.go"
+
+// runtimeFS holds the hand-written support declarations of the debug package,
+// which are copied into the helpers file.
+//
+//go:embed debug/message/message.go debug/message/frame.go debug/message/equal.go debug/message/codec.go
+var runtimeFS embed.FS
+
+// runtimeFiles lists the files of runtimeFS in the order of the helpers file.
+var runtimeFiles = []string{
+	"debug/message/message.go",
+	"debug/message/frame.go",
+	"debug/message/equal.go",
+	"debug/message/codec.go",
+}
+
+// helpersOptionalWrapper wraps optional values in the types declared by the
+// helpers file, i.e. in the generated package itself.
+var helpersOptionalWrapper = &OptionalWrapper{
+	Value: "Value",
+	IsSet: "IsSet",
+	Set:   "Set",
+}
+
+// renderHelpers generates the helpers file, declaring the wrapper types of
+// the param types in wrapped and the support declarations of the runtime,
+// such as ParamAccessor and the escaping helpers.
+func (f *FileGenerator) renderHelpers(wrapped []string) ([]byte, error) {
+	file := newFile(f.packageName, f.importPath, f.buildExpr)
+
+	for _, typ := range wrapped {
+		generateWrapperType(file, typ)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := file.Render(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	for _, name := range runtimeFiles {
+		decls, paths, err := runtimeDecls(name)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("\n")
+		buf.Write(decls)
+		imports = append(imports, paths...)
+	}
+
+	src, err := addImports(buf.Bytes(), imports)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.NoReflect {
+		err = checkNoReflection(src)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return src, nil
+}
+
+// runtimeDecls returns the declarations of the runtime file name, i.e. the
+// source following the import declarations, and the paths it imports.
+func runtimeDecls(name string) ([]byte, []string, error) {
+	src, err := runtimeFS.ReadFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "parsing runtime file %s failed", name)
+	}
+
+	offset := fset.Position(file.Name.End()).Offset
+	var paths []string
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "parsing runtime file %s failed", name)
+		}
+		paths = append(paths, path)
+	}
+	if len(file.Decls) > 0 {
+		offset = fset.Position(file.Decls[len(file.Decls)-1].End()).Offset
+	}
+
+	return src[offset:], paths, nil
+}
+
+// wrappedTypes returns the param types whose optional values are wrapped in
+// the types declared by the helpers file, ordered by the names of the types.
+func (f *FileGenerator) wrappedTypes() []string {
+	seen := make(map[string]bool)
+	var types []string
+
+	for _, s := range f.structGenerators {
+		if s.optionalWrapper() != helpersOptionalWrapper {
+			continue
+		}
+
+		for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+			for _, param := range params {
+				typ := param.Param.Type
+				if !param.FieldInfo.FieldIsMaybe || len(maybeTypeNames[typ]) == 0 || seen[typ] {
+					continue
+				}
+
+				seen[typ] = true
+				types = append(types, typ)
+			}
+		}
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return maybeTypeNames[types[i]] < maybeTypeNames[types[j]]
+	})
+
+	return types
+}
+
+// generateWrapperType generates the type wrapping optional values of the
+// param type typ, equivalent to the type of the maybe package.
+func generateWrapperType(file *jen.File, typ string) {
+	name := maybeTypeNames[typ]
+	receiver := jen.Id("m").Op("*").Id(name)
+
+	file.Commentf("%s holds an optional value, which is set if IsSet is true.", name)
+	file.Type().Id(name).Struct(
+		jen.Id("Value").Add(basicGolangType(typ)),
+		jen.Id("IsSet").Bool(),
+	)
+
+	file.Line()
+
+	file.Comment("Get returns the value and whether it is set.")
+	file.Func().Params(receiver).Id("Get").Params().Params(basicGolangType(typ), jen.Bool()).Block(
+		jen.Return(jen.Id("m").Dot("Value"), jen.Id("m").Dot("IsSet")),
+	)
+
+	file.Line()
+
+	file.Comment("GetDefault returns the value if it is set, def otherwise.")
+	file.Func().Params(receiver).Id("GetDefault").Params(jen.Id("def").Add(basicGolangType(typ))).
+		Add(basicGolangType(typ)).
+		Block(
+			jen.If(jen.Id("m").Dot("IsSet")).Block(
+				jen.Return(jen.Id("m").Dot("Value")),
+			),
+			jen.Return(jen.Id("def")),
+		)
+
+	file.Line()
+
+	file.Comment("Set sets the value.")
+	file.Func().Params(receiver).Id("Set").Params(jen.Id("val").Add(basicGolangType(typ))).Block(
+		jen.Id("m").Dot("Value").Op("=").Id("val"),
+		jen.Id("m").Dot("IsSet").Op("=").True(),
+	)
+
+	file.Line()
+
+	file.Comment("Unset marks the value as not set.")
+	file.Func().Params(receiver).Id("Unset").Params().Block(
+		jen.Id("m").Dot("IsSet").Op("=").False(),
+	)
+
+	file.Line()
+}
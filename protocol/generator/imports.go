@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// addImports adds imports of all paths to the source code src, unless they
+// are already imported. The imports are merged into the existing import
+// declaration.
+func addImports(src []byte, paths []string) ([]byte, error) {
+	if len(paths) == 0 {
+		return src, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing generated code failed")
+	}
+
+	imported := make(map[string]bool)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing generated code failed")
+		}
+		imported[path] = true
+	}
+
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		if genDecl, ok := d.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			decl = genDecl
+			break
+		}
+	}
+	if decl == nil {
+		decl = &ast.GenDecl{
+			TokPos: file.Name.End(),
+			Tok:    token.IMPORT,
+		}
+		file.Decls = append([]ast.Decl{decl}, file.Decls...)
+	}
+
+	for _, path := range paths {
+		if imported[path] {
+			continue
+		}
+		imported[path] = true
+
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				ValuePos: decl.TokPos,
+				Kind:     token.STRING,
+				Value:    strconv.Quote(path),
+			},
+		}
+		decl.Specs = append(decl.Specs, spec)
+		file.Imports = append(file.Imports, spec)
+	}
+
+	if len(decl.Specs) > 1 && !decl.Lparen.IsValid() {
+		decl.Lparen = decl.TokPos
+		decl.Rparen = decl.TokPos
+	}
+
+	ast.SortImports(fset, file)
+
+	buf := bytes.NewBuffer(nil)
+	err = format.Node(buf, fset, file)
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting generated code failed")
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// addStringMapper makes m available for string params. The returned function
+// restores the mappers of the string type spec, leaving the global type spec
+// as it was before.
+func addStringMapper(m *Mapper) (restore func()) {
+	typeSpecsMu.Lock()
+	defer typeSpecsMu.Unlock()
+
+	saved := stringTypeSpec.Mappers
+	stringTypeSpec.Mappers = append(saved[:len(saved):len(saved)], m)
+
+	return func() {
+		typeSpecsMu.Lock()
+		defer typeSpecsMu.Unlock()
+
+		stringTypeSpec.Mappers = saved
+	}
+}
+
+var _ = ginkgo.Describe("Mapper imports", func() {
+	var (
+		importingMapper *Mapper
+		restore         func()
+	)
+
+	ginkgo.BeforeEach(func() {
+		mapper := *BasicMapper
+		mapper.Name = "importing"
+		mapper.ImportsFunc = func(ctx *Context) []string {
+			return []string{"net/url", "net"}
+		}
+		importingMapper = &mapper
+
+		restore = addStringMapper(importingMapper)
+	})
+
+	ginkgo.AfterEach(func() {
+		restore()
+	})
+
+	ginkgo.It("should import the packages declared by mappers", func() {
+		g := NewStructGenerator(&Message{
+			Command: "IMP",
+			PositionalParams: []*Param{
+				&Param{
+					Mode:     ParamModePositional,
+					Name:     "Address",
+					Type:     "string",
+					Mapper:   "importing",
+					Required: true,
+				},
+			},
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Ω(g.Render(buf)).Should(Succeed())
+
+		file, err := parser.ParseFile(token.NewFileSet(), "", buf.Bytes(), parser.ImportsOnly)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var paths []string
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			Ω(err).ShouldNot(HaveOccurred())
+			paths = append(paths, path)
+		}
+
+		Ω(paths).Should(ContainElement("net"))
+		Ω(paths).Should(ContainElement("net/url"))
+		Ω(paths).Should(ContainElement("fmt"))
+	})
+
+	ginkgo.It("should restore the mappers of the string type spec", func() {
+		mappers := stringTypeSpec.Mappers
+		restore := addStringMapper(&Mapper{Name: "restored"})
+		Ω(stringTypeSpec.Mappers).Should(HaveLen(len(mappers) + 1))
+		restore()
+		Ω(stringTypeSpec.Mappers).Should(Equal(mappers))
+	})
+})
+
+var _ = ginkgo.Describe("Mapper imports with NoReflect", func() {
+	var (
+		reflectingMapper *Mapper
+		restore          func()
+	)
+
+	ginkgo.BeforeEach(func() {
+		mapper := *BasicMapper
+		mapper.Name = "reflecting"
+		mapper.ImportsFunc = func(ctx *Context) []string {
+			return []string{"reflect"}
+		}
+		reflectingMapper = &mapper
+
+		restore = addStringMapper(reflectingMapper)
+	})
+
+	ginkgo.AfterEach(func() {
+		restore()
+	})
+
+	ginkgo.It("should reject packages relying on reflection declared by mappers", func() {
+		message := &Message{
+			Command: "REF",
+			PositionalParams: []*Param{
+				&Param{
+					Mode:     ParamModePositional,
+					Name:     "Value",
+					Type:     "string",
+					Mapper:   "reflecting",
+					Required: true,
+				},
+			},
+		}
+
+		g := NewStructGenerator(message, WithNoReflect())
+		err := g.Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(ErrReflectionUsed))
+
+		_, err = NewFileGenerator(&Definition{Messages: []*Message{message}}, WithNoReflect()).RenderFiles()
+		Ω(errors.Cause(err)).Should(Equal(ErrReflectionUsed))
+
+		Ω(NewStructGenerator(message).Render(bytes.NewBuffer(nil))).Should(Succeed())
+	})
+})
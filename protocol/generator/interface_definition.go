@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Error variables related to deriving messages from Go interfaces.
+var (
+	ErrInterfaceNotFound     = errors.New("interface not declared by the source")
+	ErrInvalidMethod         = errors.New("method must take no arguments and return a single value")
+	ErrUnsupportedReturnType = errors.New("return type cannot be mapped to a param type")
+)
+
+// interfaceReturnTypes maps the return types of the methods of interfaces
+// describing messages to the type, mapper and requiredness of the params.
+// The return types are those of the fields generated for the params, with
+// packages referenced by their names.
+var interfaceReturnTypes = map[string]Param{
+	"int":                   {Type: "int", Required: true},
+	"float64":               {Type: "float", Required: true},
+	"string":                {Type: "string", Required: true},
+	"*encoding.Base32Value": {Type: "base32", Required: true},
+	"net.IP":                {Type: "ip", Required: true},
+	"netip.Addr":            {Type: "addr", Required: true},
+	"encoding.TTH":          {Type: "tth", Required: true},
+	"[]byte":                {Type: "bytes", Required: true},
+	"time.Time":             {Type: "timestamp", Required: true},
+	"time.Duration":         {Type: "duration", Required: true},
+	"encoding.Features":     {Type: "features", Required: true},
+
+	"maybe.Int":         {Type: "int"},
+	"maybe.Float64":     {Type: "float"},
+	"maybe.String":      {Type: "string"},
+	"maybe.Base32Value": {Type: "base32"},
+	"maybe.IP":          {Type: "ip"},
+	"maybe.Addr":        {Type: "addr"},
+	"maybe.TTH":         {Type: "tth"},
+	"maybe.Bytes":       {Type: "bytes"},
+	"maybe.Time":        {Type: "timestamp"},
+	"maybe.Duration":    {Type: "duration"},
+	"maybe.Features":    {Type: "features"},
+
+	"[]int":                   {Type: "int", Mapper: "list", Required: true},
+	"[]float64":               {Type: "float", Mapper: "list", Required: true},
+	"[]string":                {Type: "string", Mapper: "list", Required: true},
+	"[]*encoding.Base32Value": {Type: "base32", Mapper: "list", Required: true},
+	"[]net.IP":                {Type: "ip", Mapper: "list", Required: true},
+	"[]netip.Addr":            {Type: "addr", Mapper: "list", Required: true},
+	"[]encoding.TTH":          {Type: "tth", Mapper: "list", Required: true},
+	"[][]byte":                {Type: "bytes", Mapper: "list", Required: true},
+	"[]time.Time":             {Type: "timestamp", Mapper: "list", Required: true},
+	"[]time.Duration":         {Type: "duration", Mapper: "list", Required: true},
+}
+
+// MessageFromInterface derives the message with the command from the
+// interface named interfaceName, which is declared by the Go source src.
+//
+// Each method of the interface describes a param named by the method. Methods
+// named like flags, i.e. by two upper case letters or digits, describe named
+// params, all other methods describe positional params in the order of
+// declaration. The return type of a method is the field type of the param:
+// maybe types describe optional params and slices describe list params. The
+// doc comment of a method is the comment of the param.
+func MessageFromInterface(src []byte, interfaceName, command string) (*Message, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing source of interface %s", interfaceName)
+	}
+
+	iface := lookupInterface(file, interfaceName)
+	if iface == nil {
+		return nil, errors.Wrapf(ErrInterfaceNotFound, "interface %s", interfaceName)
+	}
+
+	message := &Message{
+		Command: command,
+	}
+
+	for _, method := range iface.Methods.List {
+		param, err := paramFromMethod(method)
+		if err != nil {
+			return nil, errors.Wrapf(err, "interface %s", interfaceName)
+		}
+
+		if param.Mode == ParamModeNamed {
+			message.NamedParams = append(message.NamedParams, param)
+		} else {
+			message.PositionalParams = append(message.PositionalParams, param)
+		}
+	}
+
+	return message, nil
+}
+
+// lookupInterface returns the interface type named name declared by file, or
+// nil if there is no such declaration.
+func lookupInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != name {
+				continue
+			}
+
+			iface, _ := typeSpec.Type.(*ast.InterfaceType)
+			return iface
+		}
+	}
+
+	return nil
+}
+
+// paramFromMethod returns the param described by the method of an interface.
+func paramFromMethod(method *ast.Field) (*Param, error) {
+	// Embedded interfaces have no names.
+	if len(method.Names) != 1 {
+		return nil, errors.Wrapf(ErrInvalidMethod, "embedded %s", types.ExprString(method.Type))
+	}
+	name := method.Names[0].Name
+
+	funcType, ok := method.Type.(*ast.FuncType)
+	if !ok || funcType.Params.NumFields() != 0 || funcType.Results.NumFields() != 1 {
+		return nil, errors.Wrapf(ErrInvalidMethod, "method %s", name)
+	}
+
+	returnType := types.ExprString(funcType.Results.List[0].Type)
+	spec, ok := interfaceReturnTypes[returnType]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedReturnType, "type %s of method %s", returnType, name)
+	}
+
+	param := spec
+	param.Name = name
+	param.Comment = strings.TrimSpace(method.Doc.Text())
+	if isFlagName(name) {
+		param.Mode = ParamModeNamed
+	} else {
+		param.Mode = ParamModePositional
+	}
+
+	return &param, nil
+}
+
+// isFlagName returns true if name consists of an upper case letter followed
+// by an upper case letter or digit, like the names of named params.
+func isFlagName(name string) bool {
+	return len(name) == 2 &&
+		name[0] >= 'A' && name[0] <= 'Z' &&
+		(name[1] >= 'A' && name[1] <= 'Z' || name[1] >= '0' && name[1] <= '9')
+}
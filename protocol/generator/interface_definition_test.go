@@ -0,0 +1,95 @@
+package generator_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+var _ = Describe("MessageFromInterface()", func() {
+	const src = `package spec
+
+import (
+	"net"
+
+	"github.com/seoester/adcl/protocol/maybe"
+)
+
+// TST describes the TST message.
+type TST interface {
+	// Code is the status code.
+	Code() int
+	Items() []string
+	NI() string
+	I4() net.IP
+	I6() maybe.Addr
+	ID() maybe.Base32Value
+}
+
+type Unsupported interface {
+	Code() uint8
+}
+
+type WithArgs interface {
+	Code(i int) int
+}
+`
+
+	It("should derive the params from the methods", func() {
+		message, err := generator.MessageFromInterface([]byte(src), "TST", "TST")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(message.Command).Should(Equal("TST"))
+		Ω(message.PositionalParams).Should(Equal([]*generator.Param{
+			&generator.Param{
+				Mode:     generator.ParamModePositional,
+				Name:     "Code",
+				Type:     "int",
+				Required: true,
+				Comment:  "Code is the status code.",
+			},
+			&generator.Param{
+				Mode:     generator.ParamModePositional,
+				Name:     "Items",
+				Type:     "string",
+				Mapper:   "list",
+				Required: true,
+			},
+		}))
+		Ω(message.NamedParams).Should(Equal([]*generator.Param{
+			&generator.Param{Mode: generator.ParamModeNamed, Name: "NI", Type: "string", Required: true},
+			&generator.Param{Mode: generator.ParamModeNamed, Name: "I4", Type: "ip", Required: true},
+			&generator.Param{Mode: generator.ParamModeNamed, Name: "I6", Type: "addr"},
+			&generator.Param{Mode: generator.ParamModeNamed, Name: "ID", Type: "base32"},
+		}))
+	})
+
+	It("should generate a compiling content type", func() {
+		message, err := generator.MessageFromInterface([]byte(src), "TST", "TST")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		files, err := generator.NewFileGenerator(&generator.Definition{
+			Messages: []*generator.Message{message},
+		}).RenderFiles()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		checkPackage(files)
+	})
+
+	It("should reject unsupported return types", func() {
+		_, err := generator.MessageFromInterface([]byte(src), "Unsupported", "TST")
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrUnsupportedReturnType))
+	})
+
+	It("should reject methods taking arguments", func() {
+		_, err := generator.MessageFromInterface([]byte(src), "WithArgs", "TST")
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidMethod))
+	})
+
+	It("should report undeclared interfaces", func() {
+		_, err := generator.MessageFromInterface([]byte(src), "Missing", "TST")
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrInterfaceNotFound))
+	})
+})
@@ -58,6 +58,20 @@ type Context struct {
 	Param  *Param
 	Mapper *Mapper
 	Type   *TypeSpec
+	// Enum is the enum named by the type of the param, nil if the param is
+	// not of an enum type.
+	Enum *Enum
+	// OptionalWrapper is the type wrapping the values of Maybe fields. The
+	// types of the maybe package are used if nil.
+	OptionalWrapper *OptionalWrapper
+}
+
+func (c *Context) optionalWrapper() *OptionalWrapper {
+	if c.OptionalWrapper == nil {
+		return defaultOptionalWrapper
+	}
+
+	return c.OptionalWrapper
 }
 
 type RenderingContext struct {
@@ -97,6 +111,10 @@ type Mapper struct {
 	Name string
 
 	ComposeFieldInfoFunc func(ctx *Context) *FieldInfo
+	// ImportsFunc returns the import paths of packages referenced by code
+	// generated by the mapper without using jen.Qual. Packages referenced
+	// using jen.Qual are imported automatically. May be nil.
+	ImportsFunc func(ctx *Context) []string
 
 	Parser  ParserSpec
 	Builder BuilderSpec
@@ -106,11 +124,28 @@ func (m Mapper) ComposeFieldInfo(ctx *Context) *FieldInfo {
 	return m.ComposeFieldInfoFunc(ctx)
 }
 
+func (m Mapper) Imports(ctx *Context) []string {
+	if m.ImportsFunc == nil {
+		return nil
+	}
+
+	return m.ImportsFunc(ctx)
+}
+
 type ParserSpec struct {
 	Positional PositionalParserSpec
 	Named      NamedParserSpec
 }
 
+// available returns true if the spec of the mode is available.
+func (p ParserSpec) available(mode ParamMode) bool {
+	if mode == ParamModeNamed {
+		return p.Named.Available
+	}
+
+	return p.Positional.Available
+}
+
 type ModeParserSpecBase struct {
 	Available           bool
 	InitialiseFieldFunc func(ctx *RenderingContext) jen.Code
@@ -237,4 +272,52 @@ func (n NamedParserSpec) ProcessFieldValue(ctx *RenderingContext, value jen.Code
 	return n.ProcessFieldValueFunc(ctx, value)
 }
 
-type BuilderSpec struct{}
+type BuilderSpec struct {
+	// EncodeValueFunc returns code evaluating to the escaped value of value,
+	// a single value of the field (or of its wrapped value). Encoding must
+	// not fail. May be nil, then the escaped values are taken from the str
+	// field.
+	EncodeValueFunc func(ctx *RenderingContext, value jen.Code) jen.Code
+	// AppendValueFunc returns code appending the escaped value of value to
+	// buf and evaluating to the extended slice. May be nil, then the result
+	// of EncodeValueFunc is appended.
+	AppendValueFunc func(ctx *RenderingContext, buf, value jen.Code) jen.Code
+	// EncodeFunc returns code encoding value, a single value of the field
+	// (or of its wrapped value), and assigning the escaped value to the new
+	// variable str. Errors are checked using the ErrorCheck of ctx. May be
+	// nil, then no builder setter is generated for the param.
+	EncodeFunc func(ctx *RenderingContext, value jen.Code) jen.Code
+}
+
+// EncodesValues returns true if the spec provides an encoder of single values.
+func (b BuilderSpec) EncodesValues() bool {
+	return b.EncodeValueFunc != nil
+}
+
+func (b BuilderSpec) EncodeValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	if b.EncodeValueFunc == nil {
+		panic(
+			fmt.Sprintf("The mapper %s has no spec available for encoding single values", ctx.Mapper.Name),
+		)
+	}
+
+	return b.EncodeValueFunc(ctx, value)
+}
+
+func (b BuilderSpec) AppendValue(ctx *RenderingContext, buf, value jen.Code) jen.Code {
+	if b.AppendValueFunc == nil {
+		return jen.Append(buf, jen.Add(b.EncodeValue(ctx, value)).Op("..."))
+	}
+
+	return b.AppendValueFunc(ctx, buf, value)
+}
+
+func (b BuilderSpec) Encode(ctx *RenderingContext, value jen.Code) jen.Code {
+	if b.EncodeFunc == nil {
+		panic(
+			fmt.Sprintf("The mapper %s has no spec available for encoding values", ctx.Mapper.Name),
+		)
+	}
+
+	return b.EncodeFunc(ctx, value)
+}
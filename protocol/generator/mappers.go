@@ -2,9 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
 )
 
 // Constants used by mapper specifications.
@@ -22,12 +24,18 @@ const (
 //     float
 //     base32
 //     ip
+//     addr
+//     tth
+//     bytes
+//     timestamp
+//     duration
+//     features
 var BasicMapper = &Mapper{
 	Name: "basic",
 	ComposeFieldInfoFunc: func(ctx *Context) *FieldInfo {
 		return &FieldInfo{
 			FieldName:          jen.Id(ctx.Param.Name),
-			FieldType:          basicGolangTypeFromParam(ctx.Param),
+			FieldType:          basicGolangTypeFromParam(ctx),
 			FieldIsMaybe:       !ctx.Param.Required,
 			StrFieldName:       jen.Id(toLowerCamelCase(ctx.Param.Name) + "Str"),
 			StrIsSingular:      true,
@@ -40,15 +48,83 @@ var BasicMapper = &Mapper{
 			ModeParserSpecBase: ModeParserSpecBase{
 				Available: true,
 			},
-			ProcessFieldValueFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
-				if ctx.FieldInfo.FieldIsMaybe {
-					return jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
-						Dot("").Id("Set").Call(jen.Add(value))
-				} else {
-					return jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
-						Op("=").Add(value).Call()
-				}
+			ProcessFieldValueFunc: basicProcessFieldValue,
+		},
+		NamedParserSpec{
+			ModeParserSpecBase: ModeParserSpecBase{
+				Available: true,
+			},
+			ParamNameFunc: func(ctx *Context) string {
+				return flagNameFromParam(ctx.Param)
+			},
+			ProcessFieldValueFunc: basicProcessFieldValue,
+		},
+	},
+	Builder: BuilderSpec{
+		EncodeValueFunc: basicEncodeValue,
+		AppendValueFunc: basicAppendValue,
+		EncodeFunc:      basicEncode,
+	},
+}
+
+// basicProcessFieldValue generates code decoding the escaped parameter value
+// and assigning the result to the field.
+func basicProcessFieldValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	stmt := jen.List(jen.Id("val"), jen.Err()).Op(":=").
+		Add(basicDecodeFromParam(ctx.Param, value)).
+		Line().
+		Add(ctx.ErrorCheck(jen.Err())).
+		Line()
+
+	if ctx.FieldInfo.FieldIsMaybe {
+		return stmt.Add(ctx.optionalWrapper().set(
+			jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName),
+			jen.Id("val"),
+		))
+	} else {
+		return stmt.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
+			Op("=").Id("val")
+	}
+}
+
+// ListMapper is a mapper interpreting a param as a list of values of a common
+// type. Each value is passed as a separate parameter. Positional list params
+// consume all following parameters which are not named parameters. Named list
+// params are repeated flags, e.g. the AN terms of SCH; NamedAll returns all
+// values, while Named and NamedGet return the first.
+//
+// Types supported:
+//     int
+//     string
+//     float
+//     base32
+//     ip
+//     addr
+//     tth
+//     bytes
+//     timestamp
+//     duration
+var ListMapper = &Mapper{
+	Name: "list",
+	ComposeFieldInfoFunc: func(ctx *Context) *FieldInfo {
+		return &FieldInfo{
+			FieldName:     jen.Id(ctx.Param.Name),
+			FieldType:     jen.Index().Add(basicGolangType(ctx.Param.Type)),
+			FieldIsMaybe:  false,
+			StrFieldName:  jen.Id(toLowerCamelCase(ctx.Param.Name) + "Str"),
+			StrIsSingular: false,
+			Multiplicity:  MultiplicityDynamic,
+		}
+	},
+	Parser: ParserSpec{
+		PositionalParserSpec{
+			ModeParserSpecBase: ModeParserSpecBase{
+				Available: true,
+			},
+			DynamicProcessCondFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
+				return jen.Op("!").Id("isNamedParam").Call(value)
 			},
+			ProcessFieldValueFunc: listProcessFieldValue,
 		},
 		NamedParserSpec{
 			ModeParserSpecBase: ModeParserSpecBase{
@@ -57,17 +133,198 @@ var BasicMapper = &Mapper{
 			ParamNameFunc: func(ctx *Context) string {
 				return flagNameFromParam(ctx.Param)
 			},
-			ProcessFieldValueFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
-				if ctx.FieldInfo.FieldIsMaybe {
-					return jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
-						Dot("").Id("Set").Call(jen.Add(value))
-				} else {
-					return jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
-						Op("=").Add(value).Call()
+			ProcessFieldValueFunc: listProcessFieldValue,
+		},
+	},
+	Builder: BuilderSpec{
+		EncodeValueFunc: basicEncodeValue,
+		AppendValueFunc: basicAppendValue,
+		EncodeFunc:      basicEncode,
+	},
+}
+
+// listProcessFieldValue generates code decoding the escaped parameter value
+// and appending the result to the field.
+func listProcessFieldValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	field := jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName)
+
+	return jen.List(jen.Id("val"), jen.Err()).Op(":=").
+		Add(basicDecodeFromParam(ctx.Param, value)).
+		Line().
+		Add(ctx.ErrorCheck(jen.Err())).
+		Line().
+		Add(field).Op("=").Append(field, jen.Id("val"))
+}
+
+// BitmaskMapper is a mapper interpreting an integer param as a bitmask. Each
+// bit named by the Bits of the param is exposed as a bool field of a struct.
+// Values with bits set which are not named are rejected. On marshalling, the
+// value is encoded from the bool fields.
+//
+// Only available for positional params.
+//
+// Types supported:
+//     int
+var BitmaskMapper = &Mapper{
+	Name: "bitmask",
+	ComposeFieldInfoFunc: func(ctx *Context) *FieldInfo {
+		return &FieldInfo{
+			FieldName: jen.Id(ctx.Param.Name),
+			FieldType: jen.StructFunc(func(group *jen.Group) {
+				for _, bit := range ctx.Param.Bits {
+					group.Id(bit).Bool()
 				}
+			}),
+			FieldIsMaybe:       false,
+			StrFieldName:       jen.Id(toLowerCamelCase(ctx.Param.Name) + "Str"),
+			StrIsSingular:      true,
+			Multiplicity:       MultiplicityStatic,
+			StaticMultiplicity: 1,
+		}
+	},
+	Parser: ParserSpec{
+		PositionalParserSpec{
+			ModeParserSpecBase: ModeParserSpecBase{
+				Available: true,
+			},
+			ProcessFieldValueFunc: bitmaskProcessFieldValue,
+		},
+		NamedParserSpec{},
+	},
+	Builder: BuilderSpec{
+		EncodeValueFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
+			return jen.Qual("strconv", "Itoa").Call(bitmaskValue(ctx, value))
+		},
+		AppendValueFunc: func(ctx *RenderingContext, buf, value jen.Code) jen.Code {
+			return jen.Qual("strconv", "AppendInt").Call(
+				buf, jen.Int64().Call(bitmaskValue(ctx, value)), jen.Lit(10),
+			)
+		},
+		EncodeFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
+			return jen.Id("str").Op(":=").Qual("strconv", "Itoa").Call(bitmaskValue(ctx, value))
+		},
+	},
+}
+
+// bitmaskValue returns code evaluating to the integer value of the bitmask
+// struct value.
+func bitmaskValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	return jen.Id("bitmask").CallFunc(func(group *jen.Group) {
+		for _, bit := range ctx.Param.Bits {
+			group.Add(value).Dot(bit)
+		}
+	})
+}
+
+// bitmaskProcessFieldValue generates code decoding the escaped parameter
+// value and assigning each bit to its field.
+func bitmaskProcessFieldValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	field := jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName)
+
+	stmt := jen.List(jen.Id("val"), jen.Err()).Op(":=").
+		Qual("strconv", "Atoi").Call(value).
+		Line().
+		Add(ctx.ErrorCheck(jen.Err())).
+		Line().
+		Err().Op("=").Id("checkBits").Call(jen.Id("val"), jen.Lit(len(ctx.Param.Bits))).
+		Line().
+		Add(ctx.ErrorCheck(jen.Err()))
+
+	for i, bit := range ctx.Param.Bits {
+		stmt.Line().Add(field).Dot(bit).Op("=").
+			Id("val").Op("&").Lit(1 << uint(i)).Op("!=").Lit(0)
+	}
+
+	return stmt
+}
+
+// EnumMapper is a mapper interpreting a param as a value of an enum of the
+// definition, the param type being the name of the enum. The field is of the
+// named type of the enum. Values not declared by the enum are rejected with
+// ErrValueNotAllowed. Optional params require a generic optional wrapper.
+//
+// Types supported:
+//     enums of the definition
+var EnumMapper = &Mapper{
+	Name: "enum",
+	ComposeFieldInfoFunc: func(ctx *Context) *FieldInfo {
+		var fieldType jen.Code = jen.Id(ctx.Enum.Name)
+		if !ctx.Param.Required {
+			wrapper := ctx.optionalWrapper()
+			fieldType = jen.Qual(wrapper.Path, wrapper.Name).Index(jen.Id(ctx.Enum.Name))
+		}
+
+		return &FieldInfo{
+			FieldName:          jen.Id(ctx.Param.Name),
+			FieldType:          fieldType,
+			FieldIsMaybe:       !ctx.Param.Required,
+			StrFieldName:       jen.Id(toLowerCamelCase(ctx.Param.Name) + "Str"),
+			StrIsSingular:      true,
+			Multiplicity:       MultiplicityStatic,
+			StaticMultiplicity: 1,
+		}
+	},
+	Parser: ParserSpec{
+		PositionalParserSpec{
+			ModeParserSpecBase: ModeParserSpecBase{
+				Available: true,
+			},
+			ProcessFieldValueFunc: enumProcessFieldValue,
+		},
+		NamedParserSpec{
+			ModeParserSpecBase: ModeParserSpecBase{
+				Available: true,
+			},
+			ParamNameFunc: func(ctx *Context) string {
+				return flagNameFromParam(ctx.Param)
 			},
+			ProcessFieldValueFunc: enumProcessFieldValue,
 		},
 	},
+	Builder: BuilderSpec{
+		EncodeValueFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
+			return basicEncodeExpr(ctx.Enum.Type, enumUnderlying(ctx, value))
+		},
+		AppendValueFunc: func(ctx *RenderingContext, buf, value jen.Code) jen.Code {
+			return basicAppendExpr(ctx.Enum.Type, buf, enumUnderlying(ctx, value))
+		},
+		EncodeFunc: func(ctx *RenderingContext, value jen.Code) jen.Code {
+			return basicEncodeType(ctx, ctx.Enum.Type, enumUnderlying(ctx, value))
+		},
+	},
+}
+
+// enumUnderlying returns code converting value, a value of the enum, to the
+// underlying type of the enum.
+func enumUnderlying(ctx *RenderingContext, value jen.Code) jen.Code {
+	return jen.Add(basicGolangType(ctx.Enum.Type)).Call(value)
+}
+
+// enumProcessFieldValue generates code decoding the escaped parameter value
+// as a value of the underlying type of the enum, rejecting values not
+// declared by the enum, and assigning the result to the field.
+func enumProcessFieldValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	val := jen.Id(ctx.Enum.Name).Call(jen.Id("val"))
+
+	isUnknown := jen.Err().Op("==").Nil().Op("&&").Op("!").Add(val).Dot("IsKnown").Call()
+
+	stmt := jen.List(jen.Id("val"), jen.Err()).Op(":=").
+		Add(basicDecode(ctx.Enum.Type, value)).
+		Line().
+		If(isUnknown).Block(jen.Err().Op("=").Id("ErrValueNotAllowed")).
+		Line().
+		Add(ctx.ErrorCheck(jen.Err())).
+		Line()
+
+	if ctx.FieldInfo.FieldIsMaybe {
+		return stmt.Add(ctx.optionalWrapper().set(
+			jen.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName),
+			val,
+		))
+	} else {
+		return stmt.Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).
+			Op("=").Add(val)
+	}
 }
 
 func flagNameFromParam(param *Param) string {
@@ -78,40 +335,223 @@ func flagNameFromParam(param *Param) string {
 	}
 }
 
-func basicGolangTypeFromParam(param *Param) jen.Code {
-	switch param.Type {
+func jsonNameFromParam(param *Param) string {
+	if len(param.JSONName) > 0 {
+		return param.JSONName
+	} else {
+		return toLowerCamelCase(param.Name)
+	}
+}
+
+func displayNameFromParam(param *Param) string {
+	if len(param.DisplayName) > 0 {
+		return param.DisplayName
+	} else {
+		return param.Name
+	}
+}
+
+// basicGolangTypeFromParam returns the golang type of the field of the param.
+// The values of optional params are wrapped by the optional wrapper of the
+// context.
+func basicGolangTypeFromParam(ctx *Context) jen.Code {
+	if !ctx.Param.Required {
+		return ctx.optionalWrapper().wrapperType(ctx.Param.Type)
+	}
+
+	return basicGolangType(ctx.Param.Type)
+}
+
+// basicGolangType returns the golang type for the param type name typ.
+func basicGolangType(typ string) jen.Code {
+	switch typ {
 	case "int":
-		if param.Required {
-			return jen.Int()
-		} else {
-			return jen.Qual(maybePackage, "Int")
-		}
+		return jen.Int()
 	case "float":
-		if param.Required {
-			return jen.Float64()
-		} else {
-			return jen.Qual(maybePackage, "Float64")
-		}
+		return jen.Float64()
 	case "string":
-		if param.Required {
-			return jen.String()
-		} else {
-			return jen.Qual(maybePackage, "String")
-		}
+		return jen.String()
 	case "base32":
-		if param.Required {
-			return jen.Op("*").Qual(encodingPackage, "Base32Value")
-		} else {
-			return jen.Qual(maybePackage, "Base32Value")
-		}
+		return jen.Op("*").Qual(encodingPackage, "Base32Value")
 	case "ip":
-		if param.Required {
-			return jen.Qual("net", "IP")
-		} else {
-			return jen.Qual(maybePackage, "IP")
+		return jen.Qual("net", "IP")
+	case "addr":
+		return jen.Qual("net/netip", "Addr")
+	case "tth":
+		return jen.Qual(encodingPackage, "TTH")
+	case "bytes":
+		return jen.Index().Byte()
+	case "timestamp":
+		return jen.Qual("time", "Time")
+	case "duration":
+		return jen.Qual("time", "Duration")
+	case "features":
+		return jen.Qual(encodingPackage, "Features")
+	default:
+		panic(fmt.Sprintf("Parameter type %s not known to basic mapper", typ))
+	}
+}
+
+// basicEncodeValue returns code evaluating to the escaped value of value, a
+// value of the param type.
+func basicEncodeValue(ctx *RenderingContext, value jen.Code) jen.Code {
+	return basicEncodeExpr(ctx.Param.Type, value)
+}
+
+// basicAppendValue returns code appending the escaped value of value, a
+// value of the param type, to buf.
+func basicAppendValue(ctx *RenderingContext, buf, value jen.Code) jen.Code {
+	return basicAppendExpr(ctx.Param.Type, buf, value)
+}
+
+// basicEncodeExpr returns code evaluating to the escaped value of value, a
+// value of the type typ. Unlike basicEncodeType, the code never fails: strings
+// are escaped without being checked and zero values of types without zero
+// parameter, such as nil IPs, evaluate to the empty string.
+func basicEncodeExpr(typ string, value jen.Code) jen.Code {
+	switch typ {
+	case "int":
+		return jen.Qual("strconv", "Itoa").Call(value)
+	case "float":
+		return jen.Qual("strconv", "FormatFloat").Call(value, jen.LitByte('f'), jen.Lit(-1), jen.Lit(64))
+	case "string":
+		return jen.Id("escapeValue").Call(value)
+	case "base32":
+		return jen.Id("formatBase32").Call(value)
+	case "ip":
+		return jen.Id("formatIP").Call(value)
+	case "addr":
+		return jen.Id("formatAddr").Call(value)
+	case "tth", "features":
+		return jen.Add(value).Dot("String").Call()
+	case "bytes":
+		return jen.Qual(encodingPackage, "EncodeToBase32String").Call(value)
+	case "timestamp":
+		return jen.Qual(encodingPackage, "FormatTimestamp").Call(value)
+	case "duration":
+		return jen.Qual(encodingPackage, "FormatDuration").Call(value)
+	default:
+		panic(fmt.Sprintf("Parameter type %s not known to basic mapper", typ))
+	}
+}
+
+// basicAppendExpr returns code appending the escaped value of value, a value
+// of the type typ, to buf, as encoded by basicEncodeExpr. Numbers, strings and
+// IP addresses are appended without allocating.
+func basicAppendExpr(typ string, buf, value jen.Code) jen.Code {
+	switch typ {
+	case "int":
+		return jen.Qual("strconv", "AppendInt").Call(buf, jen.Int64().Call(value), jen.Lit(10))
+	case "float":
+		return jen.Qual("strconv", "AppendFloat").Call(buf, value, jen.LitByte('f'), jen.Lit(-1), jen.Lit(64))
+	case "string":
+		return jen.Id("appendEscaped").Call(buf, value)
+	case "ip":
+		return jen.Id("appendIP").Call(buf, value)
+	case "addr":
+		return jen.Id("appendAddr").Call(buf, value)
+	case "timestamp":
+		return jen.Qual("strconv", "AppendInt").Call(buf, jen.Add(value).Dot("Unix").Call(), jen.Lit(10))
+	case "duration":
+		return jen.Qual("strconv", "AppendInt").Call(
+			buf, jen.Int64().Call(jen.Add(value).Op("/").Qual("time", "Second")), jen.Lit(10),
+		)
+	default:
+		return jen.Append(buf, jen.Add(basicEncodeExpr(typ, value)).Op("..."))
+	}
+}
+
+// basicEncode generates code encoding value, a value of the param type, and
+// assigning the escaped value to str.
+func basicEncode(ctx *RenderingContext, value jen.Code) jen.Code {
+	return basicEncodeType(ctx, ctx.Param.Type, value)
+}
+
+// basicEncodeType generates code encoding value, a value of the type typ,
+// and assigning the escaped value to str.
+func basicEncodeType(ctx *RenderingContext, typ string, value jen.Code) jen.Code {
+	switch typ {
+	case "int":
+		return jen.Id("str").Op(":=").Qual("strconv", "Itoa").Call(value)
+	case "float":
+		return jen.Id("str").Op(":=").Qual("strconv", "FormatFloat").Call(value, jen.LitByte('f'), jen.Lit(-1), jen.Lit(64))
+	case "string":
+		return jen.List(jen.Id("str"), jen.Err()).Op(":=").
+			Qual(encodingPackage, "EncodeToADCString").Call(value).
+			Line().
+			Add(ctx.ErrorCheck(jen.Err()))
+	case "base32", "ip", "tth", "features":
+		return jen.Id("str").Op(":=").Add(value).Dot("String").Call()
+	case "bytes":
+		return jen.Id("str").Op(":=").Qual(encodingPackage, "EncodeToBase32String").Call(value)
+	case "addr":
+		return jen.Id("str").Op(":=").Qual(encodingPackage, "FormatAddr").Call(value)
+	case "timestamp":
+		return jen.Id("str").Op(":=").Qual(encodingPackage, "FormatTimestamp").Call(value)
+	case "duration":
+		return jen.Id("str").Op(":=").Qual(encodingPackage, "FormatDuration").Call(value)
+	default:
+		panic(fmt.Sprintf("Parameter type %s not known to basic mapper", typ))
+	}
+}
+
+// basicDecodeFromParam returns code decoding the escaped value. The code
+// evaluates to the decoded value and an error.
+func basicDecodeFromParam(param *Param, value jen.Code) jen.Code {
+	return basicDecode(param.Type, value)
+}
+
+// basicDecode returns code decoding the escaped value of the type typ. The
+// code evaluates to the decoded value and an error.
+func basicDecode(typ string, value jen.Code) jen.Code {
+	switch typ {
+	case "int":
+		return jen.Qual("strconv", "Atoi").Call(value)
+	case "float":
+		return jen.Qual("strconv", "ParseFloat").Call(value, jen.Lit(64))
+	case "string":
+		return jen.Qual(encodingPackage, "DecodeADCString").Call(value)
+	case "base32":
+		return jen.Qual(encodingPackage, "ParseBase32Value").Call(value)
+	case "ip":
+		return jen.Qual(encodingPackage, "ParseIP").Call(value)
+	case "addr":
+		return jen.Qual(encodingPackage, "ParseAddr").Call(value)
+	case "tth":
+		return jen.Qual(encodingPackage, "ParseTTH").Call(value)
+	case "bytes":
+		return jen.Qual(encodingPackage, "DecodeBase32String").Call(value)
+	case "timestamp":
+		return jen.Qual(encodingPackage, "ParseTimestamp").Call(value)
+	case "duration":
+		return jen.Qual(encodingPackage, "ParseDuration").Call(value)
+	case "features":
+		return jen.Qual(encodingPackage, "ParseFeatures").Call(value)
+	default:
+		panic(fmt.Sprintf("Parameter type %s not known to basic mapper", typ))
+	}
+}
+
+// basicLitFromString returns a literal of the param type typ with the value
+// represented by s. Only types with comparable values are supported.
+func basicLitFromString(typ string, s string) (jen.Code, error) {
+	switch typ {
+	case "int":
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, err
 		}
+		return jen.Lit(i), nil
+	case "float":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return jen.Lit(f), nil
+	case "string":
+		return jen.Lit(s), nil
 	default:
-		panic(fmt.Sprintf("Parameter type %s not known to basic mapper"))
+		return nil, errors.Errorf("values of type %s cannot be compared", typ)
 	}
 }
 
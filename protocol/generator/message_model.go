@@ -22,21 +22,164 @@ func (p ParamMode) String() string {
 	}
 }
 
+// Definition is a protocol definition, consisting of messages and the enums
+// shared between them.
+type Definition struct {
+	// Extension is the name of the protocol extension specifying the
+	// messages, e.g. "NAT0". It is empty for messages of the base protocol.
+	// The messages of extensions are meant to be generated into packages of
+	// their own, with flag constants prefixed by the extension name, see
+	// WithFlagPrefix.
+	Extension string
+	Enums     []*Enum
+	Messages  []*Message
+}
+
+// Enum is a set of named values of a param type, shared by the messages of a
+// definition. Each value results in a constant named by the enum name
+// followed by the value name.
+type Enum struct {
+	Name    string
+	Type    string
+	Comment string
+	Values  []*EnumValue
+}
+
+type EnumValue struct {
+	Name string
+	// Value is the decoded string form of the value, in the same format as
+	// the AllowedValues of a param.
+	Value   string
+	Comment string
+}
+
 type Message struct {
-	Command          string
+	// Command is the command of the message. It may be a pattern describing
+	// a family of commands, in which each '?' matches any upper case letter
+	// or digit, e.g. "EX?". The content type of a family stores the concrete
+	// command.
+	Command string
+	// Name is the base of the generated identifiers, such as the content
+	// type. Command is used if Name is empty. Name is required if Command is
+	// a pattern.
+	Name string
+	// Types lists the message types the message is valid in, i.e. the
+	// first characters of the messages, such as "BDE". Any type is valid if
+	// Types is empty.
+	Types string
+	// Phases lists the protocol phases the message is valid in, i.e. any of
+	// PROTOCOL, IDENTIFY, VERIFY, NORMAL and DATA. Any phase is valid if
+	// Phases is empty.
+	Phases []string
+	// Comment documents the message. It is included in the doc comment of
+	// the content type.
+	Comment string
+	// Section is the section of the specification defining the message,
+	// e.g. "ADC § 5.3.1" or "EXT § 3.27". It is noted in the doc comment of
+	// the content type.
+	Section          string
 	PositionalParams []*Param
 	NamedParams      []*Param
 	Flags            []*Flag
+	// OneOf lists groups of params by name, of which exactly one param must
+	// be present in the message, e.g. TR and FN. The groups are checked by
+	// ParseInto and the generated Validate method.
+	OneOf [][]string
+	// Examples are sample lines of the message. For messages with examples,
+	// RenderTest generates a round-trip test of the examples.
+	Examples []*Example
+}
+
+// Example is a sample line of a message and the values its params decode to.
+type Example struct {
+	// Line is the message as returned by MarshalADC, without the trailing
+	// newline, e.g. "SID AAAB".
+	Line string
+	// Values maps the names of all params set by Line to their decoded
+	// values, formatted as by fmt.Sprint. Values of optional params are
+	// unwrapped, multi-valued params without values are not set.
+	Values map[string]string
 }
 
 type Param struct {
 	Mode     ParamMode
 	Name     string
 	FlagName string
+	// DisplayName is the name of the param presented to users, e.g. by
+	// the generated descriptor. Name is used if DisplayName is empty.
+	DisplayName string
+	// JSONName is the key of the param in the json struct tag of its field,
+	// e.g. "nick" for NI, see WithStructTags. Name in lower camel case is used
+	// if JSONName is empty.
+	JSONName string
 	Type     string
 	Mapper   string
 	Required bool
 	Comment  string
+	// AllowedValues restricts the values of the param to the listed ones,
+	// if non-empty. Values are specified in their decoded string form and
+	// compared to the decoded value by the generated Validate method.
+	AllowedValues []string
+	// AllowedEnum names an enum of the definition, whose values are allowed
+	// in addition to AllowedValues. The enum must be of the type of the
+	// param.
+	AllowedEnum string
+	// Min and Max bound the values of int and float params (inclusive), if
+	// non-empty. The bounds are specified in the same format as the
+	// AllowedValues and checked by the generated Validate method.
+	Min string
+	Max string
+	// Length is the number of characters of the encoded values of base32
+	// params, e.g. 39 for CIDs. Values of any length are valid if Length is
+	// zero.
+	Length int
+	// ValidationMessage is the message included in the error returned by
+	// the generated Validate method if a constraint of the param fails. A
+	// default message describing the constraint is used if empty.
+	ValidationMessage string
+	// Bits names the bits of an int param using the bitmask mapper,
+	// starting with the least significant bit. Each bit is exposed as a
+	// bool field.
+	Bits []string
+	// Deprecated marks the param as deprecated by the protocol, giving the
+	// reason. A "Deprecated:" comment is generated on the field and the flag
+	// constant of the param. Deprecated params are still decoded and
+	// reported by the generated DecodeWarnings method, deprecated named
+	// params are dropped when encoding unless WithDeprecatedEncoding is set.
+	Deprecated string
+	// RequiredIf makes the optional param required if the condition holds,
+	// e.g. U4 is required if SU contains UDP4. The condition is checked by
+	// ParseInto and the generated Validate method.
+	RequiredIf *Condition
+	// GatedBy names a named param of the message, whose presence gates
+	// this optional positional param. Gated params may be placed in the
+	// middle of the positional params, the indices of subsequent params
+	// shift depending on the gate.
+	GatedBy string
+	// Sensitive marks the param as holding sensitive data, such as
+	// passwords or private IDs. Its value is masked in the copy returned
+	// by the generated Redacted method.
+	Sensitive bool
+	// Const is the fixed (escaped) token of a positional marker param. The
+	// token is emitted by the positional accessors and checked by ParseInto,
+	// no field is generated. Const params must be required string params.
+	Const string
+	// CacheKeyPart marks the param as part of the key identifying the
+	// message in caches, such as the token and the TTH of search results.
+	// A CacheKey method is generated if any param is marked. Cache key
+	// parts must have a single value.
+	CacheKeyPart bool
+}
+
+// Condition is a condition on the value of a param of a message.
+type Condition struct {
+	// Param is the name of the param.
+	Param string
+	// Value is specified in the decoded string form, like the
+	// AllowedValues of params. The condition holds if the param is present
+	// and, unless Value is empty, has the value. For params of the features
+	// type, the condition holds if the set contains the feature Value.
+	Value string
 }
 
 type Flag struct {
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to optional wrappers.
+var (
+	ErrInvalidOptionalWrapper = errors.New("optional wrapper lacks a package or an accessor")
+)
+
+// OptionalWrapper describes the type wrapping the values of optional params,
+// i.e. the type of Maybe fields. Value and IsSet name the accessors of the
+// wrapped value and its presence, each either a field or a method without
+// arguments, indicated by a trailing "()". Set names the method setting the
+// value, which is called on the (addressable) field.
+type OptionalWrapper struct {
+	// Path is the import path of the package declaring the wrapper.
+	Path string
+	// Name is the name of the generic wrapper type, which is instantiated
+	// with the value type, e.g. "Option" for Option[int]. If empty, the
+	// package declares one wrapper type per param type, named like the
+	// types of the maybe package, e.g. Int and Base32Value.
+	Name  string
+	Value string
+	IsSet string
+	Set   string
+}
+
+// defaultOptionalWrapper wraps optional values in the types of the maybe
+// package.
+var defaultOptionalWrapper = &OptionalWrapper{
+	Path:  maybePackage,
+	Value: "Value",
+	IsSet: "IsSet",
+	Set:   "Set",
+}
+
+// maybeTypeNames contains the names of the wrapper types of the maybe package
+// by param type.
+var maybeTypeNames = map[string]string{
+	"int":    "Int",
+	"float":  "Float64",
+	"string": "String",
+	"base32": "Base32Value",
+	"ip":     "IP",
+	"addr":   "Addr",
+	"tth":    "TTH",
+	"bytes":  "Bytes",
+
+	"timestamp": "Time",
+	"duration":  "Duration",
+	"features":  "Features",
+}
+
+// validate returns ErrInvalidOptionalWrapper if the package or any accessor
+// is missing or not a valid identifier.
+func (w *OptionalWrapper) validate() error {
+	valid := len(w.Path) > 0 &&
+		(len(w.Name) == 0 || token.IsIdentifier(w.Name)) &&
+		token.IsIdentifier(strings.TrimSuffix(w.Value, "()")) &&
+		token.IsIdentifier(strings.TrimSuffix(w.IsSet, "()")) &&
+		token.IsIdentifier(w.Set)
+	if !valid {
+		return errors.Wrapf(ErrInvalidOptionalWrapper, "wrapper %s.%s", w.Path, w.Name)
+	}
+
+	return nil
+}
+
+// wrapperType returns the type wrapping values of the param type typ.
+func (w *OptionalWrapper) wrapperType(typ string) jen.Code {
+	if len(w.Name) == 0 {
+		return jen.Qual(w.Path, maybeTypeNames[typ])
+	}
+
+	return jen.Qual(w.Path, w.Name).Index(basicGolangType(typ))
+}
+
+// access returns code applying the field or method accessor to field.
+func access(field jen.Code, accessor string) *jen.Statement {
+	if name := strings.TrimSuffix(accessor, "()"); name != accessor {
+		return jen.Add(field).Dot(name).Call()
+	}
+
+	return jen.Add(field).Dot(accessor)
+}
+
+// value returns code evaluating to the value wrapped by field.
+func (w *OptionalWrapper) value(field jen.Code) *jen.Statement {
+	return access(field, w.Value)
+}
+
+// isSet returns code evaluating to true if the value of field is set.
+func (w *OptionalWrapper) isSet(field jen.Code) *jen.Statement {
+	return access(field, w.IsSet)
+}
+
+// set returns code setting the value of field to val.
+func (w *OptionalWrapper) set(field, val jen.Code) *jen.Statement {
+	return jen.Add(field).Dot(w.Set).Call(val)
+}
+
+// optionalWrapper returns the wrapper of optional values configured for the
+// generator. Unless configured otherwise, the wrapper types are declared by
+// the helpers file if WithHelpers is set.
+func (s *StructGenerator) optionalWrapper() *OptionalWrapper {
+	if s.OptionalWrapper == nil && s.helpers {
+		return helpersOptionalWrapper
+	}
+	if s.OptionalWrapper == nil {
+		return defaultOptionalWrapper
+	}
+
+	return s.OptionalWrapper
+}
@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"go/build/constraint"
+	"go/token"
+	"io"
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+// Error variables related to generator options.
+var (
+	ErrInvalidPackageName = errors.New("package name is not a valid Go identifier")
+	ErrConflictingOptions = errors.New("options conflict with each other")
+	ErrInvalidBuildTags   = errors.New("build tags are not a valid build constraint expression")
+	ErrInvalidImportPath  = errors.New("import path does not end in a valid package name")
+	ErrInvalidFlagPrefix  = errors.New("flag prefix does not start an exported Go identifier")
+)
+
+// defaultPackageName is the name of the package of generated files if no
+// package name is configured.
+const defaultPackageName = "message"
+
+// Option configures a StructGenerator, see NewStructGenerator.
+type Option func(s *StructGenerator)
+
+// WithPackage sets the name of the package of the generated files. The
+// default package name is "message".
+func WithPackage(name string) Option {
+	return func(s *StructGenerator) {
+		if !token.IsIdentifier(name) {
+			s.optionErr = errors.Wrapf(ErrInvalidPackageName, "package name %q", name)
+		} else if len(s.packageName) > 0 && s.packageName != name {
+			s.optionErr = errors.Wrapf(ErrConflictingOptions, "package names %s and %s",
+				s.packageName, name)
+		}
+
+		s.packageName = name
+	}
+}
+
+// WithBuildTags adds a //go:build constraint line to the generated files.
+// Each tag is a build constraint expression, e.g. "linux && !386". The
+// generated files are only compiled if all expressions are satisfied.
+func WithBuildTags(tags ...string) Option {
+	return func(s *StructGenerator) {
+		for _, tag := range tags {
+			expr, err := constraint.Parse("//go:build " + tag)
+			if err != nil {
+				s.optionErr = errors.Wrapf(ErrInvalidBuildTags, "build tags %q: %v", tag, err)
+				return
+			}
+
+			if s.buildExpr == nil {
+				s.buildExpr = expr
+			} else {
+				s.buildExpr = &constraint.AndExpr{X: s.buildExpr, Y: expr}
+			}
+		}
+	}
+}
+
+// GeneratorConfig describes the package the code is generated into, see
+// WithConfig. This allows extensions to generate their messages into
+// packages of their own. The package must declare the runtime the generated
+// code builds upon, e.g. ParamAccessor and ContentBase, as the debug/message
+// package does.
+type GeneratorConfig struct {
+	// PackageName is the name of the package. If empty, the last element of
+	// ImportPath is used, or "message" if ImportPath is empty as well.
+	PackageName string
+	// ImportPath is the import path of the package. Declarations of the
+	// package itself, e.g. wrapper types of MaybePath, are referenced
+	// without qualifier.
+	ImportPath string
+	// MaybePath is the import path of the package declaring the wrappers of
+	// optional values. If empty, the wrappers of the maybe package or of
+	// WithOptionalWrapper are used.
+	MaybePath string
+	// BuildTags are build constraint expressions, see WithBuildTags.
+	BuildTags []string
+}
+
+// WithConfig configures the package of the generated files as described by
+// config. It conflicts with WithPackage naming a different package.
+func WithConfig(config GeneratorConfig) Option {
+	return func(s *StructGenerator) {
+		packageName := config.PackageName
+		if len(packageName) == 0 && len(config.ImportPath) > 0 {
+			packageName = path.Base(config.ImportPath)
+			if !token.IsIdentifier(packageName) {
+				s.optionErr = errors.Wrapf(ErrInvalidImportPath, "import path %q", config.ImportPath)
+				return
+			}
+		}
+
+		if len(packageName) > 0 {
+			WithPackage(packageName)(s)
+		}
+		s.importPath = config.ImportPath
+
+		if len(config.MaybePath) > 0 {
+			wrapper := *s.optionalWrapper()
+			wrapper.Path = config.MaybePath
+			s.OptionalWrapper = &wrapper
+		}
+
+		WithBuildTags(config.BuildTags...)(s)
+	}
+}
+
+// WithEnums makes the enums available to params of the message, as the
+// FileGenerator does with the enums of its definition. The enums themselves
+// are not generated.
+func WithEnums(enums ...*Enum) Option {
+	return func(s *StructGenerator) {
+		s.enums = append(s.enums, enums...)
+	}
+}
+
+// WithFlagPrefix prefixes the names of the flag types and flag constants of
+// the messages, e.g. "NAT0" results in NAT0INFFlag and NAT0INFFlagNI. This
+// distinguishes the flags of extension messages from those of the base
+// protocol.
+func WithFlagPrefix(prefix string) Option {
+	return func(s *StructGenerator) {
+		if !token.IsIdentifier(prefix) || !token.IsExported(prefix) {
+			s.optionErr = errors.Wrapf(ErrInvalidFlagPrefix, "flag prefix %q", prefix)
+		}
+
+		s.flagPrefix = prefix
+	}
+}
+
+// WithTrace sets Trace of the generator.
+func WithTrace(w io.Writer) Option {
+	return func(s *StructGenerator) {
+		s.Trace = w
+	}
+}
+
+// WithValueReceivers causes all methods not modifying the content to be
+// generated with value receivers. ParseInto always has a pointer receiver.
+func WithValueReceivers() Option {
+	return func(s *StructGenerator) {
+		s.valueReceivers = true
+	}
+}
+
+// WithNoReflect sets NoReflect of the generator.
+func WithNoReflect() Option {
+	return func(s *StructGenerator) {
+		s.NoReflect = true
+	}
+}
+
+// WithRawPassthrough causes the content types to keep the line parsed by
+// UnmarshalADC, which MarshalADC returns verbatim until the content is
+// modified. This allows proxies to forward messages byte for byte. Direct
+// writes to the fields of a content are not detected, MarkDirty must be called
+// after them.
+func WithRawPassthrough() Option {
+	return func(s *StructGenerator) {
+		s.rawPassthrough = true
+	}
+}
+
+// WithClone causes a Clone method returning a deep copy to be generated for
+// each content type. Contents are compared by the generated Equal method,
+// which compares the escaped values of the params.
+func WithClone() Option {
+	return func(s *StructGenerator) {
+		s.clone = true
+	}
+}
+
+// WithEncodeTo causes an EncodeTo method to be generated for each content
+// type. EncodeTo appends the content like AppendADC, but is unchecked, i.e.
+// incomplete contents are encoded as malformed lines instead of failing. It
+// does not allocate apart from growing the buffer, which suits hot paths such
+// as broadcasting validated INF and SCH messages.
+func WithEncodeTo() Option {
+	return func(s *StructGenerator) {
+		s.encodeTo = true
+	}
+}
+
+// WithDeprecatedEncoding causes deprecated named params to be encoded by
+// MarshalADC and the related methods, e.g. for talking to legacy peers. They
+// are dropped by default.
+func WithDeprecatedEncoding() Option {
+	return func(s *StructGenerator) {
+		s.encodeDeprecated = true
+	}
+}
+
+// WithStructTags causes the fields of params to be tagged with their wire
+// and JSON names, e.g. `adc:"NI" json:"nick,omitempty"`, which allows
+// reflection-based tools such as config dumps and JSON bridges to map fields
+// back to params. The JSON names are set by the JSONName of params.
+func WithStructTags() Option {
+	return func(s *StructGenerator) {
+		s.structTags = true
+	}
+}
+
+// WithHelpers causes FileGenerator.RenderFiles to write the support
+// declarations used by the content types into the file named
+// HelpersFileName, making the generated package self-contained. Unless an
+// OptionalWrapper is set, optional values are wrapped in types declared by
+// the helpers file instead of the types of the maybe package.
+func WithHelpers() Option {
+	return func(s *StructGenerator) {
+		s.helpers = true
+	}
+}
+
+// WithOptionalWrapper sets OptionalWrapper of the generator.
+func WithOptionalWrapper(wrapper OptionalWrapper) Option {
+	return func(s *StructGenerator) {
+		s.OptionalWrapper = &wrapper
+	}
+}
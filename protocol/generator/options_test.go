@@ -0,0 +1,386 @@
+package generator_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+var _ = Describe("generator.Option", func() {
+	Describe("WithPackage()", func() {
+		It("should set the package of the generated file", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithPackage("adc")))
+			Ω(src).Should(HavePrefix("package adc\n"))
+
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&testMessage, generator.WithPackage("adc")).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(buf.String()).Should(HavePrefix("package adc\n"))
+		})
+
+		It("should set the package of files generated by FileGenerator", func() {
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			files, err := generator.NewFileGenerator(definition, generator.WithPackage("adc")).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, src := range files {
+				Ω(string(src)).Should(HavePrefix("package adc\n"))
+			}
+		})
+
+		It("should default to the message package", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			Ω(src).Should(HavePrefix("package message\n"))
+		})
+
+		It("should reject invalid package names", func() {
+			g := generator.NewStructGenerator(&testMessage, generator.WithPackage("adc-message"))
+			Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrInvalidPackageName))
+		})
+
+		It("should reject conflicting package names", func() {
+			g := generator.NewStructGenerator(&testMessage,
+				generator.WithPackage("adc"), generator.WithPackage("message"))
+			Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrConflictingOptions))
+
+			g = generator.NewStructGenerator(&testMessage,
+				generator.WithPackage("adc"), generator.WithPackage("adc"))
+			Ω(g.Render(bytes.NewBuffer(nil))).Should(Succeed())
+		})
+	})
+
+	Describe("WithBuildTags()", func() {
+		It("should add the build constraint above the package clause", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithBuildTags("linux && !386")))
+			Ω(src).Should(HavePrefix("//go:build linux && !386\n\npackage message\n"))
+
+			file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.PackageClauseOnly)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(file.Doc).Should(BeNil())
+		})
+
+		It("should combine several tags into a single constraint", func() {
+			src := render(generator.NewStructGenerator(&testMessage,
+				generator.WithBuildTags("linux", "amd64 || arm64"), generator.WithBuildTags("!purego")))
+			Ω(src).Should(HavePrefix("//go:build linux && (amd64 || arm64) && !purego\n"))
+		})
+
+		It("should constrain all files generated by FileGenerator", func() {
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			files, err := generator.NewFileGenerator(definition, generator.WithBuildTags("linux")).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, src := range files {
+				Ω(string(src)).Should(HavePrefix("//go:build linux\n"))
+			}
+
+			buf := bytes.NewBuffer(nil)
+			err = generator.NewStructGenerator(&testMessage, generator.WithBuildTags("linux")).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(buf.String()).Should(HavePrefix("//go:build linux\n"))
+		})
+
+		It("should reject invalid build constraint expressions", func() {
+			for _, tag := range []string{"linux &&", "(linux", "linux\n//go:build amd64", ""} {
+				g := generator.NewStructGenerator(&testMessage, generator.WithBuildTags(tag))
+				Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrInvalidBuildTags))
+			}
+		})
+	})
+
+	Describe("WithConfig()", func() {
+		It("should derive the package name from the import path", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithConfig(generator.GeneratorConfig{
+				ImportPath: "example.com/ext/extmsg",
+				BuildTags:  []string{"ext"},
+			})))
+			Ω(src).Should(HavePrefix("//go:build ext\n\npackage extmsg\n"))
+		})
+
+		It("should not qualify wrappers declared by the package itself", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithConfig(generator.GeneratorConfig{
+				PackageName: "adc",
+				ImportPath:  "example.com/ext/message",
+				MaybePath:   "example.com/ext/message",
+			})))
+			Ω(src).Should(HavePrefix("package adc\n"))
+			Ω(src).Should(MatchRegexp(`I4\s+IP\n`))
+			Ω(imports(src)).ShouldNot(ContainElement("example.com/ext/message"))
+		})
+
+		It("should import wrappers of other packages", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithConfig(generator.GeneratorConfig{
+				MaybePath: "example.com/ext/opt",
+			})))
+			Ω(src).Should(HavePrefix("package message\n"))
+			Ω(src).Should(MatchRegexp(`I4\s+opt\.IP\n`))
+		})
+
+		It("should reject import paths not ending in a package name", func() {
+			g := generator.NewStructGenerator(&testMessage, generator.WithConfig(generator.GeneratorConfig{
+				ImportPath: "example.com/ext/ext-msg",
+			}))
+			Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrInvalidImportPath))
+		})
+
+		It("should conflict with WithPackage naming a different package", func() {
+			g := generator.NewStructGenerator(&testMessage, generator.WithPackage("adc"),
+				generator.WithConfig(generator.GeneratorConfig{PackageName: "ext"}))
+			Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrConflictingOptions))
+		})
+	})
+
+	Describe("WithEncodeTo()", func() {
+		It("should generate an unchecked EncodeTo method", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithEncodeTo()))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) EncodeTo(buf []byte) []byte {"))
+			Ω(src).Should(ContainSubstring("buf = encodeFlags(buf, t.Flags)"))
+			Ω(src).Should(ContainSubstring("AppendADC"))
+
+			src = render(generator.NewStructGenerator(&testMessage))
+			Ω(src).ShouldNot(ContainSubstring(") EncodeTo("))
+		})
+	})
+
+	Describe("WithStructTags()", func() {
+		It("should tag the fields of params", func() {
+			msg := testMessage
+			msg.NamedParams = append([]*generator.Param{
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "NK", JSONName: "nick", Type: "string"},
+			}, testMessage.NamedParams...)
+
+			src := render(generator.NewStructGenerator(&msg, generator.WithStructTags()))
+			Ω(src).Should(MatchRegexp("Code\\s+int `adc:\"Code,positional\" json:\"code\"`"))
+			Ω(src).Should(MatchRegexp("NI\\s+string `adc:\"NI\" json:\"ni\"`"))
+			Ω(src).Should(MatchRegexp("NK\\s+maybe.String `adc:\"NK\" json:\"nick,omitempty\"`"))
+
+			src = render(generator.NewStructGenerator(&msg))
+			Ω(src).ShouldNot(ContainSubstring("`adc:"))
+		})
+	})
+
+	Describe("WithDeprecatedEncoding()", func() {
+		It("should encode deprecated named params", func() {
+			src := render(generator.NewStructGenerator(&deprecatedMessage, generator.WithDeprecatedEncoding()))
+			Ω(src).Should(ContainSubstring("buf = append(buf, \" OL\"...)\n\t\tbuf = appendEscaped(buf, d.OL.Value)"))
+			Ω(src).Should(ContainSubstring("func (d *DEPContent) DecodeWarnings() []string"))
+		})
+	})
+
+	Describe("WithFlagPrefix()", func() {
+		It("should prefix the flag type and constants", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithFlagPrefix("NAT0")))
+			Ω(src).Should(ContainSubstring("type NAT0TSTFlag string"))
+			Ω(src).Should(MatchRegexp(`NAT0TSTFlagNI\s+NAT0TSTFlag = "NI"`))
+			Ω(src).ShouldNot(MatchRegexp(`[^0]TSTFlag`))
+		})
+
+		It("should reject prefixes not starting an exported identifier", func() {
+			for _, prefix := range []string{"nat0", "0NAT", "NA-T", ""} {
+				g := generator.NewStructGenerator(&testMessage, generator.WithFlagPrefix(prefix))
+				Ω(errors.Cause(g.Render(bytes.NewBuffer(nil)))).Should(Equal(generator.ErrInvalidFlagPrefix))
+			}
+		})
+	})
+
+	Describe("WithTrace()", func() {
+		It("should trace the resolved field layout of all params", func() {
+			trace := bytes.NewBuffer(nil)
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithTrace(trace)))
+			Ω(src).Should(Equal(render(generator.NewStructGenerator(&testMessage))))
+
+			lines := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+			Ω(lines).Should(HaveLen(5))
+			Ω(lines).Should(ContainElement("message TST: positional param Items: type string, mapper list, " +
+				"field Items []string, str field itemsStr, maybe false, singular false, multiplicity dynamic"))
+			Ω(lines).Should(ContainElement("message TST: named param I4: type ip, mapper basic, " +
+				"field I4 maybe.IP, str field i4Str, maybe true, singular true, multiplicity static (1)"))
+		})
+
+		It("should be passed to the generators of FileGenerator", func() {
+			trace := bytes.NewBuffer(nil)
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			_, err := generator.NewFileGenerator(definition, generator.WithTrace(trace)).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(trace.String()).Should(ContainSubstring("message TST: positional param Code: type int"))
+		})
+	})
+
+	Describe("WithValueReceivers()", func() {
+		It("should generate value receivers for methods not modifying the content", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithValueReceivers()))
+			Ω(src).Should(ContainSubstring("func (t TSTContent) Positional() []string"))
+			Ω(src).Should(ContainSubstring("func (t TSTContent) MarshalADC() ([]byte, error)"))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) ParseInto("))
+		})
+
+		It("should generate a compiling content type", func() {
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			files, err := generator.NewFileGenerator(definition, generator.WithValueReceivers()).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			checkPackage(files)
+		})
+	})
+
+	Describe("WithRawPassthrough()", func() {
+		It("should generate the raw line fields and MarkDirty", func() {
+			Ω(render(generator.NewStructGenerator(&testMessage))).ShouldNot(ContainSubstring("MarkDirty"))
+
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithRawPassthrough()))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) MarkDirty()"))
+			Ω(src).Should(ContainSubstring("t.raw = rawLine(line)"))
+		})
+
+		It("should generate a compiling content type", func() {
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			files, err := generator.NewFileGenerator(definition,
+				generator.WithRawPassthrough(), generator.WithValueReceivers()).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			checkPackage(files)
+		})
+	})
+
+	Describe("WithClone()", func() {
+		It("should generate Clone", func() {
+			Ω(render(generator.NewStructGenerator(&testMessage))).ShouldNot(ContainSubstring("Clone()"))
+
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithClone()))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) Clone() *TSTContent"))
+		})
+
+		It("should generate a compiling content type", func() {
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&testMessage},
+			}
+			files, err := generator.NewFileGenerator(definition,
+				generator.WithClone(), generator.WithValueReceivers()).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			checkPackage(files)
+		})
+	})
+
+	Describe("WithOptionalWrapper()", func() {
+		const optionPath = "example.com/option"
+
+		// optionSrc declares a generic wrapper in the style of the standard
+		// library, exposing the value and its presence by methods only.
+		const optionSrc = `package option
+
+type Option[T any] struct {
+	value T
+	ok    bool
+}
+
+func (o Option[T]) Value() T { return o.value }
+
+func (o Option[T]) IsSome() bool { return o.ok }
+
+func (o *Option[T]) Set(value T) {
+	o.value = value
+	o.ok = true
+}
+`
+
+		wrapper := generator.OptionalWrapper{
+			Path:  optionPath,
+			Name:  "Option",
+			Value: "Value()",
+			IsSet: "IsSome()",
+			Set:   "Set",
+		}
+
+		// importOption type checks the package declared by optionSrc.
+		importOption := func(fset *token.FileSet) (*types.Package, error) {
+			file, err := parser.ParseFile(fset, "option.go", optionSrc, 0)
+			if err != nil {
+				return nil, err
+			}
+			conf := types.Config{}
+			return conf.Check(optionPath, fset, []*ast.File{file}, nil)
+		}
+
+		It("should wrap the values of optional params in the wrapper type", func() {
+			src := render(generator.NewStructGenerator(&testMessage, generator.WithOptionalWrapper(wrapper)))
+			Ω(src).Should(ContainSubstring("option.Option[net.IP]"))
+			Ω(src).Should(ContainSubstring("t.I4.Set(val)"))
+			Ω(src).Should(ContainSubstring("if t.I4.IsSome() {"))
+			Ω(src).ShouldNot(ContainSubstring(".IsSet"))
+		})
+
+		It("should generate a compiling content type", func() {
+			// Redacted sets the masked values of sensitive optional params.
+			msg := testMessage
+			msg.NamedParams = append([]*generator.Param{
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "PD", Type: "base32", Sensitive: true},
+			}, testMessage.NamedParams...)
+			definition := &generator.Definition{
+				Messages: []*generator.Message{&msg},
+			}
+			files, err := generator.NewFileGenerator(definition, generator.WithOptionalWrapper(wrapper)).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fset := token.NewFileSet()
+			option, err := importOption(fset)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fallback := importer.ForCompiler(fset, "source", nil)
+			checkPackageWith(fset, files, importerFunc(func(path string) (*types.Package, error) {
+				if path == optionPath {
+					return option, nil
+				}
+				return fallback.Import(path)
+			}))
+		})
+
+		It("should reject wrappers lacking accessors", func() {
+			invalid := wrapper
+			invalid.IsSet = ""
+			err := generator.NewStructGenerator(&testMessage, generator.WithOptionalWrapper(invalid)).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidOptionalWrapper))
+
+			invalid = wrapper
+			invalid.Value = "Value(x)"
+			err = generator.NewStructGenerator(&testMessage, generator.WithOptionalWrapper(invalid)).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidOptionalWrapper))
+		})
+	})
+
+	Describe("WithNoReflect()", func() {
+		It("should set NoReflect", func() {
+			Ω(generator.NewStructGenerator(&testMessage, generator.WithNoReflect()).NoReflect).Should(BeTrue())
+			Ω(generator.NewFileGenerator(&generator.Definition{}, generator.WithNoReflect()).NoReflect).Should(BeTrue())
+		})
+	})
+})
+
+// importerFunc implements types.Importer by a function.
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("AppendPositional", func() {
+	var (
+		fixedMapper *Mapper
+		restore     func()
+	)
+
+	// render renders a message with a single positional param of the fixed
+	// mapper, which has the static multiplicity n.
+	render := func(n int) string {
+		fixedMapper.ComposeFieldInfoFunc = func(ctx *Context) *FieldInfo {
+			return &FieldInfo{
+				FieldName:          jen.Id(ctx.Param.Name),
+				FieldType:          jen.Index().String(),
+				StrFieldName:       jen.Id(toLowerCamelCase(ctx.Param.Name) + "Str"),
+				StrIsSingular:      false,
+				Multiplicity:       MultiplicityStatic,
+				StaticMultiplicity: n,
+			}
+		}
+
+		g := NewStructGenerator(&Message{
+			Command: "FIX",
+			PositionalParams: []*Param{
+				&Param{
+					Mode:     ParamModePositional,
+					Name:     "Values",
+					Type:     "string",
+					Mapper:   "fixed",
+					Required: true,
+				},
+			},
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Ω(g.Render(buf)).Should(Succeed())
+
+		_, err := parser.ParseFile(token.NewFileSet(), "", buf.Bytes(), 0)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return buf.String()
+	}
+
+	ginkgo.BeforeEach(func() {
+		mapper := *ListMapper
+		mapper.Name = "fixed"
+		fixedMapper = &mapper
+
+		restore = addStringMapper(fixedMapper)
+	})
+
+	ginkgo.AfterEach(func() {
+		restore()
+	})
+
+	ginkgo.It("should append the values of small static multiplicities element by element", func() {
+		src := render(2)
+		Ω(src).Should(ContainSubstring("return append(dst, c.valuesStr[0], c.valuesStr[1])"))
+	})
+
+	ginkgo.It("should append the values of large static multiplicities as a slice", func() {
+		src := render(16)
+		Ω(src).Should(ContainSubstring("dst = append(dst, c.valuesStr[:16]...)"))
+	})
+})
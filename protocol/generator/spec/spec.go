@@ -0,0 +1,364 @@
+// Package spec loads protocol definitions for the generator from YAML files,
+// so that the message catalogue can be maintained as data.
+//
+// A file lists enums and messages. The params of a message are split into the
+// positional and named lists, which determine their mode:
+//
+//	messages:
+//	  - command: SID
+//	    types: I
+//	    phases: [PROTOCOL]
+//	    positional:
+//	      - name: SID
+//	        type: base32
+//	        required: true
+//	    named:
+//	      - name: NI
+//	        type: string
+//	        comment: Nick name of the client.
+//	    flags:
+//	      - Any number of flags may be sent.
+//	    examples:
+//	      - line: SID AAAB
+//	        values: {SID: AAAB}
+//
+// The keys of params correspond to the fields of generator.Param, written in
+// lower case with underscores, e.g. display_name and gated_by. Files
+// specifying the messages of a protocol extension name it by the top-level
+// extension key, e.g. "extension: NAT0". Constraints spanning several params
+// are specified by the required_if key of params and the one_of key of
+// messages:
+//
+//	messages:
+//	  - command: RES
+//	    named:
+//	      - name: FN
+//	        type: string
+//	      - name: TR
+//	        type: tth
+//	      - name: SU
+//	        type: features
+//	      - name: U4
+//	        type: int
+//	        required_if: {param: SU, value: UDP4}
+//	    one_of:
+//	      - [TR, FN]
+//
+// Params whose type names an enum have fields of the enum type:
+//
+//	enums:
+//	  - name: Severity
+//	    type: int
+//	    values:
+//	      - name: Success
+//	        value: "0"
+//	      - name: Fatal
+//	        value: "2"
+//	messages:
+//	  - command: STA
+//	    positional:
+//	      - name: Severity
+//	        type: Severity
+//	        required: true
+package spec
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+// Error variables related to loading specifications.
+var (
+	ErrMissingCommand = errors.New("message lacks a command")
+	ErrMissingName    = errors.New("param or enum lacks a name")
+	ErrMixedExtension = errors.New("specification files of different extensions cannot be merged")
+)
+
+type definitionSpec struct {
+	Extension string        `yaml:"extension"`
+	Enums     []enumSpec    `yaml:"enums"`
+	Messages  []messageSpec `yaml:"messages"`
+}
+
+type enumSpec struct {
+	Name    string          `yaml:"name"`
+	Type    string          `yaml:"type"`
+	Comment string          `yaml:"comment"`
+	Values  []enumValueSpec `yaml:"values"`
+}
+
+type enumValueSpec struct {
+	Name    string `yaml:"name"`
+	Value   string `yaml:"value"`
+	Comment string `yaml:"comment"`
+}
+
+type messageSpec struct {
+	Command    string        `yaml:"command"`
+	Name       string        `yaml:"name"`
+	Types      string        `yaml:"types"`
+	Phases     []string      `yaml:"phases"`
+	Comment    string        `yaml:"comment"`
+	Section    string        `yaml:"section"`
+	Positional []paramSpec   `yaml:"positional"`
+	Named      []paramSpec   `yaml:"named"`
+	Flags      []string      `yaml:"flags"`
+	OneOf      [][]string    `yaml:"one_of"`
+	Examples   []exampleSpec `yaml:"examples"`
+}
+
+type exampleSpec struct {
+	Line   string            `yaml:"line"`
+	Values map[string]string `yaml:"values"`
+}
+
+type paramSpec struct {
+	Name              string         `yaml:"name"`
+	FlagName          string         `yaml:"flag_name"`
+	DisplayName       string         `yaml:"display_name"`
+	JSONName          string         `yaml:"json_name"`
+	Type              string         `yaml:"type"`
+	Mapper            string         `yaml:"mapper"`
+	Required          bool           `yaml:"required"`
+	Comment           string         `yaml:"comment"`
+	AllowedValues     []string       `yaml:"allowed_values"`
+	AllowedEnum       string         `yaml:"allowed_enum"`
+	Min               string         `yaml:"min"`
+	Max               string         `yaml:"max"`
+	Length            int            `yaml:"length"`
+	ValidationMessage string         `yaml:"validation_message"`
+	Bits              []string       `yaml:"bits"`
+	Deprecated        string         `yaml:"deprecated"`
+	RequiredIf        *conditionSpec `yaml:"required_if"`
+	GatedBy           string         `yaml:"gated_by"`
+	Sensitive         bool           `yaml:"sensitive"`
+	Const             string         `yaml:"const"`
+	CacheKeyPart      bool           `yaml:"cache_key_part"`
+}
+
+type conditionSpec struct {
+	Param string `yaml:"param"`
+	Value string `yaml:"value"`
+}
+
+// Parse parses the YAML specification data into a definition. Unknown keys
+// are rejected.
+func Parse(data []byte) (*generator.Definition, error) {
+	var spec definitionSpec
+	err := yaml.UnmarshalStrict(data, &spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing specification")
+	}
+
+	return spec.definition()
+}
+
+// Load reads the YAML specification from r and parses it into a definition.
+func Load(r io.Reader) (*generator.Definition, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading specification")
+	}
+
+	return Parse(data)
+}
+
+// LoadFiles loads the YAML specification files and merges them into a single
+// definition, keeping the order of the files. All files must specify the
+// same extension, ErrMixedExtension is returned otherwise.
+func LoadFiles(paths ...string) (*generator.Definition, error) {
+	definition := &generator.Definition{}
+
+	for i, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading specification file %s", path)
+		}
+
+		fileDefinition, err := Parse(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "specification file %s", path)
+		}
+
+		if i > 0 && fileDefinition.Extension != definition.Extension {
+			return nil, errors.Wrapf(ErrMixedExtension, "extension %q of specification file %s, previous files specify %q",
+				fileDefinition.Extension, path, definition.Extension)
+		}
+		definition.Extension = fileDefinition.Extension
+
+		definition.Enums = append(definition.Enums, fileDefinition.Enums...)
+		definition.Messages = append(definition.Messages, fileDefinition.Messages...)
+	}
+
+	return definition, nil
+}
+
+// LoadDir loads all YAML specification files in dir, see Files, and merges
+// them as LoadFiles does.
+func LoadDir(dir string) (*generator.Definition, error) {
+	paths, err := Files(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadFiles(paths...)
+}
+
+// Files returns the paths of the YAML specification files in dir, i.e. the
+// files with the extension .yaml or .yml, in lexical order. Sub-directories
+// are not searched.
+func Files(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading specification directory %s", dir)
+	}
+
+	var paths []string
+	for _, info := range infos {
+		ext := filepath.Ext(info.Name())
+		if !info.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			paths = append(paths, filepath.Join(dir, info.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (d *definitionSpec) definition() (*generator.Definition, error) {
+	definition := &generator.Definition{
+		Extension: d.Extension,
+	}
+
+	for _, enumSpec := range d.Enums {
+		enum, err := enumSpec.enum()
+		if err != nil {
+			return nil, err
+		}
+		definition.Enums = append(definition.Enums, enum)
+	}
+
+	for _, messageSpec := range d.Messages {
+		message, err := messageSpec.message()
+		if err != nil {
+			return nil, err
+		}
+		definition.Messages = append(definition.Messages, message)
+	}
+
+	return definition, nil
+}
+
+func (e *enumSpec) enum() (*generator.Enum, error) {
+	if len(e.Name) == 0 {
+		return nil, errors.Wrapf(ErrMissingName, "enum of type %s", e.Type)
+	}
+
+	enum := &generator.Enum{
+		Name:    e.Name,
+		Type:    e.Type,
+		Comment: e.Comment,
+	}
+	for _, value := range e.Values {
+		enum.Values = append(enum.Values, &generator.EnumValue{
+			Name:    value.Name,
+			Value:   value.Value,
+			Comment: value.Comment,
+		})
+	}
+
+	return enum, nil
+}
+
+func (m *messageSpec) message() (*generator.Message, error) {
+	if len(m.Command) == 0 {
+		return nil, errors.Wrapf(ErrMissingCommand, "message %s", m.Name)
+	}
+
+	message := &generator.Message{
+		Command: m.Command,
+		Name:    m.Name,
+		Types:   m.Types,
+		Phases:  m.Phases,
+		Comment: m.Comment,
+		Section: m.Section,
+		OneOf:   m.OneOf,
+	}
+
+	for _, paramSpec := range m.Positional {
+		param, err := paramSpec.param(generator.ParamModePositional)
+		if err != nil {
+			return nil, errors.Wrapf(err, "message %s", m.Command)
+		}
+		message.PositionalParams = append(message.PositionalParams, param)
+	}
+
+	for _, paramSpec := range m.Named {
+		param, err := paramSpec.param(generator.ParamModeNamed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "message %s", m.Command)
+		}
+		message.NamedParams = append(message.NamedParams, param)
+	}
+
+	for _, comment := range m.Flags {
+		message.Flags = append(message.Flags, &generator.Flag{
+			Comment: comment,
+		})
+	}
+
+	for _, example := range m.Examples {
+		message.Examples = append(message.Examples, &generator.Example{
+			Line:   example.Line,
+			Values: example.Values,
+		})
+	}
+
+	return message, nil
+}
+
+func (p *paramSpec) param(mode generator.ParamMode) (*generator.Param, error) {
+	if len(p.Name) == 0 {
+		return nil, errors.Wrapf(ErrMissingName, "%s param of type %s", mode, p.Type)
+	}
+
+	var requiredIf *generator.Condition
+	if p.RequiredIf != nil {
+		requiredIf = &generator.Condition{
+			Param: p.RequiredIf.Param,
+			Value: p.RequiredIf.Value,
+		}
+	}
+
+	return &generator.Param{
+		Mode:              mode,
+		Name:              p.Name,
+		FlagName:          p.FlagName,
+		DisplayName:       p.DisplayName,
+		JSONName:          p.JSONName,
+		Type:              p.Type,
+		Mapper:            p.Mapper,
+		Required:          p.Required,
+		Comment:           p.Comment,
+		AllowedValues:     p.AllowedValues,
+		AllowedEnum:       p.AllowedEnum,
+		Min:               p.Min,
+		Max:               p.Max,
+		Length:            p.Length,
+		ValidationMessage: p.ValidationMessage,
+		Bits:              p.Bits,
+		Deprecated:        p.Deprecated,
+		RequiredIf:        requiredIf,
+		GatedBy:           p.GatedBy,
+		Sensitive:         p.Sensitive,
+		Const:             p.Const,
+		CacheKeyPart:      p.CacheKeyPart,
+	}, nil
+}
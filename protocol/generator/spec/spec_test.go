@@ -0,0 +1,268 @@
+package spec_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+	"github.com/seoester/adcl/protocol/generator/spec"
+)
+
+const sidSpec = `
+messages:
+  - command: SID
+    types: I
+    phases: [PROTOCOL]
+    positional:
+      - name: SID
+        type: base32
+        required: true
+        length: 4
+`
+
+const resSpec = `
+enums:
+  - name: Slots
+    type: int
+    values:
+      - name: One
+        value: "1"
+messages:
+  - command: RES
+    section: ADC § 5.3.8
+    named:
+      - name: FN
+        display_name: File name
+        json_name: fileName
+        type: string
+        required: true
+        comment: Full path of the file.
+      - name: SL
+        type: int
+        allowed_enum: Slots
+        min: "0"
+        max: "1024"
+      - name: TO
+        type: string
+        sensitive: true
+        cache_key_part: true
+    flags:
+      - Flags of extensions.
+`
+
+var _ = Describe("Parse()", func() {
+	It("should load messages with their params", func() {
+		definition, err := spec.Parse([]byte(resSpec))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(definition.Messages).Should(HaveLen(1))
+		msg := definition.Messages[0]
+		Ω(msg.Command).Should(Equal("RES"))
+		Ω(msg.Section).Should(Equal("ADC § 5.3.8"))
+		Ω(msg.PositionalParams).Should(BeEmpty())
+		Ω(msg.NamedParams).Should(HaveLen(3))
+		Ω(*msg.NamedParams[0]).Should(Equal(generator.Param{
+			Mode:        generator.ParamModeNamed,
+			Name:        "FN",
+			DisplayName: "File name",
+			JSONName:    "fileName",
+			Type:        "string",
+			Required:    true,
+			Comment:     "Full path of the file.",
+		}))
+		Ω(msg.NamedParams[1].AllowedEnum).Should(Equal("Slots"))
+		Ω(msg.NamedParams[1].Min).Should(Equal("0"))
+		Ω(msg.NamedParams[1].Max).Should(Equal("1024"))
+		Ω(msg.NamedParams[2].Sensitive).Should(BeTrue())
+		Ω(msg.NamedParams[2].CacheKeyPart).Should(BeTrue())
+		Ω(msg.Flags).Should(Equal([]*generator.Flag{{Comment: "Flags of extensions."}}))
+
+		Ω(definition.Enums).Should(HaveLen(1))
+		Ω(definition.Enums[0].Values).Should(Equal([]*generator.EnumValue{{Name: "One", Value: "1"}}))
+	})
+
+	It("should set the mode of params by their list", func() {
+		definition, err := spec.Parse([]byte(sidSpec))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		msg := definition.Messages[0]
+		Ω(msg.Types).Should(Equal("I"))
+		Ω(msg.Phases).Should(Equal([]string{"PROTOCOL"}))
+		Ω(msg.PositionalParams).Should(HaveLen(1))
+		Ω(msg.PositionalParams[0].Mode).Should(Equal(generator.ParamModePositional))
+		Ω(msg.PositionalParams[0].Length).Should(Equal(4))
+	})
+
+	It("should produce definitions accepted by the generator", func() {
+		definition, err := spec.Parse([]byte(resSpec))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		files, err := generator.NewFileGenerator(definition).RenderFiles()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(files).Should(HaveKey("content_res.go"))
+	})
+
+	It("should type params named by an enum", func() {
+		definition, err := spec.Parse([]byte(`
+enums:
+  - name: Severity
+    type: int
+    values:
+      - name: Success
+        value: "0"
+      - name: Fatal
+        value: "2"
+messages:
+  - command: STA
+    positional:
+      - name: Severity
+        type: Severity
+        required: true
+`))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		files, err := generator.NewFileGenerator(definition).RenderFiles()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(files[generator.SupportFileName])).Should(ContainSubstring("type Severity int"))
+		Ω(string(files["content_sta.go"])).Should(MatchRegexp(`Severity\s+Severity\n`))
+	})
+
+	It("should load the examples of messages", func() {
+		definition, err := spec.Parse([]byte(sidSpec + `
+    examples:
+      - line: SID AAAB
+        values: {SID: AAAB}
+`))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(definition.Messages[0].Examples).Should(Equal([]*generator.Example{
+			&generator.Example{Line: "SID AAAB", Values: map[string]string{"SID": "AAAB"}},
+		}))
+	})
+
+	It("should load constraints spanning several params", func() {
+		definition, err := spec.Parse([]byte(`
+messages:
+  - command: DLD
+    named:
+      - name: TR
+        type: tth
+      - name: FN
+        type: string
+      - name: SU
+        type: features
+      - name: U4
+        type: int
+        required_if: {param: SU, value: UDP4}
+    one_of:
+      - [TR, FN]
+`))
+		Ω(err).ShouldNot(HaveOccurred())
+		msg := definition.Messages[0]
+		Ω(msg.NamedParams[3].RequiredIf).Should(Equal(&generator.Condition{Param: "SU", Value: "UDP4"}))
+		Ω(msg.OneOf).Should(Equal([][]string{{"TR", "FN"}}))
+
+		_, err = generator.NewFileGenerator(definition).RenderFiles()
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should load the extension of the messages", func() {
+		definition, err := spec.Parse([]byte("extension: NAT0\n" + sidSpec))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(definition.Extension).Should(Equal("NAT0"))
+	})
+
+	It("should reject unknown keys", func() {
+		_, err := spec.Parse([]byte("messages:\n  - command: SID\n    positionals: []\n"))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("should reject messages without command", func() {
+		_, err := spec.Parse([]byte("messages:\n  - name: SID\n"))
+		Ω(errors.Cause(err)).Should(Equal(spec.ErrMissingCommand))
+	})
+
+	It("should reject params without name", func() {
+		_, err := spec.Parse([]byte("messages:\n  - command: SID\n    named:\n      - type: int\n"))
+		Ω(errors.Cause(err)).Should(Equal(spec.ErrMissingName))
+	})
+})
+
+var _ = Describe("LoadFiles()", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "spec")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should merge the definitions of all files in order", func() {
+		sidPath := filepath.Join(dir, "sid.yaml")
+		resPath := filepath.Join(dir, "res.yaml")
+		Ω(ioutil.WriteFile(sidPath, []byte(sidSpec), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(resPath, []byte(resSpec), 0644)).Should(Succeed())
+
+		definition, err := spec.LoadFiles(sidPath, resPath)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(definition.Messages).Should(HaveLen(2))
+		Ω(definition.Messages[0].Command).Should(Equal("SID"))
+		Ω(definition.Messages[1].Command).Should(Equal("RES"))
+		Ω(definition.Enums).Should(HaveLen(1))
+	})
+
+	It("should reject files of different extensions", func() {
+		sidPath := filepath.Join(dir, "sid.yaml")
+		natPath := filepath.Join(dir, "nat.yaml")
+		Ω(ioutil.WriteFile(sidPath, []byte(sidSpec), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(natPath, []byte("extension: NAT0\n"+resSpec), 0644)).Should(Succeed())
+
+		_, err := spec.LoadFiles(sidPath, natPath)
+		Ω(errors.Cause(err)).Should(Equal(spec.ErrMixedExtension))
+	})
+
+	It("should fail for missing files", func() {
+		_, err := spec.LoadFiles(filepath.Join(dir, "missing.yaml"))
+		Ω(os.IsNotExist(errors.Cause(err))).Should(BeTrue())
+	})
+})
+
+var _ = Describe("LoadDir()", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "spec")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should load the YAML files of the directory in lexical order", func() {
+		Ω(ioutil.WriteFile(filepath.Join(dir, "b_sid.yml"), []byte(sidSpec), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(dir, "a_res.yaml"), []byte(resSpec), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# Specs"), 0644)).Should(Succeed())
+		Ω(os.Mkdir(filepath.Join(dir, "ext.yaml"), 0755)).Should(Succeed())
+
+		definition, err := spec.LoadDir(dir)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(definition.Messages).Should(HaveLen(2))
+		Ω(definition.Messages[0].Command).Should(Equal("RES"))
+		Ω(definition.Messages[1].Command).Should(Equal("SID"))
+	})
+
+	It("should fail for missing directories", func() {
+		_, err := spec.LoadDir(filepath.Join(dir, "missing"))
+		Ω(os.IsNotExist(errors.Cause(err))).Should(BeTrue())
+	})
+})
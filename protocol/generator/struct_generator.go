@@ -3,48 +3,213 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 	"github.com/pkg/errors"
 )
 
+// Error variables related to the StructGenerator.
+var (
+	ErrReflectionUsed    = errors.New("generated code depends on reflection, but NoReflect is set")
+	ErrInvalidFlagName   = errors.New("flag name cannot be mapped to a Go identifier")
+	ErrAmbiguousFlagName = errors.New("flag names map to Go identifiers only differing by case")
+	ErrInvalidType       = errors.New("invalid message type")
+	ErrInvalidPhase      = errors.New("invalid protocol phase")
+	ErrInvalidTypeName   = errors.New("type name is not an exported Go identifier")
+	ErrMultipleDynamic   = errors.New("more than one positional param has dynamic multiplicity")
+	ErrUnavailableMode   = errors.New("mapper does not support the mode of the param")
+	ErrInvalidBits       = errors.New("bits must be distinct Go identifiers, at most 63")
+)
+
+// messageTypes contains the characters of all message types.
+const messageTypes = "BCDEFHIU"
+
+// reservedIdents contains the single letter identifiers declared by the
+// generated methods, which cannot be used as receiver names.
+var reservedIdents = map[string]bool{"f": true, "i": true, "n": true, "w": true}
+
+// fallbackTypeLetter is the receiver name used if the first letter of the
+// type name is reserved.
+const fallbackTypeLetter = "c"
+
+// protocolPhases contains the names of all protocol phases.
+var protocolPhases = []string{"PROTOCOL", "IDENTIFY", "VERIFY", "NORMAL", "DATA"}
+
+// reflectionPackages contains the import paths of packages which rely on
+// reflection for their core functionality.
+var reflectionPackages = []string{
+	"reflect",
+	"encoding/gob",
+	"encoding/json",
+	"encoding/xml",
+}
+
 type paramInfo struct {
 	Param     *Param
 	Mapper    *Mapper
 	Type      *TypeSpec
 	FieldInfo *FieldInfo
+	// FlagConstName is the name of the flag constant of a named param.
+	FlagConstName string
+	// Gate is the named param gating a positional param, if any.
+	Gate *paramInfo
+	// AllowedEnum is the enum named by the AllowedEnum of the param, if any.
+	AllowedEnum *Enum
+	// Enum is the enum named by the type of the param, if any.
+	Enum *Enum
 }
 
 type StructGenerator struct {
-	message *Message
+	// NoReflect restricts the generated code to not depend on reflection,
+	// which is required by constrained compilers such as TinyGo. The
+	// Format, String, Labels and LogValue methods, which format values using
+	// fmt verbs and slog.Any, are omitted. Render fails with
+	// ErrReflectionUsed if the generated code imports any package relying on
+	// reflection, e.g. one declared by a mapper.
+	NoReflect bool
+	// Trace receives a description of the field layout of each param
+	// resolved by the generator, intended for debugging mappers. Nothing is
+	// traced if Trace is nil. Tracing does not affect the generated code.
+	Trace io.Writer
+	// TypeName overrides the name of the generated content type, which is
+	// the command followed by "Content" by default. It must be an exported
+	// Go identifier.
+	TypeName string
+	// OptionalWrapper is the type wrapping the values of optional params.
+	// The types of the maybe package are used if nil.
+	OptionalWrapper *OptionalWrapper
 
-	typeName     string
-	typeLetter   string
-	flagTypeName string
+	message *Message
+	// enums are the enums of the definition the message is part of.
+	enums []*Enum
+	// sharedRegistration is set if the content type is registered by the
+	// shared support file instead of an init function of its own file.
+	sharedRegistration bool
+
+	packageName string
+	// importPath is the import path of the package of the generated files,
+	// empty if unknown.
+	importPath     string
+	valueReceivers bool
+	rawPassthrough bool
+	clone          bool
+	encodeTo       bool
+	// encodeDeprecated is set if deprecated named params are encoded.
+	encodeDeprecated bool
+	// structTags is set if the fields of params are tagged, see
+	// WithStructTags.
+	structTags bool
+	// helpers is set if the support declarations are generated into the
+	// package of the content types, see WithHelpers.
+	helpers bool
+	// flagPrefix prefixes the names of the flag type and constants.
+	flagPrefix string
+	// buildExpr is the build constraint of the generated files, nil if the
+	// files are unconstrained.
+	buildExpr constraint.Expr
+	// optionErr is the error resulting from invalid or conflicting options.
+	optionErr error
+
+	typeName       string
+	typeLetter     string
+	flagTypeName   string
+	descriptorName string
 
 	positionalParams []paramInfo
 	namedParams      []paramInfo
+	imports          []string
 }
 
-func NewStructGenerator(message *Message) *StructGenerator {
-	return &StructGenerator{
+// NewStructGenerator returns a generator of the content type of message,
+// configured by opts. Invalid or conflicting options are detected here, the
+// resulting error is returned by the Render methods.
+func NewStructGenerator(message *Message, opts ...Option) *StructGenerator {
+	s := &StructGenerator{
 		message: message,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if len(s.packageName) == 0 {
+		s.packageName = defaultPackageName
+	}
+
+	return s
 }
 
 func (s *StructGenerator) Generate() error {
-	err := s.prepare()
+	buf := bytes.NewBuffer(nil)
+
+	err := s.Render(buf)
 	if err != nil {
 		return err
 	}
 
-	file := s.generateFile()
+	fmt.Println(buf.String())
+
+	return nil
+}
+
+// Render generates the file containing the struct type and its methods and
+// writes the formatted source code to w.
+func (s *StructGenerator) Render(w io.Writer) error {
+	err := s.prepare()
+	if err != nil {
+		return err
+	}
 
 	buf := bytes.NewBuffer(nil)
-	file.Render(buf)
 
-	fmt.Println(buf.String())
+	err = s.generateFile().Render(buf)
+	if err != nil {
+		return err
+	}
+
+	src, err := addImports(buf.Bytes(), s.imports)
+	if err != nil {
+		return err
+	}
+
+	if s.NoReflect {
+		err = checkNoReflection(src)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+// checkNoReflection returns ErrReflectionUsed if the source code src imports
+// any package relying on reflection.
+func checkNoReflection(src []byte) error {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ImportsOnly)
+	if err != nil {
+		return errors.Wrap(err, "parsing generated code failed")
+	}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return errors.Wrap(err, "parsing generated code failed")
+		}
+
+		for _, reflectionPackage := range reflectionPackages {
+			if path == reflectionPackage {
+				return errors.Wrapf(ErrReflectionUsed, "package %s imported", path)
+			}
+		}
+	}
 
 	return nil
 }
@@ -52,62 +217,370 @@ func (s *StructGenerator) Generate() error {
 func (s *StructGenerator) prepare() error {
 	var err error
 
+	if s.optionErr != nil {
+		return s.optionErr
+	}
+
+	if s.OptionalWrapper != nil {
+		err = s.OptionalWrapper.validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.prepareFamily()
+	if err != nil {
+		return err
+	}
+
+	s.typeName = s.baseName() + "Content"
+	if len(s.TypeName) > 0 {
+		if !token.IsIdentifier(s.TypeName) || !token.IsExported(s.TypeName) {
+			return errors.Wrapf(ErrInvalidTypeName, "type name %s of message %s", s.TypeName, s.message.Command)
+		}
+		s.typeName = s.TypeName
+	}
+	s.typeLetter = strings.ToLower(s.typeName[0:1])
+	if reservedIdents[s.typeLetter] {
+		s.typeLetter = fallbackTypeLetter
+	}
+	s.flagTypeName = s.flagPrefix + s.baseName() + "Flag"
+	s.descriptorName = toLowerCamelCase(s.baseName()) + "Descriptor"
+
+	for _, typ := range []byte(s.message.Types) {
+		if !strings.ContainsRune(messageTypes, rune(typ)) {
+			return errors.Wrapf(ErrInvalidType, "type %q of message %s", typ, s.message.Command)
+		}
+	}
+
+	for _, phase := range s.message.Phases {
+		if !isProtocolPhase(phase) {
+			return errors.Wrapf(ErrInvalidPhase, "phase %s of message %s", phase, s.message.Command)
+		}
+	}
+
 	s.positionalParams, err = s.prepareParams(s.message.PositionalParams)
 	if err != nil {
 		return err
 	}
 
+	err = s.checkDynamicPositionals()
+	if err != nil {
+		return err
+	}
+
 	s.namedParams, err = s.prepareParams(s.message.NamedParams)
 	if err != nil {
 		return err
 	}
 
-	s.typeName = s.message.Command + "Content"
-	s.typeLetter = strings.ToLower(s.typeName[0:1])
-	s.flagTypeName = s.message.Command + "Flag"
+	err = s.prepareConsts()
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareConstraints()
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareGates()
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareConditions()
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareCacheKey()
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareExamples()
+	if err != nil {
+		return err
+	}
+
+	s.prepareImports()
+
+	err = s.prepareFlagConstNames()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkDynamicPositionals rejects messages with more than one positional param
+// of dynamic multiplicity, as the tokens cannot be assigned unambiguously.
+func (s *StructGenerator) checkDynamicPositionals() error {
+	var dynamic *Param
+	for _, param := range s.positionalParams {
+		if param.FieldInfo.Multiplicity == MultiplicityStatic {
+			continue
+		}
+		if dynamic != nil {
+			return errors.Wrapf(ErrMultipleDynamic, "params %s and %s of message %s",
+				dynamic.Name, param.Param.Name, s.message.Command)
+		}
+		dynamic = param.Param
+	}
+
+	return nil
+}
+
+// prepareFlagConstNames sets the FlagConstName of all named params. Flag
+// names are sanitized to form valid Go identifiers, flag names resulting in
+// constant names only differing by case are rejected.
+func (s *StructGenerator) prepareFlagConstNames() error {
+	seen := make(map[string]string)
+
+	for i := range s.namedParams {
+		param := &s.namedParams[i]
+		ctx := s.createContext(*param)
+		name := param.Mapper.Parser.Named.ParamName(&ctx)
+
+		suffix, err := sanitizeFlagName(name)
+		if err != nil {
+			return errors.Wrapf(err, "invalid flag name %q of param %s of message %s",
+				name, param.Param.Name, s.message.Command)
+		}
+
+		folded := strings.ToUpper(suffix)
+		if other, ok := seen[folded]; ok {
+			return errors.Wrapf(ErrAmbiguousFlagName, "flag names %q and %q of message %s",
+				other, name, s.message.Command)
+		}
+		seen[folded] = name
+
+		param.FlagConstName = s.flagTypeName + suffix
+	}
 
 	return nil
 }
 
+// sanitizeFlagName maps the flag name to a suffix usable in a Go identifier.
+// Letters and digits are retained, other printable ASCII characters are
+// replaced by an underscore followed by their hexadecimal code.
+func sanitizeFlagName(name string) (string, error) {
+	if len(name) == 0 {
+		return "", ErrInvalidFlagName
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+			b.WriteByte(c)
+		case 0x20 < c && c < 0x7f:
+			fmt.Fprintf(&b, "_%02X", c)
+		default:
+			return "", ErrInvalidFlagName
+		}
+	}
+
+	return b.String(), nil
+}
+
+// prepareImports collects the union of the imports declared by the mappers of
+// all params.
+func (s *StructGenerator) prepareImports() {
+	seen := make(map[string]bool)
+	s.imports = nil
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			ctx := s.createContext(param)
+
+			for _, path := range param.Mapper.Imports(&ctx) {
+				if !seen[path] {
+					seen[path] = true
+					s.imports = append(s.imports, path)
+				}
+			}
+		}
+	}
+
+	sort.Strings(s.imports)
+}
+
 func (s *StructGenerator) prepareParams(params []*Param) ([]paramInfo, error) {
 	paramInfos := make([]paramInfo, 0, len(params))
 
 	for _, param := range params {
-		typeSpec, err := TypeSpecFromName(param.Type)
-		if err != nil {
-			return nil, errors.Wrapf(err, "type resolution failed for type name %s specified by "+
-				"param %s of message %s", param.Name, s.message.Command)
+		enum := s.lookupEnum(param.Type)
+		if enum != nil {
+			if !param.Required && len(s.optionalWrapper().Name) == 0 {
+				return nil, errors.Wrapf(ErrOptionalEnum, "param %s of message %s with enum type %s",
+					param.Name, s.message.Command, param.Type)
+			}
 		}
-		mapper, err := ResolveMapperFromParam(param)
+
+		typeSpec, mapper, err := s.resolveMapper(param, enum)
 		if err != nil {
-			return nil, errors.Wrapf(err, "mapper resolution failed for param %s of message %s "+
-				"with type %s", param.Name, s.message.Command)
+			return nil, err
+		}
+
+		if !mapper.Parser.available(param.Mode) {
+			return nil, errors.Wrapf(ErrUnavailableMode, "mapper %s of param %s of message %s in mode %s",
+				mapper.Name, param.Name, s.message.Command, param.Mode)
+		}
+
+		if mapper == BitmaskMapper {
+			err = s.checkBits(param)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		ctx := Context{
-			Param:  param,
-			Mapper: mapper,
-			Type:   typeSpec,
+			Param:           param,
+			Mapper:          mapper,
+			Type:            typeSpec,
+			Enum:            enum,
+			OptionalWrapper: s.optionalWrapper(),
 		}
 
-		paramInfos = append(paramInfos, paramInfo{
+		info := paramInfo{
 			Param:     param,
 			Mapper:    mapper,
 			Type:      typeSpec,
 			FieldInfo: mapper.ComposeFieldInfo(&ctx),
-		})
+			Enum:      enum,
+		}
+		s.traceParam(info)
+
+		paramInfos = append(paramInfos, info)
 	}
 
 	return paramInfos, nil
 }
 
+// checkBits checks the Bits of a param using the bitmask mapper. The bits name
+// the fields of a struct and are encoded in a non-negative int.
+func (s *StructGenerator) checkBits(param *Param) error {
+	if len(param.Bits) > 63 {
+		return errors.Wrapf(ErrInvalidBits, "%d bits of param %s of message %s",
+			len(param.Bits), param.Name, s.message.Command)
+	}
+
+	seen := make(map[string]bool, len(param.Bits))
+	for _, bit := range param.Bits {
+		if !token.IsIdentifier(bit) || seen[bit] {
+			return errors.Wrapf(ErrInvalidBits, "bit %q of param %s of message %s",
+				bit, param.Name, s.message.Command)
+		}
+		seen[bit] = true
+	}
+
+	return nil
+}
+
+// lookupEnum returns the enum of the definition named name, or nil if there is
+// none.
+func (s *StructGenerator) lookupEnum(name string) *Enum {
+	for _, enum := range s.enums {
+		if enum.Name == name {
+			return enum
+		}
+	}
+
+	return nil
+}
+
+// resolveMapper returns the type spec and the mapper of the param. Params of
+// an enum type are mapped by EnumMapper.
+func (s *StructGenerator) resolveMapper(param *Param, enum *Enum) (*TypeSpec, *Mapper, error) {
+	if enum != nil {
+		if len(param.Mapper) > 0 && param.Mapper != EnumMapper.Name {
+			return nil, nil, errors.Wrapf(ErrUnknownMapperName, "mapper resolution failed for "+
+				"param %s of message %s with enum type %s", param.Name, s.message.Command, param.Type)
+		}
+
+		return enumTypeSpec, EnumMapper, nil
+	}
+
+	typeSpec, err := TypeSpecFromName(param.Type)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "type resolution failed for type name %s specified by "+
+			"param %s of message %s", param.Type, param.Name, s.message.Command)
+	}
+	mapper, err := ResolveMapperFromParam(param)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "mapper resolution failed for param %s of message %s "+
+			"with type %s", param.Name, s.message.Command, param.Type)
+	}
+
+	return typeSpec, mapper, nil
+}
+
+// valueType returns the type of the values of the param, i.e. the type of
+// the field without optional wrapper or slice.
+func (param paramInfo) valueType() jen.Code {
+	if param.Enum != nil {
+		return jen.Id(param.Enum.Name)
+	}
+
+	return basicGolangType(param.Param.Type)
+}
+
+// traceParam writes the resolved field layout of the param to Trace.
+func (s *StructGenerator) traceParam(param paramInfo) {
+	if s.Trace == nil {
+		return
+	}
+
+	fieldInfo := param.FieldInfo
+
+	fmt.Fprintf(s.Trace, "message %s: %s param %s: type %s, mapper %s, field %#v %#v, "+
+		"str field %#v, maybe %t, singular %t, multiplicity %s",
+		s.message.Command, param.Param.Mode, param.Param.Name, param.Param.Type, param.Mapper.Name,
+		fieldInfo.FieldName, fieldInfo.FieldType, fieldInfo.StrFieldName,
+		fieldInfo.FieldIsMaybe, fieldInfo.StrIsSingular, fieldInfo.Multiplicity)
+	if fieldInfo.Multiplicity == MultiplicityStatic {
+		fmt.Fprintf(s.Trace, " (%d)", fieldInfo.StaticMultiplicity)
+	}
+	fmt.Fprintln(s.Trace)
+}
+
 func (s *StructGenerator) generateFile() *jen.File {
-	file := jen.NewFile("message")
+	file := newFile(s.packageName, s.importPath, s.buildExpr)
+
+	s.generateDecls(file)
+
+	return file
+}
+
+// newFile returns a new file of the package, starting with the generated code
+// comment. References to the package at importPath are not qualified, unless
+// importPath is empty. If buildExpr is not nil, the file is constrained by it.
+func newFile(packageName, importPath string, buildExpr constraint.Expr) *jen.File {
+	file := jen.NewFilePathName(importPath, packageName)
+
+	addBuildConstraint(file, buildExpr)
 
 	file.Comment("Code generated by adcl/protocol/generator. DO NOT EDIT.")
 
 	file.Line()
 
+	return file
+}
+
+// addBuildConstraint adds the //go:build line of buildExpr as a header to
+// file. Nothing is added if buildExpr is nil.
+func addBuildConstraint(file *jen.File, buildExpr constraint.Expr) {
+	if buildExpr != nil {
+		file.HeaderComment("//go:build " + buildExpr.String())
+	}
+}
+
+// generateDecls adds the declarations of the struct type and its methods to
+// file.
+func (s *StructGenerator) generateDecls(file *jen.File) {
 	file.Type().Id(s.flagTypeName).String()
 
 	if len(s.namedParams) > 0 {
@@ -115,107 +588,826 @@ func (s *StructGenerator) generateFile() *jen.File {
 			DefsFunc(s.generateFlagConstants)
 	}
 
+	file.Comment("String returns the name of the flag constant matching f, or the flag")
+	file.Comment("wrapped in the flag type name if f is not known.")
+	file.Func().Params(jen.Id("f").Id(s.flagTypeName)).
+		Id("String").Params().String().
+		BlockFunc(s.generateFlagString)
+
+	file.Comment("IsKnown reports whether f is one of the flag constants of the message.")
+	file.Func().Params(jen.Id("f").Id(s.flagTypeName)).
+		Id("IsKnown").Params().Bool().
+		BlockFunc(s.generateFlagIsKnown)
+
 	file.Var().Id("_").Id("ParamAccessor").Op("=").Op("&").Id(s.typeName).Values()
+	file.Var().Id("_").Id("ADCMarshaler").Op("=").Op("&").Id(s.typeName).Values()
+	file.Var().Id("_").Id("ADCUnmarshaler").Op("=").Op("&").Id(s.typeName).Values()
+	file.Var().Id("_").Qual("io", "WriterTo").Op("=").Op("&").Id(s.typeName).Values()
+	if !s.NoReflect {
+		file.Var().Id("_").Qual("fmt", "Formatter").Op("=").Op("&").Id(s.typeName).Values()
+		file.Var().Id("_").Qual("fmt", "Stringer").Op("=").Op("&").Id(s.typeName).Values()
+		file.Var().Id("_").Qual("log/slog", "LogValuer").Op("=").Op("&").Id(s.typeName).Values()
+	}
+	if len(s.deprecatedParams()) > 0 {
+		file.Var().Id("_").Id("DecodeWarner").Op("=").Op("&").Id(s.typeName).Values()
+	}
+
+	if !s.sharedRegistration {
+		file.Func().Id("init").Params().Block(
+			s.generateRegistration(),
+		)
+	}
 
+	addComment(file, s.typeComment())
 	file.Type().Id(s.typeName).StructFunc(s.generateStructFields)
 
-	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+	file.Comment("Positional returns the (escaped) positional params. The command is not a")
+	file.Comment("positional param, it is only emitted and consumed by MarshalADC and")
+	file.Comment("UnmarshalADC.")
+	file.Func().Params(s.receiver()).
 		Id("Positional").Params().Index().String().
-		BlockFunc(s.generatePositional)
+		Block(
+			jen.Return(jen.Id(s.typeLetter).Dot("AppendPositional").Call(jen.Nil())),
+		)
 
 	file.Line()
 
-	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+	file.Comment("AppendPositional appends the (escaped) positional params to dst and")
+	file.Comment("returns the extended slice.")
+	file.Func().Params(s.receiver()).
+		Id("AppendPositional").Params(jen.Id("dst").Index().String()).Index().String().
+		BlockFunc(s.generateAppendPositional)
+
+	file.Line()
+
+	file.Comment("PosLen returns the number of positional params, excluding the command.")
+	file.Func().Params(s.receiver()).
 		Id("PosLen").Params().Int().
 		BlockFunc(s.generatePosLen)
 
 	file.Line()
 
-	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+	file.Comment("PosAt returns the (escaped) positional param at index i, i.e. PosAt(0) is")
+	file.Comment("the first param following the command.")
+	file.Func().Params(s.receiver()).
 		Id("PosAt").Params(jen.Id("i").Int()).String().
 		BlockFunc(s.generatePosAt)
 
 	file.Line()
 
-	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
-		Id("Named").Params().Map(jen.String()).String().
-		BlockFunc(s.generateNamed)
+	// Without named params, Named, NamedGet and NamedAll of the embedded
+	// ContentBase are sufficient.
+	if len(s.namedParams) > 0 {
+		file.Comment("Named returns the (escaped) values of the named params set in the content,")
+		file.Comment("keyed by flag name, including the flags not mapped to a param. Modifying the")
+		file.Comment("returned map does not affect the content.")
+		file.Func().Params(s.receiver()).
+			Id("Named").Params().Map(jen.String()).String().
+			BlockFunc(s.generateNamed)
 
-	file.Line()
+		file.Line()
 
-	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
-		Id("NamedGet").Params(jen.Id("key").String()).Params(jen.String(), jen.Bool()).
-		BlockFunc(s.generateNamedGet)
+		file.Comment("NamedGet returns the (escaped) value of the named param key and whether it")
+		file.Comment("is set. The first value of multi-valued params is returned.")
+		file.Func().Params(s.receiver()).
+			Id("NamedGet").Params(jen.Id("key").String()).Params(jen.String(), jen.Bool()).
+			BlockFunc(s.generateNamedGet)
 
-	return file
-}
+		file.Line()
 
-func (s *StructGenerator) generateFlagConstants(group *jen.Group) {
-	for ind, param := range s.namedParams {
-		ctx := s.createContext(param)
+		file.Comment("NamedAll returns the (escaped) values of the named param key, one value per")
+		file.Comment("occurrence of the flag. nil is returned if the param is not present.")
+		file.Func().Params(s.receiver()).
+			Id("NamedAll").Params(jen.Id("key").String()).Index().String().
+			BlockFunc(s.generateNamedAll)
 
-		name := param.Mapper.Parser.Named.ParamName(&ctx)
+		file.Line()
 
-		if ind == 0 {
-			group.Id(s.flagTypeName + name).Id(s.flagTypeName).Op("=").Lit(name)
-		} else {
-			group.Id(s.flagTypeName + name).Op("=").Lit(name)
-		}
+		s.generateTypedGetters(file)
 	}
-}
 
-func (s *StructGenerator) generateStructFields(group *jen.Group) {
-	s.generateParamsStructFields(group, s.positionalParams)
-	s.generateParamsStructFields(group, s.namedParams)
+	file.Comment("PosByName returns the (escaped) value of the positional param name and")
+	file.Comment("whether it is present. The first value of multi-valued params is returned.")
+	file.Func().Params(s.receiver()).
+		Id("PosByName").Params(jen.Id("name").String()).Params(jen.String(), jen.Bool()).
+		BlockFunc(s.generatePosByName)
 
-	group.Id("Flags").Map(jen.String()).String()
+	file.Line()
 
-	group.Line()
+	if s.hasFixedLayout() {
+		file.Comment("PositionalValues returns the positional params as a tuple of their values.")
+		file.Func().Params(s.receiver()).
+			Id("PositionalValues").Params().ParamsFunc(func(group *jen.Group) {
+			for _, param := range s.positionalParams {
+				if !isConstParam(param) {
+					group.Add(param.FieldInfo.FieldType)
+				}
+			}
+		}).
+			Block(
+				jen.ReturnFunc(func(group *jen.Group) {
+					for _, param := range s.positionalParams {
+						if !isConstParam(param) {
+							group.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+						}
+					}
+				}),
+			)
 
-	if len(s.message.Flags) == 0 {
-		group.Comment("No known additional flags.")
-	} else {
-		for _, flag := range s.message.Flags {
-			group.Comment(flag.Comment)
-		}
+		file.Line()
 	}
-}
 
-func (s *StructGenerator) generateParamsStructFields(group *jen.Group, params []paramInfo) {
-	for _, param := range params {
-		info := param.FieldInfo
+	file.Comment("ParseInto replaces the content by params, the (escaped) tokens of the")
+	file.Comment("message following the command. Both the fields and the escaped values are")
+	file.Comment("set. Flags not mapped to a param are stored in Flags. opts configures the")
+	file.Comment("handling of deviations from the definition, nil selects strict parsing.")
+	file.Comment("Required params with values AppendADC considers missing, such as empty")
+	file.Comment("strings, are rejected with ErrMissingParam.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("ParseInto").Params(
+		jen.Id("params").Index().String(),
+		jen.Id("opts").Op("*").Id("ParseOptions"),
+	).Error().
+		BlockFunc(s.generateParseInto)
 
-		// TODO: Format and insert comment
+	file.Line()
 
-		group.Add(info.FieldName).Add(info.FieldType)
+	file.Commentf("%sFromAccessor returns the content held by pa, e.g. a RawContent of", s.typeName)
+	file.Comment("the command. The params of pa are parsed and checked as by ParseInto.")
+	file.Func().Id(s.typeName+"FromAccessor").Params(jen.Id("pa").Id("ParamAccessor")).
+		Params(jen.Op("*").Id(s.typeName), jen.Error()).
+		Block(
+			jen.Var().Id(s.typeLetter).Id(s.typeName),
+			jen.If(
+				jen.Err().Op(":=").Id(s.typeLetter).Dot("ParseInto").Call(jen.Id("accessorParams").Call(jen.Id("pa")), jen.Nil()),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Return(jen.Op("&").Id(s.typeLetter), jen.Nil()),
+		)
 
-		strFieldType := jen.String()
-		if !info.StrIsSingular {
-			strFieldType = jen.Index().Add(strFieldType)
-		}
-		group.Add(info.StrFieldName).Add(strFieldType)
+	file.Line()
+
+	file.Comment("Decode parses the (escaped) positional params and the (escaped) named")
+	file.Comment("params, keyed by flag name, into the content. Both the fields and the")
+	file.Comment("escaped values are set and checked as by ParseInto.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("Decode").Params(
+		jen.Id("positional").Index().String(),
+		jen.Id("named").Map(jen.String()).String(),
+	).Error().
+		Block(
+			jen.List(jen.Id("params"), jen.Err()).Op(":=").Id("joinParams").Call(jen.Id("positional"), jen.Id("named")),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(s.wrapError("decoding message "+s.message.Command, jen.Err())),
+			),
+			jen.Line(),
+			jen.Return(jen.Id(s.typeLetter).Dot("ParseInto").Call(jen.Id("params"), jen.Nil())),
+		)
+
+	file.Line()
+
+	file.Comment("ParseTokens parses tokens, the (escaped) tokens of the message starting")
+	file.Comment("with the command, e.g. as split by an upstream framer.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("ParseTokens").Params(jen.Id("tokens").Index().String()).Error().
+		BlockFunc(s.generateParseTokens)
+
+	file.Line()
+
+	file.Comment("UnmarshalADC parses line, the message as returned by MarshalADC.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("UnmarshalADC").Params(jen.Id("line").Index().Byte()).Error().
+		BlockFunc(s.generateUnmarshalADC)
+
+	file.Line()
+
+	file.Comment("ParseADCInto parses the first message of data, which is terminated by a")
+	file.Comment("newline, and returns the number of bytes consumed including the terminator.")
+	file.Comment("ErrIncomplete is returned if data does not hold a complete message. The")
+	file.Comment("message is consumed even if parsing fails.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("ParseADCInto").Params(jen.Id("data").Index().Byte()).Params(jen.Int(), jen.Error()).
+		Block(
+			jen.Id("end").Op(":=").Qual("bytes", "IndexByte").Call(jen.Id("data"), jen.LitRune('\n')),
+			jen.If(jen.Id("end").Op("<").Lit(0)).Block(
+				jen.Return(jen.Lit(0), jen.Id("ErrIncomplete")),
+			),
+			jen.Line(),
+			jen.Return(
+				jen.Id("end").Op("+").Lit(1),
+				jen.Id(s.typeLetter).Dot("UnmarshalADC").Call(jen.Id("data").Index(jen.Empty(), jen.Id("end").Op("+").Lit(1))),
+			),
+		)
+
+	file.Line()
+
+	file.Comment("SetNamedAll replaces all named params by the (escaped) values of named,")
+	file.Comment("keyed by flag name. Flags not mapped to a param are stored in Flags. The")
+	file.Comment("content is not modified if an error is returned.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("SetNamedAll").Params(jen.Id("named").Map(jen.String()).String()).Error().
+		Block(
+			jen.Id("decoded").Op(":=").Op("*").Id(s.typeLetter),
+			jen.If(
+				jen.Err().Op(":=").Id("decoded").Dot("setNamedAll").Call(jen.Id("named")),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Err()),
+			),
+			jen.Line(),
+			jen.Op("*").Id(s.typeLetter).Op("=").Id("decoded"),
+			jen.Return(jen.Nil()),
+		)
+
+	file.Line()
+
+	file.Comment("setNamedAll implements SetNamedAll, the content is modified even if an")
+	file.Comment("error is returned.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("setNamedAll").Params(jen.Id("named").Map(jen.String()).String()).Error().
+		BlockFunc(s.generateSetNamedAll)
+
+	file.Line()
+
+	s.generateMapMethods(file)
+
+	if s.rawPassthrough {
+		s.generateMarkDirty(file)
+	}
+
+	file.Comment("AppendADC appends the content in the ADC wire format to buf and returns")
+	file.Comment("the extended buffer. The command is followed by the (escaped) positional")
+	file.Comment("and named params and terminated by a newline. The values are encoded from")
+	file.Comment("the fields, as are those returned by the other accessors. An error is")
+	file.Comment("returned if a required param is missing, together with buf truncated to")
+	file.Comment("its original length.")
+	file.Func().Params(s.receiver()).
+		Id("AppendADC").Params(jen.Id("buf").Index().Byte()).Params(jen.Index().Byte(), jen.Error()).
+		BlockFunc(func(group *jen.Group) {
+			s.generateMarshal(group, appendTarget{})
+		})
+
+	file.Line()
+
+	if s.encodeTo {
+		file.Comment("EncodeTo is the unchecked variant of AppendADC. It appends the content in")
+		file.Comment("the ADC wire format to buf and returns the extended buffer, but does not")
+		file.Comment("check for missing required params or mismatching lengths. Incomplete")
+		file.Comment("contents result in malformed lines, so the content must pass Validate.")
+		file.Comment("The values are encoded from the fields as by AppendADC. Numbers, strings")
+		file.Comment("and IP addresses are encoded without allocating if buf has sufficient")
+		file.Comment("capacity, see WireSize.")
+		file.Func().Params(s.receiver()).
+			Id("EncodeTo").Params(jen.Id("buf").Index().Byte()).Index().Byte().
+			BlockFunc(func(group *jen.Group) {
+				s.generateMarshal(group, encodeTarget{})
+			})
+
+		file.Line()
+	}
+
+	file.Comment("ADCString returns the output of MarshalADC as a string, without copying")
+	file.Comment("it. The empty string is returned if MarshalADC fails.")
+	file.Func().Params(s.receiver()).
+		Id("ADCString").Params().String().
+		BlockFunc(func(group *jen.Group) {
+			s.generateMarshal(group, builderTarget{typeLetter: s.typeLetter})
+		})
+
+	file.Line()
+
+	file.Comment("Validate checks the params against the constraints of the message, such as")
+	file.Comment("required params and allowed values. All violations are listed by the")
+	file.Comment("returned ValidationError.")
+	file.Func().Params(s.receiver()).
+		Id("Validate").Params().Error().
+		BlockFunc(s.generateValidate)
+
+	file.Line()
+
+	file.Comment("MissingRequired returns the names of all required params which are not")
+	file.Comment("set.")
+	file.Func().Params(s.receiver()).
+		Id("MissingRequired").Params().Index().String().
+		BlockFunc(s.generateMissingRequired)
+
+	file.Line()
+
+	file.Var().Id(s.descriptorName).Op("=").Id("MessageDescriptor").
+		Values(jen.DictFunc(s.generateDescriptor))
+
+	file.Comment("Descriptor returns the descriptor of the message, which describes its")
+	file.Comment("params.")
+	file.Func().Params(s.receiver()).
+		Id("Descriptor").Params().Id("MessageDescriptor").
+		Block(
+			jen.Return(jen.Id(s.descriptorName)),
+		)
+
+	file.Line()
+
+	s.generateCommandMethods(file)
+
+	if len(s.message.Types) == 1 {
+		file.Comment("MsgType returns the message type, the only type the message is valid in.")
+	} else {
+		file.Comment("MsgType returns the message type of the frame the content has been parsed")
+		file.Comment("from, or 0 if the content has not been parsed from a frame.")
+	}
+	file.Func().Params(s.receiver()).
+		Id("MsgType").Params().Byte().
+		BlockFunc(func(group *jen.Group) {
+			if len(s.message.Types) == 1 {
+				group.Return(jen.LitRune(rune(s.message.Types[0])))
+			} else {
+				group.Return(jen.Id(s.typeLetter).Dot("msgType"))
+			}
+		})
+
+	file.Line()
+
+	file.Comment("MarshalADC returns the content in the ADC wire format, see AppendADC.")
+	file.Func().Params(s.receiver()).
+		Id("MarshalADC").Params().Params(jen.Index().Byte(), jen.Error()).
+		Block(
+			jen.Return(jen.Id(s.typeLetter).Dot("AppendADC").Call(jen.Nil())),
+		)
+
+	file.Line()
+
+	file.Comment("WireSize returns the number of bytes of the output of MarshalADC, without")
+	file.Comment("marshalling the content. Missing required params are not detected.")
+	file.Func().Params(s.receiver()).
+		Id("WireSize").Params().Int().
+		BlockFunc(s.generateWireSize)
+
+	file.Line()
+
+	file.Comment("WriteTo writes the content in the ADC wire format to w, see AppendADC.")
+	file.Func().Params(s.receiver()).
+		Id("WriteTo").Params(jen.Id("w").Qual("io", "Writer")).Params(jen.Int64(), jen.Error()).
+		Block(
+			jen.List(jen.Id("buf"), jen.Err()).Op(":=").Id(s.typeLetter).Dot("AppendADC").Call(jen.Nil()),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Lit(0), jen.Err()),
+			),
+			jen.Line(),
+			jen.List(jen.Id("n"), jen.Err()).Op(":=").Id("w").Dot("Write").Call(jen.Id("buf")),
+			jen.Return(jen.Int64().Parens(jen.Id("n")), jen.Err()),
+		)
+
+	file.Line()
+
+	file.Comment("Equal returns true if the content and other have the same (escaped)")
+	file.Comment("positional and named params, including the flags not mapped to a param.")
+	file.Func().Params(s.receiver()).
+		Id("Equal").Params(jen.Id("other").Op("*").Id(s.typeName)).Bool().
+		Block(
+			jen.Return(jen.Add(s.equalCommand()).Id("equalParams").Call(jen.Id(s.typeLetter), jen.Id("other"))),
+		)
+
+	file.Line()
+
+	file.Comment("EqualIgnoring returns true if the content and other are equal according to")
+	file.Comment("Equal, apart from the params and unknown flags named by ignore. Names")
+	file.Comment("neither naming a param nor a flag are ignored.")
+	file.Func().Params(s.receiver()).
+		Id("EqualIgnoring").Params(jen.Id("other").Op("*").Id(s.typeName), jen.Id("ignore").Op("...").String()).Bool().
+		BlockFunc(s.generateEqualIgnoring)
+
+	file.Line()
+
+	file.Comment("HashKey returns a canonical key of the content, e.g. for deduplicating")
+	file.Comment("messages in a map. Contents equal according to Equal share the same key.")
+	file.Func().Params(s.receiver()).
+		Id("HashKey").Params().String().
+		Block(
+			jen.Return(jen.Id("hashKey").Call(s.receiverPtr())),
+		)
+
+	file.Line()
+
+	file.Comment("EqualBytes returns true if the content equals line, a message as returned")
+	file.Comment("by MarshalADC. mode selects whether line is parsed and compared by Equal or")
+	file.Comment("compared to the output of MarshalADC byte by byte.")
+	file.Func().Params(s.receiver()).
+		Id("EqualBytes").Params(jen.Id("line").Index().Byte(), jen.Id("mode").Id("EqualMode")).
+		Params(jen.Bool(), jen.Error()).
+		Block(
+			jen.Return(jen.Id("equalBytes").Call(
+				s.receiverPtr(),
+				jen.Id("line"),
+				jen.Id("mode"),
+				jen.Func().Params(jen.Id("params").Index().String()).
+					Params(jen.Id("ParamAccessor"), jen.Error()).
+					Block(
+						jen.Var().Id("other").Id(s.typeName),
+						jen.Err().Op(":=").Id("other").Dot("ParseInto").Call(jen.Id("params"), jen.Nil()),
+						jen.Return(jen.Op("&").Id("other"), jen.Err()),
+					),
+			)),
+		)
+
+	file.Line()
+
+	file.Comment("Redacted returns a copy of the content with the values of sensitive params")
+	file.Comment("masked, e.g. for logging. Strings are replaced by \"***\", other values by their")
+	file.Comment("zero value. The copy does not share memory with the content.")
+	file.Func().Params(s.receiver()).
+		Id("Redacted").Params().Op("*").Id(s.typeName).
+		BlockFunc(s.generateRedacted)
+
+	file.Line()
+
+	if s.clone {
+		file.Comment("Clone returns a deep copy of the content, which does not share memory with")
+		file.Comment("the content.")
+		file.Func().Params(s.receiver()).
+			Id("Clone").Params().Op("*").Id(s.typeName).
+			BlockFunc(s.generateClone)
+
+		file.Line()
+	}
+
+	if !s.NoReflect {
+		s.generateDisplayMethods(file)
+	}
+
+	if len(s.deprecatedParams()) > 0 {
+		file.Comment("DecodeWarnings returns a warning for each deprecated param present in the")
+		file.Comment("content. Contents decoded from messages of legacy peers hold deprecated")
+		file.Comment("params.")
+		file.Func().Params(s.receiver()).
+			Id("DecodeWarnings").Params().Index().String().
+			BlockFunc(s.generateDecodeWarnings)
+
+		file.Line()
+	}
+
+	if s.hasCacheKey() {
+		file.Comment("CacheKey returns the key identifying the message in caches, composed of")
+		file.Comment("the (escaped) tokens of the cache key parts as written by AppendADC.")
+		file.Func().Params(s.receiver()).
+			Id("CacheKey").Params().String().
+			BlockFunc(s.generateCacheKey)
+
+		file.Line()
+	}
+
+	file.Comment("SetOptionalCount returns the number of optional params which are set.")
+	file.Func().Params(s.receiver()).
+		Id("SetOptionalCount").Params().Int().
+		BlockFunc(s.generateSetOptionalCount)
+
+	file.Line()
+
+	if !s.NoReflect && s.hasSensitive() {
+		file.Comment("marshalRedacted marshals the content as AppendADC does into a new buffer,")
+		file.Comment("masking the values of sensitive params.")
+		file.Func().Params(s.receiver()).
+			Id("marshalRedacted").Params().Params(jen.Index().Byte(), jen.Error()).
+			BlockFunc(func(group *jen.Group) {
+				group.Id("buf").Op(":=").Make(jen.Index().Byte(), jen.Lit(0), jen.Id(s.typeLetter).Dot("WireSize").Call())
+				s.generateMarshal(group, redactTarget{})
+			})
+
+		file.Line()
+	}
+
+	if !s.NoReflect {
+		file.Comment("Format implements fmt.Formatter. %s and %v write the wire form, %q the quoted")
+		file.Comment("wire form, %x and %X its hex encoding and %+v the params labelled by their")
+		file.Comment("names. Sensitive params are masked.")
+		file.Func().Params(s.receiver()).
+			Id("Format").Params(jen.Id("f").Qual("fmt", "State"), jen.Id("verb").Rune()).
+			BlockFunc(s.generateFormat)
+
+		file.Line()
+	}
+
+	s.generateBuilder(file)
+}
+
+// generateDisplayMethods generates the LogValue, String and Labels methods,
+// which format the values of the params using slog.Any and fmt verbs, i.e.
+// depend on reflection.
+func (s *StructGenerator) generateDisplayMethods(file *jen.File) {
+	file.Comment("LogValue implements slog.LogValuer. The value is a group of the command and")
+	file.Comment("the params, omitting unset optional params. Sensitive params are masked.")
+	file.Func().Params(s.receiver()).
+		Id("LogValue").Params().Qual("log/slog", "Value").
+		BlockFunc(s.generateLogValue)
+
+	file.Line()
+
+	file.Comment("String returns a human-readable representation of the content, consisting of")
+	file.Comment("the command and the params labelled by their names, e.g. for debugging.")
+	file.Comment("Unset optional params are omitted and sensitive params are masked.")
+	file.Func().Params(s.receiver()).
+		Id("String").Params().String().
+		BlockFunc(s.generateString)
+
+	file.Line()
+
+	file.Comment("Labels returns the values of the params keyed by their display names, e.g.")
+	file.Comment("for labelling metrics. Unset optional params and sensitive params are")
+	file.Comment("omitted.")
+	file.Func().Params(s.receiver()).
+		Id("Labels").Params().Map(jen.String()).String().
+		BlockFunc(s.generateLabels)
+
+	file.Line()
+}
+
+// generateSetOptionalCount generates the body of the SetOptionalCount
+// method, counting the Maybe fields of all params which are set.
+func (s *StructGenerator) generateSetOptionalCount(group *jen.Group) {
+	var maybeParams []paramInfo
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.FieldInfo.FieldIsMaybe {
+				maybeParams = append(maybeParams, param)
+			}
+		}
+	}
+
+	if len(maybeParams) == 0 {
+		group.Return(jen.Lit(0))
+		return
+	}
+
+	group.Var().Id("n").Int()
+	for _, param := range maybeParams {
+		group.If(s.optionalWrapper().isSet(jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName))).Block(
+			jen.Id("n").Op("++"),
+		)
+	}
+
+	group.Return(jen.Id("n"))
+}
+
+// generateFormat generates the body of the Format method. The field-labeled
+// dump for %+v is generated, all other verbs are handled by formatContent.
+// Sensitive params are masked in both, the latter formats the output of
+// marshalRedacted.
+func (s *StructGenerator) generateFormat(group *jen.Group) {
+	format := s.typeName + "{"
+	var args []jen.Code
+	sensitive := false
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			if param.Param.Sensitive {
+				format += param.Param.Name + ":" + redactedValue + " "
+				sensitive = true
+				continue
+			}
+
+			format += param.Param.Name + ":%v "
+			args = append(args, jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName))
+		}
+	}
+	format += "Flags:%v}"
+	args = append(args, jen.Id(s.typeLetter).Dot("Flags"))
+
+	group.If(jen.Id("verb").Op("==").LitRune('v').Op("&&").Id("f").Dot("Flag").Call(jen.LitRune('+'))).Block(
+		jen.Qual("fmt", "Fprintf").Call(
+			append([]jen.Code{jen.Id("f"), jen.Lit(format)}, args...)...,
+		),
+		jen.Return(),
+	)
+
+	group.Line()
+
+	if sensitive {
+		group.Id("formatContent").Call(jen.Id("f"), jen.Id("verb"), jen.Id(s.typeLetter).Dot("marshalRedacted"))
+		return
+	}
+
+	group.Id("formatContent").Call(jen.Id("f"), jen.Id("verb"), jen.Id(s.typeLetter).Dot("MarshalADC"))
+}
+
+// hasSensitive returns true if the message has sensitive params.
+func (s *StructGenerator) hasSensitive() bool {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.Param.Sensitive && !isConstParam(param) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// receiver returns the receiver of methods not modifying the content.
+func (s *StructGenerator) receiver() jen.Code {
+	if s.valueReceivers {
+		return jen.Id(s.typeLetter).Id(s.typeName)
+	}
+
+	return jen.Id(s.typeLetter).Op("*").Id(s.typeName)
+}
+
+// receiverPtr returns code evaluating to a pointer to the content within
+// methods using receiver.
+func (s *StructGenerator) receiverPtr() jen.Code {
+	if s.valueReceivers {
+		return jen.Op("&").Id(s.typeLetter)
+	}
+
+	return jen.Id(s.typeLetter)
+}
+
+// receiverValue returns code evaluating to the content within methods using
+// receiver.
+func (s *StructGenerator) receiverValue() jen.Code {
+	if s.valueReceivers {
+		return jen.Id(s.typeLetter)
+	}
+
+	return jen.Op("*").Id(s.typeLetter)
+}
+
+// generateRegistration generates the call registering the content type for
+// the command of the message.
+func (s *StructGenerator) generateRegistration() jen.Code {
+	return jen.Id("registerContent").Call(
+		jen.Lit(s.message.Command),
+		jen.Func().Params().Id("content").Block(
+			jen.Return(jen.Op("&").Id(s.typeName).Values()),
+		),
+	)
+}
+
+func (s *StructGenerator) generateFlagConstants(group *jen.Group) {
+	for _, param := range s.namedParams {
+		ctx := s.createContext(param)
+
+		name := param.Mapper.Parser.Named.ParamName(&ctx)
+
+		s.addParamComment(group, param, param.FlagConstName)
+		s.addDeprecatedComment(group, param)
+		group.Id(param.FlagConstName).Id(s.flagTypeName).Op("=").Lit(name)
+	}
+}
+
+func (s *StructGenerator) generateFlagString(group *jen.Group) {
+	if len(s.namedParams) > 0 {
+		group.Switch(jen.Id("f")).BlockFunc(func(group *jen.Group) {
+			for _, param := range s.namedParams {
+				group.Case(jen.Id(param.FlagConstName)).Block(
+					jen.Return(jen.Lit(param.FlagConstName)),
+				)
+			}
+		})
+	}
+
+	group.Return(jen.Lit(s.flagTypeName + "(").Op("+").String().Call(jen.Id("f")).Op("+").Lit(")"))
+}
+
+func (s *StructGenerator) generateFlagIsKnown(group *jen.Group) {
+	if len(s.namedParams) > 0 {
+		group.Switch(jen.Id("f")).Block(
+			jen.CaseFunc(func(group *jen.Group) {
+				for _, param := range s.namedParams {
+					group.Id(param.FlagConstName)
+				}
+			}).Block(
+				jen.Return(jen.True()),
+			),
+		)
+	}
+
+	group.Return(jen.False())
+}
+
+func (s *StructGenerator) generateStructFields(group *jen.Group) {
+	s.generateParamsStructFields(group, s.positionalParams)
+	s.generateParamsStructFields(group, s.namedParams)
+
+	group.Id("ContentBase")
+
+	if s.isFamily() {
+		group.Line()
+		group.Comment("command is the concrete command of the family.")
+		group.Id("command").String()
+	}
+
+	if s.rawPassthrough {
+		s.generateRawFields(group)
+	}
+
+	group.Line()
+
+	group.Comment("Truncated is set if ParseInto truncated a value exceeding the")
+	group.Comment("MaxValueLength of the ParseOptions.")
+	group.Id("Truncated").Bool()
+	group.Comment("Compressed is set by ParseInto if the Compressed option of the")
+	group.Comment("ParseOptions is set. It is not part of the marshalled content.")
+	group.Id("Compressed").Bool()
+
+	group.Line()
+
+	if len(s.message.Flags) == 0 {
+		group.Comment("No known additional flags.")
+	} else {
+		for _, flag := range s.message.Flags {
+			addComment(group, flag.Comment)
+		}
+	}
+}
+
+func (s *StructGenerator) generateParamsStructFields(group *jen.Group, params []paramInfo) {
+	for _, param := range params {
+		if isConstParam(param) {
+			continue
+		}
+
+		info := param.FieldInfo
+
+		s.addParamComment(group, param, param.Param.Name)
+		for _, comment := range s.conditionComments(param) {
+			addComment(group, comment)
+		}
+		s.addDeprecatedComment(group, param)
+		field := group.Add(info.FieldName).Add(info.FieldType)
+		if s.structTags {
+			field.Tag(structTags(param))
+		}
+
+		strFieldType := jen.String()
+		if !info.StrIsSingular {
+			strFieldType = jen.Index().Add(strFieldType)
+		}
+		group.Add(info.StrFieldName).Add(strFieldType)
 
 		group.Line()
 	}
 }
 
-func (s *StructGenerator) generatePositional(group *jen.Group) {
+// structTags returns the struct tags of the field of the param. The adc tag
+// holds the flag name of named params and the name of positional params,
+// followed by ",positional". The json tag holds the JSON name of the param,
+// optional params are omitted if empty.
+func structTags(param paramInfo) map[string]string {
+	adc := flagNameFromParam(param.Param)
+	if param.Param.Mode == ParamModePositional {
+		adc = param.Param.Name + ",positional"
+	}
+
+	json := jsonNameFromParam(param.Param)
+	if !param.Param.Required {
+		json += ",omitempty"
+	}
+
+	return map[string]string{"adc": adc, "json": json}
+}
+
+// addDeprecatedComment adds a "Deprecated:" comment to group if the param is
+// deprecated.
+func (s *StructGenerator) addDeprecatedComment(group *jen.Group, param paramInfo) {
+	if len(param.Param.Deprecated) > 0 {
+		group.Comment("Deprecated: " + param.Param.Deprecated)
+	}
+}
+
+func (s *StructGenerator) generateAppendPositional(group *jen.Group) {
+	if s.hasGates() {
+		s.generateAppendPositionalGated(group)
+		return
+	}
+
 	var numStatic int
+	var hasSliceAppend bool
 
 	for _, param := range s.positionalParams {
 		if param.FieldInfo.Multiplicity == MultiplicityStatic {
 			numStatic++
 		}
+		if isSliceAppended(param) {
+			hasSliceAppend = true
+		}
 	}
 
-	if numStatic == len(s.positionalParams) {
-		// All params have static multiplicity, build slice literal.
+	if len(s.positionalParams) == 0 {
+		group.Return(jen.Id("dst"))
+	} else if numStatic == len(s.positionalParams) && !hasSliceAppend {
+		// All params have static multiplicity, append all values at once.
 
 		group.Return(
-			jen.Index().String().ValuesFunc(func(group *jen.Group) {
+			jen.AppendFunc(func(group *jen.Group) {
+				group.Id("dst")
 				for _, param := range s.positionalParams {
 					if param.FieldInfo.StrIsSingular {
-						group.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+						group.Add(s.singularStrValue(param))
 					} else {
 						for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
 							group.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
@@ -226,360 +1418,1089 @@ func (s *StructGenerator) generatePositional(group *jen.Group) {
 			}),
 		)
 	} else if numStatic == 0 && len(s.positionalParams) == 1 {
-		// There is only a single, dynamic multiplicity param, return its str
-		// field.
+		// There is only a single, dynamic multiplicity param, append its
+		// values.
 
-		group.Return(
-			jen.Id(s.typeLetter).Dot("").Add(s.positionalParams[0].FieldInfo.StrFieldName),
-		)
-	} else {
-		// Params have mixed multiplicity, build slice of positionals
-		// manually.
+		s.generateAppendDynamic(group, s.positionalParams[0])
 
-		group.Id("positionals").Op(":=").Make(
-			jen.Index().String(),
-			jen.Lit(0),
-			jen.Id(s.typeLetter).Dot("PosLen").Call(),
-		)
+		group.Return(jen.Id("dst"))
+	} else {
+		// Params have mixed multiplicity or large static multiplicity,
+		// append values param by param.
 
 		for _, param := range s.positionalParams {
 			if param.FieldInfo.StrIsSingular {
-				group.Id("positionals").Op("=").Append(
-					jen.Id("positionals"),
-					jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName),
+				group.Id("dst").Op("=").Append(
+					jen.Id("dst"),
+					s.singularStrValue(param),
+				)
+			} else if isSliceAppended(param) {
+				group.Id("dst").Op("=").Append(
+					jen.Id("dst"),
+					jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
+						Index(jen.Empty(), jen.Lit(param.FieldInfo.StaticMultiplicity)).Op("..."),
 				)
 			} else if param.FieldInfo.Multiplicity == MultiplicityStatic {
-				group.Id("positionals").Op("=").AppendFunc(func(group *jen.Group) {
-					group.Id("positionals")
+				group.Id("dst").Op("=").AppendFunc(func(group *jen.Group) {
+					group.Id("dst")
 					for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
 						group.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
 							Index(jen.Lit(i))
 					}
 				})
-			} else {
-				group.Id("positionals").Op("=").Append(
-					jen.Id("positionals"),
-					jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).Op("..."),
-				)
+			} else {
+				s.generateAppendDynamic(group, param)
+			}
+		}
+
+		group.Return(jen.Id("dst"))
+	}
+}
+
+// generateAppendDynamic generates code appending the escaped values of the
+// dynamic multiplicity param to dst. The str field is appended at once if the
+// values are taken from it.
+func (s *StructGenerator) generateAppendDynamic(group *jen.Group, param paramInfo) {
+	if !encodesFromField(param) {
+		group.Id("dst").Op("=").Append(jen.Id("dst"), s.strStmt(param).Op("..."))
+		return
+	}
+
+	group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(s.fieldStmt(param))).Block(
+		jen.Id("dst").Op("=").Append(jen.Id("dst"), s.elemStrValue(param, jen.Id("val"))),
+	)
+}
+
+// sliceAppendMultiplicity is the static multiplicity from which the values of
+// a multi-valued param are appended as a slice instead of element by element.
+// Appending a slice outperforms passing the elements as individual arguments
+// for larger multiplicities, see BenchmarkAppendStaticValues.
+const sliceAppendMultiplicity = 8
+
+// isSliceAppended returns true if the values of the param are appended as a
+// slice of its str field by AppendPositional.
+// hasFixedLayout returns true if the message has positional params with
+// fields, all of which are static, singular and not gated.
+func (s *StructGenerator) hasFixedLayout() bool {
+	numFields := 0
+	for _, param := range s.positionalParams {
+		if isConstParam(param) {
+			continue
+		}
+		if param.FieldInfo.Multiplicity != MultiplicityStatic || !param.FieldInfo.StrIsSingular ||
+			param.FieldInfo.FieldIsMaybe || param.Gate != nil {
+			return false
+		}
+		numFields++
+	}
+
+	return numFields > 0
+}
+
+func isSliceAppended(param paramInfo) bool {
+	return !param.FieldInfo.StrIsSingular &&
+		param.FieldInfo.Multiplicity == MultiplicityStatic &&
+		param.FieldInfo.StaticMultiplicity >= sliceAppendMultiplicity
+}
+
+func (s *StructGenerator) generatePosLen(group *jen.Group) {
+	if s.hasGates() {
+		s.generatePosLenGated(group)
+		return
+	}
+
+	var staticSum int
+	var stmt jen.Statement
+
+	for _, param := range s.positionalParams {
+		if param.FieldInfo.Multiplicity == MultiplicityStatic {
+			staticSum += param.FieldInfo.StaticMultiplicity
+		} else {
+			stmt.Op("+").Len(s.paramValues(param))
+		}
+	}
+
+	if staticSum > 0 || len(stmt) == 0 {
+		group.Return(jen.Lit(staticSum).Add(&stmt))
+	} else {
+		// Remove first Op("+")
+		stmt = stmt[1:]
+		group.Return(&stmt)
+	}
+}
+
+// posAtPanic returns the panic of PosAt for the out of range index i, naming
+// the command, the index and the valid range.
+func (s *StructGenerator) posAtPanic() jen.Code {
+	return jen.Panic(jen.Qual("fmt", "Sprintf").Call(
+		jen.Lit(s.message.Command+".PosAt: index %d out of range [0,%d)"),
+		jen.Id("i"),
+		jen.Id(s.typeLetter).Dot("PosLen").Call(),
+	))
+}
+
+func (s *StructGenerator) generatePosAt(group *jen.Group) {
+	if s.hasGates() {
+		s.generatePosAtGated(group)
+		return
+	}
+
+	var numStatic int
+
+	if len(s.positionalParams) == 0 {
+		group.Add(s.posAtPanic())
+		return
+	}
+
+	for _, param := range s.positionalParams {
+		if param.FieldInfo.Multiplicity == MultiplicityStatic {
+			numStatic++
+		}
+	}
+
+	if numStatic == len(s.positionalParams) {
+		// All params have static multiplicity, build switch statement.
+
+		var runningIndex int
+
+		group.Switch(jen.Id("i")).BlockFunc(func(group *jen.Group) {
+			for _, param := range s.positionalParams {
+				if param.FieldInfo.StrIsSingular {
+					group.Case(jen.Lit(runningIndex)).Block(
+						jen.Return(s.singularStrValue(param)),
+					)
+					runningIndex++
+				} else {
+					for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
+						group.Case(jen.Lit(runningIndex)).Block(
+							jen.Return(
+								jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
+									Index(jen.Lit(i)),
+							),
+						)
+						runningIndex++
+					}
+				}
+			}
+
+			group.Default().Block(
+				s.posAtPanic(),
+			)
+		})
+	} else if numStatic == 0 && len(s.positionalParams) == 1 {
+		// There is only a single, dynamic multiplicity param, return its
+		// ith value.
+
+		param := s.positionalParams[0]
+		values := s.paramValues(param)
+
+		group.If(
+			jen.Id("i").Op("<").Lit(0).Op("||").Id("i").Op(">=").Len(values),
+		).Block(
+			s.posAtPanic(),
+		)
+
+		group.Line()
+
+		group.Return(
+			s.elemStrValue(param, jen.Add(values).Index(jen.Id("i"))),
+		)
+	} else {
+		// Params have mixed multiplicity, build conditional switch statement
+		// manually.
+
+		var runningStaticIndex int
+		var runningDynamicLens []jen.Code
+
+		runningLenStmt := func(op string, dynamics ...jen.Code) *jen.Statement {
+			var stmt jen.Statement
+
+			if runningStaticIndex > 0 || len(runningDynamicLens) == 0 {
+				stmt.Lit(runningStaticIndex).Op(op)
+			}
+
+			for _, dynamic := range runningDynamicLens {
+				stmt.Add(dynamic).Op(op)
+			}
+
+			for _, dynamic := range dynamics {
+				stmt.Add(dynamic).Op(op)
+			}
+
+			// Remove last Op(op)
+			stmt = stmt[:len(stmt)-1]
+
+			return &stmt
+		}
+
+		group.Switch().BlockFunc(func(group *jen.Group) {
+			group.Case(jen.Id("i").Op("<").Lit(0)).Block(
+				s.posAtPanic(),
+			)
+
+			for _, param := range s.positionalParams {
+				if param.FieldInfo.StrIsSingular {
+					group.Case(
+						jen.Id("i").Op("==").Add(runningLenStmt("+")),
+					).Block(
+						jen.Return(s.singularStrValue(param)),
+					)
+					runningStaticIndex++
+				} else if param.FieldInfo.Multiplicity == MultiplicityStatic {
+					for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
+						group.Case(
+							jen.Id("i").Op("==").Add(runningLenStmt("+")),
+						).Block(
+							jen.Return(
+								jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
+									Index(jen.Lit(i)),
+							),
+						)
+						runningStaticIndex++
+					}
+				} else {
+					dynamicLen := jen.Len(s.paramValues(param))
+
+					group.Case(
+						jen.Id("i").Op("<").Add(runningLenStmt("+", dynamicLen)),
+					).Block(
+						jen.Return(
+							s.elemStrValue(param, s.paramValues(param).
+								// TODO: Omit -0 from [i-0]
+								Index(jen.Id("i").Op("-").Add(runningLenStmt("-")))),
+						),
+					)
+					runningDynamicLens = append(runningDynamicLens, dynamicLen)
+				}
+			}
+
+			group.Default().Block(
+				s.posAtPanic(),
+			)
+		})
+	}
+}
+
+func (s *StructGenerator) generateNamed(group *jen.Group) {
+	group.Id("params").Op(":=").Id(s.typeLetter).Dot("UnknownFlags").Call()
+
+	group.Line()
+
+	for _, param := range s.namedParams {
+		setStmt := jen.Id("params").Index(jen.Lit(s.flagName(param))).Op("=")
+		if param.FieldInfo.StrIsSingular {
+			setStmt.Add(s.singularStrValue(param))
+		} else {
+			// The first value of multi-valued params is returned.
+			setStmt.Add(s.elemStrValue(param, s.paramValues(param).Index(jen.Lit(0))))
+		}
+
+		s.ifPresent(group, param, setStmt)
+	}
+
+	group.Line()
+
+	group.Return(jen.Id("params"))
+}
+
+func (s *StructGenerator) generateNamedGet(group *jen.Group) {
+	// TODO: Should the switch block be wrapped in the follwing if?
+	// group.If(jen.Len(jen.Id("key")).Op("==").Lit(2)).Block()
+
+	if len(s.namedParams) > 0 {
+		group.Switch(jen.Id(s.flagTypeName).Parens(jen.Id("key"))).
+			BlockFunc(func(group *jen.Group) {
+				for _, param := range s.namedParams {
+					group.Case(jen.Id(param.FlagConstName)).BlockFunc(func(group *jen.Group) {
+						s.generateNamedGetCase(group, param)
+					})
+				}
+			})
+
+		group.Line()
+	}
+
+	group.Return(
+		jen.Id(s.typeLetter).Dot("ContentBase").Dot("NamedGet").Call(jen.Id("key")),
+	)
+}
+
+// generateNamedAll generates the body of the NamedAll method. Unlike
+// NamedGet, all values of repeated params are returned.
+func (s *StructGenerator) generateNamedAll(group *jen.Group) {
+	var repeated []paramInfo
+	for _, param := range s.namedParams {
+		if !param.FieldInfo.StrIsSingular {
+			repeated = append(repeated, param)
+		}
+	}
+
+	if len(repeated) > 0 {
+		group.Switch(jen.Id(s.flagTypeName).Parens(jen.Id("key"))).BlockFunc(func(group *jen.Group) {
+			for _, param := range repeated {
+				group.Case(jen.Id(param.FlagConstName)).BlockFunc(func(group *jen.Group) {
+					group.If(jen.Len(s.paramValues(param)).Op("==").Lit(0)).Block(
+						jen.Return(jen.Nil()),
+					)
+					s.generateEncodeValues(group, param, "values")
+					group.Return(jen.Id("values"))
+				})
+			}
+		})
+
+		group.Line()
+	}
+
+	group.If(
+		jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").Id(s.typeLetter).Dot("NamedGet").Call(jen.Id("key")),
+		jen.Id("ok"),
+	).Block(
+		jen.Return(jen.Index().String().Values(jen.Id("val"))),
+	)
+
+	group.Return(jen.Nil())
+}
+
+// generateNamedGetCase generates the case of the NamedGet method returning
+// the value of the named param.
+func (s *StructGenerator) generateNamedGetCase(group *jen.Group, param paramInfo) {
+	if param.FieldInfo.StrIsSingular {
+		s.ifPresent(group, param, jen.Return(s.singularStrValue(param), jen.True()))
+	} else {
+		// The first value of multi-valued params is returned.
+		group.If(jen.Len(s.paramValues(param)).Op(">").Lit(0)).Block(
+			jen.Return(s.elemStrValue(param, s.paramValues(param).Index(jen.Lit(0))), jen.True()),
+		)
+	}
+
+	if !s.alwaysPresent(param) {
+		group.Return(jen.Lit(""), jen.False())
+	}
+}
+
+func (s *StructGenerator) generateParseInto(group *jen.Group) {
+	// numLeading is the number of leading positionals which are never
+	// classified as named params, as their values may match the flag
+	// grammar.
+	var numStatic, numStaticBefore, numLeading int
+	var dynamicParam *paramInfo
+	leading := true
+
+	for i, param := range s.positionalParams {
+		if param.FieldInfo.Multiplicity != MultiplicityStatic || param.Gate != nil ||
+			!mayMatchFlagGrammar(param) {
+			leading = false
+		}
+		if leading {
+			numLeading += param.FieldInfo.StaticMultiplicity
+		}
+
+		if param.FieldInfo.Multiplicity == MultiplicityStatic {
+			numStatic += param.FieldInfo.StaticMultiplicity
+			if dynamicParam == nil {
+				numStaticBefore += param.FieldInfo.StaticMultiplicity
+			}
+		} else if dynamicParam == nil {
+			dynamicParam = &s.positionalParams[i]
+		}
+	}
+
+	numStaticAfter := numStatic - numStaticBefore
+
+	// posIndex returns code evaluating to the index of the ith static
+	// positional before or after the dynamic param.
+	posIndex := func(i int, afterDynamic bool) *jen.Statement {
+		if !afterDynamic {
+			return jen.Lit(i)
+		} else if numStaticAfter == i {
+			return jen.Id("end")
+		} else {
+			return jen.Id("end").Op("-").Lit(numStaticAfter - i)
+		}
+	}
+
+	group.Op("*").Id(s.typeLetter).Op("=").Id(s.typeName).Values()
+	group.Id(s.typeLetter).Dot("Compressed").Op("=").Id("opts").Dot("compressed").Call()
+
+	group.Line()
+
+	group.If(
+		jen.Err().Op(":=").Id("opts").Dot("checkCounts").Call(jen.Id("params")),
+		jen.Err().Op("!=").Nil(),
+	).Block(
+		jen.Return(s.wrapError("parsing message "+s.message.Command, jen.Err())),
+	)
+
+	group.Line()
+
+	for _, param := range s.positionalParams {
+		ctx := s.createRenderingContext(param)
+		s.addNonNil(group, param.Mapper.Parser.Positional.InitialiseField(&ctx))
+	}
+	for _, param := range s.namedParams {
+		ctx := s.createRenderingContext(param)
+		s.addNonNil(group, param.Mapper.Parser.Named.InitialiseField(&ctx))
+	}
+
+	group.Line()
+
+	missingStmt := jen.Return(s.wrapError("parsing message "+s.message.Command, jen.Id("ErrMissingParam")))
+
+	// numPositionals evaluates to the number of positionals expected by
+	// messages without dynamic param. numRequired evaluates to the number of
+	// static positionals, which are filled before tokens are classified as
+	// named params.
+	var numPositionals, numRequired *jen.Statement
+
+	if numStatic > 0 {
+		// plain is the number of the following tokens not matching the flag
+		// grammar. Tokens matching it are positionals as long as the static
+		// positionals could not be filled otherwise.
+		group.Id("plain").Op(":=").Lit(0)
+		group.For(jen.List(jen.Id("_"), jen.Id("param")).Op(":=").Range().Id("params")).Block(
+			jen.If(jen.Op("!").Id("isNamedParam").Call(jen.Id("param"))).Block(
+				jen.Id("plain").Op("++"),
+			),
+		)
+
+		group.Line()
+	}
+
+	var gatedIndices []*jen.Statement
+	if s.hasGates() {
+		// Gates are only set if the ungated positionals are filled by
+		// tokens not matching the flag grammar, otherwise a token matching
+		// the gate is an ungated positional.
+		s.generateGateVars(group, func(param paramInfo) *jen.Statement {
+			gate := param.Gate
+			ctx := s.createContext(*gate)
+			return jen.Id("plain").Op(">=").Lit(s.numUngatedPositionals()).Op("&&").
+				Id("hasNamedParam").Call(
+				jen.Id("params"),
+				jen.Lit(gate.Mapper.Parser.Named.ParamName(&ctx)),
+			)
+		})
+
+		gatedIndices = s.gatedPosIndices()
+
+		lenStmt := jen.Lit(s.numUngatedPositionals())
+		for _, param := range s.positionalParams {
+			if param.Gate != nil {
+				lenStmt.Op("+").Id(s.gateVar(param))
+			}
+		}
+
+		// The tokens of set gates are not positionals.
+		tokensStmt := lenStmt.Clone()
+		for _, param := range s.positionalParams {
+			if param.Gate != nil {
+				tokensStmt.Op("+").Id(s.gateVar(param))
+			}
+		}
+
+		group.If(jen.Len(jen.Id("params")).Op("<").Add(tokensStmt)).Block(missingStmt)
+
+		group.Line()
+
+		numPositionals = lenStmt.Clone()
+		numRequired = lenStmt.Clone()
+	} else if numStatic > 0 {
+		group.If(jen.Len(jen.Id("params")).Op("<").Lit(numStatic)).Block(missingStmt)
+
+		group.Line()
+
+		if dynamicParam == nil {
+			numPositionals = jen.Lit(numStatic)
+		}
+		numRequired = jen.Lit(numStatic)
+	}
+
+	if dynamicParam != nil {
+		// end is the number of positionals, i.e. all params accepted by the
+		// dynamic param's mapper and those needed to fill the static
+		// positionals. Named params may be interleaved.
+		ctx := s.createRenderingContext(*dynamicParam)
+
+		cond := dynamicParam.Mapper.Parser.Positional.DynamicProcessCond(&ctx, jen.Id("param"))
+
+		if numStatic > 0 {
+			// The tokens are classified as by the loop below, see plain.
+			cond = jen.Add(cond).Op("||").Id("end").Op("+").Id("rest").Op("<").Lit(numStatic)
+			if numLeading > 0 {
+				cond = jen.Id("end").Op("<").Lit(numLeading).Op("||").Add(cond)
+			}
+
+			group.Id("rest").Op(":=").Id("plain")
+			group.Id("end").Op(":=").Lit(0)
+			group.For(
+				jen.List(jen.Id("_"), jen.Id("param")).Op(":=").Range().Id("params"),
+			).Block(
+				jen.If(jen.Op("!").Id("isNamedParam").Call(jen.Id("param"))).Block(
+					jen.Id("rest").Op("--"),
+				),
+				jen.If(cond).Block(
+					jen.Id("end").Op("++"),
+				),
+			)
+		} else {
+			group.Id("end").Op(":=").Lit(0)
+			group.For(
+				jen.List(jen.Id("_"), jen.Id("param")).Op(":=").Range().Id("params"),
+			).Block(
+				jen.If(cond).Block(
+					jen.Id("end").Op("++"),
+				),
+			)
+		}
+
+		if numStatic > 0 {
+			group.If(jen.Id("end").Op("<").Lit(numStatic)).Block(missingStmt)
+		}
+
+		group.Line()
+	}
+
+	// posConds evaluate to true if the token at pos is a value of the
+	// positional param.
+	posConds := make([]*jen.Statement, 0, len(s.positionalParams))
+
+	var runningIndex int
+	var afterDynamic bool
+
+	for i, param := range s.positionalParams {
+		var cond *jen.Statement
+		if gatedIndices != nil {
+			cond = jen.Id("pos").Op("==").Add(gatedIndices[i])
+			if param.Gate != nil {
+				cond = jen.Id(s.gateVar(param)).Op("==").Lit(1).Op("&&").Add(cond)
+			} else if !param.FieldInfo.StrIsSingular {
+				cond = jen.Id("pos").Op(">=").Add(gatedIndices[i]).
+					Op("&&").
+					Id("pos").Op("<").Add(gatedIndices[i].Clone()).Op("+").
+					Lit(param.FieldInfo.StaticMultiplicity)
+			}
+		} else if param.FieldInfo.Multiplicity != MultiplicityStatic {
+			if numStaticAfter == 0 {
+				cond = jen.Id("pos").Op("<").Id("end")
+			} else {
+				cond = jen.Id("pos").Op("<").Id("end").Op("-").Lit(numStaticAfter)
+			}
+		} else if param.FieldInfo.StrIsSingular {
+			cond = jen.Id("pos").Op("==").Add(posIndex(runningIndex, afterDynamic))
+		} else {
+			cond = jen.Id("pos").Op(">=").Add(posIndex(runningIndex, afterDynamic)).
+				Op("&&").
+				Id("pos").Op("<").Add(posIndex(runningIndex+param.FieldInfo.StaticMultiplicity, afterDynamic))
+		}
+		posConds = append(posConds, cond)
+
+		if param.FieldInfo.Multiplicity == MultiplicityStatic {
+			runningIndex += param.FieldInfo.StaticMultiplicity
+		} else {
+			runningIndex = 0
+			afterDynamic = true
+		}
+	}
+
+	// Tokens are classified as named params by the flag grammar, except
+	// for the leading positionals, which may look like named params, e.g.
+	// SIDs, and the tokens of const params at their positions. pos is the
+	// index of the token among the positionals.
+	namedCond := jen.Id("isNamedParam").Call(jen.Id("param"))
+	if numLeading > 0 {
+		namedCond = jen.Id("pos").Op(">=").Lit(numLeading).Op("&&").Add(namedCond)
+	}
+	if numRequired != nil {
+		// Tokens matching set gates are always named params.
+		filledCond := jen.Id("pos").Op("+").Id("plain").Op(">=").Add(numRequired)
+		for _, param := range s.positionalParams {
+			if param.Gate != nil {
+				ctx := s.createContext(*param.Gate)
+				filledCond.Op("||").Id(s.gateVar(param)).Op("==").Lit(1).Op("&&").
+					Id("param").Index(jen.Empty(), jen.Lit(2)).Op("==").
+					Lit(param.Gate.Mapper.Parser.Named.ParamName(&ctx))
+			}
+		}
+		namedCond.Op("&&").Parens(filledCond)
+	}
+	for i, param := range s.positionalParams {
+		if isConstParam(param) && matchesFlagGrammar(param.Param.Const) {
+			namedCond.Op("&&").Op("!").Parens(
+				posConds[i].Clone().Op("&&").Id("param").Op("==").Lit(param.Param.Const),
+			)
+		}
+	}
+
+	if len(s.positionalParams) > 0 {
+		group.Id("pos").Op(":=").Lit(0)
+	}
+
+	group.For(
+		jen.List(jen.Id("_"), jen.Id("param")).Op(":=").Range().Id("params"),
+	).BlockFunc(func(group *jen.Group) {
+		if numRequired != nil {
+			group.If(jen.Op("!").Id("isNamedParam").Call(jen.Id("param"))).Block(
+				jen.Id("plain").Op("--"),
+			)
+			group.Line()
+		}
+
+		group.Switch().BlockFunc(func(group *jen.Group) {
+			group.Case(namedCond).BlockFunc(func(group *jen.Group) {
+				s.generateParseIntoNamed(group)
+				if len(s.positionalParams) > 0 {
+					group.Continue()
+				}
+			})
+
+			for i, param := range s.positionalParams {
+				ctx := s.createRenderingContext(param)
+				strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+
+				if isConstParam(param) {
+					group.Case(posConds[i]).Block(
+						jen.If(jen.Id("param").Op("!=").Lit(param.Param.Const)).Block(
+							jen.Return(s.wrapError(s.paramErrorPrefix(param), jen.Id("ErrConstMismatch"))),
+						),
+					)
+					continue
+				}
+
+				group.Case(posConds[i]).BlockFunc(func(group *jen.Group) {
+					s.generateTruncateValue(group, jen.Id("param"), jen.Id("val"))
+					s.generateCheckUTF8(group, jen.Id("param"), jen.Lit(s.paramErrorPrefix(param)+": %w"))
+					if param.FieldInfo.StrIsSingular {
+						group.Add(strStmt).Op("=").Id("param")
+					} else {
+						group.Add(strStmt).Op("=").Append(jen.Add(strStmt), jen.Id("param"))
+					}
+					s.addNonNil(group, param.Mapper.Parser.Positional.ProcessFieldValue(&ctx, jen.Id("param")))
+				})
 			}
+
+			group.Default().Block(
+				jen.If(
+					jen.Err().Op(":=").Id("opts").Dot("surplusPositional").Call(jen.Id("param")),
+					jen.Err().Op("!=").Nil(),
+				).Block(
+					jen.Return(s.wrapError("parsing message "+s.message.Command, jen.Err())),
+				),
+			)
+		})
+
+		if len(s.positionalParams) > 0 {
+			group.Line()
+			group.Id("pos").Op("++")
 		}
+	})
 
-		group.Return(jen.Id("positionals"))
+	group.Line()
+
+	if numPositionals != nil {
+		// Named params interleaved with the positionals are not detected
+		// by the length check preceding the loop.
+		group.If(jen.Id("pos").Op("<").Add(numPositionals)).Block(missingStmt)
+
+		group.Line()
 	}
-}
 
-func (s *StructGenerator) generatePosLen(group *jen.Group) {
-	var staticSum int
-	var stmt jen.Statement
+	// Required params are checked as by AppendADC, so empty values of
+	// present params are rejected as well. The str fields of named params
+	// are checked for absent flags, as the fields of some types always hold
+	// values.
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !param.Param.Required || isConstParam(param) {
+				continue
+			}
 
-	for _, param := range s.positionalParams {
-		ctx := s.createRenderingContext(param)
+			missing := s.paramMissing(param)
+			if param.Param.Mode == ParamModeNamed && param.FieldInfo.StrIsSingular {
+				absent := s.strStmt(param).Op("==").Lit("")
+				if missing != nil && encodesFromField(param) {
+					absent.Op("||").Add(missing)
+				}
+				missing = absent
+			} else if missing == nil || !param.FieldInfo.StrIsSingular && param.Param.Mode == ParamModePositional {
+				continue
+			}
 
-		if param.FieldInfo.Multiplicity == MultiplicityStatic {
-			staticSum += param.FieldInfo.StaticMultiplicity
-		} else {
-			stmt.Op("+").Add(param.FieldInfo.DynamicMultiplicity(&ctx))
+			cond := jen.Add(missing)
+			if param.Gate != nil {
+				cond = s.gateCond(param).Op("&&").Parens(cond)
+			}
+
+			group.If(cond).Block(
+				jen.Return(s.wrapError(s.paramErrorPrefix(param), jen.Id("ErrMissingParam"))),
+			)
 		}
 	}
 
-	if staticSum > 0 || len(stmt) == 0 {
-		group.Return(jen.Lit(staticSum).Add(&stmt))
-	} else {
-		// Remove first Op("+")
-		stmt = stmt[1:]
-		group.Return(&stmt)
+	for _, param := range s.positionalParams {
+		ctx := s.createRenderingContext(param)
+		s.addNonNil(group, param.Mapper.Parser.Positional.FinaliseField(&ctx))
+	}
+	for _, param := range s.namedParams {
+		ctx := s.createRenderingContext(param)
+		s.addNonNil(group, param.Mapper.Parser.Named.FinaliseField(&ctx))
 	}
+
+	s.generateConditionChecks(group, s.paramErrorPrefix, "parsing message "+s.message.Command,
+		func(err jen.Code) jen.Code {
+			return jen.Return(err)
+		})
+
+	group.Line()
+
+	group.Return(jen.Nil())
 }
 
-func (s *StructGenerator) generatePosAt(group *jen.Group) {
-	var numStatic int
+// matchesFlagGrammar returns true if the token would be classified as a named
+// param, i.e. starts with an upper case letter followed by an upper case
+// letter or digit.
+func matchesFlagGrammar(token string) bool {
+	isUpperAlpha := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
 
-	if len(s.positionalParams) == 0 {
-		group.Panic(jen.Lit("index out of range"))
-		return
+	return len(token) >= 2 && isUpperAlpha(token[0]) && (isUpperAlpha(token[1]) || isDigit(token[1]))
+}
+
+// mayMatchFlagGrammar returns true if values of the param may be mistaken
+// for named params, i.e. start with two upper case letters or digits.
+func mayMatchFlagGrammar(param paramInfo) bool {
+	switch param.Param.Type {
+	case "int", "float", "ip", "addr", "timestamp", "duration":
+		return false
+	default:
+		return true
 	}
+}
 
-	for _, param := range s.positionalParams {
-		if param.FieldInfo.Multiplicity == MultiplicityStatic {
-			numStatic++
+// isProtocolPhase returns true if phase is the name of a protocol phase.
+func isProtocolPhase(phase string) bool {
+	for _, p := range protocolPhases {
+		if p == phase {
+			return true
 		}
 	}
 
-	if numStatic == len(s.positionalParams) {
-		// All params have static multiplicity, build switch statement.
+	return false
+}
 
-		var runningIndex int
+// phaseConstName returns the name of the constant of the protocol phase, e.g.
+// PhaseNormal for NORMAL.
+func phaseConstName(phase string) string {
+	return "Phase" + phase[:1] + strings.ToLower(phase[1:])
+}
 
-		group.Switch(jen.Id("i")).BlockFunc(func(group *jen.Group) {
-			for _, param := range s.positionalParams {
-				if param.FieldInfo.StrIsSingular {
-					group.Case(jen.Lit(runningIndex)).Block(
-						jen.Return(
-							jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName),
-						),
-					)
-					runningIndex++
-				} else {
-					for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
-						group.Case(jen.Lit(runningIndex)).Block(
-							jen.Return(
-								jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
-									Index(jen.Lit(i)),
-							),
-						)
-						runningIndex++
-					}
-				}
-			}
+// generateTruncateValue generates code truncating the escaped value as
+// configured by the opts variable and assigning the result to param.
+func (s *StructGenerator) generateTruncateValue(group *jen.Group, value jen.Code, result jen.Code) {
+	group.If(
+		jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").Id("opts").Dot("truncateValue").Call(value),
+		jen.Id("ok"),
+	).Block(
+		jen.Id("param").Op("=").Add(result),
+		jen.Id(s.typeLetter).Dot("Truncated").Op("=").True(),
+	)
+}
 
-			group.Default().Block(
-				jen.Panic(jen.Lit("index out of range")),
-			)
-		})
-	} else if numStatic == 0 && len(s.positionalParams) == 1 {
-		// There is only a single, dynamic multiplicity param, return the ith
-		// element of its str field.
+// generateCheckUTF8 generates code checking the escaped value to be valid
+// UTF-8 if required by the options. format and args are passed to
+// fmt.Errorf followed by the error.
+func (s *StructGenerator) generateCheckUTF8(group *jen.Group, value jen.Code, format jen.Code, args ...jen.Code) {
+	group.If(
+		jen.Err().Op(":=").Id("opts").Dot("checkUTF8").Call(value),
+		jen.Err().Op("!=").Nil(),
+	).Block(
+		jen.Return(jen.Qual("fmt", "Errorf").Call(
+			append(append([]jen.Code{format}, args...), jen.Err())...,
+		)),
+	)
+}
 
-		group.Return(
-			jen.Id(s.typeLetter).Dot("").Add(s.positionalParams[0].FieldInfo.StrFieldName).
-				Index(jen.Id("i")),
-		)
-	} else {
-		// Params have mixed multiplicity, build conditional switch statement
-		// manually.
+func (s *StructGenerator) generateParseIntoNamed(group *jen.Group) {
+	s.generateTruncateValue(
+		group,
+		jen.Id("param").Index(jen.Lit(2), jen.Empty()),
+		jen.Id("param").Index(jen.Empty(), jen.Lit(2)).Op("+").Id("val"),
+	)
+	s.generateCheckUTF8(
+		group,
+		jen.Id("param").Index(jen.Lit(2), jen.Empty()),
+		jen.Lit("parsing flag %s of message "+s.message.Command+": %w"),
+		jen.Id("param").Index(jen.Empty(), jen.Lit(2)),
+	)
 
-		var runningStaticIndex int
-		var runningDynamicLens []jen.Code
+	s.generateSetNamed(group, true)
+}
 
-		runningLenStmt := func(op string, dynamics ...jen.Code) *jen.Statement {
-			var stmt jen.Statement
+// generateSetNamedAll generates the body of the setNamedAll method. The
+// fields of all named params and Flags are reset before the named params are
+// assigned.
+func (s *StructGenerator) generateSetNamedAll(group *jen.Group) {
+	group.Add(s.markDirty())
+	if len(s.namedParams) > 0 {
+		group.Var().Id("zero").Id(s.typeName)
+	}
+	for _, param := range s.namedParams {
+		for _, field := range []jen.Code{param.FieldInfo.FieldName, param.FieldInfo.StrFieldName} {
+			group.Id(s.typeLetter).Dot("").Add(field).Op("=").Id("zero").Dot("").Add(field)
+		}
+	}
+	group.Id(s.typeLetter).Dot("Flags").Op("=").Nil()
 
-			if runningStaticIndex > 0 || len(runningDynamicLens) == 0 {
-				stmt.Lit(runningStaticIndex).Op(op)
-			}
+	group.Line()
 
-			for _, dynamic := range runningDynamicLens {
-				stmt.Add(dynamic).Op(op)
-			}
+	group.For(
+		jen.List(jen.Id("key"), jen.Id("value")).Op(":=").Range().Id("named"),
+	).BlockFunc(func(group *jen.Group) {
+		group.If(jen.Len(jen.Id("key")).Op("!=").Lit(2)).Block(
+			jen.Return(s.wrapError("setting named params of message "+s.message.Command,
+				jen.Id("ErrMalformedFlag"))),
+		)
+		group.Id("param").Op(":=").Id("key").Op("+").Id("value")
 
-			for _, dynamic := range dynamics {
-				stmt.Add(dynamic).Op(op)
-			}
+		s.generateSetNamed(group, false)
+	})
 
-			// Remove last Op(op)
-			stmt = stmt[:len(stmt)-1]
+	group.Line()
 
-			return &stmt
+	group.Return(jen.Nil())
+}
+
+// generateSetNamed generates code assigning the named param held by the param
+// variable, i.e. the flag name followed by the escaped value, to its field or
+// to Flags if it is not mapped to a param. If checkDuplicates is set, params
+// of singular named params are checked for repetition, which requires the
+// opts variable.
+func (s *StructGenerator) generateSetNamed(group *jen.Group, checkDuplicates bool) {
+	flagsStmt := jen.Id(s.typeLetter).Dot("Flags")
+
+	setFlagStmts := []jen.Code{
+		jen.If(jen.Add(flagsStmt).Op("==").Nil()).Block(
+			jen.Add(flagsStmt).Op("=").Make(jen.Map(jen.String()).String()),
+		),
+		jen.Add(flagsStmt).Index(jen.Id("param").Index(jen.Empty(), jen.Lit(2))).
+			Op("=").Id("param").Index(jen.Lit(2), jen.Empty()),
+	}
+
+	if len(s.namedParams) == 0 {
+		for _, stmt := range setFlagStmts {
+			group.Add(stmt)
 		}
+		return
+	}
+
+	group.Switch(jen.Id(s.flagTypeName).Parens(jen.Id("param").Index(jen.Empty(), jen.Lit(2)))).
+		BlockFunc(func(group *jen.Group) {
+			for _, param := range s.namedParams {
+				renderingCtx := s.createRenderingContext(param)
+				strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
 
-		group.Switch().BlockFunc(func(group *jen.Group) {
-			for _, param := range s.positionalParams {
 				if param.FieldInfo.StrIsSingular {
-					group.Case(
-						jen.Id("i").Op("==").Add(runningLenStmt("+")),
-					).Block(
-						jen.Return(
-							jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName),
-						),
-					)
-					runningStaticIndex++
-				} else if param.FieldInfo.Multiplicity == MultiplicityStatic {
-					for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
-						group.Case(
-							jen.Id("i").Op("==").Add(runningLenStmt("+")),
-						).Block(
-							jen.Return(
-								jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
-									Index(jen.Lit(i).Op("-").Add(runningLenStmt("-"))),
-							),
-						)
-						runningStaticIndex++
-					}
+					group.Case(jen.Id(param.FlagConstName)).BlockFunc(func(group *jen.Group) {
+						if checkDuplicates {
+							group.If(
+								jen.Err().Op(":=").Id("opts").Dot("checkDuplicateFlag").Call(strStmt, jen.Id("param")),
+								jen.Err().Op("!=").Nil(),
+							).Block(
+								jen.Return(jen.Qual("fmt", "Errorf").Call(
+									jen.Lit("parsing flag %s of message "+s.message.Command+": %w"),
+									jen.Id("param").Index(jen.Empty(), jen.Lit(2)),
+									jen.Err(),
+								)),
+							)
+						}
+						group.Add(strStmt).Op("=").Id("param")
+						group.Add(param.Mapper.Parser.Named.ProcessFieldValue(
+							&renderingCtx,
+							jen.Id("param").Index(jen.Lit(2), jen.Empty()),
+						))
+					})
 				} else {
-					ctx := s.createRenderingContext(param)
-					dynamicLen := param.FieldInfo.DynamicMultiplicity(&ctx)
-
-					group.Case(
-						jen.Id("i").Op("<").Add(runningLenStmt("+", dynamicLen)),
-					).Block(
-						jen.Return(
-							jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
-								// TODO: Omit -0 from [i-0]
-								Index(jen.Lit("i").Op("-").Add(runningLenStmt("-"))),
-						),
-					)
-					runningDynamicLens = append(runningDynamicLens, dynamicLen)
+					group.Case(jen.Id(param.FlagConstName)).BlockFunc(func(group *jen.Group) {
+						group.Add(strStmt).Op("=").Append(jen.Add(strStmt), jen.Id("param"))
+						s.addNonNil(group, param.Mapper.Parser.Named.ProcessFieldValue(
+							&renderingCtx,
+							jen.Id("param").Index(jen.Lit(2), jen.Empty()),
+						))
+					})
 				}
 			}
 
-			group.Default().Block(
-				jen.Panic(jen.Lit("index out of range")),
-			)
+			group.Default().Block(setFlagStmts...)
 		})
-	}
 }
 
-func (s *StructGenerator) generateNamed(group *jen.Group) {
-	if len(s.namedParams) == 0 {
-		// There are no named parameters, return the Flags map.
-		group.Return(
-			jen.Id(s.typeLetter).Dot("Flags"),
+func (s *StructGenerator) generateWireSize(group *jen.Group) {
+	if s.rawPassthrough {
+		group.If(s.rawValid()).Block(
+			jen.Return(jen.Len(jen.Id(s.typeLetter).Dot("raw"))),
 		)
 
-		return
+		group.Line()
 	}
 
-	group.Id("params").Op(":=").Make(jen.Map(jen.String()).String())
+	group.Id("n").Op(":=").Len(s.commandValue())
 
 	group.Line()
 
-	group.For(
-		jen.List(jen.Id("key"), jen.Id("val")).
-			Op(":=").
-			Range().Id(s.typeLetter).Dot("Flags"),
-	).Block(
-		jen.Id("params").Index(jen.Id("key")).Op("=").Id("val"),
-	)
-
-	group.Line()
+	for _, param := range s.positionalParams {
+		if param.Gate != nil {
+			group.If(s.gateCond(param)).Block(
+				s.countToken(s.singularStrValue(param)),
+			)
+		} else if isConstParam(param) || param.FieldInfo.StrIsSingular {
+			group.Add(s.countToken(s.singularStrValue(param)))
+		} else {
+			group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(s.paramValues(param))).Block(
+				s.countToken(s.elemToken(param, jen.Id("val"))),
+			)
+		}
+	}
 
 	for _, param := range s.namedParams {
-		strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
-		if !param.FieldInfo.StrIsSingular {
-			strStmt.Index(jen.Lit(0))
+		if s.isDropped(param) {
+			continue
 		}
 
-		setStmt := jen.Id("params").Index(
-			jen.Add(strStmt).
-				Index(
-					jen.Empty(), jen.Lit(2),
-				),
-		).
-			Op("=").
-			Add(strStmt).
-			Index(
-				jen.Lit(2), jen.Empty(),
+		if !param.FieldInfo.StrIsSingular {
+			group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(s.paramValues(param))).Block(
+				s.countToken(s.elemToken(param, jen.Id("val"))),
 			)
-
-		if param.FieldInfo.FieldIsMaybe || !param.FieldInfo.StrIsSingular {
-			var condStmts []jen.Code
-
-			if param.FieldInfo.FieldIsMaybe {
-				condStmts = append(
-					condStmts,
-					jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName).
-						Dot("IsSet"),
-				)
-			}
-			if !param.FieldInfo.StrIsSingular {
-				condStmts = append(
-					condStmts,
-					jen.Len(
-						jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName),
-					).
-						Op(">").Lit(0),
-				)
-			}
-
+		} else if param.FieldInfo.FieldIsMaybe {
 			group.If(
-				s.opJoin("&&", condStmts...)...,
-			).
-				Block(
-					setStmt,
-				)
-		} else {
-			group.Add(
-				setStmt,
+				s.optionalWrapper().isSet(s.fieldStmt(param)),
+			).Block(
+				s.countToken(s.namedStrValue(param)),
 			)
+		} else {
+			group.Add(s.countToken(s.namedStrValue(param)))
 		}
 	}
 
+	group.Id("n").Op("+=").Id("flagsSize").Call(jen.Id(s.typeLetter).Dot("Flags"))
+
 	group.Line()
 
-	group.Return(jen.Id("params"))
+	group.Return(jen.Id("n").Op("+").Lit(1))
 }
 
-func (s *StructGenerator) generateNamedGet(group *jen.Group) {
-	// TODO: Should the switch block be wrapped in the follwing if?
-	// group.If(jen.Len(jen.Id("key")).Op("==").Lit(2)).Block()
+func (s *StructGenerator) generateDescriptor(dict jen.Dict) {
+	dict[jen.Id("Command")] = jen.Lit(s.message.Command)
+
+	if len(s.message.Types) > 0 {
+		dict[jen.Id("Types")] = jen.Lit(s.message.Types)
+	}
+
+	if len(s.message.Phases) > 0 {
+		phases := make([]jen.Code, 0, len(s.message.Phases))
+		for _, phase := range s.message.Phases {
+			phases = append(phases, jen.Id(phaseConstName(phase)))
+		}
+		dict[jen.Id("Phases")] = jen.Add(s.opJoin("|", phases...)...)
+	}
+
+	if len(s.positionalParams) > 0 {
+		dict[jen.Id("Positional")] = jen.Index().Id("ParamDescriptor").
+			ValuesFunc(func(group *jen.Group) {
+				for _, param := range s.positionalParams {
+					group.Add(s.paramDescriptor(param))
+				}
+			})
+	}
 
 	if len(s.namedParams) > 0 {
-		group.Switch(jen.Id(s.flagTypeName).Parens(jen.Id("key"))).
-			BlockFunc(func(group *jen.Group) {
+		dict[jen.Id("Named")] = jen.Index().Id("ParamDescriptor").
+			ValuesFunc(func(group *jen.Group) {
 				for _, param := range s.namedParams {
-					ctx := s.createContext(param)
-					name := param.Mapper.Parser.Named.ParamName(&ctx)
+					group.Add(s.paramDescriptor(param))
+				}
+			})
+	}
+}
 
-					returnStrStmt := jen.Id(s.typeLetter).Dot("").
-						Add(param.FieldInfo.StrFieldName)
-					if !param.FieldInfo.StrIsSingular {
-						returnStrStmt.Index(jen.Lit(0))
-					}
-					returnStrStmt.Index(
-						jen.Lit(2), jen.Empty(),
-					)
+func (s *StructGenerator) paramDescriptor(param paramInfo) jen.Code {
+	dict := jen.Dict{
+		jen.Id("Name"):        jen.Lit(param.Param.Name),
+		jen.Id("DisplayName"): jen.Lit(displayNameFromParam(param.Param)),
+		jen.Id("Type"):        jen.Lit(param.Param.Type),
+		jen.Id("Required"):    jen.Lit(param.Param.Required),
+	}
 
-					if param.FieldInfo.FieldIsMaybe || !param.FieldInfo.StrIsSingular {
-						var condStmts []jen.Code
+	if param.Param.Mode == ParamModeNamed {
+		ctx := s.createContext(param)
+		dict[jen.Id("FlagName")] = jen.Lit(param.Mapper.Parser.Named.ParamName(&ctx))
+	}
 
-						if param.FieldInfo.FieldIsMaybe {
-							condStmts = append(
-								condStmts,
-								jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName).
-									Dot("IsSet"),
-							)
-						}
-						if !param.FieldInfo.StrIsSingular {
-							condStmts = append(
-								condStmts,
-								jen.Len(
-									jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName),
-								).
-									Op(">").Lit(0),
-							)
-						}
+	return jen.Values(dict)
+}
 
-						group.Case(jen.Id(s.flagTypeName + name)).
-							If(
-								s.opJoin("&&", condStmts...)...,
-							).
-							Block(
-								jen.Return(jen.List(
-									returnStrStmt,
-									jen.Lit(true),
-								)),
-							).Else().
-							Block(
-								jen.Return(jen.List(
-									jen.Lit(""),
-									jen.Lit(false),
-								)),
-							)
-					} else {
-						group.Case(jen.Id(s.flagTypeName + name)).
-							Return(jen.List(
-								returnStrStmt,
-								jen.Lit(true),
-							))
-					}
+// countToken returns code adding the size of token, preceded by a separator,
+// to n. It is the counterpart of marshalTarget.token used by WireSize.
+func (s *StructGenerator) countToken(token jen.Code) jen.Code {
+	return jen.Id("n").Op("+=").Lit(1).Op("+").Len(token)
+}
 
-				}
+// addNonNil adds code to group, if code is non-nil. Hooks of mappers return
+// nil if they don't generate any code.
+func (s *StructGenerator) addNonNil(group *jen.Group, code jen.Code) {
+	if code != nil {
+		group.Add(code)
+	}
+}
 
-			})
+// wrapError returns code wrapping the error accessed by errorVar, prefixing
+// it with prefix.
+func (s *StructGenerator) wrapError(prefix string, errorVar jen.Code) jen.Code {
+	return jen.Qual("fmt", "Errorf").Call(jen.Lit(prefix+": %w"), errorVar)
+}
+
+// wrapErrorDetail is like wrapError, but appends detail to the message of the
+// wrapped error.
+func (s *StructGenerator) wrapErrorDetail(prefix string, errorVar jen.Code, detail string) jen.Code {
+	return jen.Qual("fmt", "Errorf").Call(jen.Lit(prefix+": %w, "+detail), errorVar)
+}
+
+func (s *StructGenerator) paramErrorPrefix(param paramInfo) string {
+	return "parsing param " + param.Param.Name + " of message " + s.message.Command
+}
+
+func (s *StructGenerator) marshalErrorPrefix(param paramInfo) string {
+	return "marshalling param " + param.Param.Name + " of message " + s.message.Command
+}
+
+// generatePosByName generates the body of the PosByName method. For params
+// with multiple values, the first value is returned.
+func (s *StructGenerator) generatePosByName(group *jen.Group) {
+	if len(s.positionalParams) > 0 {
+		group.Switch(jen.Id("name")).BlockFunc(func(group *jen.Group) {
+			for _, param := range s.positionalParams {
+				values := s.paramValues(param)
+
+				if param.Gate != nil {
+					group.Case(jen.Lit(param.Param.Name)).Block(
+						jen.Return(s.singularStrValue(param), s.gateCond(param)),
+					)
+				} else if param.FieldInfo.StrIsSingular {
+					group.Case(jen.Lit(param.Param.Name)).Block(
+						jen.Return(s.singularStrValue(param), jen.True()),
+					)
+				} else {
+					group.Case(jen.Lit(param.Param.Name)).Block(
+						jen.If(jen.Len(values).Op(">").Lit(0)).Block(
+							jen.Return(s.elemStrValue(param, values.Clone().Index(jen.Lit(0))), jen.True()),
+						),
+					)
+				}
+			}
+		})
 
 		group.Line()
 	}
 
-	group.
-		List(
-			jen.Id("key"), jen.Id("val"),
-		).
-		Op(":=").
-		Id(s.typeLetter).Dot("Flags").Index(jen.Id("key"))
-
-	group.Return(jen.List(
-		jen.Id("key"), jen.Id("val"),
-	))
+	group.Return(jen.Lit(""), jen.False())
 }
 
 func (s *StructGenerator) opJoin(op string, codes ...jen.Code) jen.Statement {
@@ -604,13 +2525,20 @@ func (s *StructGenerator) createRenderingContext(param paramInfo) RenderingConte
 		Context:    s.createContext(param),
 		ContentVar: jen.Id(s.typeLetter),
 		FieldInfo:  param.FieldInfo,
+		ErrorCheckFunc: func(errorVar jen.Code) jen.Code {
+			return jen.If(jen.Add(errorVar).Op("!=").Nil()).Block(
+				jen.Return(s.wrapError(s.paramErrorPrefix(param), errorVar)),
+			)
+		},
 	}
 }
 
 func (s *StructGenerator) createContext(param paramInfo) Context {
 	return Context{
-		Param:  param.Param,
-		Mapper: param.Mapper,
-		Type:   param.Type,
+		Param:           param.Param,
+		Mapper:          param.Mapper,
+		Type:            param.Type,
+		Enum:            param.Enum,
+		OptionalWrapper: s.optionalWrapper(),
 	}
 }
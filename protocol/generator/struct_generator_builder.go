@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// builderName returns the name of the builder type of the message.
+func (s *StructGenerator) builderName() string {
+	return s.baseName() + "Builder"
+}
+
+// generateBuilder generates the builder type of the content type, its
+// constructor, a chainable setter per param and the Build method.
+func (s *StructGenerator) generateBuilder(file *jen.File) {
+	name := s.builderName()
+
+	addComment(file, name+" builds "+s.typeName+" values. Its setters maintain both the "+
+		"fields and the escaped values of the params. The first error encountered by a "+
+		"setter is returned by Build.")
+	file.Type().Id(name).Struct(
+		jen.Id("content").Id(s.typeName),
+		jen.Id("err").Error(),
+	)
+
+	file.Line()
+
+	if s.isFamily() {
+		file.Commentf("New%s returns a builder of contents with the command, which must match", name)
+		file.Commentf("%s.", s.message.Command)
+		file.Func().Id("New"+name).Params(jen.Id("command").String()).Op("*").Id(name).
+			Block(
+				jen.Id("b").Op(":=").Op("&").Id(name).Values(),
+				jen.Id("b").Dot("err").Op("=").Id("b").Dot("content").Dot("SetCommand").Call(jen.Id("command")),
+				jen.Return(jen.Id("b")),
+			)
+	} else {
+		file.Commentf("New%s returns a builder of empty contents.", name)
+		file.Func().Id("New" + name).Params().Op("*").Id(name).
+			Block(
+				jen.Return(jen.Op("&").Id(name).Values()),
+			)
+	}
+
+	file.Line()
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !hasBuilderSetter(param) {
+				continue
+			}
+
+			s.generateBuilderSetter(file, param)
+			file.Line()
+		}
+	}
+
+	file.Comment("Build returns the content if all setters succeeded and the content passes")
+	file.Comment("Validate.")
+	file.Func().Params(jen.Id("b").Op("*").Id(name)).
+		Id("Build").Params().Params(jen.Op("*").Id(s.typeName), jen.Error()).
+		Block(
+			jen.If(jen.Id("b").Dot("err").Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Id("b").Dot("err")),
+			),
+			jen.If(
+				jen.Err().Op(":=").Id("b").Dot("content").Dot("Validate").Call(),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Nil(), jen.Err()),
+			),
+			jen.Line(),
+			jen.Id("content").Op(":=").Id("b").Dot("content"),
+			jen.Return(jen.Op("&").Id("content"), jen.Nil()),
+		)
+}
+
+// hasBuilderSetter returns true if a builder setter is generated for the
+// param. The values of Maybe and multi-valued fields are only known for the
+// built-in types.
+func hasBuilderSetter(param paramInfo) bool {
+	if isConstParam(param) || param.Mapper.Builder.EncodeFunc == nil {
+		return false
+	}
+
+	return isBuiltinType(param.Param.Type) || param.Enum != nil ||
+		param.FieldInfo.StrIsSingular && !param.FieldInfo.FieldIsMaybe
+}
+
+// generateBuilderSetter generates the setter of the param. Setters of
+// multi-valued params are variadic and replace all values.
+func (s *StructGenerator) generateBuilderSetter(file *jen.File, param paramInfo) {
+	ctx := s.createRenderingContext(param)
+	ctx.ContentVar = jen.Id("b").Dot("content")
+	ctx.ErrorCheckFunc = func(errorVar jen.Code) jen.Code {
+		return jen.If(jen.Add(errorVar).Op("!=").Nil()).Block(
+			jen.Id("b").Dot("err").Op("=").Add(s.wrapError(s.builderErrorPrefix(param), errorVar)),
+			jen.Return(jen.Id("b")),
+		)
+	}
+
+	field := jen.Id("b").Dot("content").Dot("").Add(param.FieldInfo.FieldName)
+	strField := jen.Id("b").Dot("content").Dot("").Add(param.FieldInfo.StrFieldName)
+
+	// Escaped values of named params include the flag name.
+	str := jen.Id("str")
+	if param.Param.Mode == ParamModeNamed {
+		str = jen.Lit(param.Mapper.Parser.Named.ParamName(&ctx.Context)).Op("+").Id("str")
+	}
+
+	valueName := "value"
+	var valueType jen.Code
+	switch {
+	case !param.FieldInfo.StrIsSingular:
+		// The field is a slice of the value type.
+		valueName = "values"
+		valueType = jen.Op("...").Add(param.valueType())
+	case param.FieldInfo.FieldIsMaybe:
+		valueType = param.valueType()
+	default:
+		valueType = param.FieldInfo.FieldType
+	}
+
+	if param.FieldInfo.StrIsSingular {
+		file.Commentf("%s sets the %s param.", param.Param.Name, param.Param.Name)
+	} else {
+		file.Commentf("%s replaces all values of the %s param.", param.Param.Name, param.Param.Name)
+	}
+	file.Func().Params(jen.Id("b").Op("*").Id(s.builderName())).
+		Id(param.Param.Name).Params(jen.Id(valueName).Add(valueType)).Op("*").Id(s.builderName()).
+		BlockFunc(func(group *jen.Group) {
+			group.If(jen.Id("b").Dot("err").Op("!=").Nil()).Block(
+				jen.Return(jen.Id("b")),
+			)
+			group.Line()
+
+			if !param.FieldInfo.StrIsSingular {
+				group.Id("strs").Op(":=").Make(jen.Index().String(), jen.Lit(0), jen.Len(jen.Id("values")))
+				group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Id("values")).Block(
+					param.Mapper.Builder.Encode(&ctx, jen.Id("val")),
+					jen.Id("strs").Op("=").Append(jen.Id("strs"), str),
+				)
+				group.Add(field).Op("=").Append(jen.Index().Add(param.valueType()).Values(), jen.Id("values").Op("..."))
+				group.Add(strField).Op("=").Id("strs")
+			} else {
+				group.Add(param.Mapper.Builder.Encode(&ctx, jen.Id("value")))
+				if param.FieldInfo.FieldIsMaybe {
+					group.Add(s.optionalWrapper().set(field, jen.Id("value")))
+				} else {
+					group.Add(field).Op("=").Id("value")
+				}
+				group.Add(strField).Op("=").Add(str)
+			}
+
+			group.Return(jen.Id("b"))
+		})
+}
+
+// builderErrorPrefix returns the prefix of errors encountered by the setter
+// of the param.
+func (s *StructGenerator) builderErrorPrefix(param paramInfo) string {
+	return "building param " + param.Param.Name + " of message " + s.message.Command
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to cache keys.
+var (
+	ErrInvalidCacheKeyPart = errors.New("cache key part must be a param with a single value")
+)
+
+// prepareCacheKey checks the params composing the cache key of the message.
+func (s *StructGenerator) prepareCacheKey() error {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.Param.CacheKeyPart && !param.FieldInfo.StrIsSingular {
+				return errors.Wrapf(ErrInvalidCacheKeyPart, "param %s of message %s",
+					param.Param.Name, s.message.Command)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasCacheKey returns true if any param of the message is a cache key part.
+func (s *StructGenerator) hasCacheKey() bool {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.Param.CacheKeyPart {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// generateCacheKey generates the body of the CacheKey method. The key joins
+// the values of the cache key parts by spaces, each part being the token
+// written by AppendADC. Named values thus keep their flag name, absent values
+// are empty.
+func (s *StructGenerator) generateCacheKey(group *jen.Group) {
+	var parts []jen.Code
+	hasOptional := false
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !param.Param.CacheKeyPart {
+				continue
+			}
+
+			value := s.marshalValue(param)
+			if !param.FieldInfo.FieldIsMaybe {
+				parts = append(parts, value)
+				continue
+			}
+
+			part := jen.Id(toLowerCamelCase(param.Param.Name) + "Part")
+			fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+			group.Var().Add(part).String()
+			group.If(s.optionalWrapper().isSet(fieldStmt)).Block(
+				jen.Add(part).Op("=").Add(value),
+			)
+			parts = append(parts, part)
+			hasOptional = true
+		}
+	}
+
+	if hasOptional {
+		group.Line()
+	}
+
+	group.Return(jen.Add(s.opJoin("+", joinWith(parts, jen.Lit(" "))...)...))
+}
+
+// joinWith returns parts interleaved with sep.
+func joinWith(parts []jen.Code, sep jen.Code) []jen.Code {
+	joined := make([]jen.Code, 0, 2*len(parts))
+	for i, part := range parts {
+		if i > 0 {
+			joined = append(joined, sep)
+		}
+		joined = append(joined, part)
+	}
+
+	return joined
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// generateClone generates the body of the Clone method. Slices and the map
+// of unknown flags are copied, as are the values of params of slice types.
+func (s *StructGenerator) generateClone(group *jen.Group) {
+	clone := jen.Id("clone")
+
+	group.Add(clone).Op(":=").Add(s.receiverValue())
+
+	group.If(jen.Id(s.typeLetter).Dot("Flags").Op("!=").Nil()).Block(
+		jen.Add(clone).Dot("Flags").Op("=").Id(s.typeLetter).Dot("UnknownFlags").Call(),
+	)
+	if s.rawPassthrough {
+		group.Add(clone).Dot("raw").Op("=").
+			Append(jen.Index().Byte().Parens(jen.Nil()), jen.Id(s.typeLetter).Dot("raw").Op("..."))
+	}
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			s.generateCloneParam(group, clone, param)
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Op("&").Add(clone))
+}
+
+// generateCloneParam generates code copying the str field and the field of
+// the param into clone, if they are slices or hold values of slice types.
+func (s *StructGenerator) generateCloneParam(group *jen.Group, clone *jen.Statement, param paramInfo) {
+	fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+	cloneFieldStmt := jen.Add(clone).Dot("").Add(param.FieldInfo.FieldName)
+
+	if !param.FieldInfo.StrIsSingular {
+		strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+		group.Add(clone).Dot("").Add(param.FieldInfo.StrFieldName).Op("=").
+			Append(jen.Index().String().Parens(jen.Nil()), jen.Add(strStmt).Op("..."))
+	}
+
+	sliceValues := param.Enum == nil && isSliceType(param.Param.Type)
+
+	switch {
+	case param.FieldInfo.Multiplicity == MultiplicityDynamic && sliceValues:
+		group.If(jen.Add(fieldStmt).Op("!=").Nil()).Block(
+			jen.Add(cloneFieldStmt).Op("=").Make(param.FieldInfo.FieldType, jen.Len(fieldStmt)),
+			jen.For(jen.List(jen.Id("i"), jen.Id("val")).Op(":=").Range().Add(fieldStmt)).Block(
+				jen.Add(cloneFieldStmt).Index(jen.Id("i")).Op("=").Add(cloneSlice(param.Param.Type, jen.Id("val"))),
+			),
+		)
+	case param.FieldInfo.Multiplicity == MultiplicityDynamic:
+		group.Add(cloneFieldStmt).Op("=").
+			Append(jen.Add(param.FieldInfo.FieldType).Parens(jen.Nil()), jen.Add(fieldStmt).Op("..."))
+	case sliceValues && param.FieldInfo.FieldIsMaybe:
+		group.If(s.optionalWrapper().isSet(fieldStmt)).Block(
+			s.optionalWrapper().set(cloneFieldStmt, cloneSlice(param.Param.Type, s.optionalWrapper().value(fieldStmt))),
+		)
+	case sliceValues:
+		group.Add(cloneFieldStmt).Op("=").Add(cloneSlice(param.Param.Type, fieldStmt))
+	}
+}
+
+// isSliceType returns true if the values of the built-in param type typ are
+// slices, which share memory when copied.
+func isSliceType(typ string) bool {
+	switch typ {
+	case "ip", "bytes", "features":
+		return true
+	default:
+		return false
+	}
+}
+
+// cloneSlice returns code evaluating to a copy of value, a slice of the param
+// type typ. Copies of nil slices are nil.
+func cloneSlice(typ string, value jen.Code) jen.Code {
+	return jen.Append(jen.Add(basicGolangType(typ)).Parens(jen.Nil()), jen.Add(value).Op("..."))
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// commentWidth is the maximum length of the lines of generated comments,
+// excluding the comment marker and the indentation.
+const commentWidth = 76
+
+// commenter is implemented by jen.File and jen.Group.
+type commenter interface {
+	Comment(str string) *jen.Statement
+}
+
+// addComment adds text as comment to c, wrapped at commentWidth. Line breaks
+// of text are kept, empty lines separate paragraphs.
+func addComment(c commenter, text string) {
+	for _, line := range wrapComment(text) {
+		c.Comment(line)
+	}
+}
+
+// wrapComment splits text into lines of at most commentWidth characters,
+// breaking at spaces. Words longer than commentWidth are not broken.
+func wrapComment(text string) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n") {
+		var line string
+		for _, word := range strings.Fields(paragraph) {
+			if len(line) > 0 && len(line)+1+len(word) > commentWidth {
+				lines = append(lines, line)
+				line = ""
+			}
+			if len(line) > 0 {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// paramComment returns the comment of the param prefixed by name, the name
+// of the documented identifier, or the empty string if the param has no
+// comment. Comments already starting with name are not prefixed.
+func paramComment(param paramInfo, name string) string {
+	comment := strings.TrimSpace(param.Param.Comment)
+	if len(comment) == 0 || strings.HasPrefix(comment, name+" ") {
+		return comment
+	}
+
+	return name + " - " + comment
+}
+
+// addParamComment adds the comment of the param, documenting the identifier
+// name, to group.
+func (s *StructGenerator) addParamComment(group *jen.Group, param paramInfo, name string) {
+	if comment := paramComment(param, name); len(comment) > 0 {
+		addComment(group, comment)
+	}
+}
+
+// typeComment returns the doc comment of the struct type, which includes the
+// comment of the message and the section of the specification.
+func (s *StructGenerator) typeComment() string {
+	var b strings.Builder
+
+	if s.isFamily() {
+		fmt.Fprintf(&b, "%s is the content of messages with commands matching %s.", s.typeName, s.message.Command)
+	} else {
+		fmt.Fprintf(&b, "%s is the content of %s messages.", s.typeName, s.message.Command)
+	}
+
+	if comment := strings.TrimSpace(s.message.Comment); len(comment) > 0 {
+		b.WriteString(" " + comment)
+	}
+	if len(s.message.Section) > 0 {
+		fmt.Fprintf(&b, " See %s.", s.message.Section)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to cross-param constraints.
+var (
+	ErrUnknownParam = errors.New("constraint names no param of the message")
+)
+
+// prepareConditions checks the conditional requirements of the params and
+// the one-of groups of the message.
+func (s *StructGenerator) prepareConditions() error {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			cond := param.Param.RequiredIf
+			if cond == nil {
+				continue
+			}
+
+			if param.Param.Required || isConstParam(param) || cond.Param == param.Param.Name {
+				return errors.Wrapf(ErrInvalidConstraint, "conditional requirement of param %s of message %s",
+					param.Param.Name, s.message.Command)
+			}
+
+			_, err := s.conditionCode(cond)
+			if err != nil {
+				return errors.Wrapf(err, "conditional requirement of param %s of message %s",
+					param.Param.Name, s.message.Command)
+			}
+		}
+	}
+
+	for _, names := range s.message.OneOf {
+		if len(names) < 2 {
+			return errors.Wrapf(ErrInvalidConstraint, "one-of group %v of message %s", names, s.message.Command)
+		}
+
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if s.lookupParam(name) == nil {
+				return errors.Wrapf(ErrUnknownParam, "param %s of one-of group of message %s", name, s.message.Command)
+			}
+			if seen[name] {
+				return errors.Wrapf(ErrInvalidConstraint, "param %s repeated in one-of group of message %s",
+					name, s.message.Command)
+			}
+			seen[name] = true
+		}
+	}
+
+	return nil
+}
+
+// lookupParam returns the param with the name, nil if the message has no such
+// param. Const params are not returned, as they have no field.
+func (s *StructGenerator) lookupParam(name string) *paramInfo {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for i := range params {
+			if params[i].Param.Name == name && !isConstParam(params[i]) {
+				return &params[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// conditionCode returns code evaluating the condition. Values can only be
+// compared for params with a single value of a comparable type or of the
+// features type.
+func (s *StructGenerator) conditionCode(cond *Condition) (jen.Code, error) {
+	param := s.lookupParam(cond.Param)
+	if param == nil {
+		return nil, errors.Wrapf(ErrUnknownParam, "param %s of condition", cond.Param)
+	}
+
+	present := s.paramPresent(*param)
+	if len(cond.Value) == 0 {
+		return present, nil
+	}
+
+	if !param.FieldInfo.StrIsSingular || param.FieldInfo.Multiplicity != MultiplicityStatic {
+		return nil, errors.Wrapf(ErrInvalidConstraint, "condition on value of multi-valued param %s", cond.Param)
+	}
+
+	value := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+	if param.FieldInfo.FieldIsMaybe {
+		value = s.optionalWrapper().value(value)
+	}
+
+	if param.Param.Type == "features" {
+		return jen.Add(present).Op("&&").Add(value).Dot("Has").Call(jen.Lit(cond.Value)), nil
+	}
+
+	typ := param.Param.Type
+	if param.Enum != nil {
+		typ = param.Enum.Type
+	}
+	lit, err := basicLitFromString(typ, cond.Value)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidConstraint, "value %s of condition on param %s: %v",
+			cond.Value, cond.Param, err)
+	}
+
+	return jen.Add(present).Op("&&").Add(value).Op("==").Add(lit), nil
+}
+
+// describeCondition returns a description of the condition for comments and
+// errors, e.g. "SU contains UDP4".
+func describeCondition(cond *Condition, param paramInfo) string {
+	switch {
+	case len(cond.Value) == 0:
+		return cond.Param + " is present"
+	case param.Param.Type == "features":
+		return cond.Param + " contains " + cond.Value
+	default:
+		return cond.Param + " is " + cond.Value
+	}
+}
+
+// joinNames joins the names to an enumeration, e.g. "TR, FN and FS".
+func joinNames(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// conditionComments returns the sentences documenting the cross-param
+// constraints involving the param.
+func (s *StructGenerator) conditionComments(param paramInfo) []string {
+	var comments []string
+
+	if cond := param.Param.RequiredIf; cond != nil {
+		comments = append(comments, "Required if "+describeCondition(cond, *s.lookupParam(cond.Param))+".")
+	}
+
+	for _, names := range s.message.OneOf {
+		for _, name := range names {
+			if name == param.Param.Name {
+				comments = append(comments, "Exactly one of "+joinNames(names)+" is present.")
+				break
+			}
+		}
+	}
+
+	return comments
+}
+
+// generateConditionChecks generates the checks of the conditional
+// requirements and the one-of groups of the message. Violations are handed to
+// fail, the errors are prefixed by paramPrefix and messagePrefix.
+func (s *StructGenerator) generateConditionChecks(group *jen.Group, paramPrefix func(paramInfo) string,
+	messagePrefix string, fail func(err jen.Code) jen.Code) {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			cond := param.Param.RequiredIf
+			if cond == nil {
+				continue
+			}
+
+			// Errors have been checked by prepareConditions
+			condCode, _ := s.conditionCode(cond)
+			detail := "required if " + describeCondition(cond, *s.lookupParam(cond.Param))
+
+			absent := jen.Op("!").Parens(s.paramPresent(param))
+			if param.FieldInfo.FieldIsMaybe {
+				absent = jen.Op("!").Add(s.paramPresent(param))
+			}
+
+			group.If(jen.Add(condCode).Op("&&").Add(absent)).Block(
+				fail(s.wrapErrorDetail(paramPrefix(param), jen.Id("ErrMissingParam"), detail)),
+			)
+		}
+	}
+
+	for _, names := range s.message.OneOf {
+		present := make([]jen.Code, 0, len(names))
+		for _, name := range names {
+			present = append(present, s.paramPresent(*s.lookupParam(name)))
+		}
+
+		group.If(jen.Op("!").Id("exactlyOne").Call(present...)).Block(
+			fail(s.wrapErrorDetail(messagePrefix, jen.Id("ErrOneOf"), "params "+joinNames(names))),
+		)
+	}
+}
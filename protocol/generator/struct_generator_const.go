@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Error variables related to const params.
+var (
+	ErrInvalidConstParam = errors.New("const param must be a required positional string param without constraints")
+	ErrInvalidConstValue = errors.New("const value must be a non-empty token")
+)
+
+// prepareConsts checks the const params of the message. Const params are
+// limited to the basic mapper, as no field is generated for them.
+func (s *StructGenerator) prepareConsts() error {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !isConstParam(param) {
+				continue
+			}
+
+			if param.Param.Mode != ParamModePositional || param.Param.Type != "string" ||
+				param.Mapper != BasicMapper || !param.Param.Required || len(param.Param.GatedBy) > 0 ||
+				len(param.Param.AllowedValues) > 0 || len(param.Param.AllowedEnum) > 0 ||
+				param.Param.Sensitive {
+				return errors.Wrapf(ErrInvalidConstParam, "param %s of message %s",
+					param.Param.Name, s.message.Command)
+			}
+
+			if strings.ContainsAny(param.Param.Const, " \n") {
+				return errors.Wrapf(ErrInvalidConstValue, "value %q of param %s of message %s",
+					param.Param.Const, param.Param.Name, s.message.Command)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isConstParam returns true if the param is a const param, i.e. has a fixed
+// token and no field.
+func isConstParam(param paramInfo) bool {
+	return len(param.Param.Const) > 0
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// deprecatedParams returns the params of the message marked as deprecated,
+// positional params first.
+func (s *StructGenerator) deprecatedParams() []paramInfo {
+	var deprecated []paramInfo
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if len(param.Param.Deprecated) > 0 && !isConstParam(param) {
+				deprecated = append(deprecated, param)
+			}
+		}
+	}
+
+	return deprecated
+}
+
+// isDropped returns true if the param is left out when encoding the content.
+// Deprecated named params are dropped unless WithDeprecatedEncoding is set.
+// Gates are always encoded, as the positional params they gate depend on
+// them.
+func (s *StructGenerator) isDropped(param paramInfo) bool {
+	if s.encodeDeprecated || len(param.Param.Deprecated) == 0 || param.Param.Mode != ParamModeNamed {
+		return false
+	}
+
+	for _, positional := range s.positionalParams {
+		if positional.Gate != nil && positional.Gate.Param == param.Param {
+			return false
+		}
+	}
+
+	return true
+}
+
+// droppedPresent returns the condition of any dropped param being present in
+// the content, nil if no param is dropped.
+func (s *StructGenerator) droppedPresent() jen.Code {
+	var conds []jen.Code
+	for _, param := range s.namedParams {
+		if s.isDropped(param) {
+			conds = append(conds, s.paramPresent(param))
+		}
+	}
+
+	if len(conds) == 0 {
+		return nil
+	}
+
+	return jen.Add(s.opJoin("||", conds...)...)
+}
+
+// generateDecodeWarnings generates the body of the DecodeWarnings method,
+// which lists the deprecated params present in the content along with the
+// reason of their deprecation.
+func (s *StructGenerator) generateDecodeWarnings(group *jen.Group) {
+	group.Var().Id("warnings").Index().String()
+
+	for _, param := range s.deprecatedParams() {
+		warning := "param " + param.Param.Name + " of message " + s.message.Command +
+			" is deprecated: " + param.Param.Deprecated
+
+		group.If(s.paramPresent(param)).Block(
+			jen.Id("warnings").Op("=").Append(jen.Id("warnings"), jen.Lit(warning)),
+		)
+	}
+
+	group.Line()
+
+	group.Return(jen.Id("warnings"))
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// generateEqualIgnoring generates the body of the EqualIgnoring method. The
+// params are compared in their escaped form, like Equal does.
+func (s *StructGenerator) generateEqualIgnoring(group *jen.Group) {
+	if s.isFamily() {
+		group.If(jen.Id(s.typeLetter).Dot("command").Op("!=").Id("other").Dot("command")).Block(
+			jen.Return(jen.False()),
+		)
+	}
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			notIgnored := jen.Op("!").Id("isIgnored").Call(jen.Id("ignore"), jen.Lit(param.Param.Name))
+
+			if !param.FieldInfo.StrIsSingular && encodesFromField(param) {
+				values := s.fieldStmt(param)
+				otherValues := jen.Id("other").Dot("").Add(param.FieldInfo.FieldName)
+
+				group.If(notIgnored).Block(
+					jen.If(jen.Len(values).Op("!=").Len(otherValues)).Block(
+						jen.Return(jen.False()),
+					),
+					jen.For(jen.List(jen.Id("i"), jen.Id("val")).Op(":=").Range().Add(values)).Block(
+						jen.If(s.valuesDiffer(param, jen.Id("val"), jen.Add(otherValues).Index(jen.Id("i")))).Block(
+							jen.Return(jen.False()),
+						),
+					),
+				)
+				continue
+			}
+
+			group.If(jen.Add(notIgnored).Op("&&").Add(s.paramsDiffer(param))).Block(
+				jen.Return(jen.False()),
+			)
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Id("equalFlagsIgnoring").Call(
+		jen.Id(s.typeLetter).Dot("Flags"),
+		jen.Id("other").Dot("Flags"),
+		jen.Id("ignore"),
+	))
+}
+
+// paramsDiffer returns code evaluating to true if the escaped values of the
+// param differ between the content and other. Multi-valued params encoded
+// from their fields are compared by generateEqualIgnoring.
+func (s *StructGenerator) paramsDiffer(param paramInfo) jen.Code {
+	field := param.FieldInfo.FieldName
+	strName := param.FieldInfo.StrFieldName
+
+	switch {
+	case !encodesFromField(param) && param.FieldInfo.StrIsSingular:
+		return jen.Id(s.typeLetter).Dot("").Add(strName).Op("!=").Id("other").Dot("").Add(strName)
+	case !encodesFromField(param):
+		return jen.Op("!").Id("equalStrs").Call(
+			jen.Id(s.typeLetter).Dot("").Add(strName),
+			jen.Id("other").Dot("").Add(strName),
+		)
+	case param.FieldInfo.FieldIsMaybe:
+		wrapper := s.optionalWrapper()
+		isSet := wrapper.isSet(s.fieldStmt(param))
+		otherIsSet := wrapper.isSet(jen.Id("other").Dot("").Add(field))
+
+		return jen.Parens(jen.Add(isSet).Op("!=").Add(otherIsSet).Op("||").
+			Add(wrapper.isSet(s.fieldStmt(param))).Op("&&").
+			Add(s.valuesDiffer(param, wrapper.value(s.fieldStmt(param)), wrapper.value(jen.Id("other").Dot("").Add(field)))))
+	default:
+		return s.valuesDiffer(param, s.fieldStmt(param), jen.Id("other").Dot("").Add(field))
+	}
+}
+
+// valuesDiffer returns code evaluating to true if the escaped values of a and
+// b, single values of the param, differ. Values of types encoded one-to-one
+// are compared directly, all others are encoded.
+func (s *StructGenerator) valuesDiffer(param paramInfo, a, b jen.Code) jen.Code {
+	if param.Mapper == BitmaskMapper || param.Enum != nil ||
+		(param.Mapper == BasicMapper || param.Mapper == ListMapper) &&
+			(param.Param.Type == "int" || param.Param.Type == "string") {
+		return jen.Add(a).Op("!=").Add(b)
+	}
+
+	return jen.Add(s.encodeValue(param, a)).Op("!=").Add(s.encodeValue(param, b))
+}
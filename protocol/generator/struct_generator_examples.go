@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to examples.
+var (
+	ErrInvalidExample = errors.New("example value does not name a param of the message")
+)
+
+// prepareExamples checks that the values of the examples name params which
+// have fields.
+func (s *StructGenerator) prepareExamples() error {
+	names := make(map[string]bool)
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !isConstParam(param) {
+				names[param.Param.Name] = true
+			}
+		}
+	}
+
+	for _, example := range s.message.Examples {
+		for name := range example.Values {
+			if !names[name] {
+				return errors.Wrapf(ErrInvalidExample, "value %s of example %q of message %s",
+					name, example.Line, s.message.Command)
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateExamplesTest generates a table-driven test decoding the line of
+// each example by UnmarshalADC, comparing the decoded values to the values
+// of the example and asserting MarshalADC encodes the line again.
+func (s *StructGenerator) generateExamplesTest(group *jen.Group) {
+	group.Id("examples").Op(":=").Index().Struct(
+		jen.Id("line").String(),
+		jen.Id("values").Map(jen.String()).String(),
+	).ValuesFunc(func(group *jen.Group) {
+		for _, example := range s.message.Examples {
+			group.Line().Values(
+				jen.Lit(example.Line),
+				jen.Map(jen.String()).String().Values(jen.DictFunc(func(dict jen.Dict) {
+					for _, name := range sortedKeys(example.Values) {
+						dict[jen.Lit(name)] = jen.Lit(example.Values[name])
+					}
+				})),
+			)
+		}
+		group.Line()
+	})
+
+	group.Line()
+
+	group.Id("values").Op(":=").Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).Map(jen.String()).String().
+		BlockFunc(s.generateExampleValues)
+
+	group.Line()
+
+	group.For(jen.List(jen.Id("_"), jen.Id("example")).Op(":=").Range().Id("examples")).BlockFunc(func(group *jen.Group) {
+		group.Var().Id(s.typeLetter).Id(s.typeName)
+		group.If(
+			jen.Err().Op(":=").Id(s.typeLetter).Dot("UnmarshalADC").Call(
+				jen.Index().Byte().Parens(jen.Id("example").Dot("line").Op("+").Lit("\n")),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("UnmarshalADC(%q) failed: %v"), jen.Id("example").Dot("line"), jen.Err()),
+			jen.Continue(),
+		)
+
+		group.Line()
+
+		group.Id("got").Op(":=").Id("values").Call(jen.Op("&").Id(s.typeLetter))
+		group.For(jen.List(jen.Id("name"), jen.Id("want")).Op(":=").Range().Id("example").Dot("values")).Block(
+			jen.If(jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").Id("got").Index(jen.Id("name")), jen.Op("!").Id("ok").Op("||").Id("val").Op("!=").Id("want")).Block(
+				jen.Id("t").Dot("Errorf").Call(
+					jen.Lit("%q: param %s = %q, %t, want %q, true"),
+					jen.Id("example").Dot("line"), jen.Id("name"), jen.Id("val"), jen.Id("ok"), jen.Id("want"),
+				),
+			),
+		)
+		group.For(jen.Id("name").Op(":=").Range().Id("got")).Block(
+			jen.If(jen.List(jen.Id("_"), jen.Id("ok")).Op(":=").Id("example").Dot("values").Index(jen.Id("name")), jen.Op("!").Id("ok")).Block(
+				jen.Id("t").Dot("Errorf").Call(
+					jen.Lit("%q: param %s is set, but has no expected value"),
+					jen.Id("example").Dot("line"), jen.Id("name"),
+				),
+			),
+		)
+
+		group.Line()
+
+		if dropped := s.droppedPresent(); dropped != nil {
+			group.If(dropped).Block(
+				jen.Comment("MarshalADC drops deprecated params, the line is not encoded again."),
+				jen.Continue(),
+			)
+		}
+		if s.rawPassthrough {
+			group.Comment("MarshalADC would return the parsed line verbatim.")
+			group.Id(s.typeLetter).Dot("MarkDirty").Call()
+		}
+		group.List(jen.Id("buf"), jen.Err()).Op(":=").Id(s.typeLetter).Dot("MarshalADC").Call()
+		group.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("%q: MarshalADC() failed: %v"), jen.Id("example").Dot("line"), jen.Err()),
+		).Else().If(jen.String().Parens(jen.Id("buf")).Op("!=").Id("example").Dot("line").Op("+").Lit("\n")).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("MarshalADC() = %q, want %q"), jen.Id("buf"), jen.Id("example").Dot("line").Op("+").Lit("\n")),
+		)
+	})
+}
+
+// generateExampleValues generates the body of the function returning the
+// values of the params set in the content, formatted as described by
+// Example. Values are formatted like in Labels, empty multi-valued params are
+// not set.
+func (s *StructGenerator) generateExampleValues(group *jen.Group) {
+	values := jen.Id("values")
+
+	group.Add(values).Op(":=").Make(jen.Map(jen.String()).String())
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+			var value jen.Code
+			if encoded := s.displayEncoded(param); encoded != nil {
+				value = encoded
+			} else if param.FieldInfo.FieldIsMaybe {
+				value = jen.Qual("fmt", "Sprint").Call(s.optionalWrapper().value(fieldStmt))
+			} else {
+				value = jen.Qual("fmt", "Sprint").Call(fieldStmt)
+			}
+			assignStmt := jen.Add(values).Index(jen.Lit(param.Param.Name)).Op("=").Add(value)
+
+			switch {
+			case param.FieldInfo.FieldIsMaybe:
+				group.If(s.optionalWrapper().isSet(fieldStmt)).Block(assignStmt)
+			case param.FieldInfo.Multiplicity == MultiplicityDynamic:
+				group.If(jen.Len(fieldStmt).Op(">").Lit(0)).Block(assignStmt)
+			default:
+				group.Add(assignStmt)
+			}
+		}
+	}
+
+	group.Return(values)
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
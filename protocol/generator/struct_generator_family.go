@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to command families.
+var (
+	ErrInvalidCommandPattern = errors.New("command pattern must consist of three upper case letters, digits or '?' and requires a name")
+)
+
+// isCommandPattern returns true if command is the pattern of a command
+// family, i.e. contains '?' wildcards.
+func isCommandPattern(command string) bool {
+	for i := 0; i < len(command); i++ {
+		if command[i] == '?' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prepareFamily checks the command pattern of messages of command families.
+func (s *StructGenerator) prepareFamily() error {
+	if !s.isFamily() {
+		return nil
+	}
+
+	command := s.message.Command
+	valid := len(command) == 3 && len(s.message.Name) > 0
+	for i := 0; valid && i < len(command); i++ {
+		c := command[i]
+		valid = c == '?' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9'
+	}
+	if !valid {
+		return errors.Wrapf(ErrInvalidCommandPattern, "pattern %s of message %s", command, s.message.Name)
+	}
+
+	return nil
+}
+
+// isFamily returns true if the message describes a command family.
+func (s *StructGenerator) isFamily() bool {
+	return isCommandPattern(s.message.Command)
+}
+
+// baseName returns the name the generated identifiers of the message are
+// based on.
+func (s *StructGenerator) baseName() string {
+	if len(s.message.Name) > 0 {
+		return s.message.Name
+	}
+
+	return s.message.Command
+}
+
+// commandValue returns code evaluating to the command of the content, which
+// is stored by contents of command families.
+func (s *StructGenerator) commandValue() jen.Code {
+	if s.isFamily() {
+		return jen.Id(s.typeLetter).Dot("command")
+	}
+
+	return jen.Lit(s.message.Command)
+}
+
+// generateCommandMethods generates the Command method and, for command
+// families, the methods setting the command.
+func (s *StructGenerator) generateCommandMethods(file *jen.File) {
+	if !s.isFamily() {
+		file.Commentf("Command returns the command of the content, i.e. %s.", s.message.Command)
+		file.Func().Params(s.receiver()).
+			Id("Command").Params().String().
+			Block(
+				jen.Return(s.commandValue()),
+			)
+
+		file.Line()
+		return
+	}
+
+	file.Commentf("Command returns the command of the content, which matches %s. It is", s.message.Command)
+	file.Comment("set by ParseTokens and FrameParser, or by SetCommand.")
+	file.Func().Params(s.receiver()).
+		Id("Command").Params().String().
+		Block(
+			jen.Return(s.commandValue()),
+		)
+
+	file.Line()
+
+	file.Commentf("SetCommand sets the command of the content, which must match %s.", s.message.Command)
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("SetCommand").Params(jen.Id("command").String()).Error().
+		Block(
+			jen.If(jen.Op("!").Id("matchCommand").Call(jen.Lit(s.message.Command), jen.Id("command"))).Block(
+				jen.Return(s.wrapError("setting command of message "+s.message.Command, jen.Id("ErrCommandMismatch"))),
+			),
+			jen.Line(),
+			jen.Id(s.typeLetter).Dot("command").Op("=").Id("command"),
+			s.markDirty(),
+			jen.Return(jen.Nil()),
+		)
+
+	file.Line()
+
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("setCommand").Params(jen.Id("command").String()).
+		Block(
+			jen.Id(s.typeLetter).Dot("command").Op("=").Id("command"),
+		)
+
+	file.Line()
+}
+
+// equalCommand returns code comparing the commands of the content and other
+// followed by &&, if the message describes a command family.
+func (s *StructGenerator) equalCommand() jen.Code {
+	if !s.isFamily() {
+		return jen.Null()
+	}
+
+	return jen.Id(s.typeLetter).Dot("command").Op("==").Id("other").Dot("command").Op("&&")
+}
+
+func (s *StructGenerator) generateParseTokens(group *jen.Group) {
+	tokenStmt := jen.Id("tokens").Index(jen.Lit(0))
+
+	var mismatch jen.Code
+	if s.isFamily() {
+		mismatch = jen.Op("!").Id("matchCommand").Call(jen.Lit(s.message.Command), tokenStmt)
+	} else {
+		mismatch = jen.Add(tokenStmt).Op("!=").Lit(s.message.Command)
+	}
+
+	group.If(jen.Len(jen.Id("tokens")).Op("==").Lit(0).Op("||").Add(mismatch)).Block(
+		jen.Return(s.wrapError("parsing message "+s.message.Command, jen.Id("ErrCommandMismatch"))),
+	)
+
+	group.Line()
+
+	parseStmt := jen.Id(s.typeLetter).Dot("ParseInto").Call(jen.Id("tokens").Index(jen.Lit(1).Op(":")), jen.Nil())
+	if !s.isFamily() {
+		group.Return(parseStmt)
+		return
+	}
+
+	group.If(jen.Err().Op(":=").Add(parseStmt), jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Err()),
+	)
+	group.Id(s.typeLetter).Dot("command").Op("=").Id("tokens").Index(jen.Lit(0))
+
+	group.Line()
+
+	group.Return(jen.Nil())
+}
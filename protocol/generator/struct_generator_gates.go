@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to gated positional params.
+var (
+	ErrUnknownGate       = errors.New("gate does not name a named param of the message")
+	ErrInvalidGatedParam = errors.New("gated param must be an optional positional param with a single value")
+	ErrAmbiguousLayout   = errors.New("positional layout with gated params is ambiguous")
+)
+
+// prepareGates resolves the gates of all positional params and checks the
+// layout of the positional params. Gated params are only supported if all
+// positional params have static multiplicity, adjacent gated params are
+// rejected.
+func (s *StructGenerator) prepareGates() error {
+	var previousGated, hasDynamic, hasGated bool
+
+	for i := range s.positionalParams {
+		param := &s.positionalParams[i]
+
+		if param.FieldInfo.Multiplicity != MultiplicityStatic {
+			hasDynamic = true
+		}
+
+		if len(param.Param.GatedBy) == 0 {
+			previousGated = false
+			continue
+		}
+		hasGated = true
+
+		for j := range s.namedParams {
+			if s.namedParams[j].Param.Name == param.Param.GatedBy {
+				param.Gate = &s.namedParams[j]
+				break
+			}
+		}
+		if param.Gate == nil {
+			return errors.Wrapf(ErrUnknownGate, "gate %s of param %s of message %s",
+				param.Param.GatedBy, param.Param.Name, s.message.Command)
+		}
+
+		if param.Param.Required || !param.FieldInfo.StrIsSingular ||
+			param.FieldInfo.Multiplicity != MultiplicityStatic {
+			return errors.Wrapf(ErrInvalidGatedParam, "param %s of message %s",
+				param.Param.Name, s.message.Command)
+		}
+
+		if previousGated {
+			return errors.Wrapf(ErrAmbiguousLayout, "adjacent gated params preceding param %s of message %s",
+				param.Param.Name, s.message.Command)
+		}
+		previousGated = true
+	}
+
+	if hasGated && hasDynamic {
+		return errors.Wrapf(ErrAmbiguousLayout, "gated params combined with params of dynamic "+
+			"multiplicity in message %s", s.message.Command)
+	}
+
+	return nil
+}
+
+// hasGates returns true if any positional param is gated.
+func (s *StructGenerator) hasGates() bool {
+	for _, param := range s.positionalParams {
+		if param.Gate != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gateVar returns the name of the variable holding 1 if the gated param is
+// present and 0 otherwise.
+func (s *StructGenerator) gateVar(param paramInfo) string {
+	return toLowerCamelCase(param.Param.Name) + "Gate"
+}
+
+// gateCond returns code evaluating to true if the gate of the param is set,
+// i.e. the named param gating the param is present in the content.
+func (s *StructGenerator) gateCond(param paramInfo) *jen.Statement {
+	return jen.Add(s.paramPresent(*param.Gate))
+}
+
+// generateGateVars generates the declaration of the gate variables of all
+// gated params. cond returns the code evaluating to true if the gate of the
+// param is set.
+func (s *StructGenerator) generateGateVars(group *jen.Group, cond func(param paramInfo) *jen.Statement) {
+	for _, param := range s.positionalParams {
+		if param.Gate == nil {
+			continue
+		}
+
+		group.Var().Id(s.gateVar(param)).Int()
+		group.If(cond(param)).Block(
+			jen.Id(s.gateVar(param)).Op("=").Lit(1),
+		)
+	}
+
+	group.Line()
+}
+
+// gatedPosIndices returns code evaluating to the index of each positional
+// param. The indices of params following gated params are shifted by the gate
+// variables.
+func (s *StructGenerator) gatedPosIndices() []*jen.Statement {
+	indices := make([]*jen.Statement, 0, len(s.positionalParams))
+
+	var runningIndex int
+	var gateVars []string
+
+	for _, param := range s.positionalParams {
+		index := jen.Lit(runningIndex)
+		for _, gateVar := range gateVars {
+			index.Op("+").Id(gateVar)
+		}
+		indices = append(indices, index)
+
+		if param.Gate != nil {
+			gateVars = append(gateVars, s.gateVar(param))
+		} else {
+			runningIndex += param.FieldInfo.StaticMultiplicity
+		}
+	}
+
+	return indices
+}
+
+// numUngatedPositionals returns the number of positionals not gated.
+func (s *StructGenerator) numUngatedPositionals() int {
+	var n int
+	for _, param := range s.positionalParams {
+		if param.Gate == nil {
+			n += param.FieldInfo.StaticMultiplicity
+		}
+	}
+
+	return n
+}
+
+func (s *StructGenerator) generateAppendPositionalGated(group *jen.Group) {
+	for _, param := range s.positionalParams {
+		var values []jen.Code
+		if param.FieldInfo.StrIsSingular {
+			values = append(values, s.singularStrValue(param))
+		} else {
+			for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
+				values = append(values, jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
+					Index(jen.Lit(i)))
+			}
+		}
+
+		appendStmt := jen.Id("dst").Op("=").Append(
+			append([]jen.Code{jen.Id("dst")}, values...)...,
+		)
+
+		if param.Gate != nil {
+			group.If(s.gateCond(param)).Block(appendStmt)
+		} else {
+			group.Add(appendStmt)
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Id("dst"))
+}
+
+func (s *StructGenerator) generatePosLenGated(group *jen.Group) {
+	s.generateGateVars(group, s.gateCond)
+
+	stmt := jen.Lit(s.numUngatedPositionals())
+	for _, param := range s.positionalParams {
+		if param.Gate != nil {
+			stmt.Op("+").Id(s.gateVar(param))
+		}
+	}
+
+	group.Return(stmt)
+}
+
+func (s *StructGenerator) generatePosAtGated(group *jen.Group) {
+	s.generateGateVars(group, s.gateCond)
+
+	indices := s.gatedPosIndices()
+
+	group.Switch().BlockFunc(func(group *jen.Group) {
+		for i, param := range s.positionalParams {
+			if param.FieldInfo.StrIsSingular {
+				cond := jen.Id("i").Op("==").Add(indices[i])
+				if param.Gate != nil {
+					cond = jen.Id(s.gateVar(param)).Op("==").Lit(1).Op("&&").Add(cond)
+				}
+
+				group.Case(cond).Block(
+					jen.Return(s.singularStrValue(param)),
+				)
+			} else {
+				for j := 0; j < param.FieldInfo.StaticMultiplicity; j++ {
+					index := indices[i].Clone()
+					if j > 0 {
+						index.Op("+").Lit(j)
+					}
+
+					group.Case(jen.Id("i").Op("==").Add(index)).Block(
+						jen.Return(
+							jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).
+								Index(jen.Lit(j)),
+						),
+					)
+				}
+			}
+		}
+
+		group.Default().Block(
+			s.posAtPanic(),
+		)
+	})
+}
@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// typedGetterName returns the name of the typed getter of the named param.
+// The field of the param already carries the name of the param.
+func typedGetterName(param paramInfo) string {
+	return "Get" + param.Param.Name
+}
+
+// generateTypedGetters generates a getter for each named param, returning the
+// decoded value of the field and whether the param is set. Unlike NamedGet,
+// the getters do not return the escaped values.
+func (s *StructGenerator) generateTypedGetters(file *jen.File) {
+	for _, param := range s.namedParams {
+		valueType := param.FieldInfo.FieldType
+		if param.FieldInfo.FieldIsMaybe {
+			valueType = param.valueType()
+		}
+		var value jen.Code = s.fieldStmt(param)
+		if param.FieldInfo.StrIsSingular {
+			value = s.fieldValue(param)
+		}
+		isSet := s.paramPresent(param)
+
+		file.Commentf("%s returns the decoded value of the %s param and whether it is set.",
+			typedGetterName(param), param.Param.Name)
+		file.Func().Params(s.receiver()).
+			Id(typedGetterName(param)).Params().Params(valueType, jen.Bool()).
+			Block(
+				jen.Return(value, isSet),
+			)
+
+		file.Line()
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+func (s *StructGenerator) generateLogValue(group *jen.Group) {
+	attrs := jen.Id("attrs")
+
+	group.Add(attrs).Op(":=").Make(
+		jen.Index().Qual("log/slog", "Attr"),
+		jen.Lit(0),
+		jen.Lit(1+len(s.positionalParams)+len(s.namedParams)),
+	)
+	group.Add(attrs).Op("=").Append(attrs, jen.Qual("log/slog", "String").Call(jen.Lit("command"), s.commandValue()))
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+			var attr jen.Code
+			if param.Param.Sensitive {
+				attr = jen.Qual("log/slog", "String").Call(jen.Lit(param.Param.Name), jen.Lit(redactedValue))
+			} else if param.FieldInfo.FieldIsMaybe {
+				attr = jen.Qual("log/slog", "Any").Call(jen.Lit(param.Param.Name), s.optionalWrapper().value(fieldStmt))
+			} else {
+				attr = jen.Qual("log/slog", "Any").Call(jen.Lit(param.Param.Name), fieldStmt)
+			}
+			appendStmt := jen.Add(attrs).Op("=").Append(attrs, attr)
+
+			if param.FieldInfo.FieldIsMaybe {
+				group.If(s.optionalWrapper().isSet(fieldStmt)).Block(appendStmt)
+			} else {
+				group.Add(appendStmt)
+			}
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Qual("log/slog", "GroupValue").Call(jen.Add(attrs).Op("...")))
+}
+
+// generateString generates the body of the String method. Values are
+// formatted like in Labels, string values are quoted.
+func (s *StructGenerator) generateString(group *jen.Group) {
+	// b would shadow the receivers of contents starting with a B.
+	b := jen.Id("sb")
+
+	group.Var().Add(b).Qual("strings", "Builder")
+	group.Add(b).Dot("WriteString").Call(s.commandValue())
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+			var writeStmt jen.Code
+			if param.Param.Sensitive {
+				writeStmt = jen.Add(b).Dot("WriteString").Call(jen.Lit(" " + param.Param.Name + "=" + redactedValue))
+			} else {
+				verb := "%v"
+				value := jen.Code(fieldStmt)
+				if encoded := s.displayEncoded(param); encoded != nil {
+					value = encoded
+				} else {
+					if param.Param.Type == "string" {
+						verb = "%q"
+					}
+					if param.FieldInfo.FieldIsMaybe {
+						value = s.optionalWrapper().value(fieldStmt)
+					}
+				}
+				writeStmt = jen.Qual("fmt", "Fprintf").Call(jen.Op("&").Add(b), jen.Lit(" "+param.Param.Name+"="+verb), value)
+			}
+
+			if param.FieldInfo.FieldIsMaybe {
+				group.If(s.optionalWrapper().isSet(fieldStmt)).Block(writeStmt)
+			} else {
+				group.Add(writeStmt)
+			}
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Add(b).Dot("String").Call())
+}
+
+// generateLabels generates the body of the Labels method. Values are
+// formatted from the decoded fields, unless the mapper encodes the value from
+// the fields, such as the bitmask mapper.
+func (s *StructGenerator) generateLabels(group *jen.Group) {
+	labels := jen.Id("labels")
+
+	var labelParams []paramInfo
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !isConstParam(param) && !param.Param.Sensitive {
+				labelParams = append(labelParams, param)
+			}
+		}
+	}
+
+	group.Add(labels).Op(":=").Make(jen.Map(jen.String()).String(), jen.Lit(len(labelParams)))
+
+	for _, param := range labelParams {
+		fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+		var value jen.Code
+		if encoded := s.displayEncoded(param); encoded != nil {
+			value = encoded
+		} else if param.FieldInfo.FieldIsMaybe {
+			value = jen.Qual("fmt", "Sprint").Call(s.optionalWrapper().value(fieldStmt))
+		} else {
+			value = jen.Qual("fmt", "Sprint").Call(fieldStmt)
+		}
+		assignStmt := jen.Add(labels).Index(jen.Lit(displayNameFromParam(param.Param))).Op("=").Add(value)
+
+		if param.FieldInfo.FieldIsMaybe {
+			group.If(s.optionalWrapper().isSet(fieldStmt)).Block(assignStmt)
+		} else {
+			group.Add(assignStmt)
+		}
+	}
+
+	group.Line()
+
+	group.Return(labels)
+}
+
+// displayEncoded returns code evaluating to the escaped value encoded from the
+// field if the field is not fit for display, such as the struct of bitmask
+// params, nil otherwise. The fields of the basic, list and enum mappers are
+// displayed as is.
+func (s *StructGenerator) displayEncoded(param paramInfo) jen.Code {
+	switch {
+	case param.Mapper == BasicMapper || param.Mapper == ListMapper || param.Mapper == EnumMapper:
+		return nil
+	case !param.FieldInfo.StrIsSingular || !param.Mapper.Builder.EncodesValues():
+		return nil
+	}
+
+	return s.encodeValue(param, s.fieldValue(param))
+}
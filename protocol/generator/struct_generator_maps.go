@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// mapKey returns the key of the param in the maps of ToMap and FromMap, i.e.
+// the flag name of named params and the name of positional params.
+func mapKey(param paramInfo) string {
+	if param.Param.Mode == ParamModeNamed {
+		return flagNameFromParam(param.Param)
+	}
+
+	return param.Param.Name
+}
+
+// generateMapMethods generates the ToMap and FromMap methods converting the
+// content from and to a map of (escaped) values.
+func (s *StructGenerator) generateMapMethods(file *jen.File) {
+	file.Comment("ToMap returns the (escaped) values of the params set in the content, keyed")
+	file.Comment("by the names of positional params and the flag names of named params. The")
+	file.Comment("values of multi-valued params are separated by spaces. Flags not mapped to")
+	file.Comment("a param are included as well.")
+	file.Func().Params(s.receiver()).
+		Id("ToMap").Params().Map(jen.String()).String().
+		BlockFunc(s.generateToMap)
+
+	file.Line()
+
+	file.Comment("FromMap replaces the content by the (escaped) values of params, keyed as")
+	file.Comment("returned by ToMap. The values are parsed and checked as by ParseInto. Keys")
+	file.Comment("not naming a param are stored in Flags, if they are flag names.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("FromMap").Params(jen.Id("values").Map(jen.String()).String()).Error().
+		BlockFunc(s.generateFromMap)
+
+	file.Line()
+}
+
+// generateToMap generates the body of the ToMap method.
+func (s *StructGenerator) generateToMap(group *jen.Group) {
+	values := jen.Id("values")
+
+	group.Add(values).Op(":=").Id(s.typeLetter).Dot("UnknownFlags").Call()
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if isConstParam(param) {
+				continue
+			}
+
+			assign := jen.Add(values).Index(jen.Lit(mapKey(param))).Op("=")
+
+			// Required positional params are set even without values, as
+			// FromMap requires their keys.
+			keyRequired := param.Param.Mode == ParamModePositional && param.Param.Required
+
+			if param.FieldInfo.StrIsSingular {
+				if keyRequired {
+					group.Add(assign.Add(s.singularStrValue(param)))
+				} else {
+					s.ifPresent(group, param, assign.Add(s.singularStrValue(param)))
+				}
+				continue
+			}
+
+			setValues := func(group *jen.Group) {
+				if !encodesFromField(param) {
+					var flagLen int
+					if param.Param.Mode == ParamModeNamed {
+						flagLen = 2
+					}
+					group.Add(assign.Id("joinValues").Call(s.strStmt(param), jen.Lit(flagLen)))
+					return
+				}
+
+				strs := toLowerCamelCase(param.Param.Name) + "Strs"
+				s.generateEncodeValues(group, param, strs)
+				group.Add(assign.Id("joinValues").Call(jen.Id(strs), jen.Lit(0)))
+			}
+
+			if keyRequired {
+				setValues(group)
+			} else {
+				group.If(jen.Len(s.paramValues(param)).Op(">").Lit(0)).BlockFunc(setValues)
+			}
+		}
+	}
+
+	group.Line()
+
+	group.Return(values)
+}
+
+// generateFromMap generates the body of the FromMap method, which assembles
+// the params in the form expected by ParseInto.
+func (s *StructGenerator) generateFromMap(group *jen.Group) {
+	params := jen.Id("params")
+	values := jen.Id("values")
+
+	group.Add(params).Op(":=").Make(jen.Index().String(), jen.Lit(0), jen.Len(values))
+
+	var keys []jen.Code
+
+	for _, param := range s.positionalParams {
+		if isConstParam(param) {
+			group.Add(params).Op("=").Append(params, jen.Lit(param.Param.Const))
+			continue
+		}
+
+		key := mapKey(param)
+		keys = append(keys, jen.Lit(key))
+
+		value := jen.Id("val")
+		if !param.FieldInfo.StrIsSingular {
+			value = jen.Id("splitValues").Call(jen.Id("val")).Op("...")
+		}
+
+		ifStmt := group.If(
+			jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").Add(values).Index(jen.Lit(key)),
+			jen.Id("ok"),
+		).Block(
+			jen.Add(params).Op("=").Append(params, value),
+		)
+
+		if param.Param.Required && param.Gate == nil {
+			ifStmt.Else().Block(
+				jen.Return(s.wrapError("converting param "+param.Param.Name+" of message "+s.message.Command,
+					jen.Id("ErrMissingParam"))),
+			)
+		}
+	}
+
+	for _, param := range s.namedParams {
+		key := mapKey(param)
+		keys = append(keys, jen.Lit(key))
+
+		var appendStmt jen.Code
+		if param.FieldInfo.StrIsSingular {
+			appendStmt = jen.Add(params).Op("=").Append(params, jen.Lit(key).Op("+").Id("val"))
+		} else {
+			appendStmt = jen.For(jen.List(jen.Id("_"), jen.Id("value")).Op(":=").Range().Id("splitValues").Call(jen.Id("val"))).Block(
+				jen.Add(params).Op("=").Append(params, jen.Lit(key).Op("+").Id("value")),
+			)
+		}
+
+		group.If(
+			jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").Add(values).Index(jen.Lit(key)),
+			jen.Id("ok"),
+		).Block(appendStmt)
+	}
+
+	group.For(jen.List(jen.Id("key"), jen.Id("val")).Op(":=").Range().Add(values)).BlockFunc(func(group *jen.Group) {
+		if len(keys) > 0 {
+			group.Switch(jen.Id("key")).Block(
+				jen.Case(keys...).Block(jen.Continue()),
+			)
+		}
+		group.If(jen.Op("!").Id("isNamedParam").Call(jen.Id("key")).Op("||").Len(jen.Id("key")).Op("!=").Lit(2)).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(
+				jen.Lit("converting message "+s.message.Command+": key %q: %w"),
+				jen.Id("key"),
+				jen.Id("ErrMalformedFlag"),
+			)),
+		)
+		group.Add(params).Op("=").Append(params, jen.Id("key").Op("+").Id("val"))
+	})
+
+	group.Line()
+
+	group.Return(jen.Id(s.typeLetter).Dot("ParseInto").Call(params, jen.Nil()))
+}
@@ -0,0 +1,342 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// marshalTarget abstracts the destination of the marshalled content, which is
+// shared by AppendADC and ADCString.
+type marshalTarget interface {
+	// begin generates code writing the command. checked is true if the
+	// method may fail.
+	begin(group *jen.Group, command jen.Code, checked bool)
+	// token generates code writing a separator followed by the escaped
+	// token.
+	token(group *jen.Group, token jen.Code)
+	// fail returns code returning err from the method, nil if the target
+	// performs no checks.
+	fail(err jen.Code) jen.Code
+	// raw returns code returning the unmodified line raw from the method.
+	raw(raw jen.Code) jen.Code
+	// end generates code writing the flags and the terminator and returning
+	// the result.
+	end(group *jen.Group, flags jen.Code)
+	// buf returns the byte slice the target appends to, nil if tokens are
+	// written as strings.
+	buf() jen.Code
+	// redacts returns true if the values of sensitive params are masked.
+	redacts() bool
+}
+
+// appendTarget appends to the buf byte slice, returning the extended slice
+// and an error. On failure, buf is returned with its original length.
+type appendTarget struct{}
+
+func (appendTarget) begin(group *jen.Group, command jen.Code, checked bool) {
+	if checked {
+		group.Id("n").Op(":=").Len(jen.Id("buf"))
+	}
+	group.Id("buf").Op("=").Append(jen.Id("buf"), jen.Add(command).Op("..."))
+}
+
+func (appendTarget) token(group *jen.Group, token jen.Code) {
+	group.Id("buf").Op("=").Append(jen.Id("buf"), jen.LitRune(' '))
+	group.Id("buf").Op("=").Append(jen.Id("buf"), jen.Add(token).Op("..."))
+}
+
+func (appendTarget) fail(err jen.Code) jen.Code {
+	return jen.Return(jen.Id("buf").Index(jen.Empty(), jen.Id("n")), err)
+}
+
+func (appendTarget) raw(raw jen.Code) jen.Code {
+	return jen.Return(jen.Append(jen.Id("buf"), jen.Add(raw).Op("...")), jen.Nil())
+}
+
+func (appendTarget) buf() jen.Code {
+	return jen.Id("buf")
+}
+
+func (appendTarget) redacts() bool {
+	return false
+}
+
+func (appendTarget) end(group *jen.Group, flags jen.Code) {
+	group.Id("buf").Op("=").Id("appendFlags").Call(jen.Id("buf"), flags)
+
+	group.Line()
+
+	group.Return(jen.Append(jen.Id("buf"), jen.LitRune('\n')), jen.Nil())
+}
+
+// builderTarget writes to a strings.Builder sized by WireSize, returning the
+// built string. The empty string is returned on errors.
+type builderTarget struct {
+	typeLetter string
+}
+
+func (t builderTarget) begin(group *jen.Group, command jen.Code, checked bool) {
+	group.Var().Id("builder").Qual("strings", "Builder")
+	group.Id("builder").Dot("Grow").Call(jen.Id(t.typeLetter).Dot("WireSize").Call())
+	group.Id("builder").Dot("WriteString").Call(command)
+}
+
+func (builderTarget) token(group *jen.Group, token jen.Code) {
+	group.Id("builder").Dot("WriteByte").Call(jen.LitRune(' '))
+	group.Id("builder").Dot("WriteString").Call(token)
+}
+
+func (builderTarget) fail(jen.Code) jen.Code {
+	return jen.Return(jen.Lit(""))
+}
+
+func (builderTarget) raw(raw jen.Code) jen.Code {
+	return jen.Return(jen.String().Call(raw))
+}
+
+func (builderTarget) buf() jen.Code {
+	return nil
+}
+
+func (builderTarget) redacts() bool {
+	return false
+}
+
+func (builderTarget) end(group *jen.Group, flags jen.Code) {
+	group.Id("writeFlags").Call(jen.Op("&").Id("builder"), flags)
+	group.Id("builder").Dot("WriteByte").Call(jen.LitRune('\n'))
+
+	group.Line()
+
+	group.Return(jen.Id("builder").Dot("String").Call())
+}
+
+// encodeTarget appends to the buf byte slice like appendTarget, but performs
+// no checks and thus never fails.
+type encodeTarget struct {
+	appendTarget
+}
+
+func (encodeTarget) begin(group *jen.Group, command jen.Code, checked bool) {
+	group.Id("buf").Op("=").Append(jen.Id("buf"), jen.Add(command).Op("..."))
+}
+
+func (encodeTarget) fail(jen.Code) jen.Code {
+	return nil
+}
+
+func (encodeTarget) raw(raw jen.Code) jen.Code {
+	return jen.Return(jen.Append(jen.Id("buf"), jen.Add(raw).Op("...")))
+}
+
+func (encodeTarget) end(group *jen.Group, flags jen.Code) {
+	group.Id("buf").Op("=").Id("encodeFlags").Call(jen.Id("buf"), flags)
+
+	group.Line()
+
+	group.Return(jen.Append(jen.Id("buf"), jen.LitRune('\n')))
+}
+
+// redactTarget appends to the buf byte slice like appendTarget, but writes
+// redactedValue in place of the values of sensitive params. The raw line is
+// never returned, as it holds the unmasked values.
+type redactTarget struct {
+	appendTarget
+}
+
+func (redactTarget) redacts() bool {
+	return true
+}
+
+// failIf generates code failing the method of target with err if cond holds.
+// Nothing is generated for targets performing no checks and nil conds.
+func failIf(group *jen.Group, target marshalTarget, cond jen.Code, err jen.Code) {
+	if fail := target.fail(err); fail != nil && cond != nil {
+		group.If(cond).Block(fail)
+	}
+}
+
+// marshalValue returns code evaluating to the token of the singular param
+// written by AppendADC.
+func (s *StructGenerator) marshalValue(param paramInfo) jen.Code {
+	if param.Param.Mode == ParamModeNamed {
+		return s.namedStrValue(param)
+	}
+
+	return s.singularStrValue(param)
+}
+
+// writeToken generates code writing the token of the singular param to
+// target.
+func (s *StructGenerator) writeToken(group *jen.Group, param paramInfo, target marshalTarget) {
+	if target.redacts() && param.Param.Sensitive {
+		target.token(group, s.redactedToken(param))
+		return
+	}
+
+	var value jen.Code
+	if encodesFromField(param) && !isConstParam(param) {
+		value = s.fieldValue(param)
+	}
+
+	s.writeEncodedToken(group, param, target, value, s.marshalValue(param))
+}
+
+// writeValues generates code writing the tokens of all values of the
+// multi-valued param to target.
+func (s *StructGenerator) writeValues(group *jen.Group, param paramInfo, target marshalTarget) {
+	if target.redacts() && param.Param.Sensitive {
+		group.For(jen.Range().Add(s.paramValues(param))).BlockFunc(func(group *jen.Group) {
+			target.token(group, s.redactedToken(param))
+		})
+		return
+	}
+
+	var value jen.Code
+	if encodesFromField(param) {
+		value = jen.Id("val")
+	}
+
+	group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(s.paramValues(param))).
+		BlockFunc(func(group *jen.Group) {
+			s.writeEncodedToken(group, param, target, value, s.elemToken(param, jen.Id("val")))
+		})
+}
+
+// redactedToken returns the token written in place of the values of the
+// sensitive param by redacting targets.
+func (s *StructGenerator) redactedToken(param paramInfo) jen.Code {
+	if param.Param.Mode == ParamModeNamed {
+		return jen.Lit(s.flagName(param) + redactedValue)
+	}
+
+	return jen.Lit(redactedValue)
+}
+
+// writeEncodedToken generates code writing token to target. If value, the
+// single value of the param encoded by token, is non-nil and the target has a
+// byte slice, the value is appended to it directly instead.
+func (s *StructGenerator) writeEncodedToken(group *jen.Group, param paramInfo, target marshalTarget, value, token jen.Code) {
+	buf := target.buf()
+	if buf == nil || value == nil {
+		target.token(group, token)
+		return
+	}
+
+	if param.Param.Mode == ParamModeNamed {
+		group.Add(buf).Op("=").Append(buf, jen.Lit(" "+s.flagName(param)).Op("..."))
+	} else {
+		group.Add(buf).Op("=").Append(buf, jen.LitRune(' '))
+	}
+	group.Add(buf).Op("=").Add(s.appendValue(param, buf, value))
+}
+
+// marshalChecked returns true if marshalling the content may fail, i.e. if
+// generateMarshal generates any checks.
+func (s *StructGenerator) marshalChecked() bool {
+	if s.isFamily() {
+		return true
+	}
+
+	for _, param := range s.positionalParams {
+		if isConstParam(param) {
+			continue
+		}
+		if param.FieldInfo.StrIsSingular && s.paramMissing(param) != nil || hasParallelSlices(param) {
+			return true
+		}
+	}
+
+	for _, param := range s.namedParams {
+		if s.isDropped(param) {
+			continue
+		}
+		switch {
+		case !param.FieldInfo.StrIsSingular:
+			if hasParallelSlices(param) {
+				return true
+			}
+		case param.FieldInfo.FieldIsMaybe:
+			if !encodesFromField(param) {
+				return true
+			}
+		case s.paramMissing(param) != nil:
+			return true
+		}
+	}
+
+	return false
+}
+
+// generateMarshal generates the body of a method marshalling the content into
+// target.
+func (s *StructGenerator) generateMarshal(group *jen.Group, target marshalTarget) {
+	if s.rawPassthrough && !target.redacts() {
+		group.If(s.rawValid()).Block(
+			target.raw(jen.Id(s.typeLetter).Dot("raw")),
+		)
+
+		group.Line()
+	}
+
+	target.begin(group, s.commandValue(), s.marshalChecked())
+	if s.isFamily() {
+		failIf(group, target, jen.Add(s.commandValue()).Op("==").Lit(""),
+			s.wrapError("marshalling message "+s.message.Command, jen.Id("ErrMissingCommand")))
+	}
+
+	group.Line()
+
+	for _, param := range s.positionalParams {
+		if isConstParam(param) {
+			s.writeToken(group, param, target)
+		} else if param.Gate != nil {
+			group.If(s.gateCond(param)).BlockFunc(func(group *jen.Group) {
+				failIf(group, target, s.paramMissing(param),
+					s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrMissingParam")))
+				s.writeToken(group, param, target)
+			})
+		} else if param.FieldInfo.StrIsSingular {
+			failIf(group, target, s.paramMissing(param),
+				s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrMissingParam")))
+			s.writeToken(group, param, target)
+		} else {
+			if hasParallelSlices(param) {
+				failIf(group, target, s.sliceLengthsDiffer(param),
+					s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrLengthMismatch")))
+			}
+			s.writeValues(group, param, target)
+		}
+	}
+
+	for _, param := range s.namedParams {
+		if s.isDropped(param) {
+			continue
+		}
+
+		if !param.FieldInfo.StrIsSingular {
+			if hasParallelSlices(param) {
+				failIf(group, target, s.sliceLengthsDiffer(param),
+					s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrLengthMismatch")))
+			}
+			s.writeValues(group, param, target)
+		} else if param.FieldInfo.FieldIsMaybe {
+			group.If(
+				s.optionalWrapper().isSet(s.fieldStmt(param)),
+			).BlockFunc(func(group *jen.Group) {
+				// Values taken from the str field may have been set without
+				// it.
+				if !encodesFromField(param) {
+					failIf(group, target, s.strStmt(param).Op("==").Lit(""),
+						s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrMissingParam")))
+				}
+				s.writeToken(group, param, target)
+			})
+		} else {
+			failIf(group, target, s.paramMissing(param),
+				s.wrapError(s.marshalErrorPrefix(param), jen.Id("ErrMissingParam")))
+			s.writeToken(group, param, target)
+		}
+	}
+
+	target.end(group, jen.Id(s.typeLetter).Dot("Flags"))
+}
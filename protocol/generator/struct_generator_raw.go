@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// rawValid returns code evaluating to true if the content holds the line
+// parsed by UnmarshalADC and has not been modified since.
+func (s *StructGenerator) rawValid() jen.Code {
+	return jen.Id(s.typeLetter).Dot("raw").Op("!=").Nil().Op("&&").Op("!").Id(s.typeLetter).Dot("dirty")
+}
+
+// markDirty returns code marking the content as modified, if raw passthrough
+// is enabled.
+func (s *StructGenerator) markDirty() jen.Code {
+	if !s.rawPassthrough {
+		return jen.Null()
+	}
+
+	return jen.Id(s.typeLetter).Dot("dirty").Op("=").True()
+}
+
+func (s *StructGenerator) generateRawFields(group *jen.Group) {
+	group.Line()
+	group.Comment("raw is the line parsed by UnmarshalADC, which is marshalled verbatim")
+	group.Comment("unless dirty is set.")
+	group.Id("raw").Index().Byte()
+	group.Comment("dirty is set if the content has been modified since raw was stored.")
+	group.Comment("Methods modifying the content set dirty or reset raw, direct writes to")
+	group.Comment("the fields must be followed by MarkDirty.")
+	group.Id("dirty").Bool()
+}
+
+func (s *StructGenerator) generateUnmarshalADC(group *jen.Group) {
+	group.List(jen.Id("tokens"), jen.Err()).Op(":=").Id("splitTokens").Call(
+		jen.String().Call(jen.Id("line")), jen.Lit(1), jen.Nil(),
+	)
+	group.If(jen.Err().Op("!=").Nil()).Block(
+		jen.Return(s.wrapError("parsing message "+s.message.Command, jen.Err())),
+	)
+
+	parseStmt := jen.Id(s.typeLetter).Dot("ParseTokens").Call(jen.Id("tokens"))
+	if !s.rawPassthrough {
+		group.Return(parseStmt)
+		return
+	}
+
+	group.If(jen.Err().Op(":=").Add(parseStmt), jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Err()),
+	)
+
+	group.Line()
+
+	group.Id(s.typeLetter).Dot("raw").Op("=").Id("rawLine").Call(jen.Id("line"))
+	group.Id(s.typeLetter).Dot("dirty").Op("=").False()
+	group.Return(jen.Nil())
+}
+
+func (s *StructGenerator) generateMarkDirty(file *jen.File) {
+	file.Comment("MarkDirty causes MarshalADC to regenerate the line from the params instead")
+	file.Comment("of returning the line parsed by UnmarshalADC. It must be called after")
+	file.Comment("modifying the fields of the content directly, including Flags. Methods")
+	file.Comment("modifying the content, such as SetNamedAll, Decode and FromMap, do not")
+	file.Comment("require it.")
+	file.Func().Params(jen.Id(s.typeLetter).Op("*").Id(s.typeName)).
+		Id("MarkDirty").Params().
+		Block(
+			jen.Id(s.typeLetter).Dot("dirty").Op("=").True(),
+		)
+
+	file.Line()
+}
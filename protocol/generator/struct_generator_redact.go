@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// redactedValue replaces the values of sensitive params in redacted copies.
+const redactedValue = "***"
+
+func (s *StructGenerator) generateRedacted(group *jen.Group) {
+	redacted := jen.Id("redacted")
+	hasZero := false
+
+	group.Add(redacted).Op(":=").Add(s.receiverValue())
+
+	group.If(jen.Id(s.typeLetter).Dot("Flags").Op("!=").Nil()).Block(
+		jen.Add(redacted).Dot("Flags").Op("=").Id(s.typeLetter).Dot("UnknownFlags").Call(),
+	)
+	if s.rawPassthrough {
+		// The raw line holds the unmasked values.
+		group.Add(redacted).Dot("raw").Op("=").Nil()
+	}
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !param.Param.Sensitive {
+				// Copied as by Clone, the values of sensitive params are
+				// replaced below.
+				s.generateCloneParam(group, redacted, param)
+			}
+
+			if param.Param.Sensitive && !isRedactedAsString(param) {
+				hasZero = true
+			}
+		}
+	}
+
+	if hasZero {
+		group.Var().Id("zero").Id(s.typeName)
+	}
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.Param.Sensitive {
+				s.generateRedactParam(group, redacted, param)
+			}
+		}
+	}
+
+	group.Line()
+
+	group.Return(jen.Op("&").Add(redacted))
+}
+
+// generateRedactParam generates code replacing the field of the sensitive
+// param in the redacted copy, as well as its str field unless the values of
+// the param are encoded from the field.
+func (s *StructGenerator) generateRedactParam(group *jen.Group, redacted *jen.Statement, param paramInfo) {
+	strStmt := jen.Add(redacted).Dot("").Add(param.FieldInfo.StrFieldName)
+	fieldStmt := jen.Add(redacted).Dot("").Add(param.FieldInfo.FieldName)
+	origFieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+	strValue := redactedValue
+	if param.Param.Mode == ParamModeNamed {
+		ctx := s.createContext(param)
+		strValue = param.Mapper.Parser.Named.ParamName(&ctx) + redactedValue
+	}
+
+	zeroStmt := jen.Id("zero").Dot("").Add(param.FieldInfo.FieldName)
+
+	setStr := !encodesFromField(param)
+
+	if !param.FieldInfo.StrIsSingular && setStr {
+		group.Add(strStmt).Op("=").Make(
+			jen.Index().String(),
+			jen.Len(jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)),
+		)
+		group.For(jen.Id("i").Op(":=").Range().Add(strStmt)).Block(
+			jen.Add(strStmt).Index(jen.Id("i")).Op("=").Lit(strValue),
+		)
+	}
+
+	if param.FieldInfo.Multiplicity == MultiplicityDynamic {
+		group.Add(fieldStmt).Op("=").Make(param.FieldInfo.FieldType, jen.Len(origFieldStmt))
+		if isRedactedAsString(param) {
+			group.For(jen.Id("i").Op(":=").Range().Add(fieldStmt)).Block(
+				jen.Add(fieldStmt).Index(jen.Id("i")).Op("=").Lit(redactedValue),
+			)
+		}
+		return
+	}
+
+	var fieldValue jen.Code
+	if isRedactedAsString(param) {
+		fieldValue = jen.Lit(redactedValue)
+	} else if param.FieldInfo.FieldIsMaybe {
+		fieldValue = s.optionalWrapper().value(zeroStmt)
+	} else {
+		fieldValue = zeroStmt
+	}
+
+	// The values of present Maybe fields are replaced, keeping them set.
+	assignStmt := jen.Add(fieldStmt).Op("=").Add(fieldValue)
+	if param.FieldInfo.FieldIsMaybe {
+		assignStmt = s.optionalWrapper().set(fieldStmt, fieldValue)
+	}
+
+	if param.FieldInfo.StrIsSingular {
+		// Only params present in the content are masked.
+		if setStr {
+			s.ifPresent(group, param, jen.Add(strStmt).Op("=").Lit(strValue), assignStmt)
+		} else {
+			s.ifPresent(group, param, assignStmt)
+		}
+	} else {
+		group.Add(assignStmt)
+	}
+}
+
+// isRedactedAsString returns true if the decoded values of the param are
+// strings, which are replaced by redactedValue. Values of other types are
+// replaced by their zero value.
+func isRedactedAsString(param paramInfo) bool {
+	return param.Param.Type == "string" &&
+		(param.Mapper == BasicMapper || param.Mapper == ListMapper)
+}
@@ -0,0 +1,896 @@
+package generator_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+// testMessage is a message covering all param modes and multiplicities.
+// deprecatedMessage has the deprecated named param OL, superseded by NW.
+var deprecatedMessage = generator.Message{
+	Command: "DEP",
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:       generator.ParamModeNamed,
+			Name:       "OL",
+			Type:       "string",
+			Deprecated: "superseded by NW",
+		},
+		&generator.Param{
+			Mode: generator.ParamModeNamed,
+			Name: "NW",
+			Type: "string",
+		},
+	},
+}
+
+var testMessage = generator.Message{
+	Command: "TST",
+	PositionalParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Code",
+			Type:     "int",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModePositional,
+			Name:     "Items",
+			Type:     "string",
+			Mapper:   "list",
+			Required: true,
+		},
+	},
+	NamedParams: []*generator.Param{
+		&generator.Param{
+			Mode:     generator.ParamModeNamed,
+			Name:     "NI",
+			Type:     "string",
+			Required: true,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModeNamed,
+			Name:     "I4",
+			Type:     "ip",
+			Required: false,
+		},
+		&generator.Param{
+			Mode:     generator.ParamModeNamed,
+			Name:     "ID",
+			Type:     "base32",
+			Required: false,
+		},
+	},
+}
+
+// render renders the message of the generator g.
+func render(g *generator.StructGenerator) string {
+	buf := bytes.NewBuffer(nil)
+	err := g.Render(buf)
+	Ω(err).ShouldNot(HaveOccurred())
+	return buf.String()
+}
+
+// imports returns the import paths of the source code src.
+func imports(src string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ImportsOnly)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	var paths []string
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		Ω(err).ShouldNot(HaveOccurred())
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+var _ = Describe("generator.StructGenerator", func() {
+	Describe("NoReflect", func() {
+		It("should render the message without depending on reflection", func() {
+			g := generator.NewStructGenerator(&testMessage)
+			g.NoReflect = true
+
+			src := render(g)
+			Ω(imports(src)).ShouldNot(ContainElement("reflect"))
+			Ω(imports(src)).ShouldNot(ContainElement("encoding/json"))
+		})
+
+		It("should omit the methods formatting values using fmt verbs and slog.Any", func() {
+			g := generator.NewStructGenerator(&testMessage)
+			g.NoReflect = true
+
+			src := render(g)
+			Ω(imports(src)).ShouldNot(ContainElement("log/slog"))
+			Ω(src).ShouldNot(ContainSubstring("slog.Any"))
+			Ω(src).ShouldNot(ContainSubstring("fmt.Sprint("))
+			Ω(src).ShouldNot(ContainSubstring("fmt.Fprintf"))
+			for _, method := range []string{"Format", "String", "Labels", "LogValue"} {
+				Ω(src).ShouldNot(ContainSubstring("TSTContent) "+method+"("), "method %s", method)
+			}
+
+			Ω(render(generator.NewStructGenerator(&testMessage))).Should(ContainSubstring("TSTContent) LogValue("))
+		})
+	})
+
+	Describe("flag constants", func() {
+		namedMessage := func(flagNames ...string) *generator.Message {
+			msg := &generator.Message{Command: "FLG"}
+			for i, flagName := range flagNames {
+				msg.NamedParams = append(msg.NamedParams, &generator.Param{
+					Mode:     generator.ParamModeNamed,
+					Name:     "Param" + strconv.Itoa(i),
+					FlagName: flagName,
+					Type:     "string",
+				})
+			}
+			return msg
+		}
+
+		It("should sanitize flag names invalid in Go identifiers", func() {
+			src := render(generator.NewStructGenerator(namedMessage("A+", "B1")))
+
+			_, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(src).Should(ContainSubstring(`FLGFlagA_2B FLGFlag = "A+"`))
+			Ω(src).Should(ContainSubstring("case FLGFlagB1:"))
+		})
+
+		It("should reject flag names which cannot be mapped", func() {
+			err := generator.NewStructGenerator(namedMessage("A\x00")).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidFlagName))
+		})
+
+		It("should reject flag names only differing by case", func() {
+			err := generator.NewStructGenerator(namedMessage("AB", "ab")).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrAmbiguousFlagName))
+		})
+	})
+
+	Describe("receivers", func() {
+		It("should not use names declared by the methods as receiver name", func() {
+			for _, command := range []string{"INF", "FIX", "NAT", "WRT"} {
+				msg := testMessage
+				msg.Command = command
+				src := render(generator.NewStructGenerator(&msg))
+				Ω(src).Should(ContainSubstring("func (c *" + command + "Content) PosAt(i int) string"))
+			}
+		})
+	})
+
+	Describe("bitmask params", func() {
+		bitmaskMessage := func(mode generator.ParamMode, bits ...string) *generator.Message {
+			param := &generator.Param{
+				Mode:     mode,
+				Name:     "ST",
+				Type:     "int",
+				Mapper:   "bitmask",
+				Required: true,
+				Bits:     bits,
+			}
+			msg := &generator.Message{Command: "TST"}
+			if mode == generator.ParamModeNamed {
+				msg.NamedParams = []*generator.Param{param}
+			} else {
+				msg.PositionalParams = []*generator.Param{param}
+			}
+			return msg
+		}
+
+		It("should reject named bitmask params", func() {
+			err := generator.NewStructGenerator(bitmaskMessage(generator.ParamModeNamed, "Fatal")).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrUnavailableMode))
+		})
+
+		It("should reject bits which are not Go identifiers", func() {
+			for _, bit := range []string{"", "1st", "no-op", "func"} {
+				err := generator.NewStructGenerator(bitmaskMessage(generator.ParamModePositional, "Fatal", bit)).
+					Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidBits), "bit %q", bit)
+			}
+		})
+
+		It("should reject duplicate bits", func() {
+			err := generator.NewStructGenerator(bitmaskMessage(generator.ParamModePositional, "Fatal", "Fatal")).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidBits))
+		})
+
+		It("should accept at most 63 bits", func() {
+			bits := make([]string, 64)
+			for i := range bits {
+				bits[i] = "B" + strconv.Itoa(i)
+			}
+			err := generator.NewStructGenerator(bitmaskMessage(generator.ParamModePositional, bits[:63]...)).
+				Render(bytes.NewBuffer(nil))
+			Ω(err).ShouldNot(HaveOccurred())
+			err = generator.NewStructGenerator(bitmaskMessage(generator.ParamModePositional, bits...)).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidBits))
+		})
+	})
+
+	Describe("dynamic multiplicity", func() {
+		It("should reject more than one positional param with dynamic multiplicity", func() {
+			list := func(name string) *generator.Param {
+				return &generator.Param{
+					Mode:     generator.ParamModePositional,
+					Name:     name,
+					Type:     "string",
+					Required: true,
+					Mapper:   "list",
+				}
+			}
+			msg := &generator.Message{
+				Command:          "TST",
+				PositionalParams: []*generator.Param{list("A"), list("B")},
+			}
+			err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrMultipleDynamic))
+		})
+	})
+
+	Describe("TypeName", func() {
+		It("should name the content type and receivers of all methods", func() {
+			g := generator.NewStructGenerator(&testMessage)
+			g.TypeName = "MessageTST"
+			src := render(g)
+
+			Ω(src).ShouldNot(ContainSubstring("TSTContent"))
+			Ω(src).Should(ContainSubstring("type MessageTST struct"))
+			Ω(src).Should(ContainSubstring("func MessageTSTFromAccessor(pa ParamAccessor) (*MessageTST, error)"))
+			for _, line := range strings.Split(src, "\n") {
+				if strings.HasPrefix(line, "func (") && !strings.HasPrefix(line, "func (f TSTFlag)") &&
+					!strings.HasPrefix(line, "func (b *TSTBuilder)") {
+					Ω(line).Should(HavePrefix("func (m *MessageTST) "))
+				}
+			}
+		})
+
+		It("should reject type names which are not exported identifiers", func() {
+			for _, typeName := range []string{"messageTST", "Message-TST", "1TST"} {
+				g := generator.NewStructGenerator(&testMessage)
+				g.TypeName = typeName
+				err := g.Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidTypeName))
+			}
+		})
+	})
+
+	Describe("cache key parts", func() {
+		It("should generate CacheKey only for messages with cache key parts", func() {
+			Ω(render(generator.NewStructGenerator(&testMessage))).ShouldNot(ContainSubstring("CacheKey"))
+
+			msg := testMessage
+			msg.NamedParams = []*generator.Param{
+				&generator.Param{
+					Mode:         generator.ParamModeNamed,
+					Name:         "NI",
+					Type:         "string",
+					CacheKeyPart: true,
+				},
+			}
+			Ω(render(generator.NewStructGenerator(&msg))).Should(ContainSubstring(`niPart = "NI" + escapeValue(t.NI.Value)`))
+		})
+
+		It("should reject cache key parts with multiple values", func() {
+			msg := testMessage
+			msg.PositionalParams = []*generator.Param{
+				&generator.Param{
+					Mode:         generator.ParamModePositional,
+					Name:         "Items",
+					Type:         "string",
+					Mapper:       "list",
+					Required:     true,
+					CacheKeyPart: true,
+				},
+			}
+			err := generator.NewStructGenerator(&msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidCacheKeyPart))
+		})
+	})
+
+	Describe("command families", func() {
+		It("should generate a command field for command patterns", func() {
+			msg := testMessage
+			msg.Command = "TS?"
+			msg.Name = "TSF"
+			src := render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("type TSFContent struct"))
+			Ω(src).Should(ContainSubstring(`matchCommand("TS?", tokens[0])`))
+		})
+
+		It("should reject invalid command patterns", func() {
+			for _, msg := range []generator.Message{
+				{Command: "TS?"},
+				{Command: "TS??", Name: "TSF"},
+				{Command: "?s?", Name: "TSF"},
+			} {
+				msg := msg
+				err := generator.NewStructGenerator(&msg).Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidCommandPattern))
+			}
+		})
+	})
+
+	Describe("dependencies", func() {
+		// isAllowedImport returns true if path is a package of the standard
+		// library or a package of this module referenced by the mappers.
+		isAllowedImport := func(path string) bool {
+			return !strings.Contains(strings.Split(path, "/")[0], ".") ||
+				strings.HasPrefix(path, "github.com/seoester/adcl/protocol/")
+		}
+
+		It("should only import the standard library and the mapper packages", func() {
+			msg := testMessage
+			msg.PositionalParams = []*generator.Param{
+				&generator.Param{Mode: generator.ParamModePositional, Name: "Code", Type: "int", Required: true},
+				&generator.Param{Mode: generator.ParamModePositional, Name: "Ratio", Type: "float", Required: true},
+				&generator.Param{Mode: generator.ParamModePositional, Name: "SID", Type: "base32", Required: true},
+				&generator.Param{Mode: generator.ParamModePositional, Name: "Addr", Type: "ip", Required: true},
+				&generator.Param{
+					Mode: generator.ParamModePositional, Name: "Status", Type: "int", Mapper: "bitmask",
+					Required: true, Bits: []string{"Fatal"},
+				},
+				&generator.Param{
+					Mode: generator.ParamModePositional, Name: "Items", Type: "string", Mapper: "list",
+					Required: true, Sensitive: true,
+				},
+			}
+
+			files, err := generator.NewFileGenerator(&generator.Definition{
+				Messages: []*generator.Message{&msg},
+			}).RenderFiles()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, src := range files {
+				for _, path := range imports(string(src)) {
+					Ω(isAllowedImport(path)).Should(BeTrue(), "unexpected import %s", path)
+				}
+				Ω(imports(string(src))).ShouldNot(ContainElement("github.com/pkg/errors"))
+			}
+		})
+	})
+
+	Describe("message types", func() {
+		It("should reject invalid message types", func() {
+			msg := testMessage
+			msg.Types = "BX"
+			err := generator.NewStructGenerator(&msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidType))
+		})
+	})
+
+	Describe("protocol phases", func() {
+		It("should describe the phases of the message", func() {
+			msg := testMessage
+			msg.Phases = []string{"IDENTIFY", "NORMAL"}
+			Ω(render(generator.NewStructGenerator(&msg))).Should(ContainSubstring("PhaseIdentify | PhaseNormal,"))
+		})
+
+		It("should reject invalid phases", func() {
+			msg := testMessage
+			msg.Phases = []string{"NORMAL", "normal"}
+			err := generator.NewStructGenerator(&msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidPhase))
+		})
+	})
+
+	Describe("PositionalValues()", func() {
+		It("should be generated for messages with static singular positionals", func() {
+			msg := testMessage
+			msg.PositionalParams = testMessage.PositionalParams[:1]
+			src := render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) PositionalValues() int {"))
+		})
+
+		It("should not be generated for messages with dynamic positionals", func() {
+			Ω(render(generator.NewStructGenerator(&testMessage))).ShouldNot(ContainSubstring("PositionalValues"))
+		})
+	})
+
+	Describe("encoders", func() {
+		It("should document the wire format methods", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			Ω(src).Should(ContainSubstring("// AppendADC appends the content in the ADC wire format to buf"))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) AppendADC(buf []byte) ([]byte, error)"))
+			Ω(src).Should(ContainSubstring("// WriteTo writes the content in the ADC wire format to w"))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) WriteTo(w io.Writer) (int64, error)"))
+		})
+	})
+
+	Describe("builder", func() {
+		It("should generate a setter per param and Build", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			Ω(src).Should(ContainSubstring("func NewTSTBuilder() *TSTBuilder"))
+			Ω(src).Should(ContainSubstring("func (b *TSTBuilder) Code(value int) *TSTBuilder"))
+			Ω(src).Should(ContainSubstring("func (b *TSTBuilder) Items(values ...string) *TSTBuilder"))
+			Ω(src).Should(ContainSubstring("func (b *TSTBuilder) I4(value net.IP) *TSTBuilder"))
+			Ω(src).Should(ContainSubstring("b.content.niStr = \"NI\" + str"))
+			Ω(src).Should(ContainSubstring("func (b *TSTBuilder) Build() (*TSTContent, error)"))
+		})
+
+		It("should not generate setters for const params", func() {
+			msg := &generator.Message{
+				Command: "MRK",
+				PositionalParams: []*generator.Param{
+					&generator.Param{
+						Mode:     generator.ParamModePositional,
+						Name:     "Marker",
+						Type:     "string",
+						Required: true,
+						Const:    "V2",
+					},
+				},
+			}
+			Ω(render(generator.NewStructGenerator(msg))).ShouldNot(ContainSubstring("func (b *MRKBuilder) Marker"))
+		})
+	})
+
+	Describe("RenderTest()", func() {
+		It("should render a NamedGet test covering all flags", func() {
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&testMessage).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(imports(src)).Should(ConsistOf("github.com/seoester/adcl/protocol/encoding", "net", "strconv", "strings", "testing"))
+			Ω(src).Should(ContainSubstring("func TestTSTContentNamedGet(t *testing.T)"))
+			Ω(src).Should(ContainSubstring(`{{TSTFlagNI, "sentinel"}, {TSTFlagI4, "192.0.2.1"}, {TSTFlagID, "AEBAG"}}`))
+		})
+
+		It("should not render a NamedGet test for messages without named params", func() {
+			buf := bytes.NewBuffer(nil)
+			msg := testMessage
+			msg.NamedParams = nil
+			err := generator.NewStructGenerator(&msg).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(buf.String()).ShouldNot(ContainSubstring("NamedGet"))
+			Ω(buf.String()).Should(ContainSubstring("func TestTSTContentPosLen(t *testing.T)"))
+		})
+
+		It("should render a PosLen test populating all positional params", func() {
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&testMessage).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(src).Should(ContainSubstring("func TestTSTContentPosLen(t *testing.T)"))
+			Ω(src).Should(ContainSubstring("t.Items = make([]string, n)"))
+			Ω(src).Should(ContainSubstring("t.PosLen(), len(t.Positional())"))
+		})
+
+		It("should populate a single content for static positional layouts", func() {
+			buf := bytes.NewBuffer(nil)
+			msg := testMessage
+			msg.PositionalParams = testMessage.PositionalParams[:1]
+			err := generator.NewStructGenerator(&msg).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(src).Should(ContainSubstring("func TestTSTContentPosLen(t *testing.T)"))
+			Ω(src).ShouldNot(ContainSubstring("for n := 0;"))
+			Ω(src).Should(ContainSubstring(`"PosLen() = %d, want len(Positional()) = %d"`))
+		})
+
+		It("should render a test asserting the positional accessors exclude the command", func() {
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&testMessage).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(src).Should(ContainSubstring("func TestTSTContentPosExcludesCommand(t *testing.T)"))
+			Ω(src).Should(ContainSubstring(`t.NI = "0"`))
+			Ω(src).Should(ContainSubstring("t.Code = 7"))
+			Ω(src).Should(ContainSubstring("got, want := t.PosAt(0), strconv.Itoa(t.Code)"))
+			Ω(src).Should(ContainSubstring("tokens[0] != t.Command()"))
+		})
+
+		It("should not render a PosLen test for messages without positional params", func() {
+			buf := bytes.NewBuffer(nil)
+			msg := testMessage
+			msg.PositionalParams = nil
+			err := generator.NewStructGenerator(&msg).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(buf.String()).ShouldNot(ContainSubstring("PosLen"))
+		})
+
+		It("should render a round-trip test of the examples", func() {
+			Ω(render(generator.NewStructGenerator(&testMessage))).ShouldNot(ContainSubstring("Examples"))
+
+			msg := testMessage
+			msg.Examples = []*generator.Example{
+				&generator.Example{Line: "TST 7 desc", Values: map[string]string{"Code": "7"}},
+			}
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&msg, generator.WithRawPassthrough()).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(src).Should(ContainSubstring("func TestTSTContentExamples(t *testing.T)"))
+			Ω(src).Should(ContainSubstring(`{"TST 7 desc", map[string]string{"Code": "7"}}`))
+			Ω(src).Should(ContainSubstring("t.UnmarshalADC([]byte(example.line + \"\\n\"))"))
+			Ω(src).Should(ContainSubstring("t.MarkDirty()"))
+		})
+
+		It("should render a fuzz target seeded with the params of the examples", func() {
+			msg := testMessage
+			msg.Examples = []*generator.Example{
+				&generator.Example{Line: "TST 7 desc", Values: map[string]string{"Code": "7"}},
+			}
+			buf := bytes.NewBuffer(nil)
+			err := generator.NewStructGenerator(&msg).RenderTest(buf)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			src := buf.String()
+			Ω(src).Should(ContainSubstring("func FuzzTSTContentDecode(f *testing.F)"))
+			Ω(src).Should(ContainSubstring(`f.Add("7 desc")`))
+			Ω(src).Should(ContainSubstring("t.ParseInto(tokens, nil)"))
+			Ω(src).Should(ContainSubstring("decoded.Equal(&t)"))
+		})
+
+		It("should reject examples naming unknown params", func() {
+			msg := testMessage
+			msg.Examples = []*generator.Example{
+				&generator.Example{Line: "TST 7 desc", Values: map[string]string{"XX": "7"}},
+			}
+			err := generator.NewStructGenerator(&msg).RenderTest(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidExample))
+		})
+	})
+
+	Describe("repeated named params", func() {
+		repeatedMessage := generator.Message{
+			Command: "RPT",
+			NamedParams: []*generator.Param{
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "AN", Type: "string", Mapper: "list"},
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "TO", Type: "string"},
+			},
+		}
+
+		It("should return all values by NamedAll", func() {
+			src := render(generator.NewStructGenerator(&repeatedMessage))
+			Ω(src).Should(ContainSubstring("func (r *RPTContent) NamedAll(key string) []string {"))
+			Ω(src).Should(MatchRegexp(`case RPTFlagAN:\n\s*if len\(r\.AN\) == 0 {`))
+			Ω(src).Should(ContainSubstring("for i, val := range r.AN {"))
+			Ω(src).ShouldNot(MatchRegexp(`NamedAll\(key string\) \[\]string {(\n[^}].*)*\n\s*case RPTFlagTO:`))
+		})
+
+		It("should only use the switch for repeated params", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			Ω(src).Should(MatchRegexp(`NamedAll\(key string\) \[\]string {\n\s*if val, ok := t\.NamedGet\(key\); ok {`))
+		})
+	})
+
+	Describe("sensitive params", func() {
+		It("should mask the values of sensitive params in the wire form of Format", func() {
+			msg := testMessage
+			msg.NamedParams = []*generator.Param{
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "PD", Type: "base32", Sensitive: true},
+			}
+			src := render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("func (t *TSTContent) marshalRedacted() ([]byte, error) {"))
+			Ω(src).Should(ContainSubstring(`buf = append(buf, "PD***"...)`))
+			Ω(src).Should(ContainSubstring("formatContent(f, verb, t.marshalRedacted)"))
+
+			src = render(generator.NewStructGenerator(&testMessage))
+			Ω(src).ShouldNot(ContainSubstring("marshalRedacted"))
+			Ω(src).Should(ContainSubstring("formatContent(f, verb, t.MarshalADC)"))
+		})
+	})
+
+	Describe("map conversion", func() {
+		It("should key positional params by name and named params by flag name", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			Ω(src).Should(ContainSubstring(`values["Code"] = strconv.Itoa(t.Code)`))
+			Ω(src).Should(ContainSubstring(`values["Items"] = joinValues(itemsStrs, 0)`))
+			Ω(src).Should(ContainSubstring(`values["NI"] = escapeValue(t.NI)`))
+
+			Ω(src).Should(ContainSubstring(`params = append(params, splitValues(val)...)`))
+			Ω(src).Should(ContainSubstring(`params = append(params, "I4"+val)`))
+			Ω(src).Should(ContainSubstring(`case "Code", "Items", "NI", "I4", "ID":`))
+			Ω(src).Should(ContainSubstring(`return t.ParseInto(params, nil)`))
+		})
+	})
+
+	Describe("cross-param constraints", func() {
+		conditionalMessage := func(cond *generator.Condition, oneOf ...[]string) *generator.Message {
+			return &generator.Message{
+				Command: "CND",
+				NamedParams: []*generator.Param{
+					&generator.Param{Mode: generator.ParamModeNamed, Name: "TR", Type: "tth"},
+					&generator.Param{Mode: generator.ParamModeNamed, Name: "FN", Type: "string"},
+					&generator.Param{Mode: generator.ParamModeNamed, Name: "SU", Type: "features"},
+					&generator.Param{Mode: generator.ParamModeNamed, Name: "SL", Type: "int"},
+					&generator.Param{Mode: generator.ParamModeNamed, Name: "U4", Type: "int", RequiredIf: cond},
+				},
+				OneOf: oneOf,
+			}
+		}
+
+		It("should check and document conditional requirements", func() {
+			src := render(generator.NewStructGenerator(conditionalMessage(&generator.Condition{Param: "SU", Value: "UDP4"})))
+			Ω(src).Should(MatchRegexp(`// Required if SU contains UDP4\.\n\s*U4\s+maybe\.Int`))
+			Ω(src).Should(ContainSubstring(`if c.SU.IsSet && c.SU.Value.Has("UDP4") && !c.U4.IsSet {`))
+
+			src = render(generator.NewStructGenerator(conditionalMessage(&generator.Condition{Param: "SL", Value: "3"})))
+			Ω(src).Should(ContainSubstring(`if c.SL.IsSet && c.SL.Value == 3 && !c.U4.IsSet {`))
+			Ω(src).Should(ContainSubstring(`"validating param U4 of message CND: %w, required if SL is 3"`))
+		})
+
+		It("should check and document one-of groups", func() {
+			src := render(generator.NewStructGenerator(conditionalMessage(nil, []string{"TR", "FN"})))
+			Ω(src).Should(MatchRegexp(`// Exactly one of TR and FN is present\.\n\s*TR\s+maybe\.TTH`))
+			Ω(src).Should(ContainSubstring(`if !exactlyOne(c.TR.IsSet, c.FN.IsSet) {`))
+			Ω(src).Should(ContainSubstring(`"parsing message CND: %w, params TR and FN"`))
+		})
+
+		It("should reject constraints naming unknown params", func() {
+			err := generator.NewStructGenerator(conditionalMessage(&generator.Condition{Param: "XX"})).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownParam))
+
+			err = generator.NewStructGenerator(conditionalMessage(nil, []string{"TR", "XX"})).
+				Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownParam))
+		})
+
+		It("should reject inapplicable constraints", func() {
+			for _, msg := range []*generator.Message{
+				conditionalMessage(&generator.Condition{Param: "U4"}),
+				conditionalMessage(&generator.Condition{Param: "TR", Value: "AAAA"}),
+				conditionalMessage(nil, []string{"TR"}),
+				conditionalMessage(nil, []string{"TR", "FN", "TR"}),
+			} {
+				err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidConstraint))
+			}
+		})
+	})
+
+	Describe("deprecated params", func() {
+		It("should mark the field and flag constant as deprecated", func() {
+			src := render(generator.NewStructGenerator(&deprecatedMessage))
+			Ω(src).Should(MatchRegexp(`// Deprecated: superseded by NW\n\s*OL\s+maybe\.String`))
+			Ω(src).Should(MatchRegexp(`// Deprecated: superseded by NW\n\s*DEPFlagOL DEPFlag = "OL"`))
+		})
+
+		It("should report present deprecated params by DecodeWarnings", func() {
+			src := render(generator.NewStructGenerator(&deprecatedMessage))
+			Ω(src).Should(ContainSubstring("var _ DecodeWarner = &DEPContent{}"))
+			Ω(src).Should(ContainSubstring("func (d *DEPContent) DecodeWarnings() []string"))
+			Ω(src).Should(ContainSubstring(`"param OL of message DEP is deprecated: superseded by NW"`))
+
+			src = render(generator.NewStructGenerator(&testMessage))
+			Ω(src).ShouldNot(ContainSubstring("DecodeWarn"))
+		})
+
+		It("should drop deprecated named params when encoding", func() {
+			src := render(generator.NewStructGenerator(&deprecatedMessage))
+			Ω(src).Should(ContainSubstring(`buf = append(buf, " NW"...)`))
+			Ω(src).ShouldNot(ContainSubstring(`buf = append(buf, " OL"...)`))
+		})
+	})
+
+	Describe("const params", func() {
+		constMessage := func(marker *generator.Param) *generator.Message {
+			return &generator.Message{
+				Command: "MRK",
+				PositionalParams: []*generator.Param{
+					&generator.Param{
+						Mode:     generator.ParamModePositional,
+						Name:     "Code",
+						Type:     "int",
+						Required: true,
+					},
+					marker,
+				},
+			}
+		}
+		marker := func() *generator.Param {
+			return &generator.Param{
+				Mode:     generator.ParamModePositional,
+				Name:     "Marker",
+				Type:     "string",
+				Required: true,
+				Const:    "V2",
+			}
+		}
+
+		It("should not generate a field for const params", func() {
+			src := render(generator.NewStructGenerator(constMessage(marker())))
+			Ω(src).ShouldNot(ContainSubstring("markerStr"))
+			Ω(src).Should(ContainSubstring(`return append(dst, strconv.Itoa(m.Code), "V2")`))
+		})
+
+		It("should reject const params which are not required positional string params", func() {
+			for _, modify := range []func(param *generator.Param){
+				func(param *generator.Param) { param.Mode = generator.ParamModeNamed },
+				func(param *generator.Param) { param.Type = "int" },
+				func(param *generator.Param) { param.Mapper = "list" },
+				func(param *generator.Param) { param.Required = false },
+				func(param *generator.Param) { param.AllowedValues = []string{"V2"} },
+			} {
+				param := marker()
+				modify(param)
+				err := generator.NewStructGenerator(constMessage(param)).Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidConstParam))
+			}
+		})
+
+		It("should reject const values which are not a single token", func() {
+			param := marker()
+			param.Const = "V 2"
+			err := generator.NewStructGenerator(constMessage(param)).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidConstValue))
+		})
+	})
+
+	Describe("comments", func() {
+		It("should document the type with the comment and section of the message", func() {
+			msg := testMessage
+			msg.Comment = "Test message."
+			msg.Section = "ADC § 9.9"
+			src := render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("// TSTContent is the content of TST messages. Test message. See ADC § 9.9.\ntype TSTContent struct"))
+		})
+
+		It("should prefix the comments of fields and flag constants", func() {
+			msg := testMessage
+			ni := *msg.NamedParams[0]
+			ni.Comment = "Nick name of the client."
+			msg.NamedParams = []*generator.Param{&ni}
+			src := render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("\t// NI - Nick name of the client.\n\tNI "))
+			Ω(src).Should(ContainSubstring("\t// TSTFlagNI - Nick name of the client.\n\tTSTFlagNI "))
+
+			ni.Comment = "NI is the nick name of the client."
+			src = render(generator.NewStructGenerator(&msg))
+			Ω(src).Should(ContainSubstring("\t// NI is the nick name of the client.\n"))
+		})
+
+		It("should wrap long comments", func() {
+			msg := testMessage
+			msg.Comment = strings.Repeat("word ", 40)
+			src := render(generator.NewStructGenerator(&msg))
+			for _, line := range strings.Split(src, "\n") {
+				if strings.HasPrefix(line, "// TSTContent") || strings.HasPrefix(line, "// word") {
+					Ω(len(line)).Should(BeNumerically("<=", 80))
+				}
+			}
+			Ω(src).Should(ContainSubstring("// word word"))
+		})
+
+		It("should document all exported functions and methods", func() {
+			src := render(generator.NewStructGenerator(&testMessage))
+			file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, decl := range file.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.IsExported() {
+					Ω(fn.Doc).ShouldNot(BeNil(), "function %s", fn.Name.Name)
+					Ω(fn.Doc.Text()).Should(HavePrefix(fn.Name.Name+" "), "function %s", fn.Name.Name)
+				}
+			}
+		})
+	})
+
+	Describe("constraints", func() {
+		constrained := func(typ string, modify func(param *generator.Param)) *generator.Message {
+			param := &generator.Param{
+				Mode: generator.ParamModeNamed,
+				Name: "CO",
+				Type: typ,
+			}
+			modify(param)
+			return &generator.Message{Command: "CON", NamedParams: []*generator.Param{param}}
+		}
+
+		It("should generate range and length checks", func() {
+			src := render(generator.NewStructGenerator(constrained("int", func(param *generator.Param) {
+				param.Required = true
+				param.Min = "1"
+				param.Max = "10"
+			})))
+			Ω(src).Should(ContainSubstring("if c.CO < 1 || c.CO > 10 {"))
+
+			src = render(generator.NewStructGenerator(constrained("base32", func(param *generator.Param) {
+				param.Length = 39
+			})))
+			Ω(src).Should(ContainSubstring("len(c.CO.Value.String()) != 39"))
+		})
+
+		It("should reject constraints not applicable to the param type", func() {
+			for _, msg := range []*generator.Message{
+				constrained("string", func(param *generator.Param) { param.Min = "1" }),
+				constrained("int", func(param *generator.Param) { param.Length = 4 }),
+				constrained("base32", func(param *generator.Param) { param.Length = -1 }),
+				constrained("int", func(param *generator.Param) { param.Min, param.Max = "2", "1" }),
+			} {
+				err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+				Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidConstraint))
+			}
+		})
+
+		It("should reject bounds not matching the param type", func() {
+			err := generator.NewStructGenerator(constrained("int", func(param *generator.Param) {
+				param.Max = "1.5"
+			})).Render(bytes.NewBuffer(nil))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("gated params", func() {
+		gatedMessage := func(positionals ...*generator.Param) *generator.Message {
+			return &generator.Message{
+				Command:          "GTD",
+				PositionalParams: positionals,
+				NamedParams: []*generator.Param{
+					&generator.Param{
+						Mode: generator.ParamModeNamed,
+						Name: "TR",
+						Type: "int",
+					},
+				},
+			}
+		}
+		gated := func(name string) *generator.Param {
+			return &generator.Param{
+				Mode:    generator.ParamModePositional,
+				Name:    name,
+				Type:    "string",
+				GatedBy: "TR",
+			}
+		}
+		required := func(name string) *generator.Param {
+			return &generator.Param{
+				Mode:     generator.ParamModePositional,
+				Name:     name,
+				Type:     "string",
+				Required: true,
+			}
+		}
+
+		It("should reject adjacent gated params", func() {
+			msg := gatedMessage(required("A"), gated("B"), gated("C"), required("D"))
+			err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrAmbiguousLayout))
+		})
+
+		It("should reject gated params combined with params of dynamic multiplicity", func() {
+			list := required("L")
+			list.Mapper = "list"
+			msg := gatedMessage(required("A"), gated("B"), list)
+			err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrAmbiguousLayout))
+		})
+
+		It("should reject gates not naming a named param", func() {
+			param := gated("B")
+			param.GatedBy = "XX"
+			msg := gatedMessage(required("A"), param)
+			err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownGate))
+		})
+
+		It("should reject required gated params", func() {
+			param := gated("B")
+			param.Required = true
+			msg := gatedMessage(required("A"), param)
+			err := generator.NewStructGenerator(msg).Render(bytes.NewBuffer(nil))
+			Ω(errors.Cause(err)).Should(Equal(generator.ErrInvalidGatedParam))
+		})
+	})
+})
@@ -0,0 +1,509 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// namedGetSentinel is the value assigned to all named string params by the
+// generated NamedGet test.
+const namedGetSentinel = "sentinel"
+
+// sampleEscaper escapes the values of samples as escapeValue does.
+var sampleEscaper = strings.NewReplacer("\\", "\\\\", " ", "\\s", "\n", "\\n")
+
+// RenderTest generates a test file for the struct type and writes the
+// formatted source code to w. The test file is placed in the same package as
+// the struct type.
+//
+// The generated tests assert that every known flag of messages with named
+// params is reachable through NamedGet, that PosLen agrees with Positional for populated contents of
+// the positional layout and that the positional accessors exclude the
+// command, which is only emitted by MarshalADC. If the message has
+// examples, their lines are decoded, compared to the expected values and
+// encoded again.
+//
+// In addition, a fuzz target feeding arbitrary params through ParseInto is
+// generated. It asserts that decoding the line encoded from the parsed
+// content yields an equal content, which encodes to the same line. The
+// params of the examples are the seed corpus.
+func (s *StructGenerator) RenderTest(w io.Writer) error {
+	err := s.prepare()
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	err = s.generateTestFile().Render(buf)
+	if err != nil {
+		return err
+	}
+
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+func (s *StructGenerator) generateTestFile() *jen.File {
+	file := newFile(s.packageName, s.importPath, s.buildExpr)
+
+	if len(s.namedParams) > 0 {
+		file.Func().Id("Test" + s.typeName + "NamedGet").
+			Params(jen.Id("t").Op("*").Qual("testing", "T")).
+			BlockFunc(s.generateNamedGetTest)
+
+		file.Line()
+	}
+
+	if len(s.positionalParams) > 0 {
+
+		file.Func().Id("Test" + s.typeName + "PosLen").
+			Params(jen.Id("t").Op("*").Qual("testing", "T")).
+			BlockFunc(s.generatePosLenTest)
+
+		file.Line()
+
+		file.Func().Id("Test" + s.typeName + "PosExcludesCommand").
+			Params(jen.Id("t").Op("*").Qual("testing", "T")).
+			BlockFunc(s.generatePosExcludesCommandTest)
+	}
+
+	if len(s.message.Examples) > 0 {
+		file.Line()
+
+		file.Func().Id("Test" + s.typeName + "Examples").
+			Params(jen.Id("t").Op("*").Qual("testing", "T")).
+			BlockFunc(s.generateExamplesTest)
+	}
+
+	file.Line()
+
+	file.Func().Id("Fuzz" + s.typeName + "Decode").
+		Params(jen.Id("f").Op("*").Qual("testing", "F")).
+		BlockFunc(s.generateFuzzDecode)
+
+	return file
+}
+
+// generateNamedGetTest generates a test setting all named params to sample
+// values and asserting NamedGet returns their escaped values for every flag
+// constant.
+func (s *StructGenerator) generateNamedGetTest(group *jen.Group) {
+	group.Var().Id(s.typeLetter).Id(s.typeName)
+
+	var cases []jen.Code
+	for _, param := range s.namedParams {
+		escaped, ok := s.setSample(group, param, namedGetSentinel)
+		if ok {
+			cases = append(cases, jen.Values(jen.Id(param.FlagConstName), jen.Lit(escaped)))
+		}
+	}
+
+	group.Line()
+
+	group.For(
+		jen.List(jen.Id("_"), jen.Id("tc")).Op(":=").Range().
+			Index().Struct(
+			jen.Id("flag").Id(s.flagTypeName),
+			jen.Id("want").String(),
+		).Values(cases...),
+	).Block(
+		jen.List(jen.Id("val"), jen.Id("ok")).Op(":=").
+			Id(s.typeLetter).Dot("NamedGet").Call(jen.String().Parens(jen.Id("tc").Dot("flag"))),
+		jen.If(jen.Op("!").Id("ok").Op("||").Id("val").Op("!=").Id("tc").Dot("want")).Block(
+			jen.Id("t").Dot("Errorf").Call(
+				jen.Lit("NamedGet(%q) = %q, %t, want %q, true"),
+				jen.Id("tc").Dot("flag"), jen.Id("val"), jen.Id("ok"), jen.Id("tc").Dot("want"),
+			),
+		),
+	)
+}
+
+// posLenSamples is the number of contents populated by the generated PosLen
+// test. The sample index determines the length of dynamic params and which
+// gates are set.
+const posLenSamples = 4
+
+// generatePosLenTest generates a test populating the positional params of
+// contents of varying lengths and asserting PosLen equals the length of the
+// slice returned by Positional. A single content is populated if the layout
+// is static, i.e. has neither dynamic nor gated params.
+func (s *StructGenerator) generatePosLenTest(group *jen.Group) {
+	var gates []paramInfo
+	varying := false
+	for _, param := range s.positionalParams {
+		if param.Gate != nil {
+			gates = append(gates, param)
+		}
+		if param.FieldInfo.Multiplicity != MultiplicityStatic {
+			varying = true
+		}
+	}
+	varying = varying || len(gates) > 0
+
+	body := func(group *jen.Group) {
+		group.Var().Id(s.typeLetter).Id(s.typeName)
+
+		for _, param := range s.positionalParams {
+			strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+
+			switch {
+			case isConstParam(param):
+				continue
+			case param.FieldInfo.Multiplicity != MultiplicityStatic:
+				group.Add(s.paramValues(param)).Op("=").Make(s.valuesType(param), jen.Id("n"))
+			case encodesFromField(param):
+				// Singular values encoded from the fields need not be
+				// populated.
+				continue
+			case param.FieldInfo.StrIsSingular:
+				group.Add(strStmt).Op("=").Lit("0")
+			default:
+				group.Add(strStmt).Op("=").Make(jen.Index().String(), jen.Lit(param.FieldInfo.StaticMultiplicity))
+			}
+		}
+
+		for i, param := range gates {
+			group.If(jen.Id("n").Op("&").Lit(1 << uint(i)).Op("!=").Lit(0)).BlockFunc(func(group *jen.Group) {
+				s.setGate(group, param)
+			})
+		}
+
+		group.Line()
+
+		errorf := jen.Id("t").Dot("Errorf").Call(
+			jen.Lit("PosLen() = %d, want len(Positional()) = %d"), jen.Id("got"), jen.Id("want"),
+		)
+		if varying {
+			errorf = jen.Id("t").Dot("Errorf").Call(
+				jen.Lit("sample %d: PosLen() = %d, want len(Positional()) = %d"),
+				jen.Id("n"), jen.Id("got"), jen.Id("want"),
+			)
+		}
+
+		group.If(
+			jen.List(jen.Id("got"), jen.Id("want")).Op(":=").
+				Id(s.typeLetter).Dot("PosLen").Call().Op(",").
+				Len(jen.Id(s.typeLetter).Dot("Positional").Call()),
+			jen.Id("got").Op("!=").Id("want"),
+		).Block(errorf)
+	}
+
+	if !varying {
+		body(group)
+		return
+	}
+
+	group.For(
+		jen.Id("n").Op(":=").Lit(0),
+		jen.Id("n").Op("<").Lit(posLenSamples),
+		jen.Id("n").Op("++"),
+	).BlockFunc(body)
+}
+
+// generatePosExcludesCommandTest generates a test populating the content with
+// distinct positional tokens and asserting the positional accessors return
+// exactly the tokens following the command in the output of MarshalADC. In
+// particular, PosAt(0) must be the first positional, not the command.
+func (s *StructGenerator) generatePosExcludesCommandTest(group *jen.Group) {
+	group.Var().Id(s.typeLetter).Id(s.typeName)
+
+	if s.isFamily() {
+		group.Id(s.typeLetter).Dot("command").Op("=").
+			Lit(strings.Replace(s.message.Command, "?", "X", -1))
+	}
+
+	// Gated params are left out by not setting their gates, dynamic params
+	// by leaving them empty. first evaluates to the value expected at PosAt(0).
+	var first jen.Code
+	n := 0
+	token := func() string {
+		tok := fmt.Sprintf("p%d", n)
+		n++
+		return tok
+	}
+
+	for _, param := range s.positionalParams {
+		strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+
+		switch {
+		case param.Gate != nil:
+			s.setSample(group, param, "gated")
+			continue
+		case isConstParam(param):
+		case param.FieldInfo.Multiplicity != MultiplicityStatic:
+			continue
+		case param.FieldInfo.StrIsSingular:
+			s.setSample(group, param, token())
+		default:
+			group.Add(strStmt).Op("=").Index().String().ValuesFunc(func(group *jen.Group) {
+				for i := 0; i < param.FieldInfo.StaticMultiplicity; i++ {
+					group.Lit(token())
+				}
+			})
+		}
+
+		if first == nil {
+			first = s.firstStrValue(param)
+		}
+	}
+
+	for _, param := range s.namedParams {
+		if param.FieldInfo.StrIsSingular && !param.FieldInfo.FieldIsMaybe {
+			s.setSample(group, param, "0")
+		}
+	}
+
+	group.Line()
+
+	group.List(jen.Id("buf"), jen.Err()).Op(":=").Id(s.typeLetter).Dot("MarshalADC").Call()
+	group.If(jen.Err().Op("!=").Nil()).Block(
+		jen.Id("t").Dot("Fatalf").Call(jen.Lit("MarshalADC() failed: %v"), jen.Err()),
+	)
+	group.Id("tokens").Op(":=").Qual("strings", "Split").Call(
+		jen.Qual("strings", "TrimSuffix").Call(jen.String().Parens(jen.Id("buf")), jen.Lit("\n")),
+		jen.Lit(" "),
+	)
+	group.If(jen.Id("tokens").Index(jen.Lit(0)).Op("!=").Id(s.typeLetter).Dot("Command").Call()).Block(
+		jen.Id("t").Dot("Errorf").Call(
+			jen.Lit("MarshalADC() starts with %q, want the command %q"),
+			jen.Id("tokens").Index(jen.Lit(0)), jen.Id(s.typeLetter).Dot("Command").Call(),
+		),
+	)
+
+	if first != nil {
+		group.Line()
+
+		group.If(
+			jen.List(jen.Id("got"), jen.Id("want")).Op(":=").Id(s.typeLetter).Dot("PosAt").Call(jen.Lit(0)).Op(",").Add(first),
+			jen.Id("got").Op("!=").Id("want"),
+		).Block(
+			jen.Id("t").Dot("Errorf").Call(
+				jen.Lit("PosAt(0) = %q, want the first positional %q"),
+				jen.Id("got"), jen.Id("want"),
+			),
+		)
+	}
+
+	group.Line()
+
+	group.Id("positional").Op(":=").Id(s.typeLetter).Dot("Positional").Call()
+	group.For(
+		jen.List(jen.Id("i"), jen.Id("param")).Op(":=").Range().Id("positional"),
+	).Block(
+		jen.If(jen.Id("i").Op("+").Lit(1).Op(">=").Len(jen.Id("tokens")).Op("||").
+			Id("tokens").Index(jen.Id("i").Op("+").Lit(1)).Op("!=").Id("param")).Block(
+			jen.Id("t").Dot("Errorf").Call(
+				jen.Lit("Positional()[%d] = %q, want token %d of MarshalADC()"),
+				jen.Id("i"), jen.Id("param"), jen.Id("i").Op("+").Lit(1),
+			),
+		),
+		jen.If(jen.Id("got").Op(":=").Id(s.typeLetter).Dot("PosAt").Call(jen.Id("i")), jen.Id("got").Op("!=").Id("param")).Block(
+			jen.Id("t").Dot("Errorf").Call(
+				jen.Lit("PosAt(%d) = %q, want Positional()[%d] = %q"),
+				jen.Id("i"), jen.Id("got"), jen.Id("i"), jen.Id("param"),
+			),
+		),
+	)
+}
+
+// generateFuzzDecode generates the body of the fuzz target of the message.
+// The fuzzed string holds the params separated by spaces, as in the lines
+// of messages.
+func (s *StructGenerator) generateFuzzDecode(group *jen.Group) {
+	group.Id("f").Dot("Add").Call(jen.Lit(""))
+	for _, example := range s.message.Examples {
+		params := ""
+		if i := strings.IndexByte(example.Line, ' '); i >= 0 {
+			params = example.Line[i+1:]
+		}
+		group.Id("f").Dot("Add").Call(jen.Lit(params))
+	}
+
+	group.Line()
+
+	group.Id("f").Dot("Fuzz").Call(jen.Func().Params(
+		jen.Id("t").Op("*").Qual("testing", "T"),
+		jen.Id("params").String(),
+	).BlockFunc(func(group *jen.Group) {
+		group.If(jen.Qual("strings", "Contains").Call(jen.Id("params"), jen.Lit("\n"))).Block(
+			jen.Comment("Lines never contain newlines."),
+			jen.Id("t").Dot("Skip").Call(),
+		)
+
+		group.Line()
+
+		group.Var().Id("tokens").Index().String()
+		group.If(jen.Id("params").Op("!=").Lit("")).Block(
+			jen.Id("tokens").Op("=").Qual("strings", "Split").Call(jen.Id("params"), jen.Lit(" ")),
+		)
+
+		group.Var().Id(s.typeLetter).Id(s.typeName)
+		group.If(
+			jen.Err().Op(":=").Id(s.typeLetter).Dot("ParseInto").Call(jen.Id("tokens"), jen.Nil()),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(),
+		)
+		if s.isFamily() {
+			group.Id(s.typeLetter).Dot("command").Op("=").
+				Lit(strings.Replace(s.message.Command, "?", "X", -1))
+		}
+
+		group.Line()
+
+		group.List(jen.Id("buf"), jen.Err()).Op(":=").Id(s.typeLetter).Dot("MarshalADC").Call()
+		group.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Id("t").Dot("Fatalf").Call(jen.Lit("MarshalADC() failed for params %q: %v"), jen.Id("params"), jen.Err()),
+		)
+
+		group.Var().Id("decoded").Id(s.typeName)
+		group.If(
+			jen.Err().Op(":=").Id("decoded").Dot("UnmarshalADC").Call(jen.Id("buf")),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Id("t").Dot("Fatalf").Call(jen.Lit("UnmarshalADC(%q) failed: %v"), jen.Id("buf"), jen.Err()),
+		)
+		equalCheck := jen.If(jen.Op("!").Id("decoded").Dot("Equal").Call(jen.Op("&").Id(s.typeLetter))).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("UnmarshalADC(%q) differs from the content parsed from params %q"), jen.Id("buf"), jen.Id("params")),
+		)
+		if dropped := s.droppedPresent(); dropped != nil {
+			group.Comment("MarshalADC drops deprecated params.")
+			group.If(jen.Op("!").Parens(dropped)).Block(equalCheck)
+		} else {
+			group.Add(equalCheck)
+		}
+
+		group.Line()
+
+		if s.rawPassthrough {
+			group.Comment("MarshalADC would return the parsed line verbatim.")
+			group.Id("decoded").Dot("MarkDirty").Call()
+		}
+		group.List(jen.Id("again"), jen.Err()).Op(":=").Id("decoded").Dot("MarshalADC").Call()
+		group.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Id("t").Dot("Fatalf").Call(jen.Lit("MarshalADC() failed for line %q: %v"), jen.Id("buf"), jen.Err()),
+		)
+		group.If(jen.String().Parens(jen.Id("again")).Op("!=").String().Parens(jen.Id("buf"))).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("MarshalADC() = %q, want %q"), jen.Id("again"), jen.Id("buf")),
+		)
+	}))
+}
+
+// valuesType returns the type of the paramValues of the multi-valued param.
+func (s *StructGenerator) valuesType(param paramInfo) jen.Code {
+	if encodesFromField(param) {
+		return param.FieldInfo.FieldType
+	}
+
+	return jen.Index().String()
+}
+
+// setSample generates code assigning a sample value to the param and
+// returns its escaped value. Multi-valued params are assigned a single value.
+// The values of string params and of params taken from the str field are
+// str. False is returned if no sample of the type of the param is known.
+func (s *StructGenerator) setSample(group *jen.Group, param paramInfo, str string) (string, bool) {
+	field := s.fieldStmt(param)
+
+	if !encodesFromField(param) {
+		token := str
+		if param.Param.Mode == ParamModeNamed {
+			token = s.flagName(param) + str
+		}
+
+		if param.FieldInfo.StrIsSingular {
+			group.Add(s.strStmt(param)).Op("=").Lit(token)
+		} else {
+			group.Add(s.strStmt(param)).Op("=").Index().String().Values(jen.Lit(token))
+		}
+		if param.FieldInfo.FieldIsMaybe {
+			group.Add(field).Dot("IsSet").Op("=").True()
+		}
+
+		return str, true
+	}
+
+	value, escaped := s.sampleValue(param, str)
+	if value == nil {
+		return "", false
+	}
+
+	switch {
+	case !param.FieldInfo.StrIsSingular:
+		group.Add(field).Op("=").Add(param.FieldInfo.FieldType).Values(value)
+	case param.FieldInfo.FieldIsMaybe:
+		group.Add(s.optionalWrapper().set(field, value))
+	default:
+		group.Add(field).Op("=").Add(value)
+	}
+
+	return escaped, true
+}
+
+// sampleValue returns a sample single value of the param encoded from its
+// field along with its escaped value. String values are str. Nil is returned
+// if no sample of the type of the param is known.
+func (s *StructGenerator) sampleValue(param paramInfo, str string) (jen.Code, string) {
+	switch {
+	case param.Mapper == BitmaskMapper:
+		if len(param.Param.Bits) == 0 {
+			return jen.Add(param.FieldInfo.FieldType).Values(), "0"
+		}
+		return jen.Add(param.FieldInfo.FieldType).Values(jen.Dict{jen.Id(param.Param.Bits[0]): jen.True()}), "1"
+	case param.Enum != nil && len(param.Enum.Values) > 0:
+		value := param.Enum.Values[0]
+		return jen.Id(enumConstName(param.Enum, value)), sampleEscaper.Replace(value.Value)
+	case param.Mapper != BasicMapper && param.Mapper != ListMapper:
+		return nil, ""
+	}
+
+	switch param.Param.Type {
+	case "int":
+		return jen.Lit(7), "7"
+	case "float":
+		return jen.Lit(1.5), "1.5"
+	case "string":
+		return jen.Lit(str), sampleEscaper.Replace(str)
+	case "base32":
+		return jen.Qual(encodingPackage, "NewBase32Value").Call(jen.Index().Byte().Values(jen.Lit(1), jen.Lit(2), jen.Lit(3))), "AEBAG"
+	case "ip":
+		return jen.Qual("net", "IPv4").Call(jen.Lit(192), jen.Lit(0), jen.Lit(2), jen.Lit(1)), "192.0.2.1"
+	case "addr":
+		return jen.Qual("net/netip", "AddrFrom4").Call(
+			jen.Index(jen.Lit(4)).Byte().Values(jen.Lit(192), jen.Lit(0), jen.Lit(2), jen.Lit(1)),
+		), "192.0.2.1"
+	case "tth":
+		return jen.Qual(encodingPackage, "TTH").Values(), strings.Repeat("A", 39)
+	case "bytes":
+		return jen.Index().Byte().Values(jen.Lit(1), jen.Lit(2), jen.Lit(3)), "AEBAG"
+	case "timestamp":
+		return jen.Qual("time", "Unix").Call(jen.Lit(7), jen.Lit(0)), "7"
+	case "duration":
+		return jen.Lit(7).Op("*").Qual("time", "Second"), "7"
+	case "features":
+		return jen.Qual(encodingPackage, "Features").Values(jen.Qual(encodingPackage, "FeatureTCP4")), "TCP4"
+	default:
+		return nil, ""
+	}
+}
+
+// firstStrValue returns code evaluating to the first escaped value of the
+// static param.
+func (s *StructGenerator) firstStrValue(param paramInfo) jen.Code {
+	if param.FieldInfo.StrIsSingular {
+		return s.singularStrValue(param)
+	}
+
+	return s.strStmt(param).Index(jen.Lit(0))
+}
+
+// setGate generates code setting the gate of the param, i.e. marking the
+// named param gating the param as present.
+func (s *StructGenerator) setGate(group *jen.Group, param paramInfo) {
+	s.setSample(group, *param.Gate, "0")
+}
@@ -0,0 +1,386 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/pkg/errors"
+)
+
+// Error variables related to constraints of params.
+var (
+	ErrInvalidConstraint = errors.New("constraint not applicable to the param")
+)
+
+// prepareConstraints checks that the constraints specified by the params of
+// the message can be enforced by the generated Validate method.
+func (s *StructGenerator) prepareConstraints() error {
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for i := range params {
+			param := &params[i]
+
+			if len(param.Param.AllowedEnum) > 0 {
+				err := s.resolveAllowedEnum(param)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, value := range param.Param.AllowedValues {
+				_, err := basicLitFromString(param.Param.Type, value)
+				if err != nil {
+					return errors.Wrapf(err, "invalid allowed value %s of param %s of message %s",
+						value, param.Param.Name, s.message.Command)
+				}
+			}
+
+			err := s.checkRange(param)
+			if err != nil {
+				return err
+			}
+
+			if param.Param.Length < 0 || param.Param.Length > 0 && param.Param.Type != "base32" {
+				return errors.Wrapf(ErrInvalidConstraint, "length %d of param %s of message %s",
+					param.Param.Length, param.Param.Name, s.message.Command)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRange returns ErrInvalidConstraint if the param specifies a range but
+// is not numeric or if the range is empty.
+func (s *StructGenerator) checkRange(param *paramInfo) error {
+	if len(param.Param.Min) == 0 && len(param.Param.Max) == 0 {
+		return nil
+	}
+
+	if param.Param.Type != "int" && param.Param.Type != "float" {
+		return errors.Wrapf(ErrInvalidConstraint, "range of param %s of message %s",
+			param.Param.Name, s.message.Command)
+	}
+
+	bounds := make([]float64, 0, 2)
+	for _, bound := range []string{param.Param.Min, param.Param.Max} {
+		if len(bound) == 0 {
+			continue
+		}
+
+		// basicLitFromString rejects non-integral bounds of int params.
+		_, err := basicLitFromString(param.Param.Type, bound)
+		if err != nil {
+			return errors.Wrapf(err, "invalid bound %s of param %s of message %s",
+				bound, param.Param.Name, s.message.Command)
+		}
+		f, _ := strconv.ParseFloat(bound, 64)
+		bounds = append(bounds, f)
+	}
+
+	if len(bounds) == 2 && bounds[0] > bounds[1] {
+		return errors.Wrapf(ErrInvalidConstraint, "empty range [%s,%s] of param %s of message %s",
+			param.Param.Min, param.Param.Max, param.Param.Name, s.message.Command)
+	}
+
+	return nil
+}
+
+// hasValueConstraints returns true if the param constrains its decoded
+// values, which are checked by generateValidateValue.
+func hasValueConstraints(param paramInfo) bool {
+	return len(param.Param.AllowedValues) > 0 || param.AllowedEnum != nil || param.Enum != nil ||
+		len(param.Param.Min) > 0 || len(param.Param.Max) > 0 || param.Param.Length > 0
+}
+
+// resolveAllowedEnum sets the AllowedEnum of the param to the enum named by
+// the param.
+func (s *StructGenerator) resolveAllowedEnum(param *paramInfo) error {
+	for _, enum := range s.enums {
+		if enum.Name == param.Param.AllowedEnum {
+			param.AllowedEnum = enum
+			break
+		}
+	}
+	if param.AllowedEnum == nil {
+		return errors.Wrapf(ErrUnknownEnum, "enum %s of param %s of message %s",
+			param.Param.AllowedEnum, param.Param.Name, s.message.Command)
+	}
+
+	if param.AllowedEnum.Type != param.Param.Type {
+		return errors.Wrapf(ErrEnumTypeMismatch, "enum %s of param %s of message %s",
+			param.Param.AllowedEnum, param.Param.Name, s.message.Command)
+	}
+
+	return nil
+}
+
+func (s *StructGenerator) generateValidate(group *jen.Group) {
+	group.Var().Id("errs").Index().Error()
+
+	group.For(jen.List(jen.Id("_"), jen.Id("name")).Op(":=").Range().Id(s.typeLetter).Dot("MissingRequired").Call()).Block(
+		appendError(jen.Qual("fmt", "Errorf").Call(
+			jen.Lit("validating param %s of message "+s.message.Command+": %w"),
+			jen.Id("name"), jen.Id("ErrMissingParam"),
+		)),
+	)
+
+	s.generateConditionChecks(group, s.validateErrorPrefix, "validating message "+s.message.Command, appendError)
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if hasParallelSlices(param) {
+				group.If(s.sliceLengthsDiffer(param)).Block(
+					appendError(s.wrapError(s.validateErrorPrefix(param), jen.Id("ErrLengthMismatch"))),
+				)
+			}
+		}
+	}
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			// The tokens of const params are checked on generation, values
+			// encoded from the fields are escaped on encoding.
+			if !isConstParam(param) && !encodesFromField(param) {
+				s.generateValidateEscaped(group, param)
+			}
+
+			// Values taken from the str field may have been set without it.
+			if param.FieldInfo.StrIsSingular && param.FieldInfo.FieldIsMaybe && !encodesFromField(param) {
+				fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+				group.If(
+					jen.Add(s.optionalWrapper().isSet(fieldStmt)).Op("&&").
+						Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName).Op("==").Lit(""),
+				).Block(
+					appendError(s.wrapError(s.validateErrorPrefix(param), jen.Id("ErrMissingParam"))),
+				)
+			}
+		}
+	}
+
+	group.If(
+		jen.Err().Op(":=").Id("checkFlagsEscaped").Call(jen.Id(s.typeLetter).Dot("Flags")),
+		jen.Err().Op("!=").Nil(),
+	).Block(
+		appendError(s.wrapError("validating flags of message "+s.message.Command, jen.Err())),
+	)
+
+	group.Line()
+
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if !hasValueConstraints(param) {
+				continue
+			}
+
+			s.generateValidateParam(group, param)
+		}
+	}
+
+	group.Return(jen.Id("validationError").Call(jen.Id("errs")))
+}
+
+// appendError returns code appending err to the errors collected by the
+// generated Validate method.
+func appendError(err jen.Code) jen.Code {
+	return jen.Id("errs").Op("=").Append(jen.Id("errs"), err)
+}
+
+// generateMissingRequired generates the body of the MissingRequired method.
+// Params with Maybe fields are optional and never reported, neither are
+// multi-valued params, which may hold no values, nor params whose values are
+// never missing, such as numbers.
+func (s *StructGenerator) generateMissingRequired(group *jen.Group) {
+	var requiredParams []paramInfo
+	for _, params := range [][]paramInfo{s.positionalParams, s.namedParams} {
+		for _, param := range params {
+			if param.Param.Required && param.FieldInfo.StrIsSingular &&
+				!param.FieldInfo.FieldIsMaybe && !s.alwaysPresent(param) && !isConstParam(param) {
+				requiredParams = append(requiredParams, param)
+			}
+		}
+	}
+
+	if len(requiredParams) == 0 {
+		group.Return(jen.Nil())
+		return
+	}
+
+	group.Var().Id("missing").Index().String()
+	for _, param := range requiredParams {
+		group.If(s.paramMissing(param)).Block(
+			jen.Id("missing").Op("=").Append(jen.Id("missing"), jen.Lit(param.Param.Name)),
+		)
+	}
+
+	group.Return(jen.Id("missing"))
+}
+
+// hasParallelSlices returns true if the field and the str field of the param
+// are slices holding one element per value, i.e. if the param has dynamic
+// multiplicity, and the values are taken from the str field.
+func hasParallelSlices(param paramInfo) bool {
+	return param.FieldInfo.Multiplicity == MultiplicityDynamic && !param.FieldInfo.StrIsSingular &&
+		!encodesFromField(param)
+}
+
+// sliceLengthsDiffer returns code evaluating to true if the field and the
+// str field of the param differ in length.
+func (s *StructGenerator) sliceLengthsDiffer(param paramInfo) jen.Code {
+	return jen.Len(jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)).Op("!=").
+		Len(jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName))
+}
+
+// generateValidateEscaped generates the check that the str field of the param
+// does not contain unescaped separators.
+func (s *StructGenerator) generateValidateEscaped(group *jen.Group, param paramInfo) {
+	strStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+
+	check := func(value jen.Code) jen.Code {
+		return jen.If(
+			jen.Err().Op(":=").Id("checkEscaped").Call(value),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			appendError(s.wrapError(s.validateErrorPrefix(param), jen.Err())),
+		)
+	}
+
+	if param.FieldInfo.StrIsSingular {
+		group.Add(check(strStmt))
+	} else {
+		group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(strStmt)).Block(
+			check(jen.Id("val")),
+		)
+	}
+}
+
+// generateValidateParam generates the checks of all constraints of a single
+// param. The checks are only performed if the param is set.
+func (s *StructGenerator) generateValidateParam(group *jen.Group, param paramInfo) {
+	fieldStmt := jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+
+	if param.FieldInfo.Multiplicity == MultiplicityDynamic || !param.FieldInfo.StrIsSingular {
+		group.For(jen.List(jen.Id("_"), jen.Id("val")).Op(":=").Range().Add(fieldStmt)).
+			BlockFunc(func(group *jen.Group) {
+				s.generateValidateValue(group, param, jen.Id("val"))
+			})
+	} else if param.FieldInfo.FieldIsMaybe {
+		group.If(s.optionalWrapper().isSet(fieldStmt)).BlockFunc(func(group *jen.Group) {
+			s.generateValidateValue(group, param, s.optionalWrapper().value(fieldStmt))
+		})
+	} else {
+		s.generateValidateValue(group, param, fieldStmt)
+	}
+}
+
+// generateValidateValue generates the checks of all constraints of a param
+// for the decoded value.
+func (s *StructGenerator) generateValidateValue(group *jen.Group, param paramInfo, value jen.Code) {
+	if param.Enum != nil {
+		detail := param.Param.ValidationMessage
+		if len(detail) == 0 {
+			var values []string
+			for _, enumValue := range param.Enum.Values {
+				values = append(values, enumValue.Value)
+			}
+			detail = "must be one of " + strings.Join(values, ", ")
+		}
+
+		group.If(jen.Op("!").Add(value).Dot("IsKnown").Call()).Block(
+			appendError(s.wrapErrorDetail(s.validateErrorPrefix(param), jen.Id("ErrValueNotAllowed"), detail)),
+		)
+	}
+
+	if len(param.Param.AllowedValues) > 0 || param.AllowedEnum != nil {
+		allowedValues := append([]string(nil), param.Param.AllowedValues...)
+		if param.AllowedEnum != nil {
+			for _, enumValue := range param.AllowedEnum.Values {
+				allowedValues = append(allowedValues, enumValue.Value)
+			}
+		}
+
+		detail := param.Param.ValidationMessage
+		if len(detail) == 0 {
+			detail = "must be one of " + strings.Join(allowedValues, ", ")
+		}
+
+		// The constants of enums are of the named type of the enum.
+		tag := value
+		if param.AllowedEnum != nil {
+			tag = jen.Id(param.AllowedEnum.Name).Call(value)
+		}
+
+		group.Switch(tag).Block(
+			jen.CaseFunc(func(group *jen.Group) {
+				for _, allowed := range param.Param.AllowedValues {
+					// Errors have been checked by prepareConstraints
+					lit, _ := basicLitFromString(param.Param.Type, allowed)
+					group.Add(lit)
+				}
+				if param.AllowedEnum != nil {
+					for _, enumValue := range param.AllowedEnum.Values {
+						group.Id(enumConstName(param.AllowedEnum, enumValue))
+					}
+				}
+			}),
+			jen.Default().Block(
+				appendError(s.wrapErrorDetail(
+					s.validateErrorPrefix(param),
+					jen.Id("ErrValueNotAllowed"),
+					detail,
+				)),
+			),
+		)
+	}
+
+	if len(param.Param.Min) > 0 || len(param.Param.Max) > 0 {
+		// Errors have been checked by prepareConstraints
+		var conds []jen.Code
+		if len(param.Param.Min) > 0 {
+			min, _ := basicLitFromString(param.Param.Type, param.Param.Min)
+			conds = append(conds, jen.Add(value).Op("<").Add(min))
+		}
+		if len(param.Param.Max) > 0 {
+			max, _ := basicLitFromString(param.Param.Type, param.Param.Max)
+			conds = append(conds, jen.Add(value).Op(">").Add(max))
+		}
+
+		detail := param.Param.ValidationMessage
+		if len(detail) == 0 {
+			switch {
+			case len(param.Param.Max) == 0:
+				detail = "must be at least " + param.Param.Min
+			case len(param.Param.Min) == 0:
+				detail = "must be at most " + param.Param.Max
+			default:
+				detail = "must be between " + param.Param.Min + " and " + param.Param.Max
+			}
+		}
+
+		group.If(jen.Add(conds[0]).Do(func(stmt *jen.Statement) {
+			if len(conds) > 1 {
+				stmt.Op("||").Add(conds[1])
+			}
+		})).Block(
+			appendError(s.wrapErrorDetail(s.validateErrorPrefix(param), jen.Id("ErrOutOfRange"), detail)),
+		)
+	}
+
+	if param.Param.Length > 0 {
+		detail := param.Param.ValidationMessage
+		if len(detail) == 0 {
+			detail = "must be " + strconv.Itoa(param.Param.Length) + " characters long"
+		}
+
+		group.If(
+			jen.Add(value).Op("!=").Nil().Op("&&").
+				Len(jen.Add(value).Dot("String").Call()).Op("!=").Lit(param.Param.Length),
+		).Block(
+			appendError(s.wrapErrorDetail(s.validateErrorPrefix(param), jen.Id("ErrInvalidLength"), detail)),
+		)
+	}
+}
+
+func (s *StructGenerator) validateErrorPrefix(param paramInfo) string {
+	return "validating param " + param.Param.Name + " of message " + s.message.Command
+}
@@ -0,0 +1,230 @@
+package generator
+
+import (
+	"github.com/dave/jennifer/jen"
+)
+
+// The fields of the params are the single source of truth of all accessors:
+// the escaped values returned by PosAt, Named and NamedGet, compared by
+// EqualIgnoring and written by AppendADC are encoded from the fields. The str
+// fields are only used for the values of params whose mappers provide no
+// encoder of single values, such as custom mappers.
+
+// encodesFromField returns true if the escaped values of the param are
+// encoded from its field, false if they are taken from the str field. Params
+// with a static multiplicity larger than one have no single values to encode.
+func encodesFromField(param paramInfo) bool {
+	return param.Mapper.Builder.EncodesValues() &&
+		(param.FieldInfo.StrIsSingular || param.FieldInfo.Multiplicity == MultiplicityDynamic)
+}
+
+// fieldStmt returns code accessing the field of the param.
+func (s *StructGenerator) fieldStmt(param paramInfo) *jen.Statement {
+	return jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.FieldName)
+}
+
+// strStmt returns code accessing the str field of the param.
+func (s *StructGenerator) strStmt(param paramInfo) *jen.Statement {
+	return jen.Id(s.typeLetter).Dot("").Add(param.FieldInfo.StrFieldName)
+}
+
+// fieldValue returns code evaluating to the value of the singular param, the
+// wrapped value of Maybe fields.
+func (s *StructGenerator) fieldValue(param paramInfo) jen.Code {
+	if param.FieldInfo.FieldIsMaybe {
+		return s.optionalWrapper().value(s.fieldStmt(param))
+	}
+
+	return s.fieldStmt(param)
+}
+
+// encodeValue returns code evaluating to the escaped value of value, a single
+// value of the param.
+func (s *StructGenerator) encodeValue(param paramInfo, value jen.Code) jen.Code {
+	ctx := s.createRenderingContext(param)
+	return param.Mapper.Builder.EncodeValue(&ctx, value)
+}
+
+// appendValue returns code appending the escaped value of value, a single
+// value of the param, to buf.
+func (s *StructGenerator) appendValue(param paramInfo, buf, value jen.Code) jen.Code {
+	ctx := s.createRenderingContext(param)
+	return param.Mapper.Builder.AppendValue(&ctx, buf, value)
+}
+
+// flagName returns the flag name of the named param.
+func (s *StructGenerator) flagName(param paramInfo) string {
+	ctx := s.createContext(param)
+	return param.Mapper.Parser.Named.ParamName(&ctx)
+}
+
+// singularStrValue returns code evaluating to the escaped value of the
+// singular param. Const params evaluate to their token.
+func (s *StructGenerator) singularStrValue(param paramInfo) jen.Code {
+	switch {
+	case isConstParam(param):
+		return jen.Lit(param.Param.Const)
+	case encodesFromField(param):
+		return s.encodeValue(param, s.fieldValue(param))
+	case param.Param.Mode == ParamModeNamed:
+		return s.strStmt(param).Index(jen.Lit(2), jen.Empty())
+	default:
+		return s.strStmt(param)
+	}
+}
+
+// namedStrValue returns code evaluating to the token of the singular named
+// param, i.e. the flag name followed by the escaped value.
+func (s *StructGenerator) namedStrValue(param paramInfo) jen.Code {
+	if encodesFromField(param) {
+		return jen.Lit(s.flagName(param)).Op("+").Add(s.encodeValue(param, s.fieldValue(param)))
+	}
+
+	return s.strStmt(param)
+}
+
+// paramValues returns code evaluating to the slice holding the values of the
+// multi-valued param, i.e. the field if the values are encoded from it and
+// the str field otherwise. Its elements are passed to elemStrValue and
+// elemToken.
+func (s *StructGenerator) paramValues(param paramInfo) *jen.Statement {
+	if encodesFromField(param) {
+		return s.fieldStmt(param)
+	}
+
+	return s.strStmt(param)
+}
+
+// elemStrValue returns code evaluating to the escaped value of elem, an
+// element of the paramValues of the multi-valued param.
+func (s *StructGenerator) elemStrValue(param paramInfo, elem jen.Code) jen.Code {
+	switch {
+	case encodesFromField(param):
+		return s.encodeValue(param, elem)
+	case param.Param.Mode == ParamModeNamed:
+		return jen.Add(elem).Index(jen.Lit(2), jen.Empty())
+	default:
+		return elem
+	}
+}
+
+// elemToken returns code evaluating to the token of elem, an element of the
+// paramValues of the multi-valued param. Tokens of named params include the
+// flag name.
+func (s *StructGenerator) elemToken(param paramInfo, elem jen.Code) jen.Code {
+	switch {
+	case !encodesFromField(param):
+		return elem
+	case param.Param.Mode == ParamModeNamed:
+		return jen.Lit(s.flagName(param)).Op("+").Add(s.encodeValue(param, elem))
+	default:
+		return s.encodeValue(param, elem)
+	}
+}
+
+// generateEncodeValues generates code assigning the escaped values of the
+// multi-valued param to the new variable name, a string slice.
+func (s *StructGenerator) generateEncodeValues(group *jen.Group, param paramInfo, name string) {
+	values := s.paramValues(param)
+
+	group.Id(name).Op(":=").Make(jen.Index().String(), jen.Len(values))
+	group.For(jen.List(jen.Id("i"), jen.Id("val")).Op(":=").Range().Add(values)).Block(
+		jen.Id(name).Index(jen.Id("i")).Op("=").Add(s.elemStrValue(param, jen.Id("val"))),
+	)
+}
+
+// valueCheck returns code evaluating to true if value, a single value of the
+// param, has no escaped value, i.e. is encoded to the empty string, or if it
+// has one if missing is false. Nil is returned if values of the param always
+// have an escaped value.
+func (s *StructGenerator) valueCheck(param paramInfo, value jen.Code, missing bool) jen.Code {
+	op, not := "!=", jen.Null()
+	if missing {
+		op, not = "==", jen.Op("!")
+	}
+
+	typ := param.Param.Type
+	switch {
+	case param.Mapper == BitmaskMapper:
+		return nil
+	case param.Enum != nil:
+		typ = param.Enum.Type
+	case param.Mapper != BasicMapper && param.Mapper != ListMapper:
+		return jen.Add(s.encodeValue(param, value)).Op(op).Lit("")
+	}
+
+	switch typ {
+	case "int", "float", "tth", "timestamp", "duration":
+		return nil
+	case "string":
+		return jen.Add(value).Op(op).Lit("")
+	case "ip", "bytes", "features":
+		return jen.Len(value).Op(op).Lit(0)
+	case "addr":
+		return jen.Add(not).Add(value).Dot("IsValid").Call()
+	default:
+		return jen.Add(s.encodeValue(param, value)).Op(op).Lit("")
+	}
+}
+
+// paramMissing returns code evaluating to true if the param has no value to
+// marshal: Maybe fields which are not set, multi-valued params without
+// values and singular params whose value has no escaped value. Nil is
+// returned if the param is never missing.
+func (s *StructGenerator) paramMissing(param paramInfo) jen.Code {
+	switch {
+	case !param.FieldInfo.StrIsSingular:
+		return jen.Len(s.paramValues(param)).Op("==").Lit(0)
+	case !encodesFromField(param):
+		missing := s.strStmt(param).Op("==").Lit("")
+		if param.FieldInfo.FieldIsMaybe {
+			// The str field may be empty although the field is set.
+			missing = jen.Op("!").Add(s.optionalWrapper().isSet(s.fieldStmt(param))).Op("||").Add(missing)
+		}
+		return missing
+	case param.FieldInfo.FieldIsMaybe:
+		return jen.Op("!").Add(s.optionalWrapper().isSet(s.fieldStmt(param)))
+	default:
+		return s.valueCheck(param, s.fieldValue(param), true)
+	}
+}
+
+// alwaysPresent returns true if the param is present in every content, i.e.
+// holds a single value which always has an escaped value.
+func (s *StructGenerator) alwaysPresent(param paramInfo) bool {
+	return !isConstParam(param) && s.paramMissing(param) == nil
+}
+
+// paramPresent returns the condition of the param being present in the
+// content, i.e. holding a value. Maybe fields are present if set, the value
+// of which is written by AppendADC even if it is empty. True is returned for
+// params which are always present.
+func (s *StructGenerator) paramPresent(param paramInfo) jen.Code {
+	switch {
+	case param.FieldInfo.FieldIsMaybe:
+		return s.optionalWrapper().isSet(s.fieldStmt(param))
+	case !param.FieldInfo.StrIsSingular:
+		return jen.Len(s.paramValues(param)).Op(">").Lit(0)
+	case !encodesFromField(param):
+		return s.strStmt(param).Op("!=").Lit("")
+	}
+
+	if present := s.valueCheck(param, s.fieldValue(param), false); present != nil {
+		return present
+	}
+
+	return jen.True()
+}
+
+// ifPresent adds code to group, guarded by the condition of the param being
+// present unless the param is always present.
+func (s *StructGenerator) ifPresent(group *jen.Group, param paramInfo, code ...jen.Code) {
+	if s.alwaysPresent(param) {
+		for _, c := range code {
+			group.Add(c)
+		}
+		return
+	}
+
+	group.If(s.paramPresent(param)).Block(code...)
+}
@@ -2,34 +2,75 @@ package generator
 
 import (
 	"errors"
+	"sync"
 )
 
 var intTypeSpec = &TypeSpec{
 	Name:          "int",
-	Mappers:       []*Mapper{BasicMapper},
+	Mappers:       []*Mapper{BasicMapper, ListMapper, BitmaskMapper},
 	DefaultMapper: BasicMapper,
 }
 
 var floatTypeSpec = &TypeSpec{
 	Name:          "float",
-	Mappers:       []*Mapper{BasicMapper},
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
 	DefaultMapper: BasicMapper,
 }
 
 var stringTypeSpec = &TypeSpec{
 	Name:          "string",
-	Mappers:       []*Mapper{BasicMapper},
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
 	DefaultMapper: BasicMapper,
 }
 
 var base32TypeSpec = &TypeSpec{
 	Name:          "base32",
-	Mappers:       []*Mapper{BasicMapper},
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
 	DefaultMapper: BasicMapper,
 }
 
 var ipTypeSpec = &TypeSpec{
 	Name:          "ip",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+var addrTypeSpec = &TypeSpec{
+	Name:          "addr",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+var tthTypeSpec = &TypeSpec{
+	Name:          "tth",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+// bytesTypeSpec maps base32 encoded values to byte slices.
+var bytesTypeSpec = &TypeSpec{
+	Name:          "bytes",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+// timestampTypeSpec maps Unix timestamps in seconds to time.Time values.
+var timestampTypeSpec = &TypeSpec{
+	Name:          "timestamp",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+// durationTypeSpec maps numbers of seconds to time.Duration values.
+var durationTypeSpec = &TypeSpec{
+	Name:          "duration",
+	Mappers:       []*Mapper{BasicMapper, ListMapper},
+	DefaultMapper: BasicMapper,
+}
+
+// featuresTypeSpec maps comma-separated feature lists to sets of features.
+var featuresTypeSpec = &TypeSpec{
+	Name:          "features",
 	Mappers:       []*Mapper{BasicMapper},
 	DefaultMapper: BasicMapper,
 }
@@ -42,21 +83,94 @@ var (
 	ErrUnknownMapperName = errors.New("unknown mapper name (for type), cannot find mapper by name")
 )
 
-func TypeSpecFromName(name string) (*TypeSpec, error) {
+// enumTypeSpec is the type spec of params of enum types. It is not
+// registered by name, as enums are declared by the definition.
+var enumTypeSpec = &TypeSpec{
+	Name:          "enum",
+	Mappers:       []*Mapper{EnumMapper},
+	DefaultMapper: EnumMapper,
+}
+
+// typeSpecs contains the type specs by name, i.e. the built-in types and the
+// types of mappers registered by RegisterMapper.
+var typeSpecs = map[string]*TypeSpec{
+	intTypeSpec.Name:    intTypeSpec,
+	floatTypeSpec.Name:  floatTypeSpec,
+	stringTypeSpec.Name: stringTypeSpec,
+	base32TypeSpec.Name: base32TypeSpec,
+	ipTypeSpec.Name:     ipTypeSpec,
+	addrTypeSpec.Name:   addrTypeSpec,
+	tthTypeSpec.Name:    tthTypeSpec,
+	bytesTypeSpec.Name:  bytesTypeSpec,
+
+	timestampTypeSpec.Name: timestampTypeSpec,
+	durationTypeSpec.Name:  durationTypeSpec,
+	featuresTypeSpec.Name:  featuresTypeSpec,
+}
+
+// typeSpecsMu guards typeSpecs and the mappers of the type specs.
+var typeSpecsMu sync.RWMutex
+
+// RegisterMapper makes the mapper m available for params of the type name.
+// If name is not a known type, a type with m as its default mapper is
+// created, i.e. params of the type use m unless they select another mapper.
+// Otherwise, m is added to the mappers of the type and is used by params
+// selecting it by its name.
+//
+// The optional wrapper only supports the built-in types, so mappers of new
+// types must not compose Maybe fields. Params of new types get builder
+// setters if the mapper has an EncodeFunc and composes singular fields.
+//
+// RegisterMapper panics if m is nil or the type already has a mapper named
+// like m. It is meant to be called from init functions, before generating.
+func RegisterMapper(name string, m *Mapper) {
+	typeSpecsMu.Lock()
+	defer typeSpecsMu.Unlock()
+
+	if m == nil {
+		panic("generator: RegisterMapper mapper is nil")
+	}
+
+	typeSpec, ok := typeSpecs[name]
+	if !ok {
+		typeSpecs[name] = &TypeSpec{
+			Name:          name,
+			Mappers:       []*Mapper{m},
+			DefaultMapper: m,
+		}
+		return
+	}
+
+	for _, mapper := range typeSpec.Mappers {
+		if mapper.Name == m.Name {
+			panic("generator: RegisterMapper called twice for mapper " + m.Name + " of type " + name)
+		}
+	}
+	typeSpec.Mappers = append(typeSpec.Mappers, m)
+}
+
+// isBuiltinType returns true if name is the name of a built-in type.
+func isBuiltinType(name string) bool {
 	switch name {
-	case "int":
-		return intTypeSpec, nil
-	case "float":
-		return intTypeSpec, nil
-	case "string":
-		return stringTypeSpec, nil
-	case "base32":
-		return base32TypeSpec, nil
-	case "ip":
-		return ipTypeSpec, nil
+	case intTypeSpec.Name, floatTypeSpec.Name, stringTypeSpec.Name, base32TypeSpec.Name, ipTypeSpec.Name,
+		addrTypeSpec.Name, tthTypeSpec.Name, bytesTypeSpec.Name, timestampTypeSpec.Name, durationTypeSpec.Name,
+		featuresTypeSpec.Name:
+		return true
 	default:
+		return false
+	}
+}
+
+func TypeSpecFromName(name string) (*TypeSpec, error) {
+	typeSpecsMu.RLock()
+	defer typeSpecsMu.RUnlock()
+
+	typeSpec, ok := typeSpecs[name]
+	if !ok {
 		return nil, ErrUnknownTypeName
 	}
+
+	return typeSpec, nil
 }
 
 func ResolveMapperFromParam(param *Param) (*Mapper, error) {
@@ -73,6 +187,9 @@ func ResolveMapperFromParam(param *Param) (*Mapper, error) {
 		return typeSpec.DefaultMapper, nil
 	}
 
+	typeSpecsMu.RLock()
+	defer typeSpecsMu.RUnlock()
+
 	for _, mapper := range typeSpec.Mappers {
 		if mapper.Name == param.Mapper {
 			return mapper, nil
@@ -0,0 +1,126 @@
+package generator_test
+
+import (
+	"bytes"
+
+	"github.com/dave/jennifer/jen"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/seoester/adcl/protocol/generator"
+)
+
+// countryMapper maps params to the Country type of a GeoIP package.
+var countryMapper = &generator.Mapper{
+	Name: "country",
+	ComposeFieldInfoFunc: func(ctx *generator.Context) *generator.FieldInfo {
+		return &generator.FieldInfo{
+			FieldName:          jen.Id(ctx.Param.Name),
+			FieldType:          jen.Qual("example.com/geo", "Country"),
+			StrFieldName:       jen.Id("country" + ctx.Param.Name + "Str"),
+			StrIsSingular:      true,
+			Multiplicity:       generator.MultiplicityStatic,
+			StaticMultiplicity: 1,
+		}
+	},
+	Parser: generator.ParserSpec{
+		Named: generator.NamedParserSpec{
+			ModeParserSpecBase: generator.ModeParserSpecBase{
+				Available: true,
+			},
+			ParamNameFunc: func(ctx *generator.Context) string {
+				return ctx.Param.Name
+			},
+			ProcessFieldValueFunc: func(ctx *generator.RenderingContext, value jen.Code) jen.Code {
+				return jen.List(jen.Id("val"), jen.Err()).Op(":=").
+					Qual("example.com/geo", "ParseCountry").Call(value).
+					Line().
+					Add(ctx.ErrorCheck(jen.Err())).
+					Line().
+					Add(ctx.ContentVar).Dot("").Add(ctx.FieldInfo.FieldName).Op("=").Id("val")
+			},
+		},
+	},
+	Builder: generator.BuilderSpec{
+		EncodeFunc: func(ctx *generator.RenderingContext, value jen.Code) jen.Code {
+			return jen.Id("str").Op(":=").Add(value).Dot("String").Call()
+		},
+	},
+}
+
+func init() {
+	generator.RegisterMapper("country", countryMapper)
+}
+
+var _ = Describe("generator.RegisterMapper()", func() {
+	countryMessage := &generator.Message{
+		Command: "GEO",
+		NamedParams: []*generator.Param{
+			&generator.Param{
+				Mode:     generator.ParamModeNamed,
+				Name:     "CC",
+				Type:     "country",
+				Required: true,
+			},
+		},
+	}
+
+	It("should create a type with the mapper as default mapper", func() {
+		typeSpec, err := generator.TypeSpecFromName("country")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(typeSpec.DefaultMapper).Should(BeIdenticalTo(countryMapper))
+
+		mapper, err := generator.ResolveMapperFromParam(countryMessage.NamedParams[0])
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(mapper).Should(BeIdenticalTo(countryMapper))
+	})
+
+	It("should generate the fields and setters of the mapper", func() {
+		src := render(generator.NewStructGenerator(countryMessage))
+		Ω(imports(src)).Should(ContainElement("example.com/geo"))
+		Ω(src).Should(MatchRegexp(`CC\s+geo\.Country\n`))
+		Ω(src).Should(ContainSubstring("geo.ParseCountry("))
+		Ω(src).Should(ContainSubstring("func (b *GEOBuilder) CC(value geo.Country) *GEOBuilder"))
+	})
+
+	It("should add mappers to known types", func() {
+		generator.RegisterMapper("string", &generator.Mapper{Name: "test-upper"})
+
+		mapper, err := generator.ResolveMapperFromParam(&generator.Param{Type: "string", Mapper: "test-upper"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(mapper.Name).Should(Equal("test-upper"))
+
+		mapper, err = generator.ResolveMapperFromParam(&generator.Param{Type: "string"})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(mapper).Should(BeIdenticalTo(generator.BasicMapper))
+	})
+
+	It("should panic on mappers registered twice for a type", func() {
+		Ω(func() { generator.RegisterMapper("country", countryMapper) }).Should(Panic())
+		Ω(func() { generator.RegisterMapper("int", nil) }).Should(Panic())
+	})
+
+	It("should leave unknown types unresolved", func() {
+		_, err := generator.TypeSpecFromName("continent")
+		Ω(err).Should(Equal(generator.ErrUnknownTypeName))
+
+		err = generator.NewStructGenerator(&generator.Message{
+			Command: "GEO",
+			NamedParams: []*generator.Param{
+				&generator.Param{Mode: generator.ParamModeNamed, Name: "CT", Type: "continent"},
+			},
+		}).Render(bytes.NewBuffer(nil))
+		Ω(errors.Cause(err)).Should(Equal(generator.ErrUnknownTypeName))
+	})
+})
+
+var _ = Describe("generator.TypeSpecFromName()", func() {
+	It("should return the spec of the type", func() {
+		for _, name := range []string{"int", "float", "string", "base32", "ip"} {
+			typeSpec, err := generator.TypeSpecFromName(name)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(typeSpec.Name).Should(Equal(name))
+		}
+	})
+})
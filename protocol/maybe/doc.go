@@ -5,3 +5,9 @@ package maybe
 //go:generate sh -c "genny -in=generic/maybe.go gen 'Type=BUILTINS' | sed s/Maybe//g > gen-builtins.go"
 //go:generate sh -c "genny -in=generic/maybe.go gen 'Type=*encoding.Base32Value' | sed s/MaybeEncodingBase32Value/Base32Value/g > gen-base32value.go"
 //go:generate sh -c "genny -in=generic/maybe.go gen 'Type=net.IP' | sed s/MaybeNetIP/IP/g > gen-ip.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=netip.Addr' | sed s/MaybeNetipAddr/Addr/g > gen-addr.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=encoding.TTH' | sed s/MaybeEncodingTTH/TTH/g > gen-tth.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=[]byte' | sed s/MaybeByteSlice/Bytes/g > gen-bytes.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=time.Time' | sed s/MaybeTimeTime/Time/g > gen-time.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=time.Duration' | sed s/MaybeTimeDuration/Duration/g > gen-duration.go"
+//go:generate sh -c "genny -in=generic/maybe.go gen 'Type=encoding.Features' | sed s/MaybeEncodingFeatures/Features/g > gen-features.go"
@@ -0,0 +1,33 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+import "net/netip"
+
+type Addr struct {
+	Value netip.Addr
+	IsSet bool
+}
+
+func (m *Addr) Get() (netip.Addr, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *Addr) GetDefault(def netip.Addr) netip.Addr {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *Addr) Set(val netip.Addr) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *Addr) Unset() {
+	m.IsSet = false
+}
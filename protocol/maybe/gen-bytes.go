@@ -0,0 +1,31 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+type Bytes struct {
+	Value []byte
+	IsSet bool
+}
+
+func (m *Bytes) Get() ([]byte, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *Bytes) GetDefault(def []byte) []byte {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *Bytes) Set(val []byte) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *Bytes) Unset() {
+	m.IsSet = false
+}
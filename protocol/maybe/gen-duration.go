@@ -0,0 +1,33 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+import "time"
+
+type Duration struct {
+	Value time.Duration
+	IsSet bool
+}
+
+func (m *Duration) Get() (time.Duration, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *Duration) GetDefault(def time.Duration) time.Duration {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *Duration) Set(val time.Duration) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *Duration) Unset() {
+	m.IsSet = false
+}
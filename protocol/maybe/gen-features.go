@@ -0,0 +1,33 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+import "github.com/seoester/adcl/protocol/encoding"
+
+type Features struct {
+	Value encoding.Features
+	IsSet bool
+}
+
+func (m *Features) Get() (encoding.Features, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *Features) GetDefault(def encoding.Features) encoding.Features {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *Features) Set(val encoding.Features) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *Features) Unset() {
+	m.IsSet = false
+}
@@ -0,0 +1,33 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+import "time"
+
+type Time struct {
+	Value time.Time
+	IsSet bool
+}
+
+func (m *Time) Get() (time.Time, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *Time) GetDefault(def time.Time) time.Time {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *Time) Set(val time.Time) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *Time) Unset() {
+	m.IsSet = false
+}
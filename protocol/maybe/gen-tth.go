@@ -0,0 +1,33 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package maybe
+
+import "github.com/seoester/adcl/protocol/encoding"
+
+type TTH struct {
+	Value encoding.TTH
+	IsSet bool
+}
+
+func (m *TTH) Get() (encoding.TTH, bool) {
+	return m.Value, m.IsSet
+}
+
+func (m *TTH) GetDefault(def encoding.TTH) encoding.TTH {
+	if m.IsSet {
+		return m.Value
+	} else {
+		return def
+	}
+}
+
+func (m *TTH) Set(val encoding.TTH) {
+	m.Value = val
+	m.IsSet = true
+}
+
+func (m *TTH) Unset() {
+	m.IsSet = false
+}